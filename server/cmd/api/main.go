@@ -82,6 +82,63 @@ func main() {
 		&models.ColetaABA{},
 		&models.ComportamentoAlvo{},
 		&models.RegistroComportamento{},
+		&models.EventoAuditoria{},
+		&models.HierarquiaPrompt{},
+		&models.NivelHierarquiaPrompt{},
+		&models.FaseIntervencao{},
+		&models.ObservacaoIntervalo{},
+		&models.IntervaloObservado{},
+		&models.CronometroComportamento{},
+		&models.ChecklistFidelidade{},
+		&models.ItemChecklistFidelidade{},
+		&models.AvaliacaoFidelidade{},
+		&models.RespostaFidelidade{},
+		&models.AvaliacaoPreferencia{},
+		&models.ItemPreferencia{},
+		&models.TentativaPreferencia{},
+		&models.AlvoEtapa{},
+		&models.SondagemGeneralizacao{},
+		&models.SondagemManutencao{},
+		&models.CategoriaABC{},
+		&models.AvaliacaoFuncional{},
+		&models.ComportamentoAvaliacaoFuncional{},
+		&models.RespostaAvaliacaoFuncional{},
+		&models.DadosABCAvaliacaoFuncional{},
+		&models.CategoriaDadosABC{},
+		&models.FuncaoDadosABC{},
+		&models.PlanoIntervencao{},
+		&models.VersaoPlanoIntervencao{},
+		&models.ComportamentoPlanoIntervencao{},
+		&models.ProgramaPlanoIntervencao{},
+		&models.CienciaPlanoIntervencao{},
+		&models.Curriculo{},
+		&models.NivelCurriculo{},
+		&models.DominioCurriculo{},
+		&models.MarcoCurriculo{},
+		&models.AplicacaoCurriculo{},
+		&models.PontuacaoMarco{},
+		&models.Instrumento{},
+		&models.EscalaInstrumento{},
+		&models.OpcaoEscalaInstrumento{},
+		&models.SubescalaInstrumento{},
+		&models.ItemInstrumento{},
+		&models.FaixaInstrumento{},
+		&models.AplicacaoInstrumento{},
+		&models.RespostaAplicacaoInstrumento{},
+		&models.PontuacaoSubescalaAplicacao{},
+		&models.ModeloPrograma{},
+		&models.NivelPromptModelo{},
+		&models.EtapaModeloPrograma{},
+		&models.AlvoModeloPrograma{},
+		&models.ObjetivoPrograma{},
+		&models.PlanoTerapeutico{},
+		&models.VersaoPlanoTerapeutico{},
+		&models.ObjetivoPlanoTerapeutico{},
+		&models.ProgramaPlanoTerapeutico{},
+		&models.CargaHorariaPlanoTerapeutico{},
+		&models.AceitePlanoTerapeutico{},
+		&models.EscalaGAS{},
+		&models.NivelEscalaGAS{},
 	); err != nil {
 		log.Fatalf("Falha ao executar migrações: %v", err)
 	}
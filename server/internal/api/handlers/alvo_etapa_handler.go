@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// AlvoEtapaHandler gerencia as requisições HTTP relacionadas ao banco de alvos das etapas e às sondagens de generalização
+type AlvoEtapaHandler struct {
+	service *service.AlvoEtapaService
+}
+
+// NewAlvoEtapaHandler cria uma nova instância de AlvoEtapaHandler
+func NewAlvoEtapaHandler(service *service.AlvoEtapaService) *AlvoEtapaHandler {
+	return &AlvoEtapaHandler{service: service}
+}
+
+// CreateAlvo godoc
+// @Summary Incluir um alvo na etapa
+// @Description Inclui um alvo ao final do banco de alvos da etapa. O alvo entra em aquisição quando há vaga na rotação configurada na etapa; caso contrário aguarda a sua vez
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param alvo body models.CreateAlvoEtapaRequest true "Dados do alvo"
+// @Success 201 {object} models.AlvoEtapa
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Etapa não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos [post]
+func (h *AlvoEtapaHandler) CreateAlvo(c *gin.Context) {
+	programaID, etapaID, ok := parseEtapaParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateAlvoEtapaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alvo, err := h.service.CreateAlvo(c.Request.Context(), programaID, etapaID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, alvo)
+}
+
+// ListAlvos godoc
+// @Summary Listar os alvos da etapa
+// @Description Retorna o banco de alvos da etapa na ordem de introdução, com a situação de cada alvo
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Success 200 {array} models.AlvoEtapa
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Etapa não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos [get]
+func (h *AlvoEtapaHandler) ListAlvos(c *gin.Context) {
+	programaID, etapaID, ok := parseEtapaParams(c)
+	if !ok {
+		return
+	}
+
+	alvos, err := h.service.ListAlvos(c.Request.Context(), programaID, etapaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alvos)
+}
+
+// ReordenarAlvos godoc
+// @Summary Reordenar os alvos da etapa
+// @Description Redefine a ordem de introdução dos alvos. A lista deve conter todos os alvos da etapa exatamente uma vez
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param ordem body models.ReordenarAlvosRequest true "Nova ordem dos alvos"
+// @Success 200 {array} models.AlvoEtapa
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Etapa não encontrada"
+// @Failure 422 {object} map[string]string "Ordem incompleta ou com alvos de outra etapa"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos/ordem [put]
+func (h *AlvoEtapaHandler) ReordenarAlvos(c *gin.Context) {
+	programaID, etapaID, ok := parseEtapaParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.ReordenarAlvosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alvos, err := h.service.ReordenarAlvos(c.Request.Context(), programaID, etapaID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alvos)
+}
+
+// GetAlvo godoc
+// @Summary Obter um alvo
+// @Description Retorna um alvo do banco de alvos da etapa
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param alvo_id path string true "ID do alvo"
+// @Success 200 {object} models.AlvoEtapa
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Etapa ou alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos/{alvo_id} [get]
+func (h *AlvoEtapaHandler) GetAlvo(c *gin.Context) {
+	programaID, etapaID, alvoID, ok := parseAlvoParams(c)
+	if !ok {
+		return
+	}
+
+	alvo, err := h.service.GetAlvo(c.Request.Context(), programaID, etapaID, alvoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alvo)
+}
+
+// UpdateAlvo godoc
+// @Summary Atualizar um alvo
+// @Description Atualiza a descrição ou move o alvo manualmente entre aguardando, aquisição, generalização e manutenção. A rotação é reaplicada em seguida
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param alvo_id path string true "ID do alvo"
+// @Param alvo body models.UpdateAlvoEtapaRequest true "Dados a atualizar"
+// @Success 200 {object} models.AlvoEtapa
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Etapa ou alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos/{alvo_id} [put]
+func (h *AlvoEtapaHandler) UpdateAlvo(c *gin.Context) {
+	programaID, etapaID, alvoID, ok := parseAlvoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateAlvoEtapaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alvo, err := h.service.UpdateAlvo(c.Request.Context(), programaID, etapaID, alvoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alvo)
+}
+
+// DeleteAlvo godoc
+// @Summary Excluir um alvo
+// @Description Exclui um alvo do banco (soft delete) e introduz o próximo alvo aguardando, se houver. As coletas já registradas são preservadas
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param alvo_id path string true "ID do alvo"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Etapa ou alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos/{alvo_id} [delete]
+func (h *AlvoEtapaHandler) DeleteAlvo(c *gin.Context) {
+	programaID, etapaID, alvoID, ok := parseAlvoParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAlvo(c.Request.Context(), programaID, etapaID, alvoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateSondagem godoc
+// @Summary Agendar uma sondagem de generalização
+// @Description Agenda uma sondagem de um alvo dominado com outra pessoa, em outro ambiente ou com outro material
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param alvo_id path string true "ID do alvo"
+// @Param sondagem body models.CreateSondagemGeneralizacaoRequest true "Dados da sondagem"
+// @Success 201 {object} models.SondagemGeneralizacao
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Etapa ou alvo não encontrado"
+// @Failure 422 {object} map[string]string "Alvo ainda não dominado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos/{alvo_id}/sondagens-generalizacao [post]
+func (h *AlvoEtapaHandler) CreateSondagem(c *gin.Context) {
+	programaID, etapaID, alvoID, ok := parseAlvoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateSondagemGeneralizacaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sondagem, err := h.service.CreateSondagem(c.Request.Context(), programaID, etapaID, alvoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, sondagem)
+}
+
+// ListSondagens godoc
+// @Summary Listar as sondagens de generalização de um alvo
+// @Description Retorna as sondagens de generalização do alvo em ordem de agendamento, realizadas ou pendentes
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param alvo_id path string true "ID do alvo"
+// @Success 200 {array} models.SondagemGeneralizacao
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Etapa ou alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos/{alvo_id}/sondagens-generalizacao [get]
+func (h *AlvoEtapaHandler) ListSondagens(c *gin.Context) {
+	programaID, etapaID, alvoID, ok := parseAlvoParams(c)
+	if !ok {
+		return
+	}
+
+	sondagens, err := h.service.ListSondagens(c.Request.Context(), programaID, etapaID, alvoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sondagens)
+}
+
+// RegistrarSondagem godoc
+// @Summary Registrar o resultado de uma sondagem de generalização
+// @Description Registra o resultado da sondagem. Quando todas as sondagens de um alvo em generalização têm acerto, o alvo passa para a manutenção
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param alvo_id path string true "ID do alvo"
+// @Param sondagem_id path string true "ID da sondagem"
+// @Param resultado body models.RegistrarSondagemGeneralizacaoRequest true "Resultado da sondagem"
+// @Success 200 {object} models.SondagemGeneralizacao
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Alvo, sondagem ou sessão não encontrada"
+// @Failure 409 {object} map[string]string "Sondagem já realizada"
+// @Failure 422 {object} map[string]string "Sessão cancelada ou de outro paciente"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos/{alvo_id}/sondagens-generalizacao/{sondagem_id}/resultado [post]
+func (h *AlvoEtapaHandler) RegistrarSondagem(c *gin.Context) {
+	programaID, etapaID, alvoID, ok := parseAlvoParams(c)
+	if !ok {
+		return
+	}
+	sondagemID, err := uuid.Parse(c.Param("sondagem_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sondagem inválido"})
+		return
+	}
+
+	var req models.RegistrarSondagemGeneralizacaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sondagem, err := h.service.RegistrarSondagem(c.Request.Context(), programaID, etapaID, alvoID, sondagemID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sondagem)
+}
+
+// DeleteSondagem godoc
+// @Summary Excluir uma sondagem de generalização
+// @Description Exclui uma sondagem de generalização (soft delete)
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param alvo_id path string true "ID do alvo"
+// @Param sondagem_id path string true "ID da sondagem"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Alvo ou sondagem não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/alvos/{alvo_id}/sondagens-generalizacao/{sondagem_id} [delete]
+func (h *AlvoEtapaHandler) DeleteSondagem(c *gin.Context) {
+	programaID, etapaID, alvoID, ok := parseAlvoParams(c)
+	if !ok {
+		return
+	}
+	sondagemID, err := uuid.Parse(c.Param("sondagem_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sondagem inválido"})
+		return
+	}
+
+	if err := h.service.DeleteSondagem(c.Request.Context(), programaID, etapaID, alvoID, sondagemID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPlanoSessao godoc
+// @Summary Plano de alvos da sessão
+// @Description Retorna, para as etapas dos programas ativos do paciente, os alvos em aquisição, os alvos em manutenção a sondar nesta sessão (em rodízio) e as sondagens de generalização agendadas até o dia da sessão
+// @Tags alvos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Success 200 {object} models.PlanoAlvosSessao
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/plano-alvos [get]
+func (h *AlvoEtapaHandler) GetPlanoSessao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	plano, err := h.service.PlanoSessao(c.Request.Context(), sessaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plano)
+}
+
+// handleError converte os erros do serviço de alvos em respostas HTTP
+func (h *AlvoEtapaHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrEtapaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Etapa não encontrada"})
+	case service.ErrAlvoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Alvo não encontrado"})
+	case service.ErrSondagemNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sondagem de generalização não encontrada"})
+	case service.ErrSessaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrSondagemRealizada:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case service.ErrSequenciaAlvosInvalida, service.ErrSondagemAlvoNaoDominado, service.ErrSessaoCancelada, service.ErrEtapaForaDoPaciente:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseAlvoParams extrai os IDs do programa, da etapa e do alvo da rota
+func parseAlvoParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	programaID, etapaID, ok := parseEtapaParams(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	alvoID, err := uuid.Parse(c.Param("alvo_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do alvo inválido"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return programaID, etapaID, alvoID, true
+}
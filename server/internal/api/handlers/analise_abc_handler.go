@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// AnaliseABCHandler gerencia as requisições HTTP da taxonomia ABC e da análise funcional dos comportamentos
+type AnaliseABCHandler struct {
+	service *service.AnaliseABCService
+}
+
+// NewAnaliseABCHandler cria uma nova instância de AnaliseABCHandler
+func NewAnaliseABCHandler(service *service.AnaliseABCService) *AnaliseABCHandler {
+	return &AnaliseABCHandler{service: service}
+}
+
+// CreateCategoria godoc
+// @Summary Incluir uma categoria ABC
+// @Description Inclui um antecedente ou uma consequência na taxonomia ABC, opcionalmente associado à função do comportamento que sugere (fuga, atenção, tangível ou automática)
+// @Tags analise-abc
+// @Accept json
+// @Produce json
+// @Param categoria body models.CreateCategoriaABCRequest true "Dados da categoria"
+// @Success 201 {object} models.CategoriaABC
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/categorias-abc [post]
+func (h *AnaliseABCHandler) CreateCategoria(c *gin.Context) {
+	var req models.CreateCategoriaABCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	categoria, err := h.service.CreateCategoria(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, categoria)
+}
+
+// GetCategoria godoc
+// @Summary Obter uma categoria ABC
+// @Description Retorna uma categoria da taxonomia ABC pelo ID
+// @Tags analise-abc
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da categoria"
+// @Success 200 {object} models.CategoriaABC
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Categoria não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/categorias-abc/{id} [get]
+func (h *AnaliseABCHandler) GetCategoria(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da categoria inválido"})
+		return
+	}
+
+	categoria, err := h.service.GetCategoria(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categoria)
+}
+
+// UpdateCategoria godoc
+// @Summary Atualizar uma categoria ABC
+// @Description Atualiza o nome, a descrição, a função sugerida ou a situação de uma categoria. Categorias inativas não podem ser selecionadas em novos registros
+// @Tags analise-abc
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da categoria"
+// @Param categoria body models.UpdateCategoriaABCRequest true "Dados a atualizar"
+// @Success 200 {object} models.CategoriaABC
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Categoria não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/categorias-abc/{id} [put]
+func (h *AnaliseABCHandler) UpdateCategoria(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da categoria inválido"})
+		return
+	}
+
+	var req models.UpdateCategoriaABCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	categoria, err := h.service.UpdateCategoria(c.Request.Context(), id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categoria)
+}
+
+// DeleteCategoria godoc
+// @Summary Excluir uma categoria ABC
+// @Description Exclui uma categoria da taxonomia. Os registros já classificados com ela continuam identificados na análise ABC
+// @Tags analise-abc
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da categoria"
+// @Success 204 "Categoria excluída com sucesso"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Categoria não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/categorias-abc/{id} [delete]
+func (h *AnaliseABCHandler) DeleteCategoria(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da categoria inválido"})
+		return
+	}
+
+	if err := h.service.DeleteCategoria(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListCategorias godoc
+// @Summary Listar a taxonomia ABC
+// @Description Retorna as categorias de antecedentes e consequências, ordenadas por tipo e nome
+// @Tags analise-abc
+// @Accept json
+// @Produce json
+// @Param tipo query string false "Tipo da categoria (antecedente ou consequencia)"
+// @Param ativas query bool false "Retornar apenas as categorias ativas (padrão: false)"
+// @Success 200 {array} models.CategoriaABC
+// @Failure 400 {object} map[string]string "Tipo inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/categorias-abc [get]
+func (h *AnaliseABCHandler) ListCategorias(c *gin.Context) {
+	var tipo *models.TipoCategoriaABC
+	if valor := c.Query("tipo"); valor != "" {
+		t := models.TipoCategoriaABC(valor)
+		if t != models.TipoCategoriaABCAntecedente && t != models.TipoCategoriaABCConsequencia {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Tipo de categoria inválido, use antecedente ou consequencia"})
+			return
+		}
+		tipo = &t
+	}
+
+	categorias, err := h.service.ListCategorias(c.Request.Context(), tipo, c.Query("ativas") == "true")
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categorias)
+}
+
+// AnalisarComportamento godoc
+// @Summary Análise ABC de um comportamento
+// @Description Calcula, a partir dos registros classificados do comportamento no período, a probabilidade de cada antecedente e de cada consequência, a probabilidade de cada consequência dado o antecedente e a pontuação de cada função, sugerindo a função hipotética (fuga, atenção, tangível ou automática)
+// @Tags analise-abc
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {object} models.AnaliseABC
+// @Failure 400 {object} map[string]string "ID, data ou período inválido"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/analise-abc [get]
+func (h *AnaliseABCHandler) AnalisarComportamento(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	analise, err := h.service.AnalisarComportamento(c.Request.Context(), comportamentoID, inicio, fim)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analise)
+}
+
+// handleError converte os erros do serviço de análise ABC em respostas HTTP
+func (h *AnaliseABCHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrCategoriaABCNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Categoria ABC não encontrada"})
+	case service.ErrComportamentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comportamento alvo não encontrado"})
+	case service.ErrInvalidInput, service.ErrPeriodoInvalido:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
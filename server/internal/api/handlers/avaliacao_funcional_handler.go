@@ -0,0 +1,426 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// AvaliacaoFuncionalHandler gerencia as requisições HTTP relacionadas às avaliações funcionais do comportamento (FBA)
+type AvaliacaoFuncionalHandler struct {
+	service *service.AvaliacaoFuncionalService
+}
+
+// NewAvaliacaoFuncionalHandler cria uma nova instância de AvaliacaoFuncionalHandler
+func NewAvaliacaoFuncionalHandler(service *service.AvaliacaoFuncionalService) *AvaliacaoFuncionalHandler {
+	return &AvaliacaoFuncionalHandler{service: service}
+}
+
+// CreateAvaliacao godoc
+// @Summary Abrir uma avaliação funcional
+// @Description Abre, em rascunho, a avaliação funcional (FBA) de um ou mais comportamentos inadequados do paciente, com as respostas das avaliações indiretas e, opcionalmente, a função hipotética
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao body models.CreateAvaliacaoFuncionalRequest true "Dados da avaliação"
+// @Success 201 {object} models.AvaliacaoFuncional
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 422 {object} map[string]string "Comportamento de outro paciente, adequado ou repetido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-funcionais [post]
+func (h *AvaliacaoFuncionalHandler) CreateAvaliacao(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	var req models.CreateAvaliacaoFuncionalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	avaliacao, err := h.service.CreateAvaliacao(c.Request.Context(), pacienteID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, avaliacao)
+}
+
+// GetAvaliacao godoc
+// @Summary Obter uma avaliação funcional
+// @Description Retorna a avaliação funcional com os comportamentos, as respostas das avaliações indiretas e os dados descritivos ABC de cada comportamento no período da avaliação. Depois do envio à revisão, os dados ABC são os copiados no envio
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Success 200 {object} models.AvaliacaoFuncional
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-funcionais/{avaliacao_id} [get]
+func (h *AvaliacaoFuncionalHandler) GetAvaliacao(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoFuncionalParams(c)
+	if !ok {
+		return
+	}
+
+	avaliacao, err := h.service.GetAvaliacao(c.Request.Context(), pacienteID, avaliacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacao)
+}
+
+// UpdateAvaliacao godoc
+// @Summary Atualizar uma avaliação funcional
+// @Description Atualiza uma avaliação funcional em rascunho. Comportamentos ou respostas informados substituem a lista anterior
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Param avaliacao body models.UpdateAvaliacaoFuncionalRequest true "Dados a atualizar"
+// @Success 200 {object} models.AvaliacaoFuncional
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Avaliação ou comportamento alvo não encontrado"
+// @Failure 422 {object} map[string]string "Avaliação fora do rascunho ou comportamento inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-funcionais/{avaliacao_id} [put]
+func (h *AvaliacaoFuncionalHandler) UpdateAvaliacao(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoFuncionalParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateAvaliacaoFuncionalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	avaliacao, err := h.service.UpdateAvaliacao(c.Request.Context(), pacienteID, avaliacaoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacao)
+}
+
+// DeleteAvaliacao godoc
+// @Summary Excluir uma avaliação funcional
+// @Description Exclui uma avaliação funcional (soft delete). Avaliações aprovadas não podem ser excluídas
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 422 {object} map[string]string "Avaliação aprovada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-funcionais/{avaliacao_id} [delete]
+func (h *AvaliacaoFuncionalHandler) DeleteAvaliacao(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoFuncionalParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAvaliacao(c.Request.Context(), pacienteID, avaliacaoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListAvaliacoes godoc
+// @Summary Listar avaliações funcionais de um paciente
+// @Description Retorna uma lista paginada das avaliações funcionais do paciente, das mais recentes para as mais antigas
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param status query string false "Situação da avaliação (rascunho, em_revisao ou aprovada)"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de avaliações e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID ou situação inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-funcionais [get]
+func (h *AvaliacaoFuncionalHandler) ListAvaliacoes(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	var status *models.StatusAvaliacaoFuncional
+	if valor := c.Query("status"); valor != "" {
+		s := models.StatusAvaliacaoFuncional(valor)
+		switch s {
+		case models.StatusAvaliacaoFuncionalRascunho, models.StatusAvaliacaoFuncionalEmRevisao, models.StatusAvaliacaoFuncionalAprovada:
+			status = &s
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Situação da avaliação inválida"})
+			return
+		}
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	avaliacoes, total, err := h.service.ListAvaliacoes(c.Request.Context(), pacienteID, status, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       avaliacoes,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// ListAvaliacoesComportamento godoc
+// @Summary Avaliações funcionais de um comportamento
+// @Description Retorna as avaliações funcionais que incluem o comportamento alvo, das mais recentes para as mais antigas
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Success 200 {array} models.AvaliacaoFuncional
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/avaliacoes-funcionais [get]
+func (h *AvaliacaoFuncionalHandler) ListAvaliacoesComportamento(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	avaliacoes, err := h.service.ListAvaliacoesComportamento(c.Request.Context(), comportamentoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacoes)
+}
+
+// EnviarRevisao godoc
+// @Summary Enviar uma avaliação funcional para revisão
+// @Description Move a avaliação do rascunho para a revisão. A avaliação precisa do período dos registros ABC, da função hipotética e do grau de confiança. Os dados ABC do período são copiados para a avaliação no envio
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Param transicao body models.TransicaoAvaliacaoFuncionalRequest false "Motivo da transição"
+// @Success 200 {object} models.AvaliacaoFuncional
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 422 {object} map[string]string "Avaliação fora do rascunho, sem período ou sem hipótese"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-funcionais/{avaliacao_id}/enviar [post]
+func (h *AvaliacaoFuncionalHandler) EnviarRevisao(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoFuncionalParams(c)
+	if !ok {
+		return
+	}
+
+	req, ok := parseTransicaoAvaliacaoFuncional(c)
+	if !ok {
+		return
+	}
+
+	avaliacao, err := h.service.EnviarRevisao(c.Request.Context(), pacienteID, avaliacaoID, req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacao)
+}
+
+// DevolverRascunho godoc
+// @Summary Devolver uma avaliação funcional ao rascunho
+// @Description Devolve uma avaliação em revisão ao rascunho para ajustes, registrando o motivo
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Param transicao body models.TransicaoAvaliacaoFuncionalRequest false "Motivo da devolução"
+// @Success 200 {object} models.AvaliacaoFuncional
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 422 {object} map[string]string "Avaliação fora da revisão"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-funcionais/{avaliacao_id}/devolver [post]
+func (h *AvaliacaoFuncionalHandler) DevolverRascunho(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoFuncionalParams(c)
+	if !ok {
+		return
+	}
+
+	req, ok := parseTransicaoAvaliacaoFuncional(c)
+	if !ok {
+		return
+	}
+
+	avaliacao, err := h.service.DevolverRascunho(c.Request.Context(), pacienteID, avaliacaoID, req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacao)
+}
+
+// Aprovar godoc
+// @Summary Aprovar uma avaliação funcional
+// @Description Aprova uma avaliação em revisão com a assinatura do avaliador. Avaliações aprovadas não podem mais ser alteradas
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Param assinatura body models.AssinarAvaliacaoFuncionalRequest true "Identificação do avaliador"
+// @Success 200 {object} models.AvaliacaoFuncional
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 422 {object} map[string]string "Avaliação fora da revisão"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-funcionais/{avaliacao_id}/aprovar [post]
+func (h *AvaliacaoFuncionalHandler) Aprovar(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoFuncionalParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.AssinarAvaliacaoFuncionalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	avaliacao, err := h.service.Aprovar(c.Request.Context(), pacienteID, avaliacaoID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacao)
+}
+
+// ListHistorico godoc
+// @Summary Histórico de uma avaliação funcional
+// @Description Retorna os eventos de auditoria das mudanças de situação da avaliação
+// @Tags avaliacoes-funcionais
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Success 200 {array} models.EventoAuditoria
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-funcionais/{avaliacao_id}/historico [get]
+func (h *AvaliacaoFuncionalHandler) ListHistorico(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoFuncionalParams(c)
+	if !ok {
+		return
+	}
+
+	eventos, err := h.service.ListHistorico(c.Request.Context(), pacienteID, avaliacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, eventos)
+}
+
+// handleError converte os erros do serviço de avaliações funcionais em respostas HTTP
+func (h *AvaliacaoFuncionalHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrAvaliacaoFuncionalNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Avaliação funcional não encontrada"})
+	case service.ErrComportamentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comportamento alvo não encontrado"})
+	case service.ErrInvalidInput, service.ErrPeriodoInvalido:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrAvaliacaoFuncionalBloqueada, service.ErrAvaliacaoFuncionalIncompleta, service.ErrTransicaoAvaliacaoFuncional,
+		service.ErrComportamentoForaDoPaciente, service.ErrComportamentoNaoInadequado, service.ErrComportamentosAvaliacaoDuplicado:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseAvaliacaoFuncionalParams extrai os IDs do paciente e da avaliação da rota
+func parseAvaliacaoFuncionalParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	avaliacaoID, err := uuid.Parse(c.Param("avaliacao_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da avaliação inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return pacienteID, avaliacaoID, true
+}
+
+// parseTransicaoAvaliacaoFuncional lê o corpo opcional com o motivo da transição
+func parseTransicaoAvaliacaoFuncional(c *gin.Context) (*models.TransicaoAvaliacaoFuncionalRequest, bool) {
+	var req models.TransicaoAvaliacaoFuncionalRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, false
+		}
+	}
+	return &req, true
+}
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// AvaliacaoPreferenciaHandler gerencia as requisições HTTP relacionadas às avaliações de preferência de reforçadores
+type AvaliacaoPreferenciaHandler struct {
+	service *service.AvaliacaoPreferenciaService
+}
+
+// NewAvaliacaoPreferenciaHandler cria uma nova instância de AvaliacaoPreferenciaHandler
+func NewAvaliacaoPreferenciaHandler(service *service.AvaliacaoPreferenciaService) *AvaliacaoPreferenciaHandler {
+	return &AvaliacaoPreferenciaHandler{service: service}
+}
+
+// CreateAvaliacao godoc
+// @Summary Registrar uma avaliação de preferência
+// @Description Registra uma avaliação de preferência MSWO, pareada ou de operante livre do paciente e calcula o percentual de seleção e a posição de cada item
+// @Tags preferencias
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao body models.CreateAvaliacaoPreferenciaRequest true "Itens e tentativas da avaliação"
+// @Success 201 {object} models.AvaliacaoPreferencia
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 422 {object} map[string]string "Dados incompatíveis com o formato da avaliação ou sessão inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-preferencia [post]
+func (h *AvaliacaoPreferenciaHandler) CreateAvaliacao(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	var req models.CreateAvaliacaoPreferenciaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	avaliacao, err := h.service.CreateAvaliacao(c.Request.Context(), pacienteID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, avaliacao)
+}
+
+// GetAvaliacao godoc
+// @Summary Obter uma avaliação de preferência
+// @Description Retorna a avaliação de preferência com os itens e as tentativas
+// @Tags preferencias
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Success 200 {object} models.AvaliacaoPreferencia
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-preferencia/{avaliacao_id} [get]
+func (h *AvaliacaoPreferenciaHandler) GetAvaliacao(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoPreferenciaParams(c)
+	if !ok {
+		return
+	}
+
+	avaliacao, err := h.service.GetAvaliacao(c.Request.Context(), pacienteID, avaliacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacao)
+}
+
+// UpdateAvaliacao godoc
+// @Summary Atualizar uma avaliação de preferência
+// @Description Atualiza a avaliação de preferência e recalcula o ranking dos itens. Tentativas informadas sem novos itens referenciam os itens já cadastrados
+// @Tags preferencias
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Param avaliacao body models.UpdateAvaliacaoPreferenciaRequest true "Dados a atualizar"
+// @Success 200 {object} models.AvaliacaoPreferencia
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 422 {object} map[string]string "Dados incompatíveis com o formato da avaliação"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-preferencia/{avaliacao_id} [put]
+func (h *AvaliacaoPreferenciaHandler) UpdateAvaliacao(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoPreferenciaParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateAvaliacaoPreferenciaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	avaliacao, err := h.service.UpdateAvaliacao(c.Request.Context(), pacienteID, avaliacaoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacao)
+}
+
+// DeleteAvaliacao godoc
+// @Summary Excluir uma avaliação de preferência
+// @Description Exclui uma avaliação de preferência (soft delete); o ranking de reforçadores deixa de considerá-la
+// @Tags preferencias
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-preferencia/{avaliacao_id} [delete]
+func (h *AvaliacaoPreferenciaHandler) DeleteAvaliacao(c *gin.Context) {
+	pacienteID, avaliacaoID, ok := parseAvaliacaoPreferenciaParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAvaliacao(c.Request.Context(), pacienteID, avaliacaoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListAvaliacoes godoc
+// @Summary Listar as avaliações de preferência de um paciente
+// @Description Retorna uma lista paginada das avaliações de preferência do paciente, das mais recentes para as mais antigas, com os itens em ordem de preferência
+// @Tags preferencias
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de avaliações e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID ou data inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/avaliacoes-preferencia [get]
+func (h *AvaliacaoPreferenciaHandler) ListAvaliacoes(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	avaliacoes, total, err := h.service.ListAvaliacoes(c.Request.Context(), pacienteID, inicio, fim, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       avaliacoes,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// ReforcadoresPaciente godoc
+// @Summary Ranking de reforçadores de um paciente
+// @Description Consolida as avaliações de preferência do paciente no período em um ranking de reforçadores, ordenado pelo percentual médio de seleção ou de engajamento de cada item
+// @Tags preferencias
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {object} models.ReforcadoresPaciente
+// @Failure 400 {object} map[string]string "ID ou data inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/reforcadores [get]
+func (h *AvaliacaoPreferenciaHandler) ReforcadoresPaciente(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	reforcadores, err := h.service.ReforcadoresPaciente(c.Request.Context(), pacienteID, inicio, fim)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reforcadores)
+}
+
+// ReforcadoresSessao godoc
+// @Summary Opções de reforçador de uma sessão
+// @Description Retorna o ranking de reforçadores do paciente da sessão, considerando as avaliações realizadas até o dia da sessão. É o conjunto de opções do campo "reforco_utilizado" das coletas
+// @Tags preferencias
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Success 200 {object} models.ReforcadoresPaciente
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/reforcadores [get]
+func (h *AvaliacaoPreferenciaHandler) ReforcadoresSessao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	reforcadores, err := h.service.ReforcadoresSessao(c.Request.Context(), sessaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reforcadores)
+}
+
+// handleError converte os erros do serviço de avaliações de preferência em respostas HTTP
+func (h *AvaliacaoPreferenciaHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrAvaliacaoPreferenciaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Avaliação de preferência não encontrada"})
+	case service.ErrSessaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+	case service.ErrInvalidInput, service.ErrPeriodoInvalido:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrItensPreferencia, service.ErrTentativasPreferencia, service.ErrDuracaoPreferencia,
+		service.ErrSessaoCancelada, service.ErrSessaoForaDoPaciente:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseAvaliacaoPreferenciaParams extrai os IDs do paciente e da avaliação da rota
+func parseAvaliacaoPreferenciaParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	avaliacaoID, err := uuid.Parse(c.Param("avaliacao_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da avaliação inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return pacienteID, avaliacaoID, true
+}
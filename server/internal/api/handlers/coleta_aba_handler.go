@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// ColetaABAHandler gerencia as requisições HTTP relacionadas às coletas de tentativas ABA
+type ColetaABAHandler struct {
+	service *service.ColetaABAService
+}
+
+// NewColetaABAHandler cria uma nova instância de ColetaABAHandler
+func NewColetaABAHandler(service *service.ColetaABAService) *ColetaABAHandler {
+	return &ColetaABAHandler{service: service}
+}
+
+// CreateColeta godoc
+// @Summary Registrar uma tentativa
+// @Description Registra uma tentativa (acerto, erro ou ajuda) de uma etapa de programa em uma sessão
+// @Tags coletas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param coleta body models.CreateColetaABARequest true "Dados da tentativa"
+// @Success 201 {object} models.ColetaABA
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Sessão, etapa, alvo ou prompt não encontrado"
+// @Failure 422 {object} map[string]string "Etapa não pertence ao paciente, alvo fora da etapa ou não introduzido, sessão cancelada ou realizada ou sem observador secundário"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/coletas [post]
+func (h *ColetaABAHandler) CreateColeta(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	var req models.CreateColetaABARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	coleta, err := h.service.CreateColeta(c.Request.Context(), sessaoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, coleta)
+}
+
+// GetColeta godoc
+// @Summary Obter uma tentativa pelo ID
+// @Description Retorna os detalhes de uma tentativa registrada na sessão
+// @Tags coletas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param coleta_id path string true "ID da coleta"
+// @Success 200 {object} models.ColetaABA
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Coleta não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/coletas/{coleta_id} [get]
+func (h *ColetaABAHandler) GetColeta(c *gin.Context) {
+	sessaoID, coletaID, ok := parseColetaParams(c)
+	if !ok {
+		return
+	}
+
+	coleta, err := h.service.GetColeta(c.Request.Context(), sessaoID, coletaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, coleta)
+}
+
+// UpdateColeta godoc
+// @Summary Atualizar uma tentativa
+// @Description Atualiza o resultado, prompt, reforço ou observações de uma tentativa
+// @Tags coletas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param coleta_id path string true "ID da coleta"
+// @Param coleta body models.UpdateColetaABARequest true "Dados da tentativa"
+// @Success 200 {object} models.ColetaABA
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Coleta não encontrada"
+// @Failure 422 {object} map[string]string "Sessão cancelada ou realizada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/coletas/{coleta_id} [put]
+func (h *ColetaABAHandler) UpdateColeta(c *gin.Context) {
+	sessaoID, coletaID, ok := parseColetaParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateColetaABARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	coleta, err := h.service.UpdateColeta(c.Request.Context(), sessaoID, coletaID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, coleta)
+}
+
+// DeleteColeta godoc
+// @Summary Excluir uma tentativa
+// @Description Exclui uma tentativa registrada na sessão (soft delete)
+// @Tags coletas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param coleta_id path string true "ID da coleta"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sessão ou coleta não encontrada"
+// @Failure 422 {object} map[string]string "Sessão cancelada ou realizada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/coletas/{coleta_id} [delete]
+func (h *ColetaABAHandler) DeleteColeta(c *gin.Context) {
+	sessaoID, coletaID, ok := parseColetaParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteColeta(c.Request.Context(), sessaoID, coletaID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListColetasBySessao godoc
+// @Summary Listar tentativas de uma sessão
+// @Description Retorna uma lista paginada das tentativas de uma sessão, opcionalmente filtradas por etapa
+// @Tags coletas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param etapa_id query string false "ID da etapa do programa"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de tentativas e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/coletas [get]
+func (h *ColetaABAHandler) ListColetasBySessao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	var etapaID *uuid.UUID
+	if raw := c.Query("etapa_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da etapa inválido"})
+			return
+		}
+		etapaID = &parsed
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	coletas, total, err := h.service.ListColetasBySessao(c.Request.Context(), sessaoID, etapaID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       coletas,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// ListColetasByEtapa godoc
+// @Summary Listar tentativas de uma etapa
+// @Description Retorna, em ordem cronológica, as tentativas do observador principal registradas para a etapa em todas as sessões
+// @Tags coletas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Success 200 {array} models.ColetaABA
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Etapa não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/coletas [get]
+func (h *ColetaABAHandler) ListColetasByEtapa(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+	etapaID, err := uuid.Parse(c.Param("etapa_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da etapa inválido"})
+		return
+	}
+
+	coletas, err := h.service.ListColetasByEtapa(c.Request.Context(), programaID, etapaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, coletas)
+}
+
+// GetResumoSessao godoc
+// @Summary Resumo das tentativas de uma sessão
+// @Description Retorna, por etapa, o percentual de acertos, o percentual de respostas independentes e a distribuição de prompts
+// @Tags coletas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Success 200 {array} models.ResumoEtapaColeta
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/coletas/resumo [get]
+func (h *ColetaABAHandler) GetResumoSessao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	resumo, err := h.service.GetResumoSessao(c.Request.Context(), sessaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resumo)
+}
+
+// handleError converte os erros do serviço de coletas em respostas HTTP
+func (h *ColetaABAHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrSessaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+	case service.ErrColetaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Coleta não encontrada"})
+	case service.ErrEtapaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Etapa do programa não encontrada"})
+	case service.ErrTipoPromptNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tipo de prompt não encontrado"})
+	case service.ErrAlvoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Alvo não encontrado"})
+	case service.ErrSondagemManutencaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sondagem de manutenção não encontrada"})
+	case service.ErrEtapaForaDoPaciente, service.ErrSessaoCancelada, service.ErrSessaoEncerrada, service.ErrObservadorSecundarioAusente,
+		service.ErrAlvoForaDaEtapa, service.ErrAlvoAguardando,
+		service.ErrSondagemManutencaoEncerrada, service.ErrSondagemManutencaoForaDaEtapa:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseColetaParams extrai os IDs da sessão e da coleta da rota
+func parseColetaParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	coletaID, err := uuid.Parse(c.Param("coleta_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da coleta inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return sessaoID, coletaID, true
+}
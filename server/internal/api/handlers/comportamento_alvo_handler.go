@@ -93,6 +93,7 @@ func (h *ComportamentoAlvoHandler) GetComportamento(c *gin.Context) {
 // @Success 200 {object} models.ComportamentoAlvo
 // @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
 // @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 422 {object} map[string]string "Método de registro alterado em comportamento com registros"
 // @Failure 500 {object} map[string]string "Erro interno do servidor"
 // @Security BearerAuth
 // @Router /api/v1/comportamentos/{id} [put]
@@ -116,6 +117,10 @@ func (h *ComportamentoAlvoHandler) UpdateComportamento(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Comportamento alvo não encontrado"})
 			return
 		}
+		if err == service.ErrMetodoRegistroComRegistros {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
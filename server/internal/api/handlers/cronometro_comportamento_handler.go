@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// CronometroComportamentoHandler gerencia as requisições HTTP relacionadas aos cronômetros de duração e latência
+type CronometroComportamentoHandler struct {
+	service *service.CronometroComportamentoService
+}
+
+// NewCronometroComportamentoHandler cria uma nova instância de CronometroComportamentoHandler
+func NewCronometroComportamentoHandler(service *service.CronometroComportamentoService) *CronometroComportamentoHandler {
+	return &CronometroComportamentoHandler{service: service}
+}
+
+// IniciarCronometro godoc
+// @Summary Iniciar um cronômetro
+// @Description Inicia, pelo relógio do servidor, um cronômetro de duração (início ao fim do episódio) ou de latência (instrução até o início da resposta) para o comportamento na sessão. Só pode haver um cronômetro de cada tipo em andamento por comportamento e sessão
+// @Tags cronometros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param cronometro body models.IniciarCronometroRequest true "Sessão e tipo do cronômetro"
+// @Success 201 {object} models.CronometroComportamento
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Comportamento alvo ou sessão não encontrada"
+// @Failure 409 {object} map[string]string "Já existe um cronômetro em andamento"
+// @Failure 422 {object} map[string]string "Sessão encerrada, de outro paciente ou método de registro incompatível"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/cronometros [post]
+func (h *CronometroComportamentoHandler) IniciarCronometro(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	var req models.IniciarCronometroRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cronometro, err := h.service.IniciarCronometro(c.Request.Context(), comportamentoID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, cronometro)
+}
+
+// EncerrarCronometro godoc
+// @Summary Parar um cronômetro
+// @Description Encerra um cronômetro em andamento. Cronômetros de duração geram automaticamente um registro de comportamento com os segundos medidos
+// @Tags cronometros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param cronometro_id path string true "ID do cronômetro"
+// @Param cronometro body models.EncerrarCronometroRequest false "Contexto e consequência do episódio"
+// @Success 200 {object} models.CronometroComportamento
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Cronômetro não encontrado"
+// @Failure 409 {object} map[string]string "Cronômetro já encerrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/cronometros/{cronometro_id}/parar [post]
+func (h *CronometroComportamentoHandler) EncerrarCronometro(c *gin.Context) {
+	comportamentoID, cronometroID, ok := parseCronometroParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.EncerrarCronometroRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	cronometro, err := h.service.EncerrarCronometro(c.Request.Context(), comportamentoID, cronometroID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cronometro)
+}
+
+// DescartarCronometro godoc
+// @Summary Descartar um cronômetro
+// @Description Exclui um cronômetro em andamento iniciado por engano, sem gerar registro
+// @Tags cronometros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param cronometro_id path string true "ID do cronômetro"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Cronômetro não encontrado"
+// @Failure 409 {object} map[string]string "Cronômetro já encerrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/cronometros/{cronometro_id} [delete]
+func (h *CronometroComportamentoHandler) DescartarCronometro(c *gin.Context) {
+	comportamentoID, cronometroID, ok := parseCronometroParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DescartarCronometro(c.Request.Context(), comportamentoID, cronometroID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListCronometrosSessao godoc
+// @Summary Listar cronômetros de uma sessão
+// @Description Retorna os cronômetros da sessão com o tempo decorrido calculado pelo servidor, permitindo retomar cronômetros abertos após uma reconexão
+// @Tags cronometros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param abertos query bool false "Retornar apenas cronômetros em andamento"
+// @Success 200 {array} models.CronometroComportamento
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/cronometros [get]
+func (h *CronometroComportamentoHandler) ListCronometrosSessao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	cronometros, err := h.service.ListCronometrosSessao(c.Request.Context(), sessaoID, c.Query("abertos") == "true")
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cronometros)
+}
+
+// ResumoSessao godoc
+// @Summary Resumir os cronômetros de uma sessão
+// @Description Retorna, por comportamento, a duração total, a duração média por episódio, o percentual do tempo da sessão e as latências medidas
+// @Tags cronometros
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Success 200 {array} models.ResumoCronometrosComportamento
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/cronometros/resumo [get]
+func (h *CronometroComportamentoHandler) ResumoSessao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	resumos, err := h.service.ResumoSessao(c.Request.Context(), sessaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resumos)
+}
+
+// handleError converte os erros do serviço de cronômetros em respostas HTTP
+func (h *CronometroComportamentoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrComportamentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comportamento alvo não encontrado"})
+	case service.ErrCronometroNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Cronômetro não encontrado"})
+	case service.ErrSessaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrCronometroEmAndamento, service.ErrCronometroEncerrado:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case service.ErrCronometroDuracaoInvalido, service.ErrSessaoCancelada, service.ErrSessaoEncerrada, service.ErrSessaoForaDoPaciente, service.ErrObservadorSecundarioAusente:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseCronometroParams extrai os IDs do comportamento e do cronômetro da rota
+func parseCronometroParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	cronometroID, err := uuid.Parse(c.Param("cronometro_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do cronômetro inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return comportamentoID, cronometroID, true
+}
@@ -0,0 +1,656 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/grafico"
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// CurriculoHandler gerencia as requisições HTTP dos currículos de avaliação baseados em marcos e das suas aplicações
+type CurriculoHandler struct {
+	service *service.CurriculoService
+}
+
+// NewCurriculoHandler cria uma nova instância de CurriculoHandler
+func NewCurriculoHandler(service *service.CurriculoService) *CurriculoHandler {
+	return &CurriculoHandler{service: service}
+}
+
+// CreateCurriculo godoc
+// @Summary Criar um currículo de avaliação
+// @Description Cria um currículo baseado em marcos (no estilo do VB-MAPP) com os níveis, os domínios e os marcos de cada domínio em cada nível. A estrutura não pode ser alterada depois da criação
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param curriculo body models.CreateCurriculoRequest true "Definição do currículo"
+// @Success 201 {object} models.Curriculo
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 422 {object} map[string]string "Estrutura inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/curriculos [post]
+func (h *CurriculoHandler) CreateCurriculo(c *gin.Context) {
+	var req models.CreateCurriculoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	curriculo, err := h.service.CreateCurriculo(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, curriculo)
+}
+
+// GetCurriculo godoc
+// @Summary Obter um currículo de avaliação
+// @Description Retorna o currículo com os níveis, os domínios e os marcos
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do currículo"
+// @Success 200 {object} models.Curriculo
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Currículo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/curriculos/{id} [get]
+func (h *CurriculoHandler) GetCurriculo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do currículo inválido"})
+		return
+	}
+
+	curriculo, err := h.service.GetCurriculo(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, curriculo)
+}
+
+// UpdateCurriculo godoc
+// @Summary Atualizar um currículo de avaliação
+// @Description Atualiza o nome, a descrição ou a situação do currículo. Currículos inativos não podem ser aplicados
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do currículo"
+// @Param curriculo body models.UpdateCurriculoRequest true "Dados a atualizar"
+// @Success 200 {object} models.Curriculo
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Currículo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/curriculos/{id} [put]
+func (h *CurriculoHandler) UpdateCurriculo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do currículo inválido"})
+		return
+	}
+
+	var req models.UpdateCurriculoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	curriculo, err := h.service.UpdateCurriculo(c.Request.Context(), id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, curriculo)
+}
+
+// DeleteCurriculo godoc
+// @Summary Excluir um currículo de avaliação
+// @Description Exclui um currículo que ainda não foi aplicado. Currículos aplicados podem ser inativados
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do currículo"
+// @Success 204 "Currículo excluído com sucesso"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Currículo não encontrado"
+// @Failure 422 {object} map[string]string "Currículo já aplicado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/curriculos/{id} [delete]
+func (h *CurriculoHandler) DeleteCurriculo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do currículo inválido"})
+		return
+	}
+
+	if err := h.service.DeleteCurriculo(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListCurriculos godoc
+// @Summary Listar currículos de avaliação
+// @Description Retorna os currículos ordenados pelo nome, com os níveis e os domínios
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param ativos query bool false "Retornar apenas os currículos ativos (padrão: false)"
+// @Success 200 {array} models.Curriculo
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/curriculos [get]
+func (h *CurriculoHandler) ListCurriculos(c *gin.Context) {
+	curriculos, err := h.service.ListCurriculos(c.Request.Context(), c.Query("ativos") == "true")
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, curriculos)
+}
+
+// CreateAplicacao godoc
+// @Summary Iniciar uma aplicação de currículo
+// @Description Inicia a aplicação de um currículo ativo ao paciente. Os marcos são pontuados em seguida
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao body models.CreateAplicacaoCurriculoRequest true "Dados da aplicação"
+// @Success 201 {object} models.AplicacaoCurriculo
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Currículo não encontrado"
+// @Failure 422 {object} map[string]string "Currículo inativo"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo [post]
+func (h *CurriculoHandler) CreateAplicacao(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	var req models.CreateAplicacaoCurriculoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	aplicacao, err := h.service.CreateAplicacao(c.Request.Context(), pacienteID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, aplicacao)
+}
+
+// GetAplicacao godoc
+// @Summary Obter uma aplicação de currículo
+// @Description Retorna a aplicação com as pontuações dos marcos
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Success 200 {object} models.AplicacaoCurriculo
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id} [get]
+func (h *CurriculoHandler) GetAplicacao(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	aplicacao, err := h.service.GetAplicacao(c.Request.Context(), pacienteID, aplicacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aplicacao)
+}
+
+// UpdateAplicacao godoc
+// @Summary Atualizar uma aplicação de currículo
+// @Description Atualiza a data, o avaliador ou as observações de uma aplicação em andamento
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Param aplicacao body models.UpdateAplicacaoCurriculoRequest true "Dados a atualizar"
+// @Success 200 {object} models.AplicacaoCurriculo
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 422 {object} map[string]string "Aplicação concluída"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id} [put]
+func (h *CurriculoHandler) UpdateAplicacao(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateAplicacaoCurriculoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	aplicacao, err := h.service.UpdateAplicacao(c.Request.Context(), pacienteID, aplicacaoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aplicacao)
+}
+
+// DeleteAplicacao godoc
+// @Summary Excluir uma aplicação de currículo
+// @Description Exclui uma aplicação em andamento. Aplicações concluídas fazem parte do histórico do paciente
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Success 204 "Aplicação excluída com sucesso"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 422 {object} map[string]string "Aplicação concluída"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id} [delete]
+func (h *CurriculoHandler) DeleteAplicacao(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAplicacao(c.Request.Context(), pacienteID, aplicacaoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListAplicacoes godoc
+// @Summary Listar aplicações de currículo
+// @Description Retorna uma lista paginada das aplicações do paciente, das mais recentes para as mais antigas
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param curriculo_id query string false "ID do currículo"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de aplicações e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo [get]
+func (h *CurriculoHandler) ListAplicacoes(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	var curriculoID *uuid.UUID
+	if valor := c.Query("curriculo_id"); valor != "" {
+		id, err := uuid.Parse(valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do currículo inválido"})
+			return
+		}
+		curriculoID = &id
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	aplicacoes, total, err := h.service.ListAplicacoes(c.Request.Context(), pacienteID, curriculoID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       aplicacoes,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// PontuarMarcos godoc
+// @Summary Pontuar marcos de uma aplicação
+// @Description Grava a pontuação (0, 0.5 ou 1) de marcos do currículo em uma aplicação em andamento. Marcos já pontuados têm a pontuação substituída
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Param pontuacoes body models.PontuarMarcosRequest true "Pontuações dos marcos"
+// @Success 200 {object} models.AplicacaoCurriculo
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 422 {object} map[string]string "Aplicação concluída, marco de outro currículo, repetido ou pontuação inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id}/pontuacoes [put]
+func (h *CurriculoHandler) PontuarMarcos(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.PontuarMarcosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	aplicacao, err := h.service.PontuarMarcos(c.Request.Context(), pacienteID, aplicacaoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aplicacao)
+}
+
+// ConcluirAplicacao godoc
+// @Summary Concluir uma aplicação de currículo
+// @Description Conclui a aplicação, que deixa de aceitar alterações e passa a permitir a criação de programas a partir dos déficits
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Success 200 {object} models.AplicacaoCurriculo
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 422 {object} map[string]string "Aplicação já concluída ou sem marcos pontuados"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id}/concluir [post]
+func (h *CurriculoHandler) ConcluirAplicacao(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	aplicacao, err := h.service.ConcluirAplicacao(c.Request.Context(), pacienteID, aplicacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aplicacao)
+}
+
+// GetResultado godoc
+// @Summary Resultado de uma aplicação de currículo
+// @Description Retorna a pontuação da aplicação no total, por nível e por domínio
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Success 200 {object} models.ResultadoAplicacaoCurriculo
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id}/resultado [get]
+func (h *CurriculoHandler) GetResultado(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	resultado, err := h.service.ResultadoAplicacao(c.Request.Context(), pacienteID, aplicacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resultado)
+}
+
+// CompararAplicacoes godoc
+// @Summary Comparar aplicações de currículo
+// @Description Compara a aplicação com uma aplicação base do mesmo currículo e do mesmo paciente: a variação por nível e domínio e os marcos ganhos e perdidos. Sem base, é usada a aplicação imediatamente anterior
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Param base query string false "ID da aplicação base"
+// @Success 200 {object} models.ComparacaoAplicacoesCurriculo
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada ou sem aplicação anterior"
+// @Failure 422 {object} map[string]string "Aplicações de currículos ou pacientes diferentes"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id}/comparacao [get]
+func (h *CurriculoHandler) CompararAplicacoes(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	var baseID *uuid.UUID
+	if valor := c.Query("base"); valor != "" {
+		id, err := uuid.Parse(valor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da aplicação base inválido"})
+			return
+		}
+		baseID = &id
+	}
+
+	comparacao, err := h.service.CompararAplicacoes(c.Request.Context(), pacienteID, aplicacaoID, baseID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comparacao)
+}
+
+// GetGrade godoc
+// @Summary Grade de marcos de uma aplicação
+// @Description Desenha a grade de marcos do currículo (domínios nas colunas e níveis empilhados), pintando cada marco com a cor da primeira aplicação em que foi pontuado: metade para 0,5 e a célula inteira para 1. Por padrão, inclui as aplicações anteriores do mesmo currículo
+// @Tags curriculos
+// @Produce image/svg+xml
+// @Produce image/png
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Param formato query string false "Formato de saída: svg ou png (padrão: svg)"
+// @Param anteriores query bool false "Incluir as aplicações anteriores (padrão: true)"
+// @Success 200 {file} binary "Grade de marcos"
+// @Failure 400 {object} map[string]string "ID ou parâmetro inválido"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id}/grade [get]
+func (h *CurriculoHandler) GetGrade(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	formato := grafico.Formato(c.DefaultQuery("formato", string(grafico.FormatoSVG)))
+	if formato != grafico.FormatoSVG && formato != grafico.FormatoPNG {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Formato inválido: use svg ou png"})
+		return
+	}
+
+	anteriores, err := strconv.ParseBool(c.DefaultQuery("anteriores", "true"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro anteriores inválido"})
+		return
+	}
+
+	grade, err := h.service.GradeAplicacao(c.Request.Context(), pacienteID, aplicacaoID, anteriores)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := grafico.RenderizarGradeMarcos(&buf, grade, formato); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, formato.ContentType(), buf.Bytes())
+}
+
+// ListDeficits godoc
+// @Summary Déficits de uma aplicação
+// @Description Retorna os marcos pontuados e não atingidos (0 ou 0,5) em uma aplicação concluída, com os programas ABA já criados para cada um
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Success 200 {array} models.DeficitMarco
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 422 {object} map[string]string "Aplicação em andamento"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id}/deficits [get]
+func (h *CurriculoHandler) ListDeficits(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	deficits, err := h.service.ListDeficits(c.Request.Context(), pacienteID, aplicacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deficits)
+}
+
+// CreateProgramaDeficit godoc
+// @Summary Criar um programa a partir de um déficit
+// @Description Cria um programa ABA ativo para trabalhar um marco não atingido em uma aplicação concluída. O programa guarda a aplicação e o marco de origem
+// @Tags curriculos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Param marco_id path string true "ID do marco"
+// @Param programa body models.CreateProgramaDeficitRequest false "Dados do programa"
+// @Success 201 {object} models.ProgramaABA
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 422 {object} map[string]string "Aplicação em andamento, marco de outro currículo ou sem déficit"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-curriculo/{aplicacao_id}/deficits/{marco_id}/programas [post]
+func (h *CurriculoHandler) CreateProgramaDeficit(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoCurriculoParams(c)
+	if !ok {
+		return
+	}
+
+	marcoID, err := uuid.Parse(c.Param("marco_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do marco inválido"})
+		return
+	}
+
+	var req models.CreateProgramaDeficitRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	programa, err := h.service.CreateProgramaDeficit(c.Request.Context(), pacienteID, aplicacaoID, marcoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, programa)
+}
+
+// handleError converte os erros do serviço de currículos em respostas HTTP
+func (h *CurriculoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrCurriculoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Currículo não encontrado"})
+	case service.ErrAplicacaoCurriculoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Aplicação de currículo não encontrada"})
+	case service.ErrAplicacaoCurriculoBaseNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrEstruturaCurriculoInvalida, service.ErrCurriculoEmUso, service.ErrCurriculoInativo,
+		service.ErrAplicacaoCurriculoConcluida, service.ErrAplicacaoCurriculoEmAndamento, service.ErrAplicacaoCurriculoVazia,
+		service.ErrAplicacoesCurriculoIncompativeis, service.ErrMarcoForaDoCurriculo, service.ErrPontuacaoMarcoInvalida,
+		service.ErrMarcosPontuacaoDuplicados, service.ErrMarcoSemDeficit:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseAplicacaoCurriculoParams extrai os IDs do paciente e da aplicação da rota
+func parseAplicacaoCurriculoParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	aplicacaoID, err := uuid.Parse(c.Param("aplicacao_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da aplicação inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return pacienteID, aplicacaoID, true
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// EscalaGASHandler gerencia as requisições HTTP relacionadas às escalas GAS dos objetivos e ao escore T GAS
+type EscalaGASHandler struct {
+	service *service.EscalaGASService
+}
+
+// NewEscalaGASHandler cria uma nova instância de EscalaGASHandler
+func NewEscalaGASHandler(service *service.EscalaGASService) *EscalaGASHandler {
+	return &EscalaGASHandler{service: service}
+}
+
+// DefinirEscala godoc
+// @Summary Definir a escala GAS de um objetivo
+// @Description Define ou substitui a escala de alcance do objetivo (Goal Attainment Scaling), com a descrição de cada nível de -2 a +2 e o peso do objetivo no escore T
+// @Tags escalas-gas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Param escala body models.DefinirEscalaGASRequest true "Níveis e peso da escala"
+// @Success 200 {object} models.EscalaGAS
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Objetivo terapêutico não encontrado"
+// @Failure 422 {object} map[string]string "Níveis ausentes ou repetidos"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/escala-gas [put]
+func (h *EscalaGASHandler) DefinirEscala(c *gin.Context) {
+	objetivoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	var req models.DefinirEscalaGASRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	escala, err := h.service.DefinirEscala(c.Request.Context(), objetivoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, escala)
+}
+
+// GetEscala godoc
+// @Summary Obter a escala GAS de um objetivo
+// @Description Retorna a escala de alcance do objetivo com a descrição de cada nível
+// @Tags escalas-gas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Success 200 {object} models.EscalaGAS
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Objetivo não encontrado ou sem escala GAS"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/escala-gas [get]
+func (h *EscalaGASHandler) GetEscala(c *gin.Context) {
+	objetivoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	escala, err := h.service.GetEscala(c.Request.Context(), objetivoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, escala)
+}
+
+// RemoverEscala godoc
+// @Summary Remover a escala GAS de um objetivo
+// @Description Remove a escala de alcance do objetivo, que deixa de compor o escore T do paciente. Os níveis já registrados no progresso são mantidos
+// @Tags escalas-gas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Objetivo não encontrado ou sem escala GAS"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/escala-gas [delete]
+func (h *EscalaGASHandler) RemoverEscala(c *gin.Context) {
+	objetivoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	if err := h.service.RemoverEscala(c.Request.Context(), objetivoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetResultadoPaciente godoc
+// @Summary Escore T GAS de um paciente
+// @Description Calcula o escore T GAS (Kiresuk e Sherman, correlação 0,3) do paciente em cada período de revisão, a partir do último nível registrado no período para cada objetivo com escala. O objetivo sem registro no período repete o último nível anterior, marcado como transportado, e o objetivo ainda não avaliado aparece sem nível, deixando o período incompleto. Períodos sem níveis registrados não são incluídos
+// @Tags escalas-gas
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param periodo query string false "Período de revisão: mes, trimestre ou semestre (padrão: trimestre)"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {object} models.ResultadoGASPaciente
+// @Failure 400 {object} map[string]string "ID, período ou datas inválidas"
+// @Failure 404 {object} map[string]string "Paciente não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/gas [get]
+func (h *EscalaGASHandler) GetResultadoPaciente(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	periodo := models.PeriodoRevisaoGAS(c.DefaultQuery("periodo", string(models.PeriodoRevisaoGASTrimestre)))
+	resultado, err := h.service.ResultadoPaciente(c.Request.Context(), pacienteID, inicio, fim, periodo)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resultado)
+}
+
+// handleError converte os erros do serviço de escalas GAS em respostas HTTP
+func (h *EscalaGASHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrObjetivoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Objetivo terapêutico não encontrado"})
+	case service.ErrEscalaGASNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Escala GAS não encontrada"})
+	case service.ErrPacienteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Paciente não encontrado"})
+	case service.ErrInvalidInput, service.ErrPeriodoInvalido, service.ErrPeriodoRevisaoInvalido:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrEscalaGASIncompleta:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
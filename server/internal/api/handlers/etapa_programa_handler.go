@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// EtapaProgramaHandler gerencia as requisições HTTP relacionadas às etapas de programas ABA
+type EtapaProgramaHandler struct {
+	service   *service.EtapaProgramaService
+	avaliacao *service.AvaliacaoDominioService
+}
+
+// NewEtapaProgramaHandler cria uma nova instância de EtapaProgramaHandler
+func NewEtapaProgramaHandler(service *service.EtapaProgramaService, avaliacao *service.AvaliacaoDominioService) *EtapaProgramaHandler {
+	return &EtapaProgramaHandler{service: service, avaliacao: avaliacao}
+}
+
+// CreateEtapa godoc
+// @Summary Criar uma etapa no programa
+// @Description Adiciona uma etapa ao final da sequência ou a insere na posição informada em "ordem"
+// @Tags etapas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa body models.CreateEtapaProgramaRequest true "Dados da etapa"
+// @Success 201 {object} models.EtapaPrograma
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Programa ABA não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas [post]
+func (h *EtapaProgramaHandler) CreateEtapa(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	var req models.CreateEtapaProgramaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	etapa, err := h.service.CreateEtapa(c.Request.Context(), programaID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, etapa)
+}
+
+// ListEtapas godoc
+// @Summary Listar etapas do programa
+// @Description Retorna as etapas do programa na ordem de ensino, com as etapas retiradas ao final
+// @Tags etapas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Success 200 {array} models.EtapaPrograma
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Programa ABA não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas [get]
+func (h *EtapaProgramaHandler) ListEtapas(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	etapas, err := h.service.ListEtapas(c.Request.Context(), programaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, etapas)
+}
+
+// GetEtapa godoc
+// @Summary Obter uma etapa pelo ID
+// @Description Retorna os detalhes de uma etapa do programa
+// @Tags etapas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Success 200 {object} models.EtapaPrograma
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Etapa não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id} [get]
+func (h *EtapaProgramaHandler) GetEtapa(c *gin.Context) {
+	programaID, etapaID, ok := parseEtapaParams(c)
+	if !ok {
+		return
+	}
+
+	etapa, err := h.service.GetEtapa(c.Request.Context(), programaID, etapaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, etapa)
+}
+
+// UpdateEtapa godoc
+// @Summary Atualizar uma etapa
+// @Description Atualiza a descrição e o critério de sucesso de uma etapa
+// @Tags etapas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Param etapa body models.UpdateEtapaProgramaRequest true "Dados da etapa"
+// @Success 200 {object} models.EtapaPrograma
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Etapa não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id} [put]
+func (h *EtapaProgramaHandler) UpdateEtapa(c *gin.Context) {
+	programaID, etapaID, ok := parseEtapaParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateEtapaProgramaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	etapa, err := h.service.UpdateEtapa(c.Request.Context(), programaID, etapaID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, etapa)
+}
+
+// ReordenarEtapas godoc
+// @Summary Reordenar etapas do programa
+// @Description Redefine a sequência das etapas ativas; a lista deve conter todas elas, cada uma uma única vez
+// @Tags etapas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param ordem body models.ReordenarEtapasRequest true "IDs das etapas na nova ordem"
+// @Success 200 {array} models.EtapaPrograma
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Programa ABA não encontrado"
+// @Failure 422 {object} map[string]string "Sequência inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/ordem [put]
+func (h *EtapaProgramaHandler) ReordenarEtapas(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	var req models.ReordenarEtapasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	etapas, err := h.service.ReordenarEtapas(c.Request.Context(), programaID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, etapas)
+}
+
+// RetirarEtapa godoc
+// @Summary Retirar uma etapa do programa
+// @Description Remove a etapa da sequência de ensino, preservando os dados já coletados
+// @Tags etapas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Success 200 {object} models.EtapaPrograma
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Etapa não encontrada"
+// @Failure 422 {object} map[string]string "Etapa já retirada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/retirar [post]
+func (h *EtapaProgramaHandler) RetirarEtapa(c *gin.Context) {
+	programaID, etapaID, ok := parseEtapaParams(c)
+	if !ok {
+		return
+	}
+
+	etapa, err := h.service.RetirarEtapa(c.Request.Context(), programaID, etapaID, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, etapa)
+}
+
+// GetProgresso godoc
+// @Summary Progresso do programa
+// @Description Retorna a etapa em treino e quantas etapas estão dominadas ou ainda não iniciadas
+// @Tags etapas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Success 200 {object} models.ProgressoProgramaResponse
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Programa ABA não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/progresso [get]
+func (h *EtapaProgramaHandler) GetProgresso(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	progresso, err := h.service.GetProgresso(c.Request.Context(), programaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progresso)
+}
+
+// GetAvaliacaoDominio godoc
+// @Summary Avaliar o critério de domínio de uma etapa
+// @Description Mostra se as sessões mais recentes da etapa atendem ao critério de domínio, sem alterar a etapa
+// @Tags etapas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Success 200 {object} models.ResultadoAvaliacaoDominio
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Etapa não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/dominio [get]
+func (h *EtapaProgramaHandler) GetAvaliacaoDominio(c *gin.Context) {
+	programaID, etapaID, ok := parseEtapaParams(c)
+	if !ok {
+		return
+	}
+
+	resultado, err := h.avaliacao.AvaliarEtapaDoPrograma(c.Request.Context(), programaID, etapaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resultado)
+}
+
+// ListHistorico godoc
+// @Summary Histórico de transições do programa
+// @Description Retorna os eventos de auditoria das mudanças de situação do programa e de suas etapas
+// @Tags etapas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Success 200 {array} models.EventoAuditoria
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Programa ABA não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/historico [get]
+func (h *EtapaProgramaHandler) ListHistorico(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	eventos, err := h.service.ListHistorico(c.Request.Context(), programaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, eventos)
+}
+
+// handleError converte os erros do serviço de etapas em respostas HTTP
+func (h *EtapaProgramaHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrProgramaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Programa ABA não encontrado"})
+	case service.ErrEtapaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Etapa do programa não encontrada"})
+	case service.ErrSequenciaEtapasInvalida, service.ErrEtapaRetirada:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseEtapaParams extrai os IDs do programa e da etapa da rota
+func parseEtapaParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	etapaID, err := uuid.Parse(c.Param("etapa_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da etapa inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return programaID, etapaID, true
+}
@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// FaseIntervencaoHandler gerencia as requisições HTTP relacionadas às fases de programas e comportamentos
+type FaseIntervencaoHandler struct {
+	service *service.FaseIntervencaoService
+}
+
+// NewFaseIntervencaoHandler cria uma nova instância de FaseIntervencaoHandler
+func NewFaseIntervencaoHandler(service *service.FaseIntervencaoService) *FaseIntervencaoHandler {
+	return &FaseIntervencaoHandler{service: service}
+}
+
+// CreateFasePrograma godoc
+// @Summary Registrar uma fase do programa
+// @Description Registra o início de uma fase (linha de base, intervenção, manutenção ou generalização) do programa ABA
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param fase body models.CreateFaseIntervencaoRequest true "Dados da fase"
+// @Success 201 {object} models.FaseIntervencao
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Programa ABA não encontrado"
+// @Failure 409 {object} map[string]string "Já existe uma fase iniciando nesta data"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/fases [post]
+func (h *FaseIntervencaoHandler) CreateFasePrograma(c *gin.Context) {
+	h.createFase(c, models.EntidadeFaseProgramaABA)
+}
+
+// ListFasesPrograma godoc
+// @Summary Listar as fases do programa
+// @Description Retorna as fases do programa ABA em ordem cronológica
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Success 200 {array} models.FaseIntervencao
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Programa ABA não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/fases [get]
+func (h *FaseIntervencaoHandler) ListFasesPrograma(c *gin.Context) {
+	h.listFases(c, models.EntidadeFaseProgramaABA)
+}
+
+// UpdateFasePrograma godoc
+// @Summary Atualizar uma fase do programa
+// @Description Atualiza o tipo, a data de início ou a descrição de uma fase do programa ABA
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param fase_id path string true "ID da fase"
+// @Param fase body models.UpdateFaseIntervencaoRequest true "Dados a atualizar"
+// @Success 200 {object} models.FaseIntervencao
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Fase não encontrada"
+// @Failure 409 {object} map[string]string "Já existe uma fase iniciando nesta data"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/fases/{fase_id} [put]
+func (h *FaseIntervencaoHandler) UpdateFasePrograma(c *gin.Context) {
+	h.updateFase(c, models.EntidadeFaseProgramaABA)
+}
+
+// DeleteFasePrograma godoc
+// @Summary Excluir uma fase do programa
+// @Description Exclui uma fase do programa ABA (soft delete); seus dados passam a pertencer à fase anterior
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param fase_id path string true "ID da fase"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Fase não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/fases/{fase_id} [delete]
+func (h *FaseIntervencaoHandler) DeleteFasePrograma(c *gin.Context) {
+	h.deleteFase(c, models.EntidadeFaseProgramaABA)
+}
+
+// GetResumoFasesPrograma godoc
+// @Summary Resumo do programa por fase
+// @Description Resume o percentual de respostas por sessão em cada fase (média, mediana, inclinação) e compara média, nível e tendência entre fases adjacentes
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param metrica query string false "Métrica: independente ou acerto (padrão: independente)"
+// @Success 200 {object} models.ResumoFasesResponse
+// @Failure 400 {object} map[string]string "ID ou métrica inválida"
+// @Failure 404 {object} map[string]string "Programa ABA não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/fases/resumo [get]
+func (h *FaseIntervencaoHandler) GetResumoFasesPrograma(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	metrica := models.MetricaDominio(c.DefaultQuery("metrica", string(models.MetricaDominioIndependente)))
+	if metrica != models.MetricaDominioIndependente && metrica != models.MetricaDominioAcerto {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Métrica inválida: use independente ou acerto"})
+		return
+	}
+
+	resumo, err := h.service.ResumoPrograma(c.Request.Context(), programaID, metrica)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resumo)
+}
+
+// CreateFaseComportamento godoc
+// @Summary Registrar uma fase do comportamento
+// @Description Registra o início de uma fase (linha de base, intervenção, manutenção ou generalização) do comportamento alvo
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param fase body models.CreateFaseIntervencaoRequest true "Dados da fase"
+// @Success 201 {object} models.FaseIntervencao
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 409 {object} map[string]string "Já existe uma fase iniciando nesta data"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/fases [post]
+func (h *FaseIntervencaoHandler) CreateFaseComportamento(c *gin.Context) {
+	h.createFase(c, models.EntidadeFaseComportamentoAlvo)
+}
+
+// ListFasesComportamento godoc
+// @Summary Listar as fases do comportamento
+// @Description Retorna as fases do comportamento alvo em ordem cronológica
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Success 200 {array} models.FaseIntervencao
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/fases [get]
+func (h *FaseIntervencaoHandler) ListFasesComportamento(c *gin.Context) {
+	h.listFases(c, models.EntidadeFaseComportamentoAlvo)
+}
+
+// UpdateFaseComportamento godoc
+// @Summary Atualizar uma fase do comportamento
+// @Description Atualiza o tipo, a data de início ou a descrição de uma fase do comportamento alvo
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param fase_id path string true "ID da fase"
+// @Param fase body models.UpdateFaseIntervencaoRequest true "Dados a atualizar"
+// @Success 200 {object} models.FaseIntervencao
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Fase não encontrada"
+// @Failure 409 {object} map[string]string "Já existe uma fase iniciando nesta data"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/fases/{fase_id} [put]
+func (h *FaseIntervencaoHandler) UpdateFaseComportamento(c *gin.Context) {
+	h.updateFase(c, models.EntidadeFaseComportamentoAlvo)
+}
+
+// DeleteFaseComportamento godoc
+// @Summary Excluir uma fase do comportamento
+// @Description Exclui uma fase do comportamento alvo (soft delete); seus dados passam a pertencer à fase anterior
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param fase_id path string true "ID da fase"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Fase não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/fases/{fase_id} [delete]
+func (h *FaseIntervencaoHandler) DeleteFaseComportamento(c *gin.Context) {
+	h.deleteFase(c, models.EntidadeFaseComportamentoAlvo)
+}
+
+// GetResumoFasesComportamento godoc
+// @Summary Resumo do comportamento por fase
+// @Description Resume a medida diária do comportamento em cada fase, considerando os dias com registros, e compara média, nível e tendência entre fases adjacentes
+// @Tags fases
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Success 200 {object} models.ResumoFasesResponse
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/fases/resumo [get]
+func (h *FaseIntervencaoHandler) GetResumoFasesComportamento(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	resumo, err := h.service.ResumoComportamento(c.Request.Context(), comportamentoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resumo)
+}
+
+// createFase registra uma fase para a entidade identificada pelo parâmetro "id" da rota
+func (h *FaseIntervencaoHandler) createFase(c *gin.Context, entidadeTipo string) {
+	entidadeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req models.CreateFaseIntervencaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fase, err := h.service.CreateFase(c.Request.Context(), entidadeTipo, entidadeID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, fase)
+}
+
+// listFases lista as fases da entidade identificada pelo parâmetro "id" da rota
+func (h *FaseIntervencaoHandler) listFases(c *gin.Context, entidadeTipo string) {
+	entidadeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	fases, err := h.service.ListFases(c.Request.Context(), entidadeTipo, entidadeID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fases)
+}
+
+// updateFase atualiza uma fase da entidade identificada pelo parâmetro "id" da rota
+func (h *FaseIntervencaoHandler) updateFase(c *gin.Context, entidadeTipo string) {
+	entidadeID, faseID, ok := parseFaseParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateFaseIntervencaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fase, err := h.service.UpdateFase(c.Request.Context(), entidadeTipo, entidadeID, faseID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fase)
+}
+
+// deleteFase exclui uma fase da entidade identificada pelo parâmetro "id" da rota
+func (h *FaseIntervencaoHandler) deleteFase(c *gin.Context, entidadeTipo string) {
+	entidadeID, faseID, ok := parseFaseParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteFase(c.Request.Context(), entidadeTipo, entidadeID, faseID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError converte os erros do serviço de fases em respostas HTTP
+func (h *FaseIntervencaoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrProgramaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Programa ABA não encontrado"})
+	case service.ErrComportamentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comportamento alvo não encontrado"})
+	case service.ErrFaseNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Fase não encontrada"})
+	case service.ErrFaseDataDuplicada:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseFaseParams extrai os IDs da entidade e da fase da rota
+func parseFaseParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	entidadeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	faseID, err := uuid.Parse(c.Param("fase_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da fase inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return entidadeID, faseID, true
+}
@@ -0,0 +1,531 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// FidelidadeHandler gerencia as requisições HTTP relacionadas à fidelidade de implementação dos programas
+type FidelidadeHandler struct {
+	service *service.FidelidadeService
+}
+
+// NewFidelidadeHandler cria uma nova instância de FidelidadeHandler
+func NewFidelidadeHandler(service *service.FidelidadeService) *FidelidadeHandler {
+	return &FidelidadeHandler{service: service}
+}
+
+// CreateChecklist godoc
+// @Summary Criar uma lista de verificação de fidelidade
+// @Description Cria uma lista de verificação da integridade do tratamento para o programa ABA, com os passos do procedimento na ordem de execução
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param checklist body models.CreateChecklistFidelidadeRequest true "Dados da lista de verificação"
+// @Success 201 {object} models.ChecklistFidelidade
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Programa não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/checklists-fidelidade [post]
+func (h *FidelidadeHandler) CreateChecklist(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	var req models.CreateChecklistFidelidadeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checklist, err := h.service.CreateChecklist(c.Request.Context(), programaID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, checklist)
+}
+
+// GetChecklist godoc
+// @Summary Obter uma lista de verificação de fidelidade
+// @Description Retorna a lista de verificação com os seus itens
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param checklist_id path string true "ID da lista de verificação"
+// @Success 200 {object} models.ChecklistFidelidade
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Lista de verificação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/checklists-fidelidade/{checklist_id} [get]
+func (h *FidelidadeHandler) GetChecklist(c *gin.Context) {
+	programaID, checklistID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	checklist, err := h.service.GetChecklist(c.Request.Context(), programaID, checklistID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, checklist)
+}
+
+// UpdateChecklist godoc
+// @Summary Atualizar uma lista de verificação de fidelidade
+// @Description Atualiza os dados da lista de verificação. Quando "itens" é informado, os passos anteriores são substituídos
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param checklist_id path string true "ID da lista de verificação"
+// @Param checklist body models.UpdateChecklistFidelidadeRequest true "Dados a atualizar"
+// @Success 200 {object} models.ChecklistFidelidade
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Lista de verificação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/checklists-fidelidade/{checklist_id} [put]
+func (h *FidelidadeHandler) UpdateChecklist(c *gin.Context) {
+	programaID, checklistID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateChecklistFidelidadeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checklist, err := h.service.UpdateChecklist(c.Request.Context(), programaID, checklistID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, checklist)
+}
+
+// DeleteChecklist godoc
+// @Summary Excluir uma lista de verificação de fidelidade
+// @Description Exclui a lista de verificação (soft delete). As avaliações já registradas são preservadas
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param checklist_id path string true "ID da lista de verificação"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Lista de verificação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/checklists-fidelidade/{checklist_id} [delete]
+func (h *FidelidadeHandler) DeleteChecklist(c *gin.Context) {
+	programaID, checklistID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteChecklist(c.Request.Context(), programaID, checklistID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListChecklists godoc
+// @Summary Listar as listas de verificação de um programa
+// @Description Retorna as listas de verificação de fidelidade do programa ABA, com os seus itens
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Success 200 {array} models.ChecklistFidelidade
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Programa não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/checklists-fidelidade [get]
+func (h *FidelidadeHandler) ListChecklists(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	checklists, err := h.service.ListChecklists(c.Request.Context(), programaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, checklists)
+}
+
+// CreateAvaliacao godoc
+// @Summary Registrar uma avaliação de fidelidade
+// @Description Registra a lista de verificação preenchida pelo supervisor para a sessão. O percentual de fidelidade considera apenas os itens aplicáveis e a avaliação é sinalizada quando fica abaixo do limiar da clínica
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param avaliacao body models.CreateAvaliacaoFidelidadeRequest true "Respostas da lista de verificação"
+// @Success 201 {object} models.AvaliacaoFidelidade
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Sessão, lista de verificação ou programa não encontrado"
+// @Failure 422 {object} map[string]string "Respostas incompletas, lista inativa, de outro paciente ou sessão cancelada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/fidelidade [post]
+func (h *FidelidadeHandler) CreateAvaliacao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	var req models.CreateAvaliacaoFidelidadeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	avaliacao, err := h.service.CreateAvaliacao(c.Request.Context(), sessaoID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, avaliacao)
+}
+
+// GetAvaliacao godoc
+// @Summary Obter uma avaliação de fidelidade
+// @Description Retorna a avaliação de fidelidade com a pontuação de cada item
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Success 200 {object} models.AvaliacaoFidelidade
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/fidelidade/{avaliacao_id} [get]
+func (h *FidelidadeHandler) GetAvaliacao(c *gin.Context) {
+	sessaoID, avaliacaoID, ok := parseAvaliacaoFidelidadeParams(c)
+	if !ok {
+		return
+	}
+
+	avaliacao, err := h.service.GetAvaliacao(c.Request.Context(), sessaoID, avaliacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacao)
+}
+
+// UpdateAvaliacao godoc
+// @Summary Atualizar uma avaliação de fidelidade
+// @Description Atualiza as respostas ou as observações da avaliação e recalcula o percentual de fidelidade
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Param avaliacao body models.UpdateAvaliacaoFidelidadeRequest true "Dados a atualizar"
+// @Success 200 {object} models.AvaliacaoFidelidade
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Avaliação ou lista de verificação não encontrada"
+// @Failure 422 {object} map[string]string "Respostas incompletas"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/fidelidade/{avaliacao_id} [put]
+func (h *FidelidadeHandler) UpdateAvaliacao(c *gin.Context) {
+	sessaoID, avaliacaoID, ok := parseAvaliacaoFidelidadeParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateAvaliacaoFidelidadeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	avaliacao, err := h.service.UpdateAvaliacao(c.Request.Context(), sessaoID, avaliacaoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacao)
+}
+
+// DeleteAvaliacao godoc
+// @Summary Excluir uma avaliação de fidelidade
+// @Description Exclui uma avaliação de fidelidade (soft delete)
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param avaliacao_id path string true "ID da avaliação"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Avaliação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/fidelidade/{avaliacao_id} [delete]
+func (h *FidelidadeHandler) DeleteAvaliacao(c *gin.Context) {
+	sessaoID, avaliacaoID, ok := parseAvaliacaoFidelidadeParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAvaliacao(c.Request.Context(), sessaoID, avaliacaoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListAvaliacoesSessao godoc
+// @Summary Listar as avaliações de fidelidade de uma sessão
+// @Description Retorna as avaliações de fidelidade registradas para a sessão
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Success 200 {array} models.AvaliacaoFidelidade
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/fidelidade [get]
+func (h *FidelidadeHandler) ListAvaliacoesSessao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	avaliacoes, err := h.service.ListAvaliacoesSessao(c.Request.Context(), sessaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, avaliacoes)
+}
+
+// ResumoTerapeuta godoc
+// @Summary Evolução da fidelidade de um terapeuta
+// @Description Retorna o percentual médio de fidelidade do terapeuta, a evolução por dia ou por semana e as sessões sinalizadas abaixo do limiar
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param terapeuta_id path string true "ID do terapeuta"
+// @Param periodo query string false "Período de agregação: dia ou semana (padrão: semana)"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {object} models.ResumoFidelidade
+// @Failure 400 {object} map[string]string "ID, data ou período inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/terapeutas/{terapeuta_id}/fidelidade [get]
+func (h *FidelidadeHandler) ResumoTerapeuta(c *gin.Context) {
+	terapeutaID, err := uuid.Parse(c.Param("terapeuta_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do terapeuta inválido"})
+		return
+	}
+
+	periodo, ok := parsePeriodoFidelidade(c)
+	if !ok {
+		return
+	}
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	resumo, err := h.service.ResumoTerapeuta(c.Request.Context(), terapeutaID, inicio, fim, periodo)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resumo)
+}
+
+// ResumoPrograma godoc
+// @Summary Evolução da fidelidade de um programa
+// @Description Retorna o percentual médio de fidelidade de implementação do programa ABA, a evolução por dia ou por semana e as sessões sinalizadas abaixo do limiar
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param periodo query string false "Período de agregação: dia ou semana (padrão: semana)"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {object} models.ResumoFidelidade
+// @Failure 400 {object} map[string]string "ID, data ou período inválido"
+// @Failure 404 {object} map[string]string "Programa não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/fidelidade [get]
+func (h *FidelidadeHandler) ResumoPrograma(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	periodo, ok := parsePeriodoFidelidade(c)
+	if !ok {
+		return
+	}
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	resumo, err := h.service.ResumoPrograma(c.Request.Context(), programaID, inicio, fim, periodo)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resumo)
+}
+
+// ListSinalizadas godoc
+// @Summary Listar avaliações de fidelidade sinalizadas
+// @Description Retorna as avaliações de fidelidade abaixo do limiar da clínica, das mais recentes para as mais antigas
+// @Tags fidelidade
+// @Accept json
+// @Produce json
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de avaliações e metadados de paginação"
+// @Failure 400 {object} map[string]string "Data inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/avaliacoes-fidelidade/sinalizadas [get]
+func (h *FidelidadeHandler) ListSinalizadas(c *gin.Context) {
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	avaliacoes, total, err := h.service.ListSinalizadas(c.Request.Context(), inicio, fim, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       avaliacoes,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// handleError converte os erros do serviço de fidelidade em respostas HTTP
+func (h *FidelidadeHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrProgramaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Programa não encontrado"})
+	case service.ErrSessaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+	case service.ErrChecklistNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Lista de verificação de fidelidade não encontrada"})
+	case service.ErrAvaliacaoFidelidadeNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Avaliação de fidelidade não encontrada"})
+	case service.ErrInvalidInput, service.ErrPeriodoInvalido:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrRespostasFidelidade, service.ErrChecklistInativo, service.ErrChecklistForaDoPaciente, service.ErrSessaoCancelada:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseChecklistParams extrai os IDs do programa e da lista de verificação da rota
+func parseChecklistParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	checklistID, err := uuid.Parse(c.Param("checklist_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da lista de verificação inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return programaID, checklistID, true
+}
+
+// parseAvaliacaoFidelidadeParams extrai os IDs da sessão e da avaliação da rota
+func parseAvaliacaoFidelidadeParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	avaliacaoID, err := uuid.Parse(c.Param("avaliacao_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da avaliação inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return sessaoID, avaliacaoID, true
+}
+
+// parsePeriodoFidelidade extrai o período de agregação da evolução da fidelidade
+func parsePeriodoFidelidade(c *gin.Context) (models.PeriodoAgregacao, bool) {
+	periodo := models.PeriodoAgregacao(c.DefaultQuery("periodo", string(models.PeriodoAgregacaoSemana)))
+	if periodo != models.PeriodoAgregacaoDia && periodo != models.PeriodoAgregacaoSemana {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Período de agregação inválido: use dia ou semana"})
+		return "", false
+	}
+	return periodo, true
+}
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/grafico"
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// GraficoHandler gerencia as requisições HTTP de renderização de gráficos
+type GraficoHandler struct {
+	service *service.GraficoService
+}
+
+// NewGraficoHandler cria uma nova instância de GraficoHandler
+func NewGraficoHandler(service *service.GraficoService) *GraficoHandler {
+	return &GraficoHandler{service: service}
+}
+
+// GetGraficoPrograma godoc
+// @Summary Gráfico de sessões de um programa ABA
+// @Description Renderiza o percentual de respostas por sessão realizada, com linhas de mudança de fase entre etapas, caminhos de dados interrompidos entre fases e linha de tendência opcional
+// @Tags graficos
+// @Produce image/svg+xml
+// @Produce image/png
+// @Param id path string true "ID do programa ABA"
+// @Param formato query string false "Formato de saída: svg ou png (padrão: svg)"
+// @Param etapa_id query string false "ID da etapa, para restringir o gráfico a uma etapa"
+// @Param metrica query string false "Métrica: independente ou acerto (padrão: independente)"
+// @Param tendencia query bool false "Incluir linha de tendência por fase"
+// @Success 200 {file} file "Imagem do gráfico"
+// @Failure 400 {object} map[string]string "ID ou parâmetro inválido"
+// @Failure 404 {object} map[string]string "Programa ABA ou etapa não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/grafico [get]
+func (h *GraficoHandler) GetGraficoPrograma(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	var etapaID *uuid.UUID
+	if raw := c.Query("etapa_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID da etapa inválido"})
+			return
+		}
+		etapaID = &parsed
+	}
+
+	metrica := models.MetricaDominio(c.DefaultQuery("metrica", string(models.MetricaDominioIndependente)))
+	if metrica != models.MetricaDominioIndependente && metrica != models.MetricaDominioAcerto {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Métrica inválida: use independente ou acerto"})
+		return
+	}
+
+	formato, tendencia, ok := parseOpcoesGrafico(c)
+	if !ok {
+		return
+	}
+
+	g, err := h.service.GraficoPrograma(c.Request.Context(), programaID, etapaID, metrica, tendencia)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.renderizar(c, g, formato)
+}
+
+// GetGraficoComportamento godoc
+// @Summary Gráfico diário de um comportamento alvo
+// @Description Renderiza a medida diária do comportamento (frequência, duração, intensidade média ou percentual de intervalos), com linha de tendência opcional
+// @Tags graficos
+// @Produce image/svg+xml
+// @Produce image/png
+// @Param id path string true "ID do comportamento alvo"
+// @Param formato query string false "Formato de saída: svg ou png (padrão: svg)"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Param tendencia query bool false "Incluir linha de tendência"
+// @Success 200 {file} file "Imagem do gráfico"
+// @Failure 400 {object} map[string]string "ID ou parâmetro inválido"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/grafico [get]
+func (h *GraficoHandler) GetGraficoComportamento(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	formato, tendencia, ok := parseOpcoesGrafico(c)
+	if !ok {
+		return
+	}
+
+	g, err := h.service.GraficoComportamento(c.Request.Context(), comportamentoID, inicio, fim, tendencia)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.renderizar(c, g, formato)
+}
+
+// GetDispersaoComportamento godoc
+// @Summary Gráfico de dispersão de um comportamento alvo
+// @Description Agrupa as ocorrências registradas do comportamento por dia da semana ou por data e por bloco de horário (scatterplot). Retorna a grade em JSON ou o mapa de calor em SVG ou PNG; quando normalizado, cada célula traz a taxa de ocorrências por hora observada, calculada a partir da duração das sessões realizadas
+// @Tags graficos
+// @Produce json
+// @Produce image/svg+xml
+// @Produce image/png
+// @Param id path string true "ID do comportamento alvo"
+// @Param formato query string false "Formato de saída: json, svg ou png (padrão: json)"
+// @Param eixo query string false "Agrupamento dos dias: dia_semana ou data (padrão: dia_semana)"
+// @Param bloco_minutos query int false "Tamanho do bloco de horário em minutos, divisor de 1440 (padrão: 30)"
+// @Param hora_inicio query int false "Primeira hora exibida (0 a 23)"
+// @Param hora_fim query int false "Hora final exibida, exclusiva (1 a 24)"
+// @Param normalizar query bool false "Dividir as ocorrências pelo tempo observado nas sessões"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {object} models.DispersaoComportamento
+// @Failure 400 {object} map[string]string "ID, parâmetro ou período inválido"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/dispersao [get]
+func (h *GraficoHandler) GetDispersaoComportamento(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	formato := c.DefaultQuery("formato", "json")
+	if formato != "json" && formato != string(grafico.FormatoSVG) && formato != string(grafico.FormatoPNG) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Formato inválido: use json, svg ou png"})
+		return
+	}
+
+	opcoes, ok := parseOpcoesDispersao(c)
+	if !ok {
+		return
+	}
+
+	dispersao, err := h.service.DispersaoComportamento(c.Request.Context(), comportamentoID, inicio, fim, opcoes)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if formato == "json" {
+		c.JSON(http.StatusOK, dispersao)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := grafico.RenderizarMapaCalor(&buf, service.MapaCalorDispersao(dispersao), grafico.Formato(formato)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, grafico.Formato(formato).ContentType(), buf.Bytes())
+}
+
+// renderizar escreve o gráfico na resposta no formato solicitado
+func (h *GraficoHandler) renderizar(c *gin.Context, g *grafico.Grafico, formato grafico.Formato) {
+	var buf bytes.Buffer
+	if err := grafico.Renderizar(&buf, g, formato); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, formato.ContentType(), buf.Bytes())
+}
+
+// handleError converte os erros do serviço de gráficos em respostas HTTP
+func (h *GraficoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrProgramaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Programa ABA não encontrado"})
+	case service.ErrEtapaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Etapa do programa não encontrada"})
+	case service.ErrComportamentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comportamento alvo não encontrado"})
+	case service.ErrPeriodoInvalido, service.ErrOpcoesDispersao, service.ErrPeriodoDispersaoLongo:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseOpcoesGrafico extrai o formato de saída e a opção de linha de tendência dos parâmetros de consulta
+func parseOpcoesGrafico(c *gin.Context) (grafico.Formato, bool, bool) {
+	formato := grafico.Formato(c.DefaultQuery("formato", string(grafico.FormatoSVG)))
+	if formato != grafico.FormatoSVG && formato != grafico.FormatoPNG {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Formato inválido: use svg ou png"})
+		return "", false, false
+	}
+
+	tendencia, err := strconv.ParseBool(c.DefaultQuery("tendencia", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro tendencia inválido"})
+		return "", false, false
+	}
+
+	return formato, tendencia, true
+}
+
+// parseOpcoesDispersao extrai o agrupamento dos dias, o tamanho do bloco, a faixa de horas e a normalização
+// do gráfico de dispersão dos parâmetros de consulta
+func parseOpcoesDispersao(c *gin.Context) (models.OpcoesDispersao, bool) {
+	opcoes := models.OpcoesDispersao{Eixo: models.EixoDispersao(c.Query("eixo"))}
+
+	inteiro := func(nome string) (*int, bool) {
+		raw := c.Query(nome)
+		if raw == "" {
+			return nil, true
+		}
+		valor, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro " + nome + " inválido"})
+			return nil, false
+		}
+		return &valor, true
+	}
+	bloco, ok := inteiro("bloco_minutos")
+	if !ok {
+		return opcoes, false
+	}
+	if bloco != nil {
+		opcoes.BlocoMinutos = *bloco
+	}
+	if opcoes.HoraInicio, ok = inteiro("hora_inicio"); !ok {
+		return opcoes, false
+	}
+	if opcoes.HoraFim, ok = inteiro("hora_fim"); !ok {
+		return opcoes, false
+	}
+
+	normalizar, err := strconv.ParseBool(c.DefaultQuery("normalizar", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetro normalizar inválido"})
+		return opcoes, false
+	}
+	opcoes.Normalizar = normalizar
+	return opcoes, true
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// HierarquiaPromptHandler gerencia as requisições HTTP relacionadas à hierarquia de prompts
+type HierarquiaPromptHandler struct {
+	service *service.HierarquiaPromptService
+}
+
+// NewHierarquiaPromptHandler cria uma nova instância de HierarquiaPromptHandler
+func NewHierarquiaPromptHandler(service *service.HierarquiaPromptService) *HierarquiaPromptHandler {
+	return &HierarquiaPromptHandler{service: service}
+}
+
+// DefinirHierarquia godoc
+// @Summary Definir a hierarquia de prompts do programa
+// @Description Cria ou substitui a hierarquia de prompts, do mais intrusivo para o menos intrusivo, e as regras de esvanecimento
+// @Tags hierarquia-prompt
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param hierarquia body models.DefinirHierarquiaPromptRequest true "Níveis e regras da hierarquia"
+// @Success 200 {object} models.HierarquiaPrompt
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Programa ABA ou tipo de prompt não encontrado"
+// @Failure 422 {object} map[string]string "Hierarquia inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/hierarquia-prompt [put]
+func (h *HierarquiaPromptHandler) DefinirHierarquia(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	var req models.DefinirHierarquiaPromptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hierarquia, err := h.service.DefinirHierarquia(c.Request.Context(), programaID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hierarquia)
+}
+
+// GetHierarquia godoc
+// @Summary Obter a hierarquia de prompts do programa
+// @Description Retorna os níveis da hierarquia de prompts e as regras de esvanecimento do programa
+// @Tags hierarquia-prompt
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Success 200 {object} models.HierarquiaPrompt
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Hierarquia não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/hierarquia-prompt [get]
+func (h *HierarquiaPromptHandler) GetHierarquia(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	hierarquia, err := h.service.GetHierarquia(c.Request.Context(), programaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hierarquia)
+}
+
+// GetRecomendacao godoc
+// @Summary Recomendar o nível de prompt da próxima sessão
+// @Description Analisa as sessões realizadas mais recentes da etapa e recomenda manter, esvanecer ou aumentar a ajuda
+// @Tags hierarquia-prompt
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Param etapa_id path string true "ID da etapa"
+// @Success 200 {object} models.RecomendacaoPromptResponse
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Hierarquia ou etapa não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/etapas/{etapa_id}/recomendacao-prompt [get]
+func (h *HierarquiaPromptHandler) GetRecomendacao(c *gin.Context) {
+	programaID, etapaID, ok := parseEtapaParams(c)
+	if !ok {
+		return
+	}
+
+	recomendacao, err := h.service.RecomendarEtapa(c.Request.Context(), programaID, etapaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recomendacao)
+}
+
+// ListAlertasPrograma godoc
+// @Summary Listar alertas de prompt do programa
+// @Description Lista as etapas em treino em que o aprendiz está preso em um nível de prompt ou dependente de prompt
+// @Tags hierarquia-prompt
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Success 200 {array} models.RecomendacaoPromptResponse
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Hierarquia não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/alertas-prompt [get]
+func (h *HierarquiaPromptHandler) ListAlertasPrograma(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	alertas, err := h.service.ListAlertasPrograma(c.Request.Context(), programaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alertas)
+}
+
+// ListAlertasPaciente godoc
+// @Summary Listar alertas de prompt do paciente
+// @Description Lista os alertas de prompt de todos os programas ativos do paciente que possuem hierarquia definida
+// @Tags hierarquia-prompt
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Success 200 {array} models.RecomendacaoPromptResponse
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/alertas-prompt [get]
+func (h *HierarquiaPromptHandler) ListAlertasPaciente(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	alertas, err := h.service.ListAlertasPaciente(c.Request.Context(), pacienteID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alertas)
+}
+
+// handleError converte os erros do serviço de hierarquia de prompts em respostas HTTP
+func (h *HierarquiaPromptHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrProgramaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Programa ABA não encontrado"})
+	case service.ErrEtapaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Etapa do programa não encontrada"})
+	case service.ErrTipoPromptNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tipo de prompt não encontrado"})
+	case service.ErrHierarquiaPromptNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.ErrHierarquiaPromptInvalida:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
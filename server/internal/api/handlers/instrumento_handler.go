@@ -0,0 +1,445 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// InstrumentoHandler gerencia as requisições HTTP dos questionários padronizados e das suas aplicações
+type InstrumentoHandler struct {
+	service *service.InstrumentoService
+}
+
+// NewInstrumentoHandler cria uma nova instância de InstrumentoHandler
+func NewInstrumentoHandler(service *service.InstrumentoService) *InstrumentoHandler {
+	return &InstrumentoHandler{service: service}
+}
+
+// CreateInstrumento godoc
+// @Summary Criar uma versão de instrumento
+// @Description Cria a definição de um questionário padronizado com as escalas de resposta, as subescalas, os itens (invertidos ou não) e as faixas de classificação. Uma definição com um código já cadastrado cria a próxima versão; a definição não pode ser alterada depois da criação
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param instrumento body models.CreateInstrumentoRequest true "Definição do instrumento"
+// @Success 201 {object} models.Instrumento
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 422 {object} map[string]string "Definição inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/instrumentos [post]
+func (h *InstrumentoHandler) CreateInstrumento(c *gin.Context) {
+	var req models.CreateInstrumentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	instrumento, err := h.service.CreateInstrumento(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, instrumento)
+}
+
+// GetInstrumento godoc
+// @Summary Obter uma versão de instrumento
+// @Description Retorna a definição completa da versão do instrumento
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da versão do instrumento"
+// @Success 200 {object} models.Instrumento
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Instrumento não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/instrumentos/{id} [get]
+func (h *InstrumentoHandler) GetInstrumento(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do instrumento inválido"})
+		return
+	}
+
+	instrumento, err := h.service.GetInstrumento(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, instrumento)
+}
+
+// UpdateInstrumento godoc
+// @Summary Atualizar uma versão de instrumento
+// @Description Atualiza o nome, a descrição ou a situação da versão. Versões inativas não podem ser aplicadas
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da versão do instrumento"
+// @Param instrumento body models.UpdateInstrumentoRequest true "Dados a atualizar"
+// @Success 200 {object} models.Instrumento
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Instrumento não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/instrumentos/{id} [put]
+func (h *InstrumentoHandler) UpdateInstrumento(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do instrumento inválido"})
+		return
+	}
+
+	var req models.UpdateInstrumentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	instrumento, err := h.service.UpdateInstrumento(c.Request.Context(), id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, instrumento)
+}
+
+// DeleteInstrumento godoc
+// @Summary Excluir uma versão de instrumento
+// @Description Exclui uma versão que ainda não foi aplicada. Versões aplicadas podem ser inativadas
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da versão do instrumento"
+// @Success 204 "Instrumento excluído com sucesso"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Instrumento não encontrado"
+// @Failure 422 {object} map[string]string "Instrumento já aplicado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/instrumentos/{id} [delete]
+func (h *InstrumentoHandler) DeleteInstrumento(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do instrumento inválido"})
+		return
+	}
+
+	if err := h.service.DeleteInstrumento(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListInstrumentos godoc
+// @Summary Listar versões de instrumentos
+// @Description Retorna as versões de instrumentos ordenadas por código e da mais recente para a mais antiga, com as subescalas e sem os itens
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param codigo query string false "Código do instrumento"
+// @Param ativos query bool false "Retornar apenas as versões ativas (padrão: false)"
+// @Success 200 {array} models.Instrumento
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/instrumentos [get]
+func (h *InstrumentoHandler) ListInstrumentos(c *gin.Context) {
+	instrumentos, err := h.service.ListInstrumentos(c.Request.Context(), c.Query("codigo"), c.Query("ativos") == "true")
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, instrumentos)
+}
+
+// ListInstrumentosPadrao godoc
+// @Summary Listar instrumentos padrão
+// @Description Retorna as definições de instrumentos distribuídas com o serviço (M-CHAT-R/F e ATEC), que podem ser instaladas como uma nova versão
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.InstrumentoPadrao
+// @Security BearerAuth
+// @Router /api/v1/instrumentos-padrao [get]
+func (h *InstrumentoHandler) ListInstrumentosPadrao(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.ListInstrumentosPadrao())
+}
+
+// InstalarInstrumentoPadrao godoc
+// @Summary Instalar um instrumento padrão
+// @Description Cria uma nova versão do instrumento a partir da definição distribuída com o serviço
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param codigo path string true "Código do instrumento padrão (mchat_rf ou atec)"
+// @Success 201 {object} models.Instrumento
+// @Failure 404 {object} map[string]string "Instrumento padrão não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/instrumentos-padrao/{codigo} [post]
+func (h *InstrumentoHandler) InstalarInstrumentoPadrao(c *gin.Context) {
+	instrumento, err := h.service.InstalarInstrumentoPadrao(c.Request.Context(), c.Param("codigo"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, instrumento)
+}
+
+// CreateAplicacao godoc
+// @Summary Registrar uma aplicação de instrumento
+// @Description Registra as respostas de um questionário para o paciente e calcula a pontuação total, as pontuações das subescalas e a classificação. Quando a classificação exige a entrevista de seguimento, a aplicação fica aguardando o seguimento
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao body models.CreateAplicacaoInstrumentoRequest true "Instrumento e respostas"
+// @Success 201 {object} models.AplicacaoInstrumento
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Instrumento não encontrado"
+// @Failure 422 {object} map[string]string "Instrumento inativo ou respostas inválidas"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-instrumento [post]
+func (h *InstrumentoHandler) CreateAplicacao(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	var req models.CreateAplicacaoInstrumentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	aplicacao, err := h.service.CreateAplicacao(c.Request.Context(), pacienteID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, aplicacao)
+}
+
+// GetAplicacao godoc
+// @Summary Obter uma aplicação de instrumento
+// @Description Retorna a aplicação com as respostas, os pontos de cada item e as pontuações das subescalas
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Success 200 {object} models.AplicacaoInstrumento
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-instrumento/{aplicacao_id} [get]
+func (h *InstrumentoHandler) GetAplicacao(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoInstrumentoParams(c)
+	if !ok {
+		return
+	}
+
+	aplicacao, err := h.service.GetAplicacao(c.Request.Context(), pacienteID, aplicacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aplicacao)
+}
+
+// DeleteAplicacao godoc
+// @Summary Excluir uma aplicação de instrumento
+// @Description Exclui uma aplicação do paciente
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Success 204 "Aplicação excluída com sucesso"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-instrumento/{aplicacao_id} [delete]
+func (h *InstrumentoHandler) DeleteAplicacao(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoInstrumentoParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAplicacao(c.Request.Context(), pacienteID, aplicacaoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListAplicacoes godoc
+// @Summary Listar aplicações de instrumentos
+// @Description Retorna uma lista paginada das aplicações do paciente, das mais recentes para as mais antigas, com as pontuações das subescalas
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param codigo query string false "Código do instrumento"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de aplicações e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-instrumento [get]
+func (h *InstrumentoHandler) ListAplicacoes(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	aplicacoes, total, err := h.service.ListAplicacoes(c.Request.Context(), pacienteID, c.Query("codigo"), page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       aplicacoes,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// RegistrarSeguimento godoc
+// @Summary Registrar a entrevista de seguimento
+// @Description Registra se cada item que pontuou na etapa inicial foi aprovado ou reprovado na entrevista de seguimento. A pontuação do seguimento é o número de itens reprovados, classificada pelas faixas de seguimento do instrumento, e a aplicação é concluída
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param aplicacao_id path string true "ID da aplicação"
+// @Param seguimento body models.SeguimentoAplicacaoRequest true "Resultado dos itens entrevistados"
+// @Success 200 {object} models.AplicacaoInstrumento
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Aplicação não encontrada"
+// @Failure 422 {object} map[string]string "Seguimento não requerido ou itens inválidos"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/aplicacoes-instrumento/{aplicacao_id}/seguimento [post]
+func (h *InstrumentoHandler) RegistrarSeguimento(c *gin.Context) {
+	pacienteID, aplicacaoID, ok := parseAplicacaoInstrumentoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.SeguimentoAplicacaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	aplicacao, err := h.service.RegistrarSeguimento(c.Request.Context(), pacienteID, aplicacaoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aplicacao)
+}
+
+// GetEvolucao godoc
+// @Summary Evolução das pontuações em um instrumento
+// @Description Retorna as pontuações total e das subescalas de cada aplicação do instrumento ao paciente, em ordem cronológica e com a variação em relação à aplicação anterior
+// @Tags instrumentos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param codigo path string true "Código do instrumento"
+// @Success 200 {object} models.EvolucaoInstrumento
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/instrumentos/{codigo}/evolucao [get]
+func (h *InstrumentoHandler) GetEvolucao(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	evolucao, err := h.service.EvolucaoInstrumento(c.Request.Context(), pacienteID, c.Param("codigo"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, evolucao)
+}
+
+// handleError converte os erros do serviço de instrumentos em respostas HTTP
+func (h *InstrumentoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrInstrumentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Instrumento não encontrado"})
+	case service.ErrInstrumentoPadraoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Instrumento padrão não encontrado"})
+	case service.ErrAplicacaoInstrumentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Aplicação de instrumento não encontrada"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrDefinicaoInstrumentoInvalida, service.ErrInstrumentoEmUso, service.ErrInstrumentoInativo,
+		service.ErrRespostasInstrumentoInvalidas, service.ErrSeguimentoNaoRequerido, service.ErrItensSeguimentoInvalidos:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseAplicacaoInstrumentoParams extrai os IDs do paciente e da aplicação da rota
+func parseAplicacaoInstrumentoParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	aplicacaoID, err := uuid.Parse(c.Param("aplicacao_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da aplicação inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return pacienteID, aplicacaoID, true
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// IOAHandler gerencia as requisições HTTP relacionadas à concordância entre observadores (IOA)
+type IOAHandler struct {
+	service *service.IOAService
+}
+
+// NewIOAHandler cria uma nova instância de IOAHandler
+func NewIOAHandler(service *service.IOAService) *IOAHandler {
+	return &IOAHandler{service: service}
+}
+
+// GetIOASessao godoc
+// @Summary Calcular o IOA de uma sessão
+// @Description Compara os dados dos observadores principal e secundário da sessão e retorna o IOA tentativa a tentativa por etapa e, por comportamento, o IOA de contagem total, de contagem exata por intervalo (frequência) e intervalo a intervalo (registro por intervalos)
+// @Tags ioa
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Param intervalo query int false "Duração dos intervalos da contagem exata, em segundos (padrão: 10)"
+// @Success 200 {object} models.IOASessao
+// @Failure 400 {object} map[string]string "ID ou intervalo inválido"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/ioa [get]
+func (h *IOAHandler) GetIOASessao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	intervalo, err := strconv.Atoi(c.DefaultQuery("intervalo", strconv.Itoa(models.DuracaoIntervaloIOAPadrao)))
+	if err != nil || intervalo < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Intervalo inválido: informe a duração em segundos"})
+		return
+	}
+
+	ioa, err := h.service.CalcularIOASessao(c.Request.Context(), sessaoID, intervalo)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ioa)
+}
+
+// CoberturaPaciente godoc
+// @Summary Cobertura de IOA de um paciente
+// @Description Retorna o percentual de sessões realizadas do paciente com IOA, comparado à meta informada, e a concordância média dessas sessões
+// @Tags ioa
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param meta query number false "Meta de cobertura, em percentual (padrão: 20)"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {object} models.CoberturaIOA
+// @Failure 400 {object} map[string]string "ID, meta ou data inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/ioa/cobertura [get]
+func (h *IOAHandler) CoberturaPaciente(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	meta, ok := parseMetaCobertura(c)
+	if !ok {
+		return
+	}
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	cobertura, err := h.service.CoberturaPaciente(c.Request.Context(), pacienteID, inicio, fim, meta)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cobertura)
+}
+
+// CoberturaTerapeuta godoc
+// @Summary Cobertura de IOA de um terapeuta
+// @Description Retorna o percentual de sessões realizadas pelo terapeuta com IOA, comparado à meta informada, e a concordância média dessas sessões
+// @Tags ioa
+// @Accept json
+// @Produce json
+// @Param terapeuta_id path string true "ID do terapeuta"
+// @Param meta query number false "Meta de cobertura, em percentual (padrão: 20)"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {object} models.CoberturaIOA
+// @Failure 400 {object} map[string]string "ID, meta ou data inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/terapeutas/{terapeuta_id}/ioa/cobertura [get]
+func (h *IOAHandler) CoberturaTerapeuta(c *gin.Context) {
+	terapeutaID, err := uuid.Parse(c.Param("terapeuta_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do terapeuta inválido"})
+		return
+	}
+
+	meta, ok := parseMetaCobertura(c)
+	if !ok {
+		return
+	}
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	cobertura, err := h.service.CoberturaTerapeuta(c.Request.Context(), terapeutaID, inicio, fim, meta)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cobertura)
+}
+
+// handleError converte os erros do serviço de IOA em respostas HTTP
+func (h *IOAHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrSessaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+	case service.ErrPeriodoInvalido:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseMetaCobertura extrai a meta de cobertura de IOA, em percentual, dos parâmetros de consulta
+func parseMetaCobertura(c *gin.Context) (float64, bool) {
+	raw := c.Query("meta")
+	if raw == "" {
+		return models.MetaCoberturaIOAPadrao, true
+	}
+	meta, err := strconv.ParseFloat(raw, 64)
+	if err != nil || meta <= 0 || meta > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Meta de cobertura inválida: informe um percentual entre 0 e 100"})
+		return 0, false
+	}
+	return meta, true
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// ModeloProgramaHandler gerencia as requisições HTTP da biblioteca de modelos de programa
+type ModeloProgramaHandler struct {
+	service *service.ModeloProgramaService
+}
+
+// NewModeloProgramaHandler cria uma nova instância de ModeloProgramaHandler
+func NewModeloProgramaHandler(service *service.ModeloProgramaService) *ModeloProgramaHandler {
+	return &ModeloProgramaHandler{service: service}
+}
+
+// CreateModelo godoc
+// @Summary Criar um modelo de programa
+// @Description Cria um modelo na biblioteca de programas da clínica, com as etapas padrão, os alvos de cada etapa, os critérios de domínio, a rotação de alvos e, opcionalmente, a hierarquia de prompts
+// @Tags modelos-programa
+// @Accept json
+// @Produce json
+// @Param modelo body models.CreateModeloProgramaRequest true "Definição do modelo"
+// @Success 201 {object} models.ModeloPrograma
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 404 {object} map[string]string "Tipo de prompt não encontrado"
+// @Failure 422 {object} map[string]string "Hierarquia de prompts inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/modelos-programa [post]
+func (h *ModeloProgramaHandler) CreateModelo(c *gin.Context) {
+	var req models.CreateModeloProgramaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	modelo, err := h.service.CreateModelo(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, modelo)
+}
+
+// GetModelo godoc
+// @Summary Obter um modelo de programa
+// @Description Retorna o modelo com as etapas, os alvos e a hierarquia de prompts
+// @Tags modelos-programa
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do modelo"
+// @Success 200 {object} models.ModeloPrograma
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Modelo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/modelos-programa/{id} [get]
+func (h *ModeloProgramaHandler) GetModelo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do modelo inválido"})
+		return
+	}
+
+	modelo, err := h.service.GetModelo(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, modelo)
+}
+
+// UpdateModelo godoc
+// @Summary Atualizar um modelo de programa
+// @Description Atualiza os dados do modelo. Quando informadas, as etapas e a hierarquia substituem as do modelo; os programas já instanciados a partir dele não são alterados
+// @Tags modelos-programa
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do modelo"
+// @Param modelo body models.UpdateModeloProgramaRequest true "Dados a atualizar"
+// @Success 200 {object} models.ModeloPrograma
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Modelo ou tipo de prompt não encontrado"
+// @Failure 422 {object} map[string]string "Hierarquia de prompts inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/modelos-programa/{id} [put]
+func (h *ModeloProgramaHandler) UpdateModelo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do modelo inválido"})
+		return
+	}
+
+	var req models.UpdateModeloProgramaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	modelo, err := h.service.UpdateModelo(c.Request.Context(), id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, modelo)
+}
+
+// DeleteModelo godoc
+// @Summary Excluir um modelo de programa
+// @Description Exclui um modelo que ainda não foi instanciado. Modelos em uso podem ser inativados
+// @Tags modelos-programa
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do modelo"
+// @Success 204 "Modelo excluído com sucesso"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Modelo não encontrado"
+// @Failure 422 {object} map[string]string "Modelo em uso"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/modelos-programa/{id} [delete]
+func (h *ModeloProgramaHandler) DeleteModelo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do modelo inválido"})
+		return
+	}
+
+	if err := h.service.DeleteModelo(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListModelos godoc
+// @Summary Listar modelos de programa
+// @Description Retorna os modelos da biblioteca ordenados por área e nome, sem as etapas
+// @Tags modelos-programa
+// @Accept json
+// @Produce json
+// @Param area query string false "Área do modelo"
+// @Param ativos query bool false "Retornar apenas os modelos ativos (padrão: false)"
+// @Success 200 {array} models.ModeloPrograma
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/modelos-programa [get]
+func (h *ModeloProgramaHandler) ListModelos(c *gin.Context) {
+	modelos, err := h.service.ListModelos(c.Request.Context(), c.Query("area"), c.Query("ativos") == "true")
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, modelos)
+}
+
+// InstanciarModelo godoc
+// @Summary Instanciar um modelo para um paciente
+// @Description Cria um programa ABA ativo para o paciente copiando as etapas, os alvos, os critérios e a hierarquia de prompts do modelo. A primeira etapa entra em treino e o programa mantém o vínculo com o modelo
+// @Tags modelos-programa
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do modelo"
+// @Param instanciacao body models.InstanciarModeloProgramaRequest true "Paciente e dados do programa"
+// @Success 201 {object} models.ProgramaABA
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Modelo ou paciente não encontrado"
+// @Failure 422 {object} map[string]string "Modelo inativo"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/modelos-programa/{id}/programas [post]
+func (h *ModeloProgramaHandler) InstanciarModelo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do modelo inválido"})
+		return
+	}
+
+	var req models.InstanciarModeloProgramaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	programa, err := h.service.InstanciarModelo(c.Request.Context(), id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, programa)
+}
+
+// ListProgramasModelo godoc
+// @Summary Listar programas de um modelo
+// @Description Retorna os programas ABA instanciados a partir do modelo
+// @Tags modelos-programa
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do modelo"
+// @Success 200 {array} models.ProgramaABA
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Modelo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/modelos-programa/{id}/programas [get]
+func (h *ModeloProgramaHandler) ListProgramasModelo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do modelo inválido"})
+		return
+	}
+
+	programas, err := h.service.ListProgramasModelo(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, programas)
+}
+
+// GetUsoModelo godoc
+// @Summary Obter o uso de um modelo
+// @Description Retorna quantos programas e pacientes usam o modelo, quantas etapas já foram dominadas e a média de dias até o domínio de cada etapa e do programa
+// @Tags modelos-programa
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do modelo"
+// @Success 200 {object} models.UsoModeloPrograma
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Modelo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/modelos-programa/{id}/uso [get]
+func (h *ModeloProgramaHandler) GetUsoModelo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do modelo inválido"})
+		return
+	}
+
+	uso, err := h.service.UsoModelo(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, uso)
+}
+
+// ListUsoModelos godoc
+// @Summary Relatório de uso dos modelos
+// @Description Retorna o uso de cada modelo da biblioteca, dos mais usados para os menos usados, com a média de dias até o domínio
+// @Tags modelos-programa
+// @Accept json
+// @Produce json
+// @Param area query string false "Área dos modelos"
+// @Success 200 {array} models.UsoModeloPrograma
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/uso-modelos-programa [get]
+func (h *ModeloProgramaHandler) ListUsoModelos(c *gin.Context) {
+	usos, err := h.service.ListUsoModelos(c.Request.Context(), c.Query("area"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usos)
+}
+
+// handleError converte os erros do serviço de modelos de programa em respostas HTTP
+func (h *ModeloProgramaHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrModeloProgramaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Modelo de programa não encontrado"})
+	case service.ErrPacienteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Paciente não encontrado"})
+	case service.ErrTipoPromptNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tipo de prompt não encontrado"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrModeloProgramaEmUso, service.ErrModeloProgramaInativo, service.ErrHierarquiaPromptInvalida:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// ObjetivoProgramaHandler gerencia as requisições HTTP dos vínculos entre objetivos terapêuticos e programas
+// ABA, do progresso derivado dos programas e da confirmação da conclusão dos objetivos
+type ObjetivoProgramaHandler struct {
+	service *service.ObjetivoProgramaService
+}
+
+// NewObjetivoProgramaHandler cria uma nova instância de ObjetivoProgramaHandler
+func NewObjetivoProgramaHandler(service *service.ObjetivoProgramaService) *ObjetivoProgramaHandler {
+	return &ObjetivoProgramaHandler{service: service}
+}
+
+// VincularPrograma godoc
+// @Summary Vincular um programa a um objetivo
+// @Description Vincula um programa ABA do mesmo paciente ao objetivo terapêutico. O progresso do objetivo passa a considerar o programa
+// @Tags objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Param vinculo body models.VincularProgramaObjetivoRequest true "Programa a vincular"
+// @Success 201 {object} models.ObjetivoPrograma
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Objetivo ou programa não encontrado"
+// @Failure 422 {object} map[string]string "Programa de outro paciente ou já vinculado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/programas [post]
+func (h *ObjetivoProgramaHandler) VincularPrograma(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	var req models.VincularProgramaObjetivoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	vinculo, err := h.service.VincularPrograma(c.Request.Context(), id, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, vinculo)
+}
+
+// DesvincularPrograma godoc
+// @Summary Desvincular um programa de um objetivo
+// @Description Remove o vínculo entre o programa ABA e o objetivo terapêutico
+// @Tags objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Param programa_id path string true "ID do programa ABA"
+// @Success 204 "Vínculo removido com sucesso"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Objetivo ou vínculo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/programas/{programa_id} [delete]
+func (h *ObjetivoProgramaHandler) DesvincularPrograma(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+	programaID, err := uuid.Parse(c.Param("programa_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	if err := h.service.DesvincularPrograma(c.Request.Context(), id, programaID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListProgramasObjetivo godoc
+// @Summary Listar programas de um objetivo
+// @Description Retorna os programas ABA vinculados ao objetivo terapêutico, ordenados pela data de início
+// @Tags objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Success 200 {array} models.ProgramaABA
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Objetivo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/programas [get]
+func (h *ObjetivoProgramaHandler) ListProgramasObjetivo(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	programas, err := h.service.ListProgramasObjetivo(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, programas)
+}
+
+// ListObjetivosPrograma godoc
+// @Summary Listar objetivos de um programa
+// @Description Retorna os objetivos terapêuticos aos quais o programa ABA está vinculado
+// @Tags programas
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Success 200 {array} models.ObjetivoTerapeutico
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Programa não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas/{id}/objetivos [get]
+func (h *ObjetivoProgramaHandler) ListObjetivosPrograma(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	objetivos, err := h.service.ListObjetivosPrograma(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, objetivos)
+}
+
+// GetProgressoProgramas godoc
+// @Summary Obter o progresso de um objetivo pelos programas
+// @Description Retorna o progresso do objetivo derivado dos programas vinculados: o percentual de etapas dominadas de cada programa (100% para os finalizados) e a média entre eles
+// @Tags objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Success 200 {object} models.ProgressoProgramasObjetivo
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Objetivo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/progresso-programas [get]
+func (h *ObjetivoProgramaHandler) GetProgressoProgramas(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	progresso, err := h.service.GetProgresso(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progresso)
+}
+
+// ConfirmarConclusao godoc
+// @Summary Confirmar a conclusão de um objetivo
+// @Description Registra a confirmação do supervisor para um objetivo cujos programas vinculados foram todos finalizados, passando o objetivo para concluído
+// @Tags objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Success 200 {object} models.ObjetivoTerapeutico
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 403 {object} map[string]string "Usuário não é supervisor"
+// @Failure 404 {object} map[string]string "Objetivo não encontrado"
+// @Failure 422 {object} map[string]string "Conclusão não pendente"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/conclusao [post]
+func (h *ObjetivoProgramaHandler) ConfirmarConclusao(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	objetivo, err := h.service.ConfirmarConclusao(c.Request.Context(), id, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, objetivo)
+}
+
+// handleError converte os erros do serviço de vínculos entre objetivos e programas em respostas HTTP
+func (h *ObjetivoProgramaHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrObjetivoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Objetivo terapêutico não encontrado"})
+	case service.ErrProgramaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Programa ABA não encontrado"})
+	case service.ErrVinculoObjetivoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrConclusaoObjetivoRestrita:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case service.ErrProgramaJaVinculado, service.ErrProgramaOutroPaciente, service.ErrConclusaoObjetivoNaoPendente:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// ObservacaoIntervaloHandler gerencia as requisições HTTP relacionadas às observações por intervalos
+type ObservacaoIntervaloHandler struct {
+	service *service.ObservacaoIntervaloService
+}
+
+// NewObservacaoIntervaloHandler cria uma nova instância de ObservacaoIntervaloHandler
+func NewObservacaoIntervaloHandler(service *service.ObservacaoIntervaloService) *ObservacaoIntervaloHandler {
+	return &ObservacaoIntervaloHandler{service: service}
+}
+
+// CreateObservacao godoc
+// @Summary Registrar uma observação por intervalos
+// @Description Registra uma observação por intervalo parcial, intervalo total ou amostragem momentânea, com a pontuação de cada intervalo. O percentual de intervalos com ocorrência é calculado automaticamente e passa a compor os agregados e gráficos do comportamento
+// @Tags observacoes-intervalo
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param observacao body models.CreateObservacaoIntervaloRequest true "Dados da observação"
+// @Success 201 {object} models.ObservacaoIntervalo
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Comportamento alvo ou sessão não encontrada"
+// @Failure 422 {object} map[string]string "Comportamento não registrado por intervalos ou sessão inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/observacoes-intervalo [post]
+func (h *ObservacaoIntervaloHandler) CreateObservacao(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	var req models.CreateObservacaoIntervaloRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	observacao, err := h.service.CreateObservacao(c.Request.Context(), comportamentoID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, observacao)
+}
+
+// GetObservacao godoc
+// @Summary Obter uma observação por intervalos pelo ID
+// @Description Retorna a observação com a pontuação de cada intervalo
+// @Tags observacoes-intervalo
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param observacao_id path string true "ID da observação"
+// @Success 200 {object} models.ObservacaoIntervalo
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Observação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/observacoes-intervalo/{observacao_id} [get]
+func (h *ObservacaoIntervaloHandler) GetObservacao(c *gin.Context) {
+	comportamentoID, observacaoID, ok := parseObservacaoParams(c)
+	if !ok {
+		return
+	}
+
+	observacao, err := h.service.GetObservacao(c.Request.Context(), comportamentoID, observacaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, observacao)
+}
+
+// UpdateObservacao godoc
+// @Summary Atualizar uma observação por intervalos
+// @Description Atualiza os dados da observação. Quando "intervalos" é informado, a pontuação anterior é substituída e o percentual é recalculado
+// @Tags observacoes-intervalo
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param observacao_id path string true "ID da observação"
+// @Param observacao body models.UpdateObservacaoIntervaloRequest true "Dados a atualizar"
+// @Success 200 {object} models.ObservacaoIntervalo
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Observação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/observacoes-intervalo/{observacao_id} [put]
+func (h *ObservacaoIntervaloHandler) UpdateObservacao(c *gin.Context) {
+	comportamentoID, observacaoID, ok := parseObservacaoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateObservacaoIntervaloRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	observacao, err := h.service.UpdateObservacao(c.Request.Context(), comportamentoID, observacaoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, observacao)
+}
+
+// DeleteObservacao godoc
+// @Summary Excluir uma observação por intervalos
+// @Description Exclui uma observação por intervalos (soft delete)
+// @Tags observacoes-intervalo
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param observacao_id path string true "ID da observação"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Observação não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/observacoes-intervalo/{observacao_id} [delete]
+func (h *ObservacaoIntervaloHandler) DeleteObservacao(c *gin.Context) {
+	comportamentoID, observacaoID, ok := parseObservacaoParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteObservacao(c.Request.Context(), comportamentoID, observacaoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListObservacoes godoc
+// @Summary Listar observações por intervalos de um comportamento
+// @Description Retorna as observações do comportamento alvo, das mais recentes para as mais antigas, opcionalmente filtradas por período
+// @Tags observacoes-intervalo
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de observações e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID ou data inválida"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/observacoes-intervalo [get]
+func (h *ObservacaoIntervaloHandler) ListObservacoes(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	observacoes, total, err := h.service.ListObservacoes(c.Request.Context(), comportamentoID, inicio, fim, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       observacoes,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// handleError converte os erros do serviço de observações por intervalos em respostas HTTP
+func (h *ObservacaoIntervaloHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrComportamentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comportamento alvo não encontrado"})
+	case service.ErrObservacaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Observação por intervalos não encontrada"})
+	case service.ErrSessaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+	case service.ErrInvalidInput, service.ErrPeriodoInvalido:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrMetodoRegistroIncompativel, service.ErrSessaoCancelada, service.ErrSessaoForaDoPaciente, service.ErrObservadorSecundarioAusente:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseObservacaoParams extrai os IDs do comportamento e da observação da rota
+func parseObservacaoParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	observacaoID, err := uuid.Parse(c.Param("observacao_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da observação inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return comportamentoID, observacaoID, true
+}
@@ -0,0 +1,569 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/documento"
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// PlanoIntervencaoHandler gerencia as requisições HTTP relacionadas aos planos de intervenção comportamental (BIP)
+type PlanoIntervencaoHandler struct {
+	service *service.PlanoIntervencaoService
+}
+
+// NewPlanoIntervencaoHandler cria uma nova instância de PlanoIntervencaoHandler
+func NewPlanoIntervencaoHandler(service *service.PlanoIntervencaoService) *PlanoIntervencaoHandler {
+	return &PlanoIntervencaoHandler{service: service}
+}
+
+// CreatePlano godoc
+// @Summary Criar um plano de intervenção
+// @Description Cria o plano de intervenção comportamental do paciente com a primeira versão em rascunho. Quando vinculado a uma avaliação funcional aprovada, os comportamentos e a função hipotética não informados são copiados da avaliação
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano body models.CreatePlanoIntervencaoRequest true "Dados do plano"
+// @Success 201 {object} models.PlanoIntervencao
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Comportamento alvo ou programa não encontrado"
+// @Failure 422 {object} map[string]string "Avaliação não aprovada, comportamento ou programa de outro paciente, comportamento adequado ou repetido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao [post]
+func (h *PlanoIntervencaoHandler) CreatePlano(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	var req models.CreatePlanoIntervencaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plano, err := h.service.CreatePlano(c.Request.Context(), pacienteID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, plano)
+}
+
+// GetPlano godoc
+// @Summary Obter um plano de intervenção
+// @Description Retorna o plano de intervenção com todas as versões, da mais recente para a mais antiga
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Success 200 {object} models.PlanoIntervencao
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Plano não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id} [get]
+func (h *PlanoIntervencaoHandler) GetPlano(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	plano, err := h.service.GetPlano(c.Request.Context(), pacienteID, planoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plano)
+}
+
+// UpdatePlano godoc
+// @Summary Atualizar um plano de intervenção
+// @Description Atualiza o título do plano. O conteúdo é alterado por meio das versões
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param plano body models.UpdatePlanoIntervencaoRequest true "Dados a atualizar"
+// @Success 200 {object} models.PlanoIntervencao
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Plano não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id} [put]
+func (h *PlanoIntervencaoHandler) UpdatePlano(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePlanoIntervencaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plano, err := h.service.UpdatePlano(c.Request.Context(), pacienteID, planoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plano)
+}
+
+// DeletePlano godoc
+// @Summary Excluir um plano de intervenção
+// @Description Exclui um plano que nunca teve versão publicada
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Success 204 "Plano excluído com sucesso"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Plano não encontrado"
+// @Failure 422 {object} map[string]string "Plano com versão publicada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id} [delete]
+func (h *PlanoIntervencaoHandler) DeletePlano(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePlano(c.Request.Context(), pacienteID, planoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListPlanos godoc
+// @Summary Listar planos de intervenção
+// @Description Retorna uma lista paginada dos planos de intervenção do paciente
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de planos e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao [get]
+func (h *PlanoIntervencaoHandler) ListPlanos(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	planos, total, err := h.service.ListPlanos(c.Request.Context(), pacienteID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       planos,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// ListPlanosComportamento godoc
+// @Summary Planos de intervenção de um comportamento
+// @Description Retorna os planos com alguma versão que trata o comportamento alvo
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Success 200 {array} models.PlanoIntervencao
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/planos-intervencao [get]
+func (h *PlanoIntervencaoHandler) ListPlanosComportamento(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	planos, err := h.service.ListPlanosComportamento(c.Request.Context(), comportamentoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, planos)
+}
+
+// ListPlanosPrograma godoc
+// @Summary Planos de intervenção de um programa
+// @Description Retorna os planos com alguma versão que inclui o programa ABA como habilidade substituta
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do programa ABA"
+// @Success 200 {array} models.PlanoIntervencao
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Programa não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/programas-aba/{id}/planos-intervencao [get]
+func (h *PlanoIntervencaoHandler) ListPlanosPrograma(c *gin.Context) {
+	programaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do programa inválido"})
+		return
+	}
+
+	planos, err := h.service.ListPlanosPrograma(c.Request.Context(), programaID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, planos)
+}
+
+// CreateVersao godoc
+// @Summary Criar uma nova versão do plano
+// @Description Cria, em rascunho, a próxima versão do plano a partir da versão mais recente, aplicando as alterações informadas. O plano só pode ter uma versão em rascunho por vez
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param versao body models.VersaoPlanoIntervencaoRequest true "Alterações da nova versão"
+// @Success 201 {object} models.VersaoPlanoIntervencao
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Plano, comportamento alvo ou programa não encontrado"
+// @Failure 422 {object} map[string]string "Rascunho já existente, comportamento ou programa inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id}/versoes [post]
+func (h *PlanoIntervencaoHandler) CreateVersao(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.VersaoPlanoIntervencaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	versao, err := h.service.CreateVersao(c.Request.Context(), pacienteID, planoID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, versao)
+}
+
+// GetVersao godoc
+// @Summary Obter uma versão do plano
+// @Description Retorna uma versão do plano com os comportamentos, os programas e as ciências registradas
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Success 200 {object} models.VersaoPlanoIntervencao
+// @Failure 400 {object} map[string]string "ID ou número inválido"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id}/versoes/{numero} [get]
+func (h *PlanoIntervencaoHandler) GetVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	versao, err := h.service.GetVersao(c.Request.Context(), pacienteID, planoID, numero)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versao)
+}
+
+// UpdateVersao godoc
+// @Summary Atualizar a versão em rascunho do plano
+// @Description Atualiza o conteúdo da versão em rascunho. Versões publicadas não podem ser alteradas
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Param versao body models.VersaoPlanoIntervencaoRequest true "Dados a atualizar"
+// @Success 200 {object} models.VersaoPlanoIntervencao
+// @Failure 400 {object} map[string]string "ID, número inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Plano, versão, comportamento alvo ou programa não encontrado"
+// @Failure 422 {object} map[string]string "Versão publicada, comportamento ou programa inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id}/versoes/{numero} [put]
+func (h *PlanoIntervencaoHandler) UpdateVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.VersaoPlanoIntervencaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	versao, err := h.service.UpdateVersao(c.Request.Context(), pacienteID, planoID, numero, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versao)
+}
+
+// PublicarVersao godoc
+// @Summary Publicar a versão em rascunho do plano
+// @Description Torna vigente a versão em rascunho, que passa a ser imutável, e marca a versão vigente anterior como substituída. A versão precisa de comportamentos alvo, estratégias antecedentes, comportamentos substitutos, estratégias de consequência e procedimentos de crise
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Success 200 {object} models.VersaoPlanoIntervencao
+// @Failure 400 {object} map[string]string "ID ou número inválido"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 422 {object} map[string]string "Versão já publicada ou incompleta"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id}/versoes/{numero}/publicar [post]
+func (h *PlanoIntervencaoHandler) PublicarVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	versao, err := h.service.PublicarVersao(c.Request.Context(), pacienteID, planoID, numero, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versao)
+}
+
+// RegistrarCiencia godoc
+// @Summary Registrar ciência em uma versão do plano
+// @Description Registra a ciência de um responsável ou do supervisor em uma versão publicada do plano
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Param ciencia body models.CienciaPlanoIntervencaoRequest true "Dados da ciência"
+// @Success 201 {object} models.VersaoPlanoIntervencao
+// @Failure 400 {object} map[string]string "ID, número inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 422 {object} map[string]string "Versão em rascunho ou ciência já registrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id}/versoes/{numero}/ciencias [post]
+func (h *PlanoIntervencaoHandler) RegistrarCiencia(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.CienciaPlanoIntervencaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	versao, err := h.service.RegistrarCiencia(c.Request.Context(), pacienteID, planoID, numero, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, versao)
+}
+
+// GetDocumentoVersao godoc
+// @Summary Documento de uma versão do plano
+// @Description Gera o documento da versão do plano, com os comportamentos alvo, as estratégias, os programas de habilidades substitutas e as ciências registradas
+// @Tags planos-intervencao
+// @Produce html
+// @Produce plain
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Param formato query string false "Formato de saída: html ou markdown (padrão: html)"
+// @Success 200 {string} string "Documento da versão"
+// @Failure 400 {object} map[string]string "ID, número ou formato inválido"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id}/versoes/{numero}/documento [get]
+func (h *PlanoIntervencaoHandler) GetDocumentoVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	formato, ok := parseFormatoDocumento(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.service.DocumentoVersao(c.Request.Context(), pacienteID, planoID, numero)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := documento.Renderizar(&buf, d, formato); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, formato.ContentType(), buf.Bytes())
+}
+
+// ListHistorico godoc
+// @Summary Histórico de um plano de intervenção
+// @Description Retorna os eventos de auditoria das publicações e substituições das versões do plano, em ordem cronológica
+// @Tags planos-intervencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Success 200 {array} models.EventoAuditoria
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Plano não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-intervencao/{plano_id}/historico [get]
+func (h *PlanoIntervencaoHandler) ListHistorico(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	eventos, err := h.service.ListHistorico(c.Request.Context(), pacienteID, planoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, eventos)
+}
+
+// handleError converte os erros do serviço de planos de intervenção em respostas HTTP
+func (h *PlanoIntervencaoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrPlanoIntervencaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Plano de intervenção não encontrado"})
+	case service.ErrVersaoPlanoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Versão do plano não encontrada"})
+	case service.ErrComportamentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comportamento alvo não encontrado"})
+	case service.ErrProgramaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Programa não encontrado"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrPlanoIntervencaoPublicado, service.ErrVersaoPlanoBloqueada, service.ErrVersaoPlanoRascunhoExistente,
+		service.ErrVersaoPlanoIncompleta, service.ErrVersaoPlanoNaoPublicada, service.ErrCienciaPlanoDuplicada,
+		service.ErrAvaliacaoFuncionalNaoAprovada, service.ErrProgramaForaDoPaciente, service.ErrProgramasPlanoDuplicado,
+		service.ErrComportamentoForaDoPaciente, service.ErrComportamentoNaoInadequado, service.ErrComportamentosAvaliacaoDuplicado:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parsePlanoIntervencaoParams extrai os IDs do paciente e do plano da rota
+func parsePlanoIntervencaoParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	planoID, err := uuid.Parse(c.Param("plano_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do plano inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return pacienteID, planoID, true
+}
+
+// parseVersaoPlanoParams extrai os IDs do paciente e do plano e o número da versão da rota
+func parseVersaoPlanoParams(c *gin.Context) (uuid.UUID, uuid.UUID, int, bool) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, 0, false
+	}
+
+	numero, err := strconv.Atoi(c.Param("numero"))
+	if err != nil || numero < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Número da versão inválido"})
+		return uuid.Nil, uuid.Nil, 0, false
+	}
+
+	return pacienteID, planoID, numero, true
+}
+
+// parseFormatoDocumento extrai o formato de saída do documento dos parâmetros de consulta
+func parseFormatoDocumento(c *gin.Context) (documento.Formato, bool) {
+	formato := documento.Formato(c.DefaultQuery("formato", string(documento.FormatoHTML)))
+	if formato != documento.FormatoHTML && formato != documento.FormatoMarkdown {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Formato inválido: use html ou markdown"})
+		return "", false
+	}
+	return formato, true
+}
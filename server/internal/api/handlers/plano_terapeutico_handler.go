@@ -0,0 +1,579 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/documento"
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// PlanoTerapeuticoHandler gerencia as requisições HTTP relacionadas aos planos terapêuticos singulares (PTS)
+type PlanoTerapeuticoHandler struct {
+	service *service.PlanoTerapeuticoService
+}
+
+// NewPlanoTerapeuticoHandler cria uma nova instância de PlanoTerapeuticoHandler
+func NewPlanoTerapeuticoHandler(service *service.PlanoTerapeuticoService) *PlanoTerapeuticoHandler {
+	return &PlanoTerapeuticoHandler{service: service}
+}
+
+// CreatePlano godoc
+// @Summary Criar um plano terapêutico
+// @Description Cria o plano terapêutico singular do paciente com a primeira versão em rascunho. Sem objetivos ou programas informados, a versão copia os objetivos em progresso e os programas ativos do paciente
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano body models.CreatePlanoTerapeuticoRequest true "Dados do plano"
+// @Success 201 {object} models.PlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Paciente, objetivo, programa ou terapia não encontrado"
+// @Failure 422 {object} map[string]string "Objetivo ou programa de outro paciente, item repetido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos [post]
+func (h *PlanoTerapeuticoHandler) CreatePlano(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	var req models.CreatePlanoTerapeuticoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plano, err := h.service.CreatePlano(c.Request.Context(), pacienteID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, plano)
+}
+
+// GetPlano godoc
+// @Summary Obter um plano terapêutico
+// @Description Retorna o plano terapêutico com todas as versões, da mais recente para a mais antiga
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Success 200 {object} models.PlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Plano não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id} [get]
+func (h *PlanoTerapeuticoHandler) GetPlano(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	plano, err := h.service.GetPlano(c.Request.Context(), pacienteID, planoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plano)
+}
+
+// UpdatePlano godoc
+// @Summary Atualizar um plano terapêutico
+// @Description Atualiza o título do plano. O conteúdo é alterado por meio das versões
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param plano body models.UpdatePlanoTerapeuticoRequest true "Dados a atualizar"
+// @Success 200 {object} models.PlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Plano não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id} [put]
+func (h *PlanoTerapeuticoHandler) UpdatePlano(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdatePlanoTerapeuticoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plano, err := h.service.UpdatePlano(c.Request.Context(), pacienteID, planoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plano)
+}
+
+// DeletePlano godoc
+// @Summary Excluir um plano terapêutico
+// @Description Exclui um plano que nunca teve versão aprovada
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Success 204 "Plano excluído com sucesso"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Plano não encontrado"
+// @Failure 422 {object} map[string]string "Plano com versão aprovada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id} [delete]
+func (h *PlanoTerapeuticoHandler) DeletePlano(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePlano(c.Request.Context(), pacienteID, planoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListPlanos godoc
+// @Summary Listar planos terapêuticos
+// @Description Retorna uma lista paginada dos planos terapêuticos do paciente
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de planos e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos [get]
+func (h *PlanoTerapeuticoHandler) ListPlanos(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	planos, total, err := h.service.ListPlanos(c.Request.Context(), pacienteID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       planos,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// CreateVersao godoc
+// @Summary Criar uma nova versão do plano
+// @Description Cria, em rascunho, a próxima versão do plano a partir da versão mais recente, copiando novamente os objetivos e os programas e aplicando as alterações informadas. O plano só pode ter uma versão em rascunho ou em aprovação por vez
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param versao body models.VersaoPlanoTerapeuticoRequest true "Alterações da nova versão"
+// @Success 201 {object} models.VersaoPlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Plano, objetivo, programa ou terapia não encontrado"
+// @Failure 422 {object} map[string]string "Versão em aberto, objetivo ou programa de outro paciente, item repetido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id}/versoes [post]
+func (h *PlanoTerapeuticoHandler) CreateVersao(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.VersaoPlanoTerapeuticoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	versao, err := h.service.CreateVersao(c.Request.Context(), pacienteID, planoID, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, versao)
+}
+
+// GetVersao godoc
+// @Summary Obter uma versão do plano
+// @Description Retorna uma versão do plano com os objetivos, os programas, a carga horária e os aceites registrados
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Success 200 {object} models.VersaoPlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID ou número inválido"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id}/versoes/{numero} [get]
+func (h *PlanoTerapeuticoHandler) GetVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	versao, err := h.service.GetVersao(c.Request.Context(), pacienteID, planoID, numero)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versao)
+}
+
+// UpdateVersao godoc
+// @Summary Atualizar a versão em rascunho do plano
+// @Description Atualiza o conteúdo da versão em rascunho. Versões enviadas para aprovação ou aprovadas não podem ser alteradas
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Param versao body models.VersaoPlanoTerapeuticoRequest true "Dados a atualizar"
+// @Success 200 {object} models.VersaoPlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID, número inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Plano, versão, objetivo, programa ou terapia não encontrado"
+// @Failure 422 {object} map[string]string "Versão fora de rascunho, objetivo ou programa de outro paciente, item repetido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id}/versoes/{numero} [put]
+func (h *PlanoTerapeuticoHandler) UpdateVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.VersaoPlanoTerapeuticoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	versao, err := h.service.UpdateVersao(c.Request.Context(), pacienteID, planoID, numero, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versao)
+}
+
+// EnviarVersao godoc
+// @Summary Enviar a versão do plano para aprovação
+// @Description Envia a versão em rascunho para aprovação do supervisor, copiando novamente os objetivos e os programas. A versão precisa de objetivos, carga horária e data de revisão futura
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Success 200 {object} models.VersaoPlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID ou número inválido"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 422 {object} map[string]string "Versão fora de rascunho, incompleta ou com revisão vencida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id}/versoes/{numero}/enviar [post]
+func (h *PlanoTerapeuticoHandler) EnviarVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	versao, err := h.service.EnviarVersao(c.Request.Context(), pacienteID, planoID, numero, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versao)
+}
+
+// AprovarVersao godoc
+// @Summary Aprovar a versão do plano
+// @Description Registra a aprovação do supervisor e torna vigente a versão enviada, marcando a versão vigente anterior como substituída
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Param aprovacao body models.AprovarVersaoPlanoTerapeuticoRequest true "Dados do supervisor"
+// @Success 200 {object} models.VersaoPlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID, número inválido ou erro de validação"
+// @Failure 403 {object} map[string]string "Usuário não é supervisor"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 422 {object} map[string]string "Versão não enviada para aprovação ou com revisão vencida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id}/versoes/{numero}/aprovar [post]
+func (h *PlanoTerapeuticoHandler) AprovarVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.AprovarVersaoPlanoTerapeuticoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	versao, err := h.service.AprovarVersao(c.Request.Context(), pacienteID, planoID, numero, &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versao)
+}
+
+// DevolverVersao godoc
+// @Summary Devolver a versão do plano para ajustes
+// @Description Devolve a versão enviada para aprovação ao rascunho, registrando o motivo da devolução
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Param devolucao body models.DevolverVersaoPlanoTerapeuticoRequest true "Motivo da devolução"
+// @Success 200 {object} models.VersaoPlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID, número inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 422 {object} map[string]string "Versão não enviada para aprovação"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id}/versoes/{numero}/devolver [post]
+func (h *PlanoTerapeuticoHandler) DevolverVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.DevolverVersaoPlanoTerapeuticoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	versao, err := h.service.DevolverVersao(c.Request.Context(), pacienteID, planoID, numero, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versao)
+}
+
+// RegistrarAceite godoc
+// @Summary Registrar o aceite de um responsável
+// @Description Registra o aceite de um responsável pelo paciente na versão vigente do plano
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Param aceite body models.AceitePlanoTerapeuticoRequest true "Dados do aceite"
+// @Success 201 {object} models.VersaoPlanoTerapeutico
+// @Failure 400 {object} map[string]string "ID, número inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 422 {object} map[string]string "Versão não vigente ou aceite já registrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id}/versoes/{numero}/aceites [post]
+func (h *PlanoTerapeuticoHandler) RegistrarAceite(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.AceitePlanoTerapeuticoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	versao, err := h.service.RegistrarAceite(c.Request.Context(), pacienteID, planoID, numero, &req, c.GetString("user_id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, versao)
+}
+
+// GetDocumentoVersao godoc
+// @Summary Documento de uma versão do plano
+// @Description Gera o documento para impressão da versão do plano, com a avaliação, os objetivos, os programas, a carga horária semanal, a aprovação do supervisor e os aceites dos responsáveis
+// @Tags planos-terapeuticos
+// @Produce html
+// @Produce plain
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Param numero path int true "Número da versão"
+// @Param formato query string false "Formato de saída: html ou markdown (padrão: html)"
+// @Success 200 {string} string "Documento da versão"
+// @Failure 400 {object} map[string]string "ID, número ou formato inválido"
+// @Failure 404 {object} map[string]string "Plano ou versão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id}/versoes/{numero}/documento [get]
+func (h *PlanoTerapeuticoHandler) GetDocumentoVersao(c *gin.Context) {
+	pacienteID, planoID, numero, ok := parseVersaoPlanoParams(c)
+	if !ok {
+		return
+	}
+
+	formato, ok := parseFormatoDocumento(c)
+	if !ok {
+		return
+	}
+
+	d, err := h.service.DocumentoVersao(c.Request.Context(), pacienteID, planoID, numero)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := documento.Renderizar(&buf, d, formato); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, formato.ContentType(), buf.Bytes())
+}
+
+// ListHistorico godoc
+// @Summary Histórico de um plano terapêutico
+// @Description Retorna os eventos de auditoria dos envios, aprovações, devoluções e substituições das versões do plano, em ordem cronológica
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param plano_id path string true "ID do plano"
+// @Success 200 {array} models.EventoAuditoria
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Plano não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/planos-terapeuticos/{plano_id}/historico [get]
+func (h *PlanoTerapeuticoHandler) ListHistorico(c *gin.Context) {
+	pacienteID, planoID, ok := parsePlanoIntervencaoParams(c)
+	if !ok {
+		return
+	}
+
+	eventos, err := h.service.ListHistorico(c.Request.Context(), pacienteID, planoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, eventos)
+}
+
+// ListLembretesRevisao godoc
+// @Summary Lembretes de revisão dos planos terapêuticos
+// @Description Retorna os planos cuja versão vigente tem revisão vencida ou prevista para os próximos dias, da revisão mais próxima para a mais distante
+// @Tags planos-terapeuticos
+// @Accept json
+// @Produce json
+// @Param dias query int false "Antecedência em dias (padrão: 30)"
+// @Success 200 {array} models.LembreteRevisaoPlanoTerapeutico
+// @Failure 400 {object} map[string]string "Antecedência inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/revisoes-planos-terapeuticos [get]
+func (h *PlanoTerapeuticoHandler) ListLembretesRevisao(c *gin.Context) {
+	dias, err := strconv.Atoi(c.DefaultQuery("dias", strconv.Itoa(service.DiasLembreteRevisaoPadrao)))
+	if err != nil || dias < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Antecedência inválida"})
+		return
+	}
+
+	lembretes, err := h.service.ListLembretesRevisao(c.Request.Context(), dias)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lembretes)
+}
+
+// handleError converte os erros do serviço de planos terapêuticos em respostas HTTP
+func (h *PlanoTerapeuticoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrPlanoTerapeuticoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Plano terapêutico não encontrado"})
+	case service.ErrVersaoPlanoTerapeuticoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Versão do plano não encontrada"})
+	case service.ErrPacienteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Paciente não encontrado"})
+	case service.ErrObjetivoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Objetivo não encontrado"})
+	case service.ErrProgramaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Programa não encontrado"})
+	case service.ErrTerapiaNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Terapia não encontrada"})
+	case service.ErrInvalidInput:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrAprovacaoPlanoRestrita:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case service.ErrPlanoTerapeuticoAprovado, service.ErrVersaoPlanoTerapeuticoBloqueada, service.ErrVersaoPlanoTerapeuticoEmAberto,
+		service.ErrVersaoPlanoTerapeuticoIncompleta, service.ErrVersaoPlanoTerapeuticoNaoEnviada, service.ErrVersaoPlanoTerapeuticoNaoVigente,
+		service.ErrAceitePlanoTerapeuticoDuplicado, service.ErrDataRevisaoPlanoInvalida, service.ErrItensPlanoTerapeuticoDuplicados,
+		service.ErrObjetivoForaDoPaciente, service.ErrProgramaForaDoPaciente:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
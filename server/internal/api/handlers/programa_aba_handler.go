@@ -110,7 +110,7 @@ func (h *ProgramaABAHandler) UpdatePrograma(c *gin.Context) {
 	}
 	programa.ID = id
 
-	result, err := h.service.UpdatePrograma(c.Request.Context(), &programa)
+	result, err := h.service.UpdatePrograma(c.Request.Context(), &programa, c.GetString("user_id"))
 	if err != nil {
 		if err == service.ErrProgramaNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Programa ABA não encontrado"})
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// ProgressoObjetivoHandler gerencia as requisições HTTP relacionadas ao progresso dos objetivos terapêuticos
+type ProgressoObjetivoHandler struct {
+	service *service.ProgressoObjetivoService
+}
+
+// NewProgressoObjetivoHandler cria uma nova instância de ProgressoObjetivoHandler
+func NewProgressoObjetivoHandler(service *service.ProgressoObjetivoService) *ProgressoObjetivoHandler {
+	return &ProgressoObjetivoHandler{service: service}
+}
+
+// CreateProgresso godoc
+// @Summary Registrar progresso de um objetivo
+// @Description Registra uma nota de progresso (0 a 10) para o objetivo terapêutico e recalcula sua sinalização. Objetivos com escala GAS exigem o nível alcançado (-2 a +2)
+// @Tags progresso-objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Param progresso body models.CreateProgressoObjetivoRequest true "Dados do progresso"
+// @Success 201 {object} models.ProgressoObjetivo
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Objetivo terapêutico não encontrado"
+// @Failure 422 {object} map[string]string "Nível GAS ausente ou objetivo sem escala GAS"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/progresso [post]
+func (h *ProgressoObjetivoHandler) CreateProgresso(c *gin.Context) {
+	objetivoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	var req models.CreateProgressoObjetivoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	progresso, err := h.service.CreateProgresso(c.Request.Context(), objetivoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, progresso)
+}
+
+// GetProgresso godoc
+// @Summary Obter um registro de progresso pelo ID
+// @Description Retorna os detalhes de um registro de progresso do objetivo
+// @Tags progresso-objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Param progresso_id path string true "ID do registro de progresso"
+// @Success 200 {object} models.ProgressoObjetivo
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Registro de progresso não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/progresso/{progresso_id} [get]
+func (h *ProgressoObjetivoHandler) GetProgresso(c *gin.Context) {
+	objetivoID, progressoID, ok := parseProgressoParams(c)
+	if !ok {
+		return
+	}
+
+	progresso, err := h.service.GetProgresso(c.Request.Context(), objetivoID, progressoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progresso)
+}
+
+// UpdateProgresso godoc
+// @Summary Atualizar um registro de progresso
+// @Description Atualiza um registro de progresso do objetivo e recalcula sua sinalização
+// @Tags progresso-objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Param progresso_id path string true "ID do registro de progresso"
+// @Param progresso body models.UpdateProgressoObjetivoRequest true "Dados a atualizar"
+// @Success 200 {object} models.ProgressoObjetivo
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Objetivo ou registro de progresso não encontrado"
+// @Failure 422 {object} map[string]string "Objetivo sem escala GAS"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/progresso/{progresso_id} [put]
+func (h *ProgressoObjetivoHandler) UpdateProgresso(c *gin.Context) {
+	objetivoID, progressoID, ok := parseProgressoParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateProgressoObjetivoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	progresso, err := h.service.UpdateProgresso(c.Request.Context(), objetivoID, progressoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progresso)
+}
+
+// DeleteProgresso godoc
+// @Summary Excluir um registro de progresso
+// @Description Exclui um registro de progresso do objetivo (soft delete) e recalcula sua sinalização
+// @Tags progresso-objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Param progresso_id path string true "ID do registro de progresso"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Objetivo ou registro de progresso não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/progresso/{progresso_id} [delete]
+func (h *ProgressoObjetivoHandler) DeleteProgresso(c *gin.Context) {
+	objetivoID, progressoID, ok := parseProgressoParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteProgresso(c.Request.Context(), objetivoID, progressoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListProgresso godoc
+// @Summary Histórico de progresso de um objetivo
+// @Description Retorna o histórico paginado de progresso do objetivo, do registro mais recente ao mais antigo
+// @Tags progresso-objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de registros de progresso e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Objetivo terapêutico não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/progresso [get]
+func (h *ProgressoObjetivoHandler) ListProgresso(c *gin.Context) {
+	objetivoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	progressos, total, err := h.service.ListProgresso(c.Request.Context(), objetivoID, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       progressos,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// GetTendencia godoc
+// @Summary Tendência do progresso de um objetivo
+// @Description Calcula a inclinação (pontos por semana) das notas nos últimos registros e classifica a tendência como melhorando, estável ou regredindo
+// @Tags progresso-objetivos
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do objetivo terapêutico"
+// @Param janela query int false "Quantidade de registros analisados (padrão: 5, mínimo: 3)"
+// @Param limiar query number false "Inclinação mínima, em pontos por semana, para considerar mudança (padrão: 0.25)"
+// @Success 200 {object} models.TendenciaObjetivoResponse
+// @Failure 400 {object} map[string]string "ID ou parâmetro inválido"
+// @Failure 404 {object} map[string]string "Objetivo terapêutico não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/objetivos/{id}/progresso/tendencia [get]
+func (h *ProgressoObjetivoHandler) GetTendencia(c *gin.Context) {
+	objetivoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return
+	}
+
+	janela, err := strconv.Atoi(c.DefaultQuery("janela", strconv.Itoa(service.JanelaTendenciaPadrao)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Janela inválida"})
+		return
+	}
+
+	limiar := 0.0
+	if raw := c.Query("limiar"); raw != "" {
+		limiar, err = strconv.ParseFloat(raw, 64)
+		if err != nil || limiar <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Limiar inválido"})
+			return
+		}
+	}
+
+	tendencia, err := h.service.GetTendencia(c.Request.Context(), objetivoID, janela, limiar)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tendencia)
+}
+
+// ListObjetivosSinalizados godoc
+// @Summary Listar objetivos sinalizados de um paciente
+// @Description Retorna os objetivos do paciente cuja tendência de progresso permanece negativa
+// @Tags progresso-objetivos
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Success 200 {array} models.ObjetivoTerapeutico
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/objetivos/sinalizados [get]
+func (h *ProgressoObjetivoHandler) ListObjetivosSinalizados(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	objetivos, err := h.service.ListObjetivosSinalizados(c.Request.Context(), pacienteID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, objetivos)
+}
+
+// handleError converte os erros do serviço de progresso em respostas HTTP
+func (h *ProgressoObjetivoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrObjetivoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Objetivo terapêutico não encontrado"})
+	case service.ErrProgressoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Registro de progresso não encontrado"})
+	case service.ErrJanelaInvalida:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrNivelGASObrigatorio, service.ErrNivelGASSemEscala:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseProgressoParams extrai os IDs do objetivo e do registro de progresso da rota
+func parseProgressoParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	objetivoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do objetivo inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	progressoID, err := uuid.Parse(c.Param("progresso_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do registro de progresso inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return objetivoID, progressoID, true
+}
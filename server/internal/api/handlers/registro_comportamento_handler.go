@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// RegistroComportamentoHandler gerencia as requisições HTTP relacionadas aos registros de comportamento
+type RegistroComportamentoHandler struct {
+	service *service.RegistroComportamentoService
+}
+
+// NewRegistroComportamentoHandler cria uma nova instância de RegistroComportamentoHandler
+func NewRegistroComportamentoHandler(service *service.RegistroComportamentoService) *RegistroComportamentoHandler {
+	return &RegistroComportamentoHandler{service: service}
+}
+
+// CreateRegistro godoc
+// @Summary Registrar uma ocorrência de comportamento
+// @Description Registra uma ocorrência do comportamento alvo. O valor é validado conforme o método de registro: frequência (inteiro >= 0), duração (segundos > 0), intensidade (1 a 5) ou intervalo (1 com ocorrência, 0 sem)
+// @Tags registros-comportamento
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param registro body models.CreateRegistroComportamentoRequest true "Dados da ocorrência"
+// @Success 201 {object} models.RegistroComportamento
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Comportamento alvo, sessão ou categoria ABC não encontrada"
+// @Failure 422 {object} map[string]string "Valor incompatível com o método de registro, sessão inválida ou categoria ABC inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/registros [post]
+func (h *RegistroComportamentoHandler) CreateRegistro(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	var req models.CreateRegistroComportamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	registro, err := h.service.CreateRegistro(c.Request.Context(), comportamentoID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, registro)
+}
+
+// GetRegistro godoc
+// @Summary Obter um registro de comportamento pelo ID
+// @Description Retorna os detalhes de uma ocorrência registrada para o comportamento alvo
+// @Tags registros-comportamento
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param registro_id path string true "ID do registro"
+// @Success 200 {object} models.RegistroComportamento
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Registro não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/registros/{registro_id} [get]
+func (h *RegistroComportamentoHandler) GetRegistro(c *gin.Context) {
+	comportamentoID, registroID, ok := parseRegistroParams(c)
+	if !ok {
+		return
+	}
+
+	registro, err := h.service.GetRegistro(c.Request.Context(), comportamentoID, registroID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, registro)
+}
+
+// UpdateRegistro godoc
+// @Summary Atualizar um registro de comportamento
+// @Description Atualiza os dados de uma ocorrência registrada
+// @Tags registros-comportamento
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param registro_id path string true "ID do registro"
+// @Param registro body models.UpdateRegistroComportamentoRequest true "Dados a atualizar"
+// @Success 200 {object} models.RegistroComportamento
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Comportamento, registro ou categoria ABC não encontrada"
+// @Failure 422 {object} map[string]string "Valor incompatível com o método de registro ou categoria ABC inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/registros/{registro_id} [put]
+func (h *RegistroComportamentoHandler) UpdateRegistro(c *gin.Context) {
+	comportamentoID, registroID, ok := parseRegistroParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateRegistroComportamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	registro, err := h.service.UpdateRegistro(c.Request.Context(), comportamentoID, registroID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, registro)
+}
+
+// DeleteRegistro godoc
+// @Summary Excluir um registro de comportamento
+// @Description Exclui uma ocorrência registrada (soft delete)
+// @Tags registros-comportamento
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param registro_id path string true "ID do registro"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Registro não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/registros/{registro_id} [delete]
+func (h *RegistroComportamentoHandler) DeleteRegistro(c *gin.Context) {
+	comportamentoID, registroID, ok := parseRegistroParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRegistro(c.Request.Context(), comportamentoID, registroID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListRegistros godoc
+// @Summary Listar registros de um comportamento
+// @Description Retorna os registros do comportamento alvo, dos mais recentes para os mais antigos, opcionalmente filtrados por período
+// @Tags registros-comportamento
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de registros e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID ou data inválida"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/registros [get]
+func (h *RegistroComportamentoHandler) ListRegistros(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	registros, total, err := h.service.ListRegistros(c.Request.Context(), comportamentoID, inicio, fim, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       registros,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// AgregarRegistros godoc
+// @Summary Agregar registros de um comportamento
+// @Description Agrega os registros por dia ou por semana (segunda a domingo). O campo "valor" traz a medida principal do método de registro: total de ocorrências, total de segundos, intensidade média ou percentual de intervalos com ocorrência
+// @Tags registros-comportamento
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do comportamento alvo"
+// @Param periodo query string false "Período de agregação: dia ou semana (padrão: dia)"
+// @Param data_inicio query string false "Data inicial (AAAA-MM-DD)"
+// @Param data_fim query string false "Data final, inclusiva (AAAA-MM-DD)"
+// @Success 200 {array} models.AgregadoRegistroComportamento
+// @Failure 400 {object} map[string]string "ID, data ou período inválido"
+// @Failure 404 {object} map[string]string "Comportamento alvo não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/comportamentos-alvo/{id}/registros/agregado [get]
+func (h *RegistroComportamentoHandler) AgregarRegistros(c *gin.Context) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return
+	}
+
+	periodo := models.PeriodoAgregacao(c.DefaultQuery("periodo", string(models.PeriodoAgregacaoDia)))
+	if periodo != models.PeriodoAgregacaoDia && periodo != models.PeriodoAgregacaoSemana {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Período de agregação inválido: use dia ou semana"})
+		return
+	}
+
+	inicio, fim, ok := parsePeriodo(c)
+	if !ok {
+		return
+	}
+
+	agregados, err := h.service.AgregarRegistros(c.Request.Context(), comportamentoID, inicio, fim, periodo)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, agregados)
+}
+
+// handleError converte os erros do serviço de registros de comportamento em respostas HTTP
+func (h *RegistroComportamentoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrComportamentoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comportamento alvo não encontrado"})
+	case service.ErrRegistroNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Registro de comportamento não encontrado"})
+	case service.ErrSessaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+	case service.ErrCategoriaABCNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Categoria ABC não encontrada"})
+	case service.ErrPeriodoInvalido:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case service.ErrValorRegistroInvalido, service.ErrSessaoCancelada, service.ErrSessaoForaDoPaciente, service.ErrObservadorSecundarioAusente,
+		service.ErrCategoriaABCTipo, service.ErrCategoriaABCInativa:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// parseRegistroParams extrai os IDs do comportamento e do registro da rota
+func parseRegistroParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	comportamentoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do comportamento inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	registroID, err := uuid.Parse(c.Param("registro_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do registro inválido"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return comportamentoID, registroID, true
+}
+
+// parsePeriodo extrai o intervalo [data_inicio, data_fim + 1 dia) dos parâmetros de consulta
+func parsePeriodo(c *gin.Context) (*time.Time, *time.Time, bool) {
+	var inicio, fim *time.Time
+	if raw := c.Query("data_inicio"); raw != "" {
+		data, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Data inicial inválida, use o formato AAAA-MM-DD"})
+			return nil, nil, false
+		}
+		inicio = &data
+	}
+	if raw := c.Query("data_fim"); raw != "" {
+		data, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Data final inválida, use o formato AAAA-MM-DD"})
+			return nil, nil, false
+		}
+		data = data.AddDate(0, 0, 1)
+		fim = &data
+	}
+	return inicio, fim, true
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// SondagemManutencaoHandler gerencia as requisições HTTP relacionadas às sondagens de manutenção das habilidades dominadas
+type SondagemManutencaoHandler struct {
+	service *service.SondagemManutencaoService
+}
+
+// NewSondagemManutencaoHandler cria uma nova instância de SondagemManutencaoHandler
+func NewSondagemManutencaoHandler(service *service.SondagemManutencaoService) *SondagemManutencaoHandler {
+	return &SondagemManutencaoHandler{service: service}
+}
+
+// ListSondagensPaciente godoc
+// @Summary Listar as sondagens de manutenção de um paciente
+// @Description Retorna uma lista paginada das sondagens de manutenção das etapas dominadas e dos alvos em manutenção do paciente, das mais recentes para as mais antigas
+// @Tags sondagens-manutencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param status query string false "Situação da sondagem (pendente, aprovada, reprovada ou cancelada)"
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de sondagens e metadados de paginação"
+// @Failure 400 {object} map[string]string "ID ou situação inválida"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/sondagens-manutencao [get]
+func (h *SondagemManutencaoHandler) ListSondagensPaciente(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	var status *models.StatusSondagemManutencao
+	if valor := c.Query("status"); valor != "" {
+		s := models.StatusSondagemManutencao(valor)
+		switch s {
+		case models.StatusSondagemManutencaoPendente, models.StatusSondagemManutencaoAprovada,
+			models.StatusSondagemManutencaoReprovada, models.StatusSondagemManutencaoCancelada:
+			status = &s
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Situação da sondagem inválida"})
+			return
+		}
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	sondagens, total, err := h.service.ListSondagensPaciente(c.Request.Context(), pacienteID, status, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       sondagens,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// PlanejarSondagensPaciente godoc
+// @Summary Planejar as sondagens de manutenção de um paciente
+// @Description Agenda a próxima sondagem de cada habilidade dominada do paciente, cancela as sondagens das habilidades que voltaram ao ensino e aloca as pendentes nas sessões planejadas. O agendamento também é atualizado ao criar, alterar, encerrar ou excluir uma sessão.
+// @Tags sondagens-manutencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Success 200 {array} models.SondagemManutencao "Sondagens pendentes"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/sondagens-manutencao/planejar [post]
+func (h *SondagemManutencaoHandler) PlanejarSondagensPaciente(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	sondagens, err := h.service.PlanejarSondagensPaciente(c.Request.Context(), pacienteID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sondagens)
+}
+
+// GetSondagem godoc
+// @Summary Obter uma sondagem de manutenção
+// @Description Retorna a sondagem de manutenção com a sessão em que foi alocada e, quando realizada, o resultado
+// @Tags sondagens-manutencao
+// @Accept json
+// @Produce json
+// @Param paciente_id path string true "ID do paciente"
+// @Param sondagem_id path string true "ID da sondagem"
+// @Success 200 {object} models.SondagemManutencao
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sondagem não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/pacientes/{paciente_id}/sondagens-manutencao/{sondagem_id} [get]
+func (h *SondagemManutencaoHandler) GetSondagem(c *gin.Context) {
+	pacienteID, err := uuid.Parse(c.Param("paciente_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do paciente inválido"})
+		return
+	}
+
+	sondagemID, err := uuid.Parse(c.Param("sondagem_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sondagem inválido"})
+		return
+	}
+
+	sondagem, err := h.service.GetSondagem(c.Request.Context(), pacienteID, sondagemID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sondagem)
+}
+
+// ListSondagensSessao godoc
+// @Summary Listar as sondagens de manutenção de uma sessão
+// @Description Retorna as sondagens de manutenção alocadas na sessão. As tentativas de cada sondagem devem ser registradas como coletas ABA com "sondagem_manutencao_id"; o resultado é calculado ao encerrar a sessão
+// @Tags sondagens-manutencao
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da sessão"
+// @Success 200 {array} models.SondagemManutencao
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/sessoes/{id}/sondagens-manutencao [get]
+func (h *SondagemManutencaoHandler) ListSondagensSessao(c *gin.Context) {
+	sessaoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da sessão inválido"})
+		return
+	}
+
+	sondagens, err := h.service.ListSondagensSessao(c.Request.Context(), sessaoID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sondagens)
+}
+
+// handleError converte os erros do serviço de sondagens de manutenção em respostas HTTP
+func (h *SondagemManutencaoHandler) handleError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrSondagemManutencaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sondagem de manutenção não encontrada"})
+	case service.ErrSessaoNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -300,6 +300,8 @@ func (h *SessaoHandler) GetSessao(c *gin.Context) {
 // @Success 200 {object} models.Sessao
 // @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
 // @Failure 404 {object} map[string]string "Sessão não encontrada"
+// @Failure 409 {object} map[string]string "Sessão possui cronômetros em andamento"
+// @Failure 422 {object} map[string]string "Sessão realizada não pode voltar para outro status"
 // @Failure 500 {object} map[string]string "Erro interno do servidor"
 // @Security BearerAuth
 // @Router /api/v1/sessoes/{id} [put]
@@ -323,6 +325,14 @@ func (h *SessaoHandler) UpdateSessao(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Sessão não encontrada"})
 			return
 		}
+		if err == service.ErrSessaoComCronometrosAbertos {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if err == service.ErrSessaoRealizada {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
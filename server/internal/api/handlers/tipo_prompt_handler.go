@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/service"
+)
+
+// TipoPromptHandler gerencia as requisições HTTP relacionadas a tipos de prompt
+type TipoPromptHandler struct {
+	service *service.TipoPromptService
+}
+
+// NewTipoPromptHandler cria uma nova instância de TipoPromptHandler
+func NewTipoPromptHandler(service *service.TipoPromptService) *TipoPromptHandler {
+	return &TipoPromptHandler{service: service}
+}
+
+// CreateTipoPrompt godoc
+// @Summary Criar um novo tipo de prompt
+// @Description Cria um novo tipo de prompt com os dados fornecidos
+// @Tags tipos-prompt
+// @Accept json
+// @Produce json
+// @Param prompt body models.TipoPrompt true "Dados do tipo de prompt"
+// @Success 201 {object} models.TipoPrompt
+// @Failure 400 {object} map[string]string "Erro de validação"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/tipos-prompt [post]
+func (h *TipoPromptHandler) CreateTipoPrompt(c *gin.Context) {
+	var prompt models.TipoPrompt
+	if err := c.ShouldBindJSON(&prompt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.CreateTipoPrompt(c.Request.Context(), &prompt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetTipoPrompt godoc
+// @Summary Obter um tipo de prompt pelo ID
+// @Description Retorna os detalhes de um tipo de prompt específico
+// @Tags tipos-prompt
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do tipo de prompt"
+// @Success 200 {object} models.TipoPrompt
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Tipo de prompt não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/tipos-prompt/{id} [get]
+func (h *TipoPromptHandler) GetTipoPrompt(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	prompt, err := h.service.GetTipoPrompt(c.Request.Context(), id)
+	if err != nil {
+		if err == service.ErrTipoPromptNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tipo de prompt não encontrado"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, prompt)
+}
+
+// UpdateTipoPrompt godoc
+// @Summary Atualizar um tipo de prompt
+// @Description Atualiza os dados de um tipo de prompt existente
+// @Tags tipos-prompt
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do tipo de prompt"
+// @Param prompt body models.TipoPrompt true "Dados do tipo de prompt"
+// @Success 200 {object} models.TipoPrompt
+// @Failure 400 {object} map[string]string "ID inválido ou erro de validação"
+// @Failure 404 {object} map[string]string "Tipo de prompt não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/tipos-prompt/{id} [put]
+func (h *TipoPromptHandler) UpdateTipoPrompt(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var prompt models.TipoPrompt
+	if err := c.ShouldBindJSON(&prompt); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	prompt.ID = id
+
+	result, err := h.service.UpdateTipoPrompt(c.Request.Context(), &prompt)
+	if err != nil {
+		if err == service.ErrTipoPromptNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tipo de prompt não encontrado"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteTipoPrompt godoc
+// @Summary Excluir um tipo de prompt
+// @Description Exclui um tipo de prompt pelo ID (soft delete)
+// @Tags tipos-prompt
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do tipo de prompt"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "ID inválido"
+// @Failure 404 {object} map[string]string "Tipo de prompt não encontrado"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/tipos-prompt/{id} [delete]
+func (h *TipoPromptHandler) DeleteTipoPrompt(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	err = h.service.DeleteTipoPrompt(c.Request.Context(), id)
+	if err != nil {
+		if err == service.ErrTipoPromptNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tipo de prompt não encontrado"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListTiposPrompt godoc
+// @Summary Listar tipos de prompt
+// @Description Retorna uma lista paginada de tipos de prompt
+// @Tags tipos-prompt
+// @Accept json
+// @Produce json
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param page_size query int false "Tamanho da página (padrão: 10)"
+// @Success 200 {object} map[string]interface{} "Lista de tipos de prompt e metadados de paginação"
+// @Failure 500 {object} map[string]string "Erro interno do servidor"
+// @Security BearerAuth
+// @Router /api/v1/tipos-prompt [get]
+func (h *TipoPromptHandler) ListTiposPrompt(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	prompts, total, err := h.service.ListTiposPrompt(c.Request.Context(), page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":       prompts,
+		"total":      total,
+		"page":       page,
+		"page_size":  pageSize,
+		"total_page": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupAlvoEtapaRoutes configura as rotas do banco de alvos das etapas, das sondagens de generalização
+// e do plano de alvos das sessões
+func SetupAlvoEtapaRoutes(router *gin.RouterGroup, handler *handlers.AlvoEtapaHandler, authMiddleware middleware.AuthMiddleware) {
+	programas := router.Group("/programas-aba")
+	programas.Use(authMiddleware.RequireAuth())
+	{
+		programas.POST("/:id/etapas/:etapa_id/alvos", handler.CreateAlvo)
+		programas.GET("/:id/etapas/:etapa_id/alvos", handler.ListAlvos)
+		programas.PUT("/:id/etapas/:etapa_id/alvos/ordem", handler.ReordenarAlvos)
+		programas.GET("/:id/etapas/:etapa_id/alvos/:alvo_id", handler.GetAlvo)
+		programas.PUT("/:id/etapas/:etapa_id/alvos/:alvo_id", handler.UpdateAlvo)
+		programas.DELETE("/:id/etapas/:etapa_id/alvos/:alvo_id", handler.DeleteAlvo)
+		programas.POST("/:id/etapas/:etapa_id/alvos/:alvo_id/sondagens-generalizacao", handler.CreateSondagem)
+		programas.GET("/:id/etapas/:etapa_id/alvos/:alvo_id/sondagens-generalizacao", handler.ListSondagens)
+		programas.POST("/:id/etapas/:etapa_id/alvos/:alvo_id/sondagens-generalizacao/:sondagem_id/resultado", handler.RegistrarSondagem)
+		programas.DELETE("/:id/etapas/:etapa_id/alvos/:alvo_id/sondagens-generalizacao/:sondagem_id", handler.DeleteSondagem)
+	}
+
+	sessoes := router.Group("/sessoes")
+	sessoes.Use(authMiddleware.RequireAuth())
+	{
+		sessoes.GET("/:id/plano-alvos", handler.GetPlanoSessao)
+	}
+}
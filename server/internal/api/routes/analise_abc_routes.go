@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupAnaliseABCRoutes configura as rotas da taxonomia ABC e da análise ABC dos comportamentos alvo
+func SetupAnaliseABCRoutes(router *gin.RouterGroup, handler *handlers.AnaliseABCHandler, authMiddleware middleware.AuthMiddleware) {
+	categorias := router.Group("/categorias-abc")
+	categorias.Use(authMiddleware.RequireAuth())
+	{
+		categorias.POST("", handler.CreateCategoria)
+		categorias.GET("", handler.ListCategorias)
+		categorias.GET("/:id", handler.GetCategoria)
+		categorias.PUT("/:id", handler.UpdateCategoria)
+		categorias.DELETE("/:id", handler.DeleteCategoria)
+	}
+
+	comportamentos := router.Group("/comportamentos-alvo")
+	comportamentos.Use(authMiddleware.RequireAuth())
+	{
+		comportamentos.GET("/:id/analise-abc", handler.AnalisarComportamento)
+	}
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupAvaliacaoFuncionalRoutes configura as rotas das avaliações funcionais do comportamento (FBA)
+func SetupAvaliacaoFuncionalRoutes(router *gin.RouterGroup, handler *handlers.AvaliacaoFuncionalHandler, authMiddleware middleware.AuthMiddleware) {
+	pacientes := router.Group("/pacientes")
+	pacientes.Use(authMiddleware.RequireAuth())
+	{
+		pacientes.POST("/:paciente_id/avaliacoes-funcionais", handler.CreateAvaliacao)
+		pacientes.GET("/:paciente_id/avaliacoes-funcionais", handler.ListAvaliacoes)
+		pacientes.GET("/:paciente_id/avaliacoes-funcionais/:avaliacao_id", handler.GetAvaliacao)
+		pacientes.PUT("/:paciente_id/avaliacoes-funcionais/:avaliacao_id", handler.UpdateAvaliacao)
+		pacientes.DELETE("/:paciente_id/avaliacoes-funcionais/:avaliacao_id", handler.DeleteAvaliacao)
+		pacientes.POST("/:paciente_id/avaliacoes-funcionais/:avaliacao_id/enviar", handler.EnviarRevisao)
+		pacientes.POST("/:paciente_id/avaliacoes-funcionais/:avaliacao_id/devolver", handler.DevolverRascunho)
+		pacientes.POST("/:paciente_id/avaliacoes-funcionais/:avaliacao_id/aprovar", handler.Aprovar)
+		pacientes.GET("/:paciente_id/avaliacoes-funcionais/:avaliacao_id/historico", handler.ListHistorico)
+	}
+
+	comportamentos := router.Group("/comportamentos-alvo")
+	comportamentos.Use(authMiddleware.RequireAuth())
+	{
+		comportamentos.GET("/:id/avaliacoes-funcionais", handler.ListAvaliacoesComportamento)
+	}
+}
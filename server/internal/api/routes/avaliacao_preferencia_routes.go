@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupAvaliacaoPreferenciaRoutes configura as rotas de avaliações de preferência e do ranking de reforçadores
+func SetupAvaliacaoPreferenciaRoutes(router *gin.RouterGroup, handler *handlers.AvaliacaoPreferenciaHandler, authMiddleware middleware.AuthMiddleware) {
+	pacientes := router.Group("/pacientes")
+	pacientes.Use(authMiddleware.RequireAuth())
+	{
+		pacientes.POST("/:paciente_id/avaliacoes-preferencia", handler.CreateAvaliacao)
+		pacientes.GET("/:paciente_id/avaliacoes-preferencia", handler.ListAvaliacoes)
+		pacientes.GET("/:paciente_id/avaliacoes-preferencia/:avaliacao_id", handler.GetAvaliacao)
+		pacientes.PUT("/:paciente_id/avaliacoes-preferencia/:avaliacao_id", handler.UpdateAvaliacao)
+		pacientes.DELETE("/:paciente_id/avaliacoes-preferencia/:avaliacao_id", handler.DeleteAvaliacao)
+		pacientes.GET("/:paciente_id/reforcadores", handler.ReforcadoresPaciente)
+	}
+
+	sessoes := router.Group("/sessoes")
+	sessoes.Use(authMiddleware.RequireAuth())
+	{
+		sessoes.GET("/:id/reforcadores", handler.ReforcadoresSessao)
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupColetaABARoutes configura as rotas de coleta de tentativas ABA, aninhadas nas sessões, e a listagem
+// das tentativas de uma etapa em todas as sessões
+func SetupColetaABARoutes(router *gin.RouterGroup, handler *handlers.ColetaABAHandler, authMiddleware middleware.AuthMiddleware) {
+	sessoes := router.Group("/sessoes")
+	sessoes.Use(authMiddleware.RequireAuth())
+	{
+		sessoes.POST("/:id/coletas", handler.CreateColeta)
+		sessoes.GET("/:id/coletas", handler.ListColetasBySessao)
+		sessoes.GET("/:id/coletas/resumo", handler.GetResumoSessao)
+		sessoes.GET("/:id/coletas/:coleta_id", handler.GetColeta)
+		sessoes.PUT("/:id/coletas/:coleta_id", handler.UpdateColeta)
+		sessoes.DELETE("/:id/coletas/:coleta_id", handler.DeleteColeta)
+	}
+
+	programas := router.Group("/programas-aba")
+	programas.Use(authMiddleware.RequireAuth())
+	{
+		programas.GET("/:id/etapas/:etapa_id/coletas", handler.ListColetasByEtapa)
+	}
+}
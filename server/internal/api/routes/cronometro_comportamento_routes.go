@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupCronometroComportamentoRoutes configura as rotas de cronômetros de duração e latência
+func SetupCronometroComportamentoRoutes(router *gin.RouterGroup, handler *handlers.CronometroComportamentoHandler, authMiddleware middleware.AuthMiddleware) {
+	comportamentos := router.Group("/comportamentos-alvo")
+	comportamentos.Use(authMiddleware.RequireAuth())
+	{
+		comportamentos.POST("/:id/cronometros", handler.IniciarCronometro)
+		comportamentos.POST("/:id/cronometros/:cronometro_id/parar", handler.EncerrarCronometro)
+		comportamentos.DELETE("/:id/cronometros/:cronometro_id", handler.DescartarCronometro)
+	}
+
+	// Rotas aninhadas para os cronômetros de uma sessão
+	sessoes := router.Group("/sessoes")
+	sessoes.Use(authMiddleware.RequireAuth())
+	{
+		sessoes.GET("/:id/cronometros", handler.ListCronometrosSessao)
+		sessoes.GET("/:id/cronometros/resumo", handler.ResumoSessao)
+	}
+}
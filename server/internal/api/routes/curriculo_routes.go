@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupCurriculoRoutes configura as rotas dos currículos de avaliação e das suas aplicações aos pacientes
+func SetupCurriculoRoutes(router *gin.RouterGroup, handler *handlers.CurriculoHandler, authMiddleware middleware.AuthMiddleware) {
+	curriculos := router.Group("/curriculos")
+	curriculos.Use(authMiddleware.RequireAuth())
+	{
+		curriculos.POST("", handler.CreateCurriculo)
+		curriculos.GET("", handler.ListCurriculos)
+		curriculos.GET("/:id", handler.GetCurriculo)
+		curriculos.PUT("/:id", handler.UpdateCurriculo)
+		curriculos.DELETE("/:id", handler.DeleteCurriculo)
+	}
+
+	aplicacoes := router.Group("/pacientes/:paciente_id/aplicacoes-curriculo")
+	aplicacoes.Use(authMiddleware.RequireAuth())
+	{
+		aplicacoes.POST("", handler.CreateAplicacao)
+		aplicacoes.GET("", handler.ListAplicacoes)
+		aplicacoes.GET("/:aplicacao_id", handler.GetAplicacao)
+		aplicacoes.PUT("/:aplicacao_id", handler.UpdateAplicacao)
+		aplicacoes.DELETE("/:aplicacao_id", handler.DeleteAplicacao)
+		aplicacoes.PUT("/:aplicacao_id/pontuacoes", handler.PontuarMarcos)
+		aplicacoes.POST("/:aplicacao_id/concluir", handler.ConcluirAplicacao)
+		aplicacoes.GET("/:aplicacao_id/resultado", handler.GetResultado)
+		aplicacoes.GET("/:aplicacao_id/comparacao", handler.CompararAplicacoes)
+		aplicacoes.GET("/:aplicacao_id/grade", handler.GetGrade)
+		aplicacoes.GET("/:aplicacao_id/deficits", handler.ListDeficits)
+		aplicacoes.POST("/:aplicacao_id/deficits/:marco_id/programas", handler.CreateProgramaDeficit)
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupEscalaGASRoutes configura as rotas das escalas GAS, aninhadas nos objetivos terapêuticos, e do
+// escore T GAS dos pacientes
+func SetupEscalaGASRoutes(router *gin.RouterGroup, handler *handlers.EscalaGASHandler, authMiddleware middleware.AuthMiddleware) {
+	objetivos := router.Group("/objetivos")
+	objetivos.Use(authMiddleware.RequireAuth())
+	{
+		objetivos.PUT("/:id/escala-gas", handler.DefinirEscala)
+		objetivos.GET("/:id/escala-gas", handler.GetEscala)
+		objetivos.DELETE("/:id/escala-gas", handler.RemoverEscala)
+	}
+
+	pacientes := router.Group("/pacientes")
+	pacientes.Use(authMiddleware.RequireAuth())
+	{
+		pacientes.GET("/:paciente_id/gas", handler.GetResultadoPaciente)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupEtapaProgramaRoutes configura as rotas de etapas, aninhadas nos programas ABA
+func SetupEtapaProgramaRoutes(router *gin.RouterGroup, handler *handlers.EtapaProgramaHandler, authMiddleware middleware.AuthMiddleware) {
+	programas := router.Group("/programas-aba")
+	programas.Use(authMiddleware.RequireAuth())
+	{
+		programas.GET("/:id/progresso", handler.GetProgresso)
+		programas.GET("/:id/historico", handler.ListHistorico)
+		programas.POST("/:id/etapas", handler.CreateEtapa)
+		programas.GET("/:id/etapas", handler.ListEtapas)
+		programas.PUT("/:id/etapas/ordem", handler.ReordenarEtapas)
+		programas.GET("/:id/etapas/:etapa_id", handler.GetEtapa)
+		programas.PUT("/:id/etapas/:etapa_id", handler.UpdateEtapa)
+		programas.POST("/:id/etapas/:etapa_id/retirar", handler.RetirarEtapa)
+		programas.GET("/:id/etapas/:etapa_id/dominio", handler.GetAvaliacaoDominio)
+	}
+}
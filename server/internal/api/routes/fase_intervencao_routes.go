@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupFaseIntervencaoRoutes configura as rotas de fases, aninhadas nos programas ABA e comportamentos alvo
+func SetupFaseIntervencaoRoutes(router *gin.RouterGroup, handler *handlers.FaseIntervencaoHandler, authMiddleware middleware.AuthMiddleware) {
+	programas := router.Group("/programas-aba")
+	programas.Use(authMiddleware.RequireAuth())
+	{
+		programas.POST("/:id/fases", handler.CreateFasePrograma)
+		programas.GET("/:id/fases", handler.ListFasesPrograma)
+		programas.GET("/:id/fases/resumo", handler.GetResumoFasesPrograma)
+		programas.PUT("/:id/fases/:fase_id", handler.UpdateFasePrograma)
+		programas.DELETE("/:id/fases/:fase_id", handler.DeleteFasePrograma)
+	}
+
+	comportamentos := router.Group("/comportamentos-alvo")
+	comportamentos.Use(authMiddleware.RequireAuth())
+	{
+		comportamentos.POST("/:id/fases", handler.CreateFaseComportamento)
+		comportamentos.GET("/:id/fases", handler.ListFasesComportamento)
+		comportamentos.GET("/:id/fases/resumo", handler.GetResumoFasesComportamento)
+		comportamentos.PUT("/:id/fases/:fase_id", handler.UpdateFaseComportamento)
+		comportamentos.DELETE("/:id/fases/:fase_id", handler.DeleteFaseComportamento)
+	}
+}
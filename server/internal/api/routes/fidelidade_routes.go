@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupFidelidadeRoutes configura as rotas de listas de verificação e avaliações de fidelidade
+func SetupFidelidadeRoutes(router *gin.RouterGroup, handler *handlers.FidelidadeHandler, authMiddleware middleware.AuthMiddleware) {
+	programas := router.Group("/programas-aba")
+	programas.Use(authMiddleware.RequireAuth())
+	{
+		programas.GET("/:id/fidelidade", handler.ResumoPrograma)
+		programas.POST("/:id/checklists-fidelidade", handler.CreateChecklist)
+		programas.GET("/:id/checklists-fidelidade", handler.ListChecklists)
+		programas.GET("/:id/checklists-fidelidade/:checklist_id", handler.GetChecklist)
+		programas.PUT("/:id/checklists-fidelidade/:checklist_id", handler.UpdateChecklist)
+		programas.DELETE("/:id/checklists-fidelidade/:checklist_id", handler.DeleteChecklist)
+	}
+
+	sessoes := router.Group("/sessoes")
+	sessoes.Use(authMiddleware.RequireAuth())
+	{
+		sessoes.POST("/:id/fidelidade", handler.CreateAvaliacao)
+		sessoes.GET("/:id/fidelidade", handler.ListAvaliacoesSessao)
+		sessoes.GET("/:id/fidelidade/:avaliacao_id", handler.GetAvaliacao)
+		sessoes.PUT("/:id/fidelidade/:avaliacao_id", handler.UpdateAvaliacao)
+		sessoes.DELETE("/:id/fidelidade/:avaliacao_id", handler.DeleteAvaliacao)
+	}
+
+	terapeutas := router.Group("/terapeutas")
+	terapeutas.Use(authMiddleware.RequireAuth())
+	{
+		terapeutas.GET("/:terapeuta_id/fidelidade", handler.ResumoTerapeuta)
+	}
+
+	avaliacoes := router.Group("/avaliacoes-fidelidade")
+	avaliacoes.Use(authMiddleware.RequireAuth())
+	{
+		avaliacoes.GET("/sinalizadas", handler.ListSinalizadas)
+	}
+}
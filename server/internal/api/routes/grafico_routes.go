@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupGraficoRoutes configura as rotas de renderização de gráficos
+func SetupGraficoRoutes(router *gin.RouterGroup, handler *handlers.GraficoHandler, authMiddleware middleware.AuthMiddleware) {
+	programas := router.Group("/programas-aba")
+	programas.Use(authMiddleware.RequireAuth())
+	{
+		programas.GET("/:id/grafico", handler.GetGraficoPrograma)
+	}
+
+	comportamentos := router.Group("/comportamentos-alvo")
+	comportamentos.Use(authMiddleware.RequireAuth())
+	{
+		comportamentos.GET("/:id/grafico", handler.GetGraficoComportamento)
+		comportamentos.GET("/:id/dispersao", handler.GetDispersaoComportamento)
+	}
+}
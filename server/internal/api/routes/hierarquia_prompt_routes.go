@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupHierarquiaPromptRoutes configura as rotas da hierarquia de prompts e das recomendações de esvanecimento
+func SetupHierarquiaPromptRoutes(router *gin.RouterGroup, handler *handlers.HierarquiaPromptHandler, authMiddleware middleware.AuthMiddleware) {
+	programas := router.Group("/programas-aba")
+	programas.Use(authMiddleware.RequireAuth())
+	{
+		programas.PUT("/:id/hierarquia-prompt", handler.DefinirHierarquia)
+		programas.GET("/:id/hierarquia-prompt", handler.GetHierarquia)
+		programas.GET("/:id/alertas-prompt", handler.ListAlertasPrograma)
+		programas.GET("/:id/etapas/:etapa_id/recomendacao-prompt", handler.GetRecomendacao)
+	}
+
+	// Rotas aninhadas para alertas de prompt de um paciente específico
+	pacientes := router.Group("/pacientes")
+	pacientes.Use(authMiddleware.RequireAuth())
+	{
+		pacientes.GET("/:paciente_id/alertas-prompt", handler.ListAlertasPaciente)
+	}
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupInstrumentoRoutes configura as rotas dos questionários padronizados, das suas aplicações aos pacientes
+// e da evolução das pontuações
+func SetupInstrumentoRoutes(router *gin.RouterGroup, handler *handlers.InstrumentoHandler, authMiddleware middleware.AuthMiddleware) {
+	instrumentos := router.Group("/instrumentos")
+	instrumentos.Use(authMiddleware.RequireAuth())
+	{
+		instrumentos.POST("", handler.CreateInstrumento)
+		instrumentos.GET("", handler.ListInstrumentos)
+		instrumentos.GET("/:id", handler.GetInstrumento)
+		instrumentos.PUT("/:id", handler.UpdateInstrumento)
+		instrumentos.DELETE("/:id", handler.DeleteInstrumento)
+	}
+
+	padroes := router.Group("/instrumentos-padrao")
+	padroes.Use(authMiddleware.RequireAuth())
+	{
+		padroes.GET("", handler.ListInstrumentosPadrao)
+		padroes.POST("/:codigo", handler.InstalarInstrumentoPadrao)
+	}
+
+	aplicacoes := router.Group("/pacientes/:paciente_id/aplicacoes-instrumento")
+	aplicacoes.Use(authMiddleware.RequireAuth())
+	{
+		aplicacoes.POST("", handler.CreateAplicacao)
+		aplicacoes.GET("", handler.ListAplicacoes)
+		aplicacoes.GET("/:aplicacao_id", handler.GetAplicacao)
+		aplicacoes.DELETE("/:aplicacao_id", handler.DeleteAplicacao)
+		aplicacoes.POST("/:aplicacao_id/seguimento", handler.RegistrarSeguimento)
+	}
+
+	evolucao := router.Group("/pacientes/:paciente_id/instrumentos")
+	evolucao.Use(authMiddleware.RequireAuth())
+	{
+		evolucao.GET("/:codigo/evolucao", handler.GetEvolucao)
+	}
+}
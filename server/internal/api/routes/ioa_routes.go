@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupIOARoutes configura as rotas de concordância entre observadores (IOA)
+func SetupIOARoutes(router *gin.RouterGroup, handler *handlers.IOAHandler, authMiddleware middleware.AuthMiddleware) {
+	sessoes := router.Group("/sessoes")
+	sessoes.Use(authMiddleware.RequireAuth())
+	{
+		sessoes.GET("/:id/ioa", handler.GetIOASessao)
+	}
+
+	pacientes := router.Group("/pacientes")
+	pacientes.Use(authMiddleware.RequireAuth())
+	{
+		pacientes.GET("/:paciente_id/ioa/cobertura", handler.CoberturaPaciente)
+	}
+
+	terapeutas := router.Group("/terapeutas")
+	terapeutas.Use(authMiddleware.RequireAuth())
+	{
+		terapeutas.GET("/:terapeuta_id/ioa/cobertura", handler.CoberturaTerapeuta)
+	}
+}
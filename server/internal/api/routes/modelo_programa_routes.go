@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupModeloProgramaRoutes configura as rotas da biblioteca de modelos de programa, da instanciação dos
+// modelos para os pacientes e do relatório de uso
+func SetupModeloProgramaRoutes(router *gin.RouterGroup, handler *handlers.ModeloProgramaHandler, authMiddleware middleware.AuthMiddleware) {
+	modelos := router.Group("/modelos-programa")
+	modelos.Use(authMiddleware.RequireAuth())
+	{
+		modelos.POST("", handler.CreateModelo)
+		modelos.GET("", handler.ListModelos)
+		modelos.GET("/:id", handler.GetModelo)
+		modelos.PUT("/:id", handler.UpdateModelo)
+		modelos.DELETE("/:id", handler.DeleteModelo)
+		modelos.POST("/:id/programas", handler.InstanciarModelo)
+		modelos.GET("/:id/programas", handler.ListProgramasModelo)
+		modelos.GET("/:id/uso", handler.GetUsoModelo)
+	}
+
+	uso := router.Group("/uso-modelos-programa")
+	uso.Use(authMiddleware.RequireAuth())
+	{
+		uso.GET("", handler.ListUsoModelos)
+	}
+}
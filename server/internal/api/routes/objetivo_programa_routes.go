@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupObjetivoProgramaRoutes configura as rotas dos vínculos entre objetivos terapêuticos e programas ABA
+func SetupObjetivoProgramaRoutes(router *gin.RouterGroup, handler *handlers.ObjetivoProgramaHandler, authMiddleware middleware.AuthMiddleware) {
+	objetivos := router.Group("/objetivos")
+	objetivos.Use(authMiddleware.RequireAuth())
+	{
+		objetivos.POST("/:id/programas", handler.VincularPrograma)
+		objetivos.GET("/:id/programas", handler.ListProgramasObjetivo)
+		objetivos.DELETE("/:id/programas/:programa_id", handler.DesvincularPrograma)
+		objetivos.GET("/:id/progresso-programas", handler.GetProgressoProgramas)
+		objetivos.POST("/:id/conclusao", handler.ConfirmarConclusao)
+	}
+
+	programas := router.Group("/programas")
+	programas.Use(authMiddleware.RequireAuth())
+	{
+		programas.GET("/:id/objetivos", handler.ListObjetivosPrograma)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupObservacaoIntervaloRoutes configura as rotas de observações por intervalos, aninhadas nos comportamentos alvo
+func SetupObservacaoIntervaloRoutes(router *gin.RouterGroup, handler *handlers.ObservacaoIntervaloHandler, authMiddleware middleware.AuthMiddleware) {
+	comportamentos := router.Group("/comportamentos-alvo")
+	comportamentos.Use(authMiddleware.RequireAuth())
+	{
+		comportamentos.POST("/:id/observacoes-intervalo", handler.CreateObservacao)
+		comportamentos.GET("/:id/observacoes-intervalo", handler.ListObservacoes)
+		comportamentos.GET("/:id/observacoes-intervalo/:observacao_id", handler.GetObservacao)
+		comportamentos.PUT("/:id/observacoes-intervalo/:observacao_id", handler.UpdateObservacao)
+		comportamentos.DELETE("/:id/observacoes-intervalo/:observacao_id", handler.DeleteObservacao)
+	}
+}
@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupPlanoIntervencaoRoutes configura as rotas dos planos de intervenção comportamental
+func SetupPlanoIntervencaoRoutes(router *gin.RouterGroup, handler *handlers.PlanoIntervencaoHandler, authMiddleware middleware.AuthMiddleware) {
+	planos := router.Group("/pacientes/:paciente_id/planos-intervencao")
+	planos.Use(authMiddleware.RequireAuth())
+	{
+		planos.POST("", handler.CreatePlano)
+		planos.GET("", handler.ListPlanos)
+		planos.GET("/:plano_id", handler.GetPlano)
+		planos.PUT("/:plano_id", handler.UpdatePlano)
+		planos.DELETE("/:plano_id", handler.DeletePlano)
+		planos.GET("/:plano_id/historico", handler.ListHistorico)
+		planos.POST("/:plano_id/versoes", handler.CreateVersao)
+		planos.GET("/:plano_id/versoes/:numero", handler.GetVersao)
+		planos.PUT("/:plano_id/versoes/:numero", handler.UpdateVersao)
+		planos.POST("/:plano_id/versoes/:numero/publicar", handler.PublicarVersao)
+		planos.POST("/:plano_id/versoes/:numero/ciencias", handler.RegistrarCiencia)
+		planos.GET("/:plano_id/versoes/:numero/documento", handler.GetDocumentoVersao)
+	}
+
+	comportamentos := router.Group("/comportamentos-alvo")
+	comportamentos.Use(authMiddleware.RequireAuth())
+	{
+		comportamentos.GET("/:id/planos-intervencao", handler.ListPlanosComportamento)
+	}
+
+	programas := router.Group("/programas-aba")
+	programas.Use(authMiddleware.RequireAuth())
+	{
+		programas.GET("/:id/planos-intervencao", handler.ListPlanosPrograma)
+	}
+}
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupPlanoTerapeuticoRoutes configura as rotas dos planos terapêuticos singulares
+func SetupPlanoTerapeuticoRoutes(router *gin.RouterGroup, handler *handlers.PlanoTerapeuticoHandler, authMiddleware middleware.AuthMiddleware) {
+	planos := router.Group("/pacientes/:paciente_id/planos-terapeuticos")
+	planos.Use(authMiddleware.RequireAuth())
+	{
+		planos.POST("", handler.CreatePlano)
+		planos.GET("", handler.ListPlanos)
+		planos.GET("/:plano_id", handler.GetPlano)
+		planos.PUT("/:plano_id", handler.UpdatePlano)
+		planos.DELETE("/:plano_id", handler.DeletePlano)
+		planos.GET("/:plano_id/historico", handler.ListHistorico)
+		planos.POST("/:plano_id/versoes", handler.CreateVersao)
+		planos.GET("/:plano_id/versoes/:numero", handler.GetVersao)
+		planos.PUT("/:plano_id/versoes/:numero", handler.UpdateVersao)
+		planos.POST("/:plano_id/versoes/:numero/enviar", handler.EnviarVersao)
+		planos.POST("/:plano_id/versoes/:numero/aprovar", handler.AprovarVersao)
+		planos.POST("/:plano_id/versoes/:numero/devolver", handler.DevolverVersao)
+		planos.POST("/:plano_id/versoes/:numero/aceites", handler.RegistrarAceite)
+		planos.GET("/:plano_id/versoes/:numero/documento", handler.GetDocumentoVersao)
+	}
+
+	revisoes := router.Group("/revisoes-planos-terapeuticos")
+	revisoes.Use(authMiddleware.RequireAuth())
+	{
+		revisoes.GET("", handler.ListLembretesRevisao)
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupProgressoObjetivoRoutes configura as rotas de progresso, aninhadas nos objetivos terapêuticos
+func SetupProgressoObjetivoRoutes(router *gin.RouterGroup, handler *handlers.ProgressoObjetivoHandler, authMiddleware middleware.AuthMiddleware) {
+	objetivos := router.Group("/objetivos")
+	objetivos.Use(authMiddleware.RequireAuth())
+	{
+		objetivos.POST("/:id/progresso", handler.CreateProgresso)
+		objetivos.GET("/:id/progresso", handler.ListProgresso)
+		objetivos.GET("/:id/progresso/tendencia", handler.GetTendencia)
+		objetivos.GET("/:id/progresso/:progresso_id", handler.GetProgresso)
+		objetivos.PUT("/:id/progresso/:progresso_id", handler.UpdateProgresso)
+		objetivos.DELETE("/:id/progresso/:progresso_id", handler.DeleteProgresso)
+	}
+
+	// Rotas aninhadas para objetivos sinalizados de um paciente específico
+	pacientes := router.Group("/pacientes")
+	pacientes.Use(authMiddleware.RequireAuth())
+	{
+		pacientes.GET("/:paciente_id/objetivos/sinalizados", handler.ListObjetivosSinalizados)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupRegistroComportamentoRoutes configura as rotas de registros, aninhadas nos comportamentos alvo
+func SetupRegistroComportamentoRoutes(router *gin.RouterGroup, handler *handlers.RegistroComportamentoHandler, authMiddleware middleware.AuthMiddleware) {
+	comportamentos := router.Group("/comportamentos-alvo")
+	comportamentos.Use(authMiddleware.RequireAuth())
+	{
+		comportamentos.POST("/:id/registros", handler.CreateRegistro)
+		comportamentos.GET("/:id/registros", handler.ListRegistros)
+		comportamentos.GET("/:id/registros/agregado", handler.AgregarRegistros)
+		comportamentos.GET("/:id/registros/:registro_id", handler.GetRegistro)
+		comportamentos.PUT("/:id/registros/:registro_id", handler.UpdateRegistro)
+		comportamentos.DELETE("/:id/registros/:registro_id", handler.DeleteRegistro)
+	}
+}
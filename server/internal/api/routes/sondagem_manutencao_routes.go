@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupSondagemManutencaoRoutes configura as rotas das sondagens de manutenção por paciente e por sessão
+func SetupSondagemManutencaoRoutes(router *gin.RouterGroup, handler *handlers.SondagemManutencaoHandler, authMiddleware middleware.AuthMiddleware) {
+	pacientes := router.Group("/pacientes")
+	pacientes.Use(authMiddleware.RequireAuth())
+	{
+		pacientes.GET("/:paciente_id/sondagens-manutencao", handler.ListSondagensPaciente)
+		pacientes.POST("/:paciente_id/sondagens-manutencao/planejar", handler.PlanejarSondagensPaciente)
+		pacientes.GET("/:paciente_id/sondagens-manutencao/:sondagem_id", handler.GetSondagem)
+	}
+
+	sessoes := router.Group("/sessoes")
+	sessoes.Use(authMiddleware.RequireAuth())
+	{
+		sessoes.GET("/:id/sondagens-manutencao", handler.ListSondagensSessao)
+	}
+}
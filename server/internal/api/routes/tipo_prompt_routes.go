@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"msd-service/server/internal/api/handlers"
+	"msd-service/server/internal/middleware"
+)
+
+// SetupTipoPromptRoutes configura as rotas relacionadas a tipos de prompt
+func SetupTipoPromptRoutes(router *gin.RouterGroup, handler *handlers.TipoPromptHandler, authMiddleware middleware.AuthMiddleware) {
+	prompts := router.Group("/tipos-prompt")
+	prompts.Use(authMiddleware.RequireAuth())
+	{
+		prompts.POST("", handler.CreateTipoPrompt)
+		prompts.GET("", handler.ListTiposPrompt)
+		prompts.GET("/:id", handler.GetTipoPrompt)
+		prompts.PUT("/:id", handler.UpdateTipoPrompt)
+		prompts.DELETE("/:id", handler.DeleteTipoPrompt)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"msd-service/server/internal/api/handlers"
 	"msd-service/server/internal/api/routes"
 	"msd-service/server/internal/middleware"
+	"msd-service/server/internal/models"
 	"msd-service/server/internal/repository"
 	"msd-service/server/internal/service"
 )
@@ -44,6 +47,52 @@ type Server struct {
 	comportamentoRepo repository.ComportamentoAlvoRepository
 	comportamentoService *service.ComportamentoAlvoService
 	comportamentoHandler *handlers.ComportamentoAlvoHandler
+	etapaRepo        repository.EtapaProgramaRepository
+	tipoPromptRepo   repository.TipoPromptRepository
+	coletaRepo       repository.ColetaABARepository
+	coletaService    *service.ColetaABAService
+	coletaHandler    *handlers.ColetaABAHandler
+	etapaService     *service.EtapaProgramaService
+	etapaHandler     *handlers.EtapaProgramaHandler
+	auditoriaRepo    repository.EventoAuditoriaRepository
+	avaliacaoDominioService *service.AvaliacaoDominioService
+	hierarquiaPromptRepo repository.HierarquiaPromptRepository
+	tipoPromptHandler *handlers.TipoPromptHandler
+	hierarquiaPromptHandler *handlers.HierarquiaPromptHandler
+	registroComportamentoRepo repository.RegistroComportamentoRepository
+	registroComportamentoHandler *handlers.RegistroComportamentoHandler
+	progressoObjetivoRepo repository.ProgressoObjetivoRepository
+	progressoObjetivoHandler *handlers.ProgressoObjetivoHandler
+	graficoHandler   *handlers.GraficoHandler
+	faseRepo         repository.FaseIntervencaoRepository
+	faseHandler      *handlers.FaseIntervencaoHandler
+	observacaoIntervaloRepo repository.ObservacaoIntervaloRepository
+	observacaoIntervaloHandler *handlers.ObservacaoIntervaloHandler
+	cronometroRepo   repository.CronometroComportamentoRepository
+	cronometroHandler *handlers.CronometroComportamentoHandler
+	ioaHandler       *handlers.IOAHandler
+	checklistFidelidadeRepo repository.ChecklistFidelidadeRepository
+	avaliacaoFidelidadeRepo repository.AvaliacaoFidelidadeRepository
+	fidelidadeHandler *handlers.FidelidadeHandler
+	avaliacaoPreferenciaRepo repository.AvaliacaoPreferenciaRepository
+	avaliacaoPreferenciaHandler *handlers.AvaliacaoPreferenciaHandler
+	alvoRepo         repository.AlvoEtapaRepository
+	sondagemGeneralizacaoRepo repository.SondagemGeneralizacaoRepository
+	alvoHandler      *handlers.AlvoEtapaHandler
+	sondagemManutencaoRepo repository.SondagemManutencaoRepository
+	sondagemManutencaoHandler *handlers.SondagemManutencaoHandler
+	categoriaABCRepo repository.CategoriaABCRepository
+	analiseABCHandler *handlers.AnaliseABCHandler
+	avaliacaoFuncionalRepo repository.AvaliacaoFuncionalRepository
+	avaliacaoFuncionalHandler *handlers.AvaliacaoFuncionalHandler
+	planoIntervencaoRepo repository.PlanoIntervencaoRepository
+	planoIntervencaoHandler *handlers.PlanoIntervencaoHandler
+	curriculoHandler *handlers.CurriculoHandler
+	instrumentoHandler *handlers.InstrumentoHandler
+	modeloProgramaHandler *handlers.ModeloProgramaHandler
+	objetivoProgramaHandler *handlers.ObjetivoProgramaHandler
+	planoTerapeuticoHandler *handlers.PlanoTerapeuticoHandler
+	escalaGASHandler *handlers.EscalaGASHandler
 	authMiddleware   middleware.AuthMiddleware
 }
 
@@ -68,20 +117,83 @@ func NewServer(db *gorm.DB, jwtSecret string) *Server {
 	authMiddleware := middleware.NewJWTAuthMiddleware(jwtSecret)
 	
 	// Repositórios
+	transactor := repository.NewGormTransactor(db)
 	pacienteRepo := repository.NewGormPacienteRepository(db)
 	terapiaRepo := repository.NewGormTerapiaRepository(db)
 	sessaoRepo := repository.NewGormSessaoRepository(db)
 	objetivoRepo := repository.NewGormObjetivoTerapeuticoRepository(db)
 	programaRepo := repository.NewGormProgramaABARepository(db)
 	comportamentoRepo := repository.NewGormComportamentoAlvoRepository(db)
+	etapaRepo := repository.NewGormEtapaProgramaRepository(db)
+	tipoPromptRepo := repository.NewGormTipoPromptRepository(db)
+	coletaRepo := repository.NewGormColetaABARepository(db)
+	auditoriaRepo := repository.NewGormEventoAuditoriaRepository(db)
+	hierarquiaPromptRepo := repository.NewGormHierarquiaPromptRepository(db)
+	registroComportamentoRepo := repository.NewGormRegistroComportamentoRepository(db)
+	progressoObjetivoRepo := repository.NewGormProgressoObjetivoRepository(db)
+	faseRepo := repository.NewGormFaseIntervencaoRepository(db)
+	observacaoIntervaloRepo := repository.NewGormObservacaoIntervaloRepository(db)
+	cronometroRepo := repository.NewGormCronometroComportamentoRepository(db)
+	checklistFidelidadeRepo := repository.NewGormChecklistFidelidadeRepository(db)
+	avaliacaoFidelidadeRepo := repository.NewGormAvaliacaoFidelidadeRepository(db)
+	avaliacaoPreferenciaRepo := repository.NewGormAvaliacaoPreferenciaRepository(db)
+	alvoRepo := repository.NewGormAlvoEtapaRepository(db)
+	sondagemGeneralizacaoRepo := repository.NewGormSondagemGeneralizacaoRepository(db)
+	sondagemManutencaoRepo := repository.NewGormSondagemManutencaoRepository(db)
+	categoriaABCRepo := repository.NewGormCategoriaABCRepository(db)
+	avaliacaoFuncionalRepo := repository.NewGormAvaliacaoFuncionalRepository(db)
+	planoIntervencaoRepo := repository.NewGormPlanoIntervencaoRepository(db)
+	curriculoRepo := repository.NewGormCurriculoRepository(db)
+	aplicacaoCurriculoRepo := repository.NewGormAplicacaoCurriculoRepository(db)
+	instrumentoRepo := repository.NewGormInstrumentoRepository(db)
+	aplicacaoInstrumentoRepo := repository.NewGormAplicacaoInstrumentoRepository(db)
+	modeloProgramaRepo := repository.NewGormModeloProgramaRepository(db)
+	objetivoProgramaRepo := repository.NewGormObjetivoProgramaRepository(db)
+	planoTerapeuticoRepo := repository.NewGormPlanoTerapeuticoRepository(db)
+	escalaGASRepo := repository.NewGormEscalaGASRepository(db)
 	
 	// Serviços
 	pacienteService := service.NewPacienteService(pacienteRepo)
 	terapiaService := service.NewTerapiaService(terapiaRepo)
-	sessaoService := service.NewSessaoService(sessaoRepo)
+	objetivoProgramaService := service.NewObjetivoProgramaService(objetivoProgramaRepo, objetivoRepo, programaRepo, etapaRepo, auditoriaRepo, transactor)
+	etapaService := service.NewEtapaProgramaService(etapaRepo, programaRepo, auditoriaRepo, objetivoProgramaService, transactor)
+	avaliacaoDominioService := service.NewAvaliacaoDominioService(coletaRepo, sessaoRepo, etapaRepo, etapaService)
+	alvoService := service.NewAlvoEtapaService(alvoRepo, sondagemGeneralizacaoRepo, etapaRepo, programaRepo, coletaRepo, sessaoRepo)
+	var intervalosManutencao []int
+	for _, valor := range strings.Split(os.Getenv("INTERVALOS_MANUTENCAO"), ",") {
+		if dias, err := strconv.Atoi(strings.TrimSpace(valor)); err == nil && dias > 0 {
+			intervalosManutencao = append(intervalosManutencao, dias)
+		}
+	}
+	sondagemManutencaoService := service.NewSondagemManutencaoService(sondagemManutencaoRepo, sessaoRepo, programaRepo, etapaRepo, alvoRepo, coletaRepo, etapaService, auditoriaRepo, transactor, intervalosManutencao)
+	sessaoService := service.NewSessaoService(sessaoRepo, avaliacaoDominioService, alvoService, sondagemManutencaoService, cronometroRepo, transactor)
 	objetivoService := service.NewObjetivoTerapeuticoService(objetivoRepo)
-	programaService := service.NewProgramaABAService(programaRepo)
-	comportamentoService := service.NewComportamentoAlvoService(comportamentoRepo)
+	programaService := service.NewProgramaABAService(programaRepo, objetivoProgramaService, auditoriaRepo, transactor)
+	comportamentoService := service.NewComportamentoAlvoService(comportamentoRepo, registroComportamentoRepo, observacaoIntervaloRepo)
+	avaliacaoPreferenciaService := service.NewAvaliacaoPreferenciaService(avaliacaoPreferenciaRepo, sessaoRepo)
+	coletaService := service.NewColetaABAService(coletaRepo, sessaoRepo, etapaRepo, programaRepo, tipoPromptRepo, alvoRepo, sondagemManutencaoRepo, avaliacaoPreferenciaService, transactor)
+	tipoPromptService := service.NewTipoPromptService(tipoPromptRepo)
+	hierarquiaPromptService := service.NewHierarquiaPromptService(hierarquiaPromptRepo, programaRepo, etapaRepo, coletaRepo, sessaoRepo, tipoPromptRepo)
+	registroComportamentoService := service.NewRegistroComportamentoService(registroComportamentoRepo, comportamentoRepo, observacaoIntervaloRepo, sessaoRepo, categoriaABCRepo)
+	progressoObjetivoService := service.NewProgressoObjetivoService(progressoObjetivoRepo, objetivoRepo, escalaGASRepo, transactor)
+	graficoService := service.NewGraficoService(programaRepo, coletaRepo, sessaoRepo, comportamentoRepo, faseRepo, etapaService, registroComportamentoService)
+	faseService := service.NewFaseIntervencaoService(faseRepo, programaRepo, comportamentoRepo, etapaRepo, coletaRepo, sessaoRepo, registroComportamentoService)
+	observacaoIntervaloService := service.NewObservacaoIntervaloService(observacaoIntervaloRepo, comportamentoRepo, sessaoRepo)
+	cronometroService := service.NewCronometroComportamentoService(cronometroRepo, comportamentoRepo, sessaoRepo, transactor)
+	ioaService := service.NewIOAService(sessaoRepo, coletaRepo, registroComportamentoRepo, observacaoIntervaloRepo, comportamentoRepo)
+	limiarFidelidade := models.LimiarFidelidadePadrao
+	if valor, err := strconv.ParseFloat(os.Getenv("LIMIAR_FIDELIDADE"), 64); err == nil {
+		limiarFidelidade = valor
+	}
+	fidelidadeService := service.NewFidelidadeService(checklistFidelidadeRepo, avaliacaoFidelidadeRepo, programaRepo, sessaoRepo, limiarFidelidade)
+	analiseABCService := service.NewAnaliseABCService(categoriaABCRepo, registroComportamentoRepo, comportamentoRepo)
+	avaliacaoFuncionalService := service.NewAvaliacaoFuncionalService(avaliacaoFuncionalRepo, comportamentoRepo, auditoriaRepo, analiseABCService, transactor)
+	planoIntervencaoService := service.NewPlanoIntervencaoService(planoIntervencaoRepo, pacienteRepo, comportamentoRepo, programaRepo, avaliacaoFuncionalRepo, auditoriaRepo, transactor)
+	curriculoService := service.NewCurriculoService(curriculoRepo, aplicacaoCurriculoRepo, programaRepo)
+	instrumentoService := service.NewInstrumentoService(instrumentoRepo, aplicacaoInstrumentoRepo)
+	modeloProgramaService := service.NewModeloProgramaService(modeloProgramaRepo, programaRepo, etapaRepo, pacienteRepo, tipoPromptRepo)
+	planoTerapeuticoService := service.NewPlanoTerapeuticoService(planoTerapeuticoRepo, pacienteRepo, objetivoRepo, programaRepo, etapaRepo, terapiaRepo, objetivoProgramaService, auditoriaRepo, transactor)
+	escalaGASService := service.NewEscalaGASService(escalaGASRepo, objetivoRepo, progressoObjetivoRepo, pacienteRepo)
 	
 	// Handlers
 	pacienteHandler := handlers.NewPacienteHandler(pacienteService)
@@ -90,6 +202,30 @@ func NewServer(db *gorm.DB, jwtSecret string) *Server {
 	objetivoHandler := handlers.NewObjetivoTerapeuticoHandler(objetivoService)
 	programaHandler := handlers.NewProgramaABAHandler(programaService)
 	comportamentoHandler := handlers.NewComportamentoAlvoHandler(comportamentoService)
+	coletaHandler := handlers.NewColetaABAHandler(coletaService)
+	etapaHandler := handlers.NewEtapaProgramaHandler(etapaService, avaliacaoDominioService)
+	tipoPromptHandler := handlers.NewTipoPromptHandler(tipoPromptService)
+	hierarquiaPromptHandler := handlers.NewHierarquiaPromptHandler(hierarquiaPromptService)
+	registroComportamentoHandler := handlers.NewRegistroComportamentoHandler(registroComportamentoService)
+	progressoObjetivoHandler := handlers.NewProgressoObjetivoHandler(progressoObjetivoService)
+	graficoHandler := handlers.NewGraficoHandler(graficoService)
+	faseHandler := handlers.NewFaseIntervencaoHandler(faseService)
+	observacaoIntervaloHandler := handlers.NewObservacaoIntervaloHandler(observacaoIntervaloService)
+	cronometroHandler := handlers.NewCronometroComportamentoHandler(cronometroService)
+	ioaHandler := handlers.NewIOAHandler(ioaService)
+	fidelidadeHandler := handlers.NewFidelidadeHandler(fidelidadeService)
+	avaliacaoPreferenciaHandler := handlers.NewAvaliacaoPreferenciaHandler(avaliacaoPreferenciaService)
+	alvoHandler := handlers.NewAlvoEtapaHandler(alvoService)
+	sondagemManutencaoHandler := handlers.NewSondagemManutencaoHandler(sondagemManutencaoService)
+	analiseABCHandler := handlers.NewAnaliseABCHandler(analiseABCService)
+	avaliacaoFuncionalHandler := handlers.NewAvaliacaoFuncionalHandler(avaliacaoFuncionalService)
+	planoIntervencaoHandler := handlers.NewPlanoIntervencaoHandler(planoIntervencaoService)
+	curriculoHandler := handlers.NewCurriculoHandler(curriculoService)
+	instrumentoHandler := handlers.NewInstrumentoHandler(instrumentoService)
+	modeloProgramaHandler := handlers.NewModeloProgramaHandler(modeloProgramaService)
+	objetivoProgramaHandler := handlers.NewObjetivoProgramaHandler(objetivoProgramaService)
+	planoTerapeuticoHandler := handlers.NewPlanoTerapeuticoHandler(planoTerapeuticoService)
+	escalaGASHandler := handlers.NewEscalaGASHandler(escalaGASService)
 
 	server := &Server{
 		router:           router,
@@ -111,6 +247,52 @@ func NewServer(db *gorm.DB, jwtSecret string) *Server {
 		comportamentoRepo: comportamentoRepo,
 		comportamentoService: comportamentoService,
 		comportamentoHandler: comportamentoHandler,
+		etapaRepo:        etapaRepo,
+		tipoPromptRepo:   tipoPromptRepo,
+		coletaRepo:       coletaRepo,
+		coletaService:    coletaService,
+		coletaHandler:    coletaHandler,
+		etapaService:     etapaService,
+		etapaHandler:     etapaHandler,
+		auditoriaRepo:    auditoriaRepo,
+		avaliacaoDominioService: avaliacaoDominioService,
+		hierarquiaPromptRepo: hierarquiaPromptRepo,
+		tipoPromptHandler: tipoPromptHandler,
+		hierarquiaPromptHandler: hierarquiaPromptHandler,
+		registroComportamentoRepo: registroComportamentoRepo,
+		registroComportamentoHandler: registroComportamentoHandler,
+		progressoObjetivoRepo: progressoObjetivoRepo,
+		progressoObjetivoHandler: progressoObjetivoHandler,
+		graficoHandler:   graficoHandler,
+		faseRepo:         faseRepo,
+		faseHandler:      faseHandler,
+		observacaoIntervaloRepo: observacaoIntervaloRepo,
+		observacaoIntervaloHandler: observacaoIntervaloHandler,
+		cronometroRepo:   cronometroRepo,
+		cronometroHandler: cronometroHandler,
+		ioaHandler:       ioaHandler,
+		checklistFidelidadeRepo: checklistFidelidadeRepo,
+		avaliacaoFidelidadeRepo: avaliacaoFidelidadeRepo,
+		fidelidadeHandler: fidelidadeHandler,
+		avaliacaoPreferenciaRepo: avaliacaoPreferenciaRepo,
+		avaliacaoPreferenciaHandler: avaliacaoPreferenciaHandler,
+		alvoRepo:         alvoRepo,
+		sondagemGeneralizacaoRepo: sondagemGeneralizacaoRepo,
+		alvoHandler:      alvoHandler,
+		sondagemManutencaoRepo: sondagemManutencaoRepo,
+		sondagemManutencaoHandler: sondagemManutencaoHandler,
+		categoriaABCRepo: categoriaABCRepo,
+		analiseABCHandler: analiseABCHandler,
+		avaliacaoFuncionalRepo: avaliacaoFuncionalRepo,
+		avaliacaoFuncionalHandler: avaliacaoFuncionalHandler,
+		planoIntervencaoRepo: planoIntervencaoRepo,
+		planoIntervencaoHandler: planoIntervencaoHandler,
+		curriculoHandler: curriculoHandler,
+		instrumentoHandler: instrumentoHandler,
+		modeloProgramaHandler: modeloProgramaHandler,
+		objetivoProgramaHandler: objetivoProgramaHandler,
+		planoTerapeuticoHandler: planoTerapeuticoHandler,
+		escalaGASHandler: escalaGASHandler,
 		authMiddleware:   authMiddleware,
 		httpServer: &http.Server{
 			Addr:    ":" + os.Getenv("PORT"),
@@ -137,6 +319,30 @@ func (s *Server) setupRoutes() {
 	routes.SetupObjetivoTerapeuticoRoutes(v1, s.objetivoHandler, s.authMiddleware)
 	routes.SetupProgramaABARoutes(v1, s.programaHandler, s.authMiddleware)
 	routes.SetupComportamentoAlvoRoutes(v1, s.comportamentoHandler, s.authMiddleware)
+	routes.SetupColetaABARoutes(v1, s.coletaHandler, s.authMiddleware)
+	routes.SetupEtapaProgramaRoutes(v1, s.etapaHandler, s.authMiddleware)
+	routes.SetupTipoPromptRoutes(v1, s.tipoPromptHandler, s.authMiddleware)
+	routes.SetupHierarquiaPromptRoutes(v1, s.hierarquiaPromptHandler, s.authMiddleware)
+	routes.SetupRegistroComportamentoRoutes(v1, s.registroComportamentoHandler, s.authMiddleware)
+	routes.SetupProgressoObjetivoRoutes(v1, s.progressoObjetivoHandler, s.authMiddleware)
+	routes.SetupGraficoRoutes(v1, s.graficoHandler, s.authMiddleware)
+	routes.SetupFaseIntervencaoRoutes(v1, s.faseHandler, s.authMiddleware)
+	routes.SetupObservacaoIntervaloRoutes(v1, s.observacaoIntervaloHandler, s.authMiddleware)
+	routes.SetupCronometroComportamentoRoutes(v1, s.cronometroHandler, s.authMiddleware)
+	routes.SetupIOARoutes(v1, s.ioaHandler, s.authMiddleware)
+	routes.SetupFidelidadeRoutes(v1, s.fidelidadeHandler, s.authMiddleware)
+	routes.SetupAvaliacaoPreferenciaRoutes(v1, s.avaliacaoPreferenciaHandler, s.authMiddleware)
+	routes.SetupAlvoEtapaRoutes(v1, s.alvoHandler, s.authMiddleware)
+	routes.SetupSondagemManutencaoRoutes(v1, s.sondagemManutencaoHandler, s.authMiddleware)
+	routes.SetupAnaliseABCRoutes(v1, s.analiseABCHandler, s.authMiddleware)
+	routes.SetupAvaliacaoFuncionalRoutes(v1, s.avaliacaoFuncionalHandler, s.authMiddleware)
+	routes.SetupPlanoIntervencaoRoutes(v1, s.planoIntervencaoHandler, s.authMiddleware)
+	routes.SetupCurriculoRoutes(v1, s.curriculoHandler, s.authMiddleware)
+	routes.SetupInstrumentoRoutes(v1, s.instrumentoHandler, s.authMiddleware)
+	routes.SetupModeloProgramaRoutes(v1, s.modeloProgramaHandler, s.authMiddleware)
+	routes.SetupObjetivoProgramaRoutes(v1, s.objetivoProgramaHandler, s.authMiddleware)
+	routes.SetupPlanoTerapeuticoRoutes(v1, s.planoTerapeuticoHandler, s.authMiddleware)
+	routes.SetupEscalaGASRoutes(v1, s.escalaGASHandler, s.authMiddleware)
 }
 
 // Start inicia o servidor HTTP
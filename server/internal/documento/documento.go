@@ -0,0 +1,161 @@
+// Package documento gera os documentos clínicos para impressão ou compartilhamento, como o plano de
+// intervenção comportamental, em HTML ou Markdown. O conteúdo é montado pelos serviços em um Documento
+// genérico, com campos de identificação, seções de texto ou listas e assinaturas.
+package documento
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// Formato representa o formato de saída do documento
+type Formato string
+
+const (
+	FormatoHTML     Formato = "html"
+	FormatoMarkdown Formato = "markdown"
+)
+
+// ContentType retorna o tipo de conteúdo HTTP do formato
+func (f Formato) ContentType() string {
+	if f == FormatoMarkdown {
+		return "text/markdown; charset=utf-8"
+	}
+	return "text/html; charset=utf-8"
+}
+
+// Campo representa um dado de identificação do documento, como o paciente ou a versão
+type Campo struct {
+	Rotulo string
+	Valor  string
+}
+
+// Secao representa uma seção do documento, com um texto livre e/ou uma lista de itens
+type Secao struct {
+	Titulo string
+	Texto  string
+	Itens  []string
+}
+
+// Assinatura representa a ciência ou a aprovação de uma pessoa no documento
+type Assinatura struct {
+	Papel   string
+	Nome    string
+	Detalhe string
+	Data    time.Time
+}
+
+// Documento descreve um documento clínico
+type Documento struct {
+	Titulo      string
+	Subtitulo   string
+	Campos      []Campo
+	Secoes      []Secao
+	Assinaturas []Assinatura
+	Rodape      string
+}
+
+// Renderizar escreve o documento no formato informado em w
+func Renderizar(w io.Writer, d *Documento, formato Formato) error {
+	switch formato {
+	case FormatoHTML:
+		return modeloHTML.Execute(w, d)
+	case FormatoMarkdown:
+		return renderizarMarkdown(w, d)
+	default:
+		return fmt.Errorf("formato de documento não suportado: %s", formato)
+	}
+}
+
+// formatarData formata as datas das assinaturas no padrão brasileiro
+func formatarData(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("02/01/2006 15:04")
+}
+
+var modeloHTML = template.Must(template.New("documento").Funcs(template.FuncMap{"data": formatarData}).Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<title>{{.Titulo}}</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; color: #333; max-width: 800px; margin: 40px auto; }
+h1 { font-size: 22px; margin-bottom: 4px; }
+h2 { font-size: 16px; border-bottom: 1px solid #ddd; padding-bottom: 4px; margin-top: 28px; }
+.subtitulo { color: #555; margin-top: 0; }
+.texto { white-space: pre-wrap; }
+table { border-collapse: collapse; }
+td { padding: 2px 12px 2px 0; vertical-align: top; }
+.assinatura { margin-top: 16px; }
+footer { margin-top: 40px; font-size: 12px; color: #555; }
+</style>
+</head>
+<body>
+<h1>{{.Titulo}}</h1>
+{{if .Subtitulo}}<p class="subtitulo">{{.Subtitulo}}</p>
+{{end}}{{if .Campos}}<table>
+{{range .Campos}}<tr><td><strong>{{.Rotulo}}</strong></td><td>{{.Valor}}</td></tr>
+{{end}}</table>
+{{end}}{{range .Secoes}}<h2>{{.Titulo}}</h2>
+{{if .Texto}}<p class="texto">{{.Texto}}</p>
+{{end}}{{if .Itens}}<ul>
+{{range .Itens}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}{{end}}{{if .Assinaturas}}<h2>Ciências e assinaturas</h2>
+{{range .Assinaturas}}<div class="assinatura"><strong>{{.Nome}}</strong> ({{.Papel}}{{if .Detalhe}}, {{.Detalhe}}{{end}}){{with data .Data}} em {{.}}{{end}}</div>
+{{end}}{{end}}{{if .Rodape}}<footer>{{.Rodape}}</footer>
+{{end}}</body>
+</html>
+`))
+
+// renderizarMarkdown escreve o documento em Markdown
+func renderizarMarkdown(w io.Writer, d *Documento) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "# %s\n\n", d.Titulo)
+	if d.Subtitulo != "" {
+		fmt.Fprintf(b, "%s\n\n", d.Subtitulo)
+	}
+	for _, campo := range d.Campos {
+		fmt.Fprintf(b, "- **%s:** %s\n", campo.Rotulo, campo.Valor)
+	}
+	if len(d.Campos) > 0 {
+		b.WriteString("\n")
+	}
+	for _, secao := range d.Secoes {
+		fmt.Fprintf(b, "## %s\n\n", secao.Titulo)
+		if texto := strings.TrimSpace(secao.Texto); texto != "" {
+			fmt.Fprintf(b, "%s\n\n", texto)
+		}
+		for _, item := range secao.Itens {
+			fmt.Fprintf(b, "- %s\n", item)
+		}
+		if len(secao.Itens) > 0 {
+			b.WriteString("\n")
+		}
+	}
+	if len(d.Assinaturas) > 0 {
+		b.WriteString("## Ciências e assinaturas\n\n")
+		for _, assinatura := range d.Assinaturas {
+			fmt.Fprintf(b, "- **%s** (%s", assinatura.Nome, assinatura.Papel)
+			if assinatura.Detalhe != "" {
+				fmt.Fprintf(b, ", %s", assinatura.Detalhe)
+			}
+			b.WriteString(")")
+			if data := formatarData(assinatura.Data); data != "" {
+				fmt.Fprintf(b, " em %s", data)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	if d.Rodape != "" {
+		fmt.Fprintf(b, "---\n\n%s\n", d.Rodape)
+	}
+	return b.Flush()
+}
@@ -0,0 +1,191 @@
+package grafico
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+)
+
+// GradeMarcos descreve a grade de marcos de um currículo de avaliação no estilo do VB-MAPP: os domínios
+// nas colunas e os níveis empilhados do primeiro (embaixo) ao último (em cima), com uma linha por marco.
+// Cada marco é pintado com a cor da primeira aplicação em que foi pontuado: a metade inferior quando
+// parcialmente atingido (0,5) e a célula inteira quando atingido (1).
+type GradeMarcos struct {
+	Titulo     string
+	Dominios   []string
+	Niveis     []NivelGrade
+	Aplicacoes []string // rótulos das aplicações, da mais antiga para a mais recente
+}
+
+// NivelGrade descreve um nível da grade. Celulas[dominio][marco] é nula quando o domínio não tem o marco no nível.
+type NivelGrade struct {
+	Nome    string
+	Celulas [][]*CelulaGrade
+}
+
+// CelulaGrade guarda as pontuações de um marco, uma por aplicação na ordem de GradeMarcos.Aplicacoes.
+// A pontuação é nula quando o marco não foi pontuado na aplicação.
+type CelulaGrade struct {
+	Pontuacoes []*float64
+}
+
+// Dimensões da grade de marcos, em pixels
+const (
+	larguraRotuloNivel = 110
+	alturaMarcoGrade   = 18
+	espacoNivelGrade   = 12
+	margemBaseGrade    = 70
+)
+
+// coresAplicacoes são as cores usadas para distinguir as aplicações na grade, na ordem das aplicações
+var coresAplicacoes = []color.RGBA{
+	corDados,
+	{R: 0xe6, G: 0x7e, B: 0x22, A: 0xff},
+	{R: 0x27, G: 0xae, B: 0x60, A: 0xff},
+	corTendencia,
+	{R: 0x8e, G: 0x44, B: 0xad, A: 0xff},
+}
+
+// RenderizarGradeMarcos desenha a grade de marcos no formato informado e a escreve em w.
+// A altura da imagem acompanha o número de marcos dos níveis.
+func RenderizarGradeMarcos(w io.Writer, g *GradeMarcos, formato Formato) error {
+	altura := margemTopo + margemBaseGrade
+	for _, nivel := range g.Niveis {
+		altura += alturaMarcoGrade*nivel.linhas() + espacoNivelGrade
+	}
+	if len(g.Niveis) == 0 {
+		altura = Altura
+	}
+
+	switch formato {
+	case FormatoSVG:
+		t := novaTelaSVG(Largura, altura)
+		g.desenhar(t, altura)
+		return t.escrever(w)
+	case FormatoPNG:
+		t := novaTelaPNG(Largura, altura)
+		g.desenhar(t, altura)
+		return t.escrever(w)
+	default:
+		return fmt.Errorf("formato de gráfico não suportado: %s", formato)
+	}
+}
+
+// desenhar aplica o layout da grade de marcos à tela
+func (g *GradeMarcos) desenhar(t tela, altura int) {
+	x0, x1 := float64(larguraRotuloNivel), float64(Largura-margemDireita)
+	t.texto(float64(Largura)/2, 24, g.Titulo, ancoraMeio, corEixo)
+
+	if len(g.Niveis) == 0 || len(g.Dominios) == 0 {
+		t.texto((x0+x1)/2, float64(altura)/2, "Currículo sem marcos", ancoraMeio, corFase)
+		return
+	}
+
+	largura := (x1 - x0) / float64(len(g.Dominios))
+	caracteres := int(largura / 7)
+	for j, dominio := range g.Dominios {
+		t.texto(x0+largura*(float64(j)+0.5), float64(margemTopo)-10, abreviar(dominio, caracteres), ancoraMeio, corEixo)
+	}
+
+	// Os níveis são desenhados de cima para baixo, do último ao primeiro
+	y := float64(margemTopo)
+	for k := len(g.Niveis) - 1; k >= 0; k-- {
+		nivel := g.Niveis[k]
+		linhas := nivel.linhas()
+		alturaNivel := float64(alturaMarcoGrade * linhas)
+		t.texto(8, y+alturaNivel/2+4, nivel.Nome, ancoraInicio, corEixo)
+
+		for i := 0; i < linhas; i++ {
+			// O primeiro marco fica na linha de baixo do nível
+			yLinha := y + alturaNivel - float64(alturaMarcoGrade*(i+1))
+			t.texto(x0-6, yLinha+alturaMarcoGrade/2+4, fmt.Sprint(i+1), ancoraFim, corFase)
+			for j := range g.Dominios {
+				g.desenharCelula(t, nivel.celula(j, i), x0+largura*float64(j), yLinha, largura)
+			}
+		}
+		for j := 0; j <= len(g.Dominios); j++ {
+			x := x0 + largura*float64(j)
+			t.linha(x, y, x, y+alturaNivel, corGrade, 1, false)
+		}
+		for i := 0; i <= linhas; i++ {
+			yLinha := y + float64(alturaMarcoGrade*i)
+			t.linha(x0, yLinha, x1, yLinha, corGrade, 1, false)
+		}
+		t.linha(x0, y+alturaNivel, x1, y+alturaNivel, corEixo, 2, false)
+		y += alturaNivel + espacoNivelGrade
+	}
+
+	// Legenda com a cor de cada aplicação
+	yLegenda := float64(altura) - 30
+	x := x0
+	for i, aplicacao := range g.Aplicacoes {
+		t.retangulo(x, yLegenda-10, 12, 12, corAplicacao(i))
+		t.texto(x+18, yLegenda, aplicacao, ancoraInicio, corEixo)
+		x += 150
+	}
+	t.retangulo(x, yLegenda-10, 12, 12, corSemObservacao)
+	t.texto(x+18, yLegenda, "sem marco", ancoraInicio, corEixo)
+	t.texto(x0, yLegenda+20, "Metade inferior: marco parcialmente atingido (0,5); célula inteira: marco atingido (1)", ancoraInicio, corFase)
+}
+
+// desenharCelula pinta um marco com a cor da primeira aplicação em que foi parcialmente atingido, na metade
+// inferior, e da primeira em que foi atingido, na metade superior
+func (g *GradeMarcos) desenharCelula(t tela, celula *CelulaGrade, x, y, largura float64) {
+	if celula == nil {
+		t.retangulo(x, y, largura, alturaMarcoGrade, corSemObservacao)
+		return
+	}
+	metade := float64(alturaMarcoGrade) / 2
+	if i := celula.primeira(0.5); i >= 0 {
+		t.retangulo(x, y+metade, largura, metade, corAplicacao(i))
+	}
+	if i := celula.primeira(1); i >= 0 {
+		t.retangulo(x, y, largura, metade, corAplicacao(i))
+	}
+}
+
+// linhas retorna o número de linhas do nível, que é o maior número de marcos entre os domínios
+func (n *NivelGrade) linhas() int {
+	linhas := 0
+	for _, marcos := range n.Celulas {
+		if len(marcos) > linhas {
+			linhas = len(marcos)
+		}
+	}
+	if linhas == 0 {
+		linhas = 1
+	}
+	return linhas
+}
+
+// celula retorna a célula do marco no domínio, ou nula quando ela não existe
+func (n *NivelGrade) celula(dominio, marco int) *CelulaGrade {
+	if dominio >= len(n.Celulas) || marco >= len(n.Celulas[dominio]) {
+		return nil
+	}
+	return n.Celulas[dominio][marco]
+}
+
+// primeira retorna o índice da primeira aplicação em que o marco alcançou a pontuação mínima, ou -1
+func (c *CelulaGrade) primeira(minimo float64) int {
+	for i, pontuacao := range c.Pontuacoes {
+		if pontuacao != nil && *pontuacao >= minimo {
+			return i
+		}
+	}
+	return -1
+}
+
+// corAplicacao retorna a cor da aplicação, repetindo as cores quando há mais aplicações que cores
+func corAplicacao(i int) color.RGBA {
+	return coresAplicacoes[i%len(coresAplicacoes)]
+}
+
+// abreviar limita o texto ao número de caracteres informado, indicando o corte com um ponto
+func abreviar(s string, limite int) string {
+	r := []rune(s)
+	if limite < 2 || len(r) <= limite {
+		return s
+	}
+	return string(r[:limite-1]) + "."
+}
@@ -0,0 +1,235 @@
+// Package grafico desenha os gráficos de linha usados na análise do comportamento aplicada (ABA),
+// com linhas de mudança de fase, rótulos de condição, caminhos de dados interrompidos entre fases
+// e linha de tendência opcional, os mapas de calor dos gráficos de dispersão e as grades de marcos dos
+// currículos de avaliação. A renderização é feita apenas com Go, em SVG ou PNG.
+package grafico
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+)
+
+// Formato representa o formato de saída do gráfico
+type Formato string
+
+const (
+	FormatoSVG Formato = "svg"
+	FormatoPNG Formato = "png"
+)
+
+// ContentType retorna o tipo de conteúdo HTTP do formato
+func (f Formato) ContentType() string {
+	if f == FormatoPNG {
+		return "image/png"
+	}
+	return "image/svg+xml"
+}
+
+// Ponto representa um valor do gráfico. Fase é o índice da condição em Grafico.Fases.
+type Ponto struct {
+	Rotulo string
+	Valor  float64
+	Fase   int
+}
+
+// Grafico descreve um gráfico de linha no padrão ABA: um ponto por sessão ou dia, agrupados em fases
+type Grafico struct {
+	Titulo    string
+	RotuloX   string
+	RotuloY   string
+	MinY      float64
+	MaxY      float64 // quando zero, é calculado a partir dos dados
+	Fases     []string
+	Pontos    []Ponto
+	Tendencia bool
+}
+
+// Dimensões padrão do gráfico, em pixels
+const (
+	Largura = 900
+	Altura  = 480
+
+	margemEsquerda = 70
+	margemDireita  = 30
+	margemTopo     = 70
+	margemBase     = 70
+	raioPonto      = 4
+	divisoesY      = 5
+)
+
+var (
+	corEixo      = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	corGrade     = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+	corDados     = color.RGBA{R: 0x1f, G: 0x4e, B: 0x9c, A: 0xff}
+	corFase      = color.RGBA{R: 0x55, G: 0x55, B: 0x55, A: 0xff}
+	corTendencia = color.RGBA{R: 0xc0, G: 0x39, B: 0x2b, A: 0xff}
+	corFundo     = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+// ancora representa o alinhamento horizontal de um texto em relação à sua posição
+type ancora int
+
+const (
+	ancoraInicio ancora = iota
+	ancoraMeio
+	ancoraFim
+)
+
+// tela é a superfície de desenho implementada pelos formatos de saída
+type tela interface {
+	linha(x1, y1, x2, y2 float64, cor color.RGBA, espessura float64, tracejada bool)
+	circulo(x, y, r float64, cor color.RGBA)
+	retangulo(x, y, largura, altura float64, cor color.RGBA)
+	texto(x, y float64, s string, a ancora, cor color.RGBA)
+}
+
+// Renderizar desenha o gráfico no formato informado e o escreve em w
+func Renderizar(w io.Writer, g *Grafico, formato Formato) error {
+	switch formato {
+	case FormatoSVG:
+		t := novaTelaSVG(Largura, Altura)
+		g.desenhar(t)
+		return t.escrever(w)
+	case FormatoPNG:
+		t := novaTelaPNG(Largura, Altura)
+		g.desenhar(t)
+		return t.escrever(w)
+	default:
+		return fmt.Errorf("formato de gráfico não suportado: %s", formato)
+	}
+}
+
+// desenhar aplica o layout do gráfico à tela
+func (g *Grafico) desenhar(t tela) {
+	x0, x1 := float64(margemEsquerda), float64(Largura-margemDireita)
+	y0, y1 := float64(Altura-margemBase), float64(margemTopo)
+
+	minY, maxY := g.limitesY()
+	posY := func(v float64) float64 {
+		return y0 - (v-minY)/(maxY-minY)*(y0-y1)
+	}
+	passoX := (x1 - x0) / float64(len(g.Pontos)+1)
+	posX := func(i int) float64 {
+		return x0 + passoX*float64(i+1)
+	}
+
+	t.texto(float64(Largura)/2, 24, g.Titulo, ancoraMeio, corEixo)
+
+	// Grade e escala do eixo Y
+	for i := 0; i <= divisoesY; i++ {
+		v := minY + (maxY-minY)*float64(i)/divisoesY
+		y := posY(v)
+		if i > 0 {
+			t.linha(x0, y, x1, y, corGrade, 1, false)
+		}
+		t.texto(x0-8, y+4, formatarValor(v), ancoraFim, corEixo)
+	}
+	t.linha(x0, y0, x1, y0, corEixo, 2, false)
+	t.linha(x0, y0, x0, y1, corEixo, 2, false)
+	t.texto(x0, y1-14, g.RotuloY, ancoraInicio, corEixo)
+	t.texto((x0+x1)/2, float64(Altura)-14, g.RotuloX, ancoraMeio, corEixo)
+
+	// Rótulos do eixo X, espaçados para não se sobreporem
+	salto := int(math.Ceil(float64(len(g.Pontos)) * 60 / (x1 - x0)))
+	if salto < 1 {
+		salto = 1
+	}
+	for i, p := range g.Pontos {
+		if i%salto == 0 {
+			t.texto(posX(i), y0+20, p.Rotulo, ancoraMeio, corEixo)
+		}
+	}
+
+	if len(g.Pontos) == 0 {
+		t.texto((x0+x1)/2, (y0+y1)/2, "Sem dados no período", ancoraMeio, corFase)
+		return
+	}
+
+	// Linhas de mudança de fase e rótulos de condição
+	inicioFase := 0
+	for i := 0; i <= len(g.Pontos); i++ {
+		if i < len(g.Pontos) && g.Pontos[i].Fase == g.Pontos[inicioFase].Fase {
+			continue
+		}
+		if i < len(g.Pontos) {
+			xFase := (posX(i-1) + posX(i)) / 2
+			t.linha(xFase, y0, xFase, y1, corFase, 1.5, true)
+		}
+		if rotulo := g.rotuloFase(g.Pontos[inicioFase].Fase); rotulo != "" {
+			t.texto((posX(inicioFase)+posX(i-1))/2, y1-30, rotulo, ancoraMeio, corFase)
+		}
+		if g.Tendencia {
+			g.desenharTendencia(t, inicioFase, i, posX, posY, minY, maxY)
+		}
+		inicioFase = i
+	}
+
+	// Caminho de dados, interrompido entre fases
+	for i := 1; i < len(g.Pontos); i++ {
+		if g.Pontos[i].Fase != g.Pontos[i-1].Fase {
+			continue
+		}
+		t.linha(posX(i-1), posY(g.Pontos[i-1].Valor), posX(i), posY(g.Pontos[i].Valor), corDados, 2, false)
+	}
+	for i, p := range g.Pontos {
+		t.circulo(posX(i), posY(p.Valor), raioPonto, corDados)
+	}
+}
+
+// desenharTendencia traça a reta de mínimos quadrados dos pontos de uma fase
+func (g *Grafico) desenharTendencia(t tela, inicio, fim int, posX func(int) float64, posY func(float64) float64, minY, maxY float64) {
+	n := fim - inicio
+	if n < 2 {
+		return
+	}
+	var somaX, somaY float64
+	for i := inicio; i < fim; i++ {
+		somaX += float64(i)
+		somaY += g.Pontos[i].Valor
+	}
+	mediaX, mediaY := somaX/float64(n), somaY/float64(n)
+	var cov, varX float64
+	for i := inicio; i < fim; i++ {
+		cov += (float64(i) - mediaX) * (g.Pontos[i].Valor - mediaY)
+		varX += (float64(i) - mediaX) * (float64(i) - mediaX)
+	}
+	inclinacao := cov / varX
+	valor := func(i int) float64 {
+		return math.Max(minY, math.Min(maxY, mediaY+inclinacao*(float64(i)-mediaX)))
+	}
+	t.linha(posX(inicio), posY(valor(inicio)), posX(fim-1), posY(valor(fim-1)), corTendencia, 1.5, true)
+}
+
+// limitesY retorna a escala do eixo Y, arredondando o máximo para cima quando não informado
+func (g *Grafico) limitesY() (float64, float64) {
+	minY, maxY := g.MinY, g.MaxY
+	if maxY <= minY {
+		for _, p := range g.Pontos {
+			maxY = math.Max(maxY, p.Valor)
+		}
+		if maxY <= minY {
+			maxY = minY + 1
+		}
+		ordem := math.Pow(10, math.Floor(math.Log10(maxY-minY)))
+		maxY = minY + math.Ceil((maxY-minY)/ordem)*ordem
+	}
+	return minY, maxY
+}
+
+// rotuloFase retorna o rótulo da condição, ou vazio quando não definido
+func (g *Grafico) rotuloFase(fase int) string {
+	if fase < 0 || fase >= len(g.Fases) {
+		return ""
+	}
+	return g.Fases[fase]
+}
+
+// formatarValor formata os valores da escala sem casas decimais desnecessárias
+func formatarValor(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
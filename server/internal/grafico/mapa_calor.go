@@ -0,0 +1,177 @@
+package grafico
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+)
+
+// MapaCalor descreve um mapa de calor: uma grade de células coloridas de acordo com o valor, usada nos
+// gráficos de dispersão (scatterplot) com os dias nas colunas e os blocos de horário nas linhas
+type MapaCalor struct {
+	Titulo  string
+	RotuloX string
+	RotuloY string
+	Legenda string
+	Colunas []string
+	Linhas  []string
+	Valores [][]*float64 // Valores[linha][coluna]; nulo quando não houve observação na célula
+}
+
+// Dimensões do mapa de calor, em pixels
+const (
+	larguraRotuloLinha = 100
+	alturaLinhaMapa    = 22
+	alturaMaximaMapa   = 2400
+	larguraLegenda     = 200
+	margemBaseMapa     = 90
+)
+
+// corSemObservacao é a cor das células sem tempo observado
+var corSemObservacao = color.RGBA{R: 0xee, G: 0xee, B: 0xee, A: 0xff}
+
+// RenderizarMapaCalor desenha o mapa de calor no formato informado e o escreve em w.
+// A altura da imagem acompanha o número de linhas da grade.
+func RenderizarMapaCalor(w io.Writer, m *MapaCalor, formato Formato) error {
+	altura := margemTopo + margemBaseMapa + alturaLinhaMapa*len(m.Linhas)
+	if len(m.Linhas) == 0 {
+		altura = Altura
+	}
+	if altura > alturaMaximaMapa {
+		altura = alturaMaximaMapa
+	}
+
+	switch formato {
+	case FormatoSVG:
+		t := novaTelaSVG(Largura, altura)
+		m.desenhar(t, altura)
+		return t.escrever(w)
+	case FormatoPNG:
+		t := novaTelaPNG(Largura, altura)
+		m.desenhar(t, altura)
+		return t.escrever(w)
+	default:
+		return fmt.Errorf("formato de gráfico não suportado: %s", formato)
+	}
+}
+
+// desenhar aplica o layout do mapa de calor à tela
+func (m *MapaCalor) desenhar(t tela, altura int) {
+	x0, x1 := float64(larguraRotuloLinha), float64(Largura-margemDireita)
+	y0, y1 := float64(margemTopo), float64(altura-margemBaseMapa)
+
+	t.texto(float64(Largura)/2, 24, m.Titulo, ancoraMeio, corEixo)
+	t.texto(x0, y0-30, m.RotuloY, ancoraInicio, corEixo)
+	t.texto((x0+x1)/2, float64(altura)-50, m.RotuloX, ancoraMeio, corEixo)
+
+	if len(m.Linhas) == 0 || len(m.Colunas) == 0 {
+		t.texto((x0+x1)/2, (y0+y1)/2, "Sem dados no período", ancoraMeio, corFase)
+		return
+	}
+
+	largura := (x1 - x0) / float64(len(m.Colunas))
+	alturaCelula := (y1 - y0) / float64(len(m.Linhas))
+	maximo := m.maximo()
+
+	// Rótulos das colunas, espaçados para não se sobreporem
+	salto := int(math.Ceil(40 / largura))
+	if salto < 1 {
+		salto = 1
+	}
+	for j, coluna := range m.Colunas {
+		if j%salto == 0 {
+			t.texto(x0+largura*(float64(j)+0.5), y0-8, coluna, ancoraMeio, corEixo)
+		}
+	}
+
+	saltoLinha := int(math.Ceil(14 / alturaCelula))
+	if saltoLinha < 1 {
+		saltoLinha = 1
+	}
+	for i, linha := range m.Linhas {
+		y := y0 + alturaCelula*float64(i)
+		if i%saltoLinha == 0 {
+			t.texto(x0-8, y+alturaCelula/2+4, linha, ancoraFim, corEixo)
+		}
+		for j := range m.Colunas {
+			x := x0 + largura*float64(j)
+			valor := m.valor(i, j)
+			if valor == nil {
+				t.retangulo(x, y, largura, alturaCelula, corSemObservacao)
+				continue
+			}
+			intensidade := 0.0
+			if maximo > 0 {
+				intensidade = *valor / maximo
+			}
+			t.retangulo(x, y, largura, alturaCelula, gradiente(intensidade))
+			if *valor > 0 && largura >= 36 && alturaCelula >= 14 {
+				cor := corEixo
+				if intensidade > 0.5 {
+					cor = corFundo
+				}
+				t.texto(x+largura/2, y+alturaCelula/2+4, formatarValor(math.Round(*valor*10)/10), ancoraMeio, cor)
+			}
+		}
+	}
+
+	// Grade entre as células
+	for j := 0; j <= len(m.Colunas); j++ {
+		x := x0 + largura*float64(j)
+		t.linha(x, y0, x, y1, corGrade, 1, false)
+	}
+	for i := 0; i <= len(m.Linhas); i++ {
+		y := y0 + alturaCelula*float64(i)
+		t.linha(x0, y, x1, y, corGrade, 1, false)
+	}
+
+	// Legenda com a escala de cores
+	yLegenda := float64(altura) - 28
+	xLegenda := x1 - larguraLegenda
+	passos := 20
+	for k := 0; k < passos; k++ {
+		t.retangulo(xLegenda+float64(larguraLegenda)*float64(k)/float64(passos), yLegenda-10,
+			float64(larguraLegenda)/float64(passos), 12, gradiente(float64(k)/float64(passos-1)))
+	}
+	t.texto(xLegenda-8, yLegenda, "0", ancoraFim, corEixo)
+	t.texto(x1, yLegenda+16, formatarValor(math.Round(maximo*10)/10), ancoraFim, corEixo)
+	t.texto(xLegenda-30, yLegenda, m.Legenda, ancoraFim, corEixo)
+	t.retangulo(x0, yLegenda-10, 12, 12, corSemObservacao)
+	t.texto(x0+18, yLegenda, "sem observação", ancoraInicio, corEixo)
+}
+
+// valor retorna o valor da célula, ou nulo quando ela não existe ou não foi observada
+func (m *MapaCalor) valor(i, j int) *float64 {
+	if i >= len(m.Valores) || j >= len(m.Valores[i]) {
+		return nil
+	}
+	return m.Valores[i][j]
+}
+
+// maximo retorna o maior valor da grade, que corresponde à cor mais intensa
+func (m *MapaCalor) maximo() float64 {
+	var maximo float64
+	for _, linha := range m.Valores {
+		for _, valor := range linha {
+			if valor != nil && *valor > maximo {
+				maximo = *valor
+			}
+		}
+	}
+	return maximo
+}
+
+// gradiente interpola entre a cor de fundo e a cor dos dados de acordo com a intensidade, entre 0 e 1
+func gradiente(intensidade float64) color.RGBA {
+	intensidade = math.Max(0, math.Min(1, intensidade))
+	canal := func(de, ate uint8) uint8 {
+		return uint8(math.Round(float64(de) + (float64(ate)-float64(de))*intensidade))
+	}
+	return color.RGBA{
+		R: canal(corFundo.R, corDados.R),
+		G: canal(corFundo.G, corDados.G),
+		B: canal(corFundo.B, corDados.B),
+		A: 0xff,
+	}
+}
@@ -0,0 +1,96 @@
+package grafico
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// semAcentos translitera os caracteres acentuados, que a fonte bitmap embutida não possui
+var semAcentos = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "é", "e", "ê", "e", "í", "i", "ó", "o", "ô", "o", "õ", "o", "ú", "u", "ç", "c",
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "É", "E", "Ê", "E", "Í", "I", "Ó", "O", "Ô", "O", "Õ", "O", "Ú", "U", "Ç", "C",
+)
+
+// telaPNG desenha o gráfico em uma imagem rasterizada
+type telaPNG struct {
+	img *image.RGBA
+}
+
+func novaTelaPNG(largura, altura int) *telaPNG {
+	img := image.NewRGBA(image.Rect(0, 0, largura, altura))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: corFundo}, image.Point{}, draw.Src)
+	return &telaPNG{img: img}
+}
+
+// linha percorre o segmento em passos de um pixel, pintando um quadrado da espessura da linha;
+// linhas tracejadas alternam segmentos de 6 pixels pintados e 4 em branco
+func (t *telaPNG) linha(x1, y1, x2, y2 float64, cor color.RGBA, espessura float64, tracejada bool) {
+	comprimento := math.Hypot(x2-x1, y2-y1)
+	passos := int(math.Ceil(comprimento))
+	if passos == 0 {
+		passos = 1
+	}
+	meia := espessura / 2
+	for i := 0; i <= passos; i++ {
+		if tracejada && i%10 >= 6 {
+			continue
+		}
+		f := float64(i) / float64(passos)
+		x, y := x1+(x2-x1)*f, y1+(y2-y1)*f
+		for px := int(math.Round(x - meia)); px <= int(math.Round(x+meia-0.5)); px++ {
+			for py := int(math.Round(y - meia)); py <= int(math.Round(y+meia-0.5)); py++ {
+				t.img.SetRGBA(px, py, cor)
+			}
+		}
+	}
+}
+
+func (t *telaPNG) circulo(x, y, r float64, cor color.RGBA) {
+	for px := int(math.Floor(x - r)); px <= int(math.Ceil(x+r)); px++ {
+		for py := int(math.Floor(y - r)); py <= int(math.Ceil(y+r)); py++ {
+			if math.Hypot(float64(px)-x, float64(py)-y) <= r {
+				t.img.SetRGBA(px, py, cor)
+			}
+		}
+	}
+}
+
+func (t *telaPNG) retangulo(x, y, largura, altura float64, cor color.RGBA) {
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+largura)), int(math.Round(y+altura)))
+	draw.Draw(t.img, r, &image.Uniform{C: cor}, image.Point{}, draw.Src)
+}
+
+func (t *telaPNG) texto(x, y float64, s string, a ancora, cor color.RGBA) {
+	if s == "" {
+		return
+	}
+	s = semAcentos.Replace(s)
+	d := &font.Drawer{
+		Dst:  t.img,
+		Src:  image.NewUniform(cor),
+		Face: basicfont.Face7x13,
+	}
+	largura := d.MeasureString(s).Round()
+	switch a {
+	case ancoraMeio:
+		x -= float64(largura) / 2
+	case ancoraFim:
+		x -= float64(largura)
+	}
+	d.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+	d.DrawString(s)
+}
+
+// escrever codifica a imagem em PNG e a escreve em w
+func (t *telaPNG) escrever(w io.Writer) error {
+	return png.Encode(w, t.img)
+}
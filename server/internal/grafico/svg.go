@@ -0,0 +1,61 @@
+package grafico
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+// telaSVG acumula os elementos do gráfico em um documento SVG
+type telaSVG struct {
+	buf bytes.Buffer
+}
+
+func novaTelaSVG(largura, altura int) *telaSVG {
+	t := &telaSVG{}
+	fmt.Fprintf(&t.buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`,
+		largura, altura, largura, altura)
+	fmt.Fprintf(&t.buf, `<rect width="100%%" height="100%%" fill="%s"/>`, hex(corFundo))
+	return t
+}
+
+func (t *telaSVG) linha(x1, y1, x2, y2 float64, cor color.RGBA, espessura float64, tracejada bool) {
+	traco := ""
+	if tracejada {
+		traco = ` stroke-dasharray="6,4"`
+	}
+	fmt.Fprintf(&t.buf, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%.1f"%s/>`,
+		x1, y1, x2, y2, hex(cor), espessura, traco)
+}
+
+func (t *telaSVG) circulo(x, y, r float64, cor color.RGBA) {
+	fmt.Fprintf(&t.buf, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`, x, y, r, hex(cor))
+}
+
+func (t *telaSVG) retangulo(x, y, largura, altura float64, cor color.RGBA) {
+	fmt.Fprintf(&t.buf, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`, x, y, largura, altura, hex(cor))
+}
+
+func (t *telaSVG) texto(x, y float64, s string, a ancora, cor color.RGBA) {
+	if s == "" {
+		return
+	}
+	alinhamento := map[ancora]string{ancoraInicio: "start", ancoraMeio: "middle", ancoraFim: "end"}[a]
+	fmt.Fprintf(&t.buf, `<text x="%.1f" y="%.1f" fill="%s" text-anchor="%s">`, x, y, hex(cor), alinhamento)
+	xml.EscapeText(&t.buf, []byte(s))
+	t.buf.WriteString(`</text>`)
+}
+
+// escrever finaliza o documento e o escreve em w
+func (t *telaSVG) escrever(w io.Writer) error {
+	t.buf.WriteString(`</svg>`)
+	_, err := w.Write(t.buf.Bytes())
+	return err
+}
+
+// hex converte uma cor para a notação hexadecimal usada no SVG
+func hex(cor color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", cor.R, cor.G, cor.B)
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EntidadeAuditoriaAlvoEtapa identifica os alvos das etapas nos eventos de auditoria
+const EntidadeAuditoriaAlvoEtapa = "alvo_etapa"
+
+// StatusAlvo representa a situação de um alvo no banco de alvos da etapa
+type StatusAlvo string
+
+const (
+	StatusAlvoAguardando    StatusAlvo = "aguardando"
+	StatusAlvoAquisicao     StatusAlvo = "aquisicao"
+	StatusAlvoGeneralizacao StatusAlvo = "generalizacao"
+	StatusAlvoManutencao    StatusAlvo = "manutencao"
+)
+
+// AlvoEtapa representa um alvo ensinado em uma etapa de programa, por exemplo um dos cartões
+// de figuras de um programa de nomeação
+type AlvoEtapa struct {
+	ID              uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	EtapaProgramaID uuid.UUID      `gorm:"type:uuid;not null;index" json:"etapa_programa_id"`
+	Descricao       string         `gorm:"type:text;not null" json:"descricao"`
+	Ordem           int            `gorm:"not null" json:"ordem"`
+	Status          StatusAlvo     `gorm:"type:varchar(20);not null;default:'aguardando'" json:"status"`
+	DominadoEm      *time.Time     `json:"dominado_em,omitempty"`
+	ReabertoEm      *time.Time     `json:"reaberto_em,omitempty"`
+	UltimaColetaEm  *time.Time     `json:"ultima_coleta_em,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (AlvoEtapa) TableName() string {
+	return "alvos_etapa"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (a *AlvoEtapa) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// DimensaoGeneralizacao representa a dimensão variada em uma sondagem de generalização
+type DimensaoGeneralizacao string
+
+const (
+	DimensaoGeneralizacaoPessoa   DimensaoGeneralizacao = "pessoa"
+	DimensaoGeneralizacaoAmbiente DimensaoGeneralizacao = "ambiente"
+	DimensaoGeneralizacaoMaterial DimensaoGeneralizacao = "material"
+)
+
+// SondagemGeneralizacao representa uma sondagem agendada de um alvo com outra pessoa, em outro ambiente
+// ou com outro material. O resultado fica nulo até a sondagem ser realizada.
+type SondagemGeneralizacao struct {
+	ID           uuid.UUID             `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AlvoID       uuid.UUID             `gorm:"type:uuid;not null;index" json:"alvo_id"`
+	Dimensao     DimensaoGeneralizacao `gorm:"type:varchar(20);not null" json:"dimensao"`
+	Descricao    string                `gorm:"type:text;not null" json:"descricao"`
+	AgendadaPara time.Time             `gorm:"not null;index" json:"agendada_para"`
+	SessaoID     *uuid.UUID            `gorm:"type:uuid" json:"sessao_id,omitempty"`
+	Resultado    *ResultadoColeta      `gorm:"type:varchar(20)" json:"resultado,omitempty"`
+	RealizadaEm  *time.Time            `json:"realizada_em,omitempty"`
+	Observacoes  string                `gorm:"type:text" json:"observacoes"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt        `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (SondagemGeneralizacao) TableName() string {
+	return "sondagens_generalizacao"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (s *SondagemGeneralizacao) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// Realizada indica se o resultado da sondagem já foi registrado
+func (s *SondagemGeneralizacao) Realizada() bool {
+	return s.Resultado != nil
+}
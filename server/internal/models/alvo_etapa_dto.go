@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAlvoEtapaRequest representa os dados necessários para incluir um alvo no banco de alvos da etapa.
+// O alvo entra em aquisição quando há vaga na rotação; caso contrário aguarda a sua vez.
+type CreateAlvoEtapaRequest struct {
+	Descricao string `json:"descricao" binding:"required" example:"Cartão: cachorro"`
+}
+
+// UpdateAlvoEtapaRequest representa os dados que podem ser atualizados em um alvo.
+// "status" permite mover o alvo manualmente entre as fases de ensino.
+type UpdateAlvoEtapaRequest struct {
+	Descricao *string     `json:"descricao" example:"Cartão: cachorro (foto)"`
+	Status    *StatusAlvo `json:"status" binding:"omitempty,oneof=aguardando aquisicao generalizacao manutencao" example:"manutencao"`
+}
+
+// ReordenarAlvosRequest representa a nova ordem de introdução dos alvos de uma etapa
+type ReordenarAlvosRequest struct {
+	AlvoIDs []uuid.UUID `json:"alvo_ids" binding:"required,min=1"`
+}
+
+// CreateSondagemGeneralizacaoRequest representa os dados para agendar uma sondagem de generalização
+type CreateSondagemGeneralizacaoRequest struct {
+	Dimensao     DimensaoGeneralizacao `json:"dimensao" binding:"required,oneof=pessoa ambiente material" example:"ambiente"`
+	Descricao    string                `json:"descricao" binding:"required" example:"Sala de aula da escola"`
+	AgendadaPara time.Time             `json:"agendada_para" binding:"required" example:"2024-03-15T00:00:00Z"`
+}
+
+// RegistrarSondagemGeneralizacaoRequest representa o resultado de uma sondagem de generalização
+type RegistrarSondagemGeneralizacaoRequest struct {
+	Resultado   ResultadoColeta `json:"resultado" binding:"required,oneof=acerto erro ajuda" example:"acerto"`
+	SessaoID    *uuid.UUID      `json:"sessao_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Observacoes string          `json:"observacoes" example:"Nomeou sem ajuda com a professora"`
+}
+
+// PlanoAlvosEtapa representa os alvos de uma etapa a trabalhar em uma sessão
+type PlanoAlvosEtapa struct {
+	ProgramaID    uuid.UUID                `json:"programa_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	EtapaID       uuid.UUID                `json:"etapa_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Etapa         string                   `json:"etapa" example:"Nomear figuras de animais"`
+	Aquisicao     []*AlvoEtapa             `json:"aquisicao"`
+	Manutencao    []*AlvoEtapa             `json:"manutencao"`
+	Generalizacao []*SondagemGeneralizacao `json:"generalizacao"`
+}
+
+// PlanoAlvosSessao representa os alvos em aquisição, as sondagens de manutenção e as sondagens de
+// generalização previstas para uma sessão
+type PlanoAlvosSessao struct {
+	SessaoID uuid.UUID         `json:"sessao_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Etapas   []PlanoAlvosEtapa `json:"etapas"`
+}
+
+// ToAlvoEtapa converte um CreateAlvoEtapaRequest para um modelo AlvoEtapa
+func (r *CreateAlvoEtapaRequest) ToAlvoEtapa(etapaID uuid.UUID) *AlvoEtapa {
+	return &AlvoEtapa{
+		EtapaProgramaID: etapaID,
+		Descricao:       r.Descricao,
+		Status:          StatusAlvoAguardando,
+	}
+}
+
+// ToSondagemGeneralizacao converte um CreateSondagemGeneralizacaoRequest para um modelo SondagemGeneralizacao
+func (r *CreateSondagemGeneralizacaoRequest) ToSondagemGeneralizacao(alvoID uuid.UUID) *SondagemGeneralizacao {
+	return &SondagemGeneralizacao{
+		AlvoID:       alvoID,
+		Dimensao:     r.Dimensao,
+		Descricao:    r.Descricao,
+		AgendadaPara: r.AgendadaPara,
+	}
+}
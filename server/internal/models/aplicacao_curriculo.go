@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatusAplicacaoCurriculo representa a situação de uma aplicação de currículo
+type StatusAplicacaoCurriculo string
+
+const (
+	StatusAplicacaoCurriculoEmAndamento StatusAplicacaoCurriculo = "em_andamento"
+	StatusAplicacaoCurriculoConcluida   StatusAplicacaoCurriculo = "concluida"
+)
+
+// AplicacaoCurriculo representa uma aplicação de um currículo a um paciente, com a pontuação de cada
+// marco avaliado. Depois de concluída, as pontuações não podem mais ser alteradas.
+type AplicacaoCurriculo struct {
+	ID          uuid.UUID                `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PacienteID  uuid.UUID                `gorm:"type:uuid;not null;index" json:"paciente_id"`
+	CurriculoID uuid.UUID                `gorm:"type:uuid;not null;index" json:"curriculo_id"`
+	Data        time.Time                `gorm:"not null" json:"data"`
+	Avaliador   string                   `gorm:"size:150" json:"avaliador"`
+	Status      StatusAplicacaoCurriculo `gorm:"type:varchar(20);not null;default:'em_andamento'" json:"status"`
+	Observacoes string                   `gorm:"type:text" json:"observacoes"`
+	UsuarioID   string                   `gorm:"size:64" json:"usuario_id,omitempty"`
+	ConcluidaEm *time.Time               `json:"concluida_em,omitempty"`
+	Pontuacoes  []PontuacaoMarco         `gorm:"foreignKey:AplicacaoID" json:"pontuacoes"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt           `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (AplicacaoCurriculo) TableName() string {
+	return "aplicacoes_curriculo"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (a *AplicacaoCurriculo) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// PontuacoesPorMarco retorna as pontuações da aplicação indexadas pelo ID do marco
+func (a *AplicacaoCurriculo) PontuacoesPorMarco() map[uuid.UUID]float64 {
+	pontuacoes := make(map[uuid.UUID]float64, len(a.Pontuacoes))
+	for _, pontuacao := range a.Pontuacoes {
+		pontuacoes[pontuacao.MarcoID] = pontuacao.Pontuacao
+	}
+	return pontuacoes
+}
+
+// PontuacaoMarco representa a pontuação de um marco em uma aplicação: 0, 0.5 ou 1
+type PontuacaoMarco struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AplicacaoID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_pontuacao_marco_aplicacao" json:"aplicacao_id"`
+	MarcoID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_pontuacao_marco_aplicacao" json:"marco_id"`
+	Pontuacao   float64   `gorm:"not null" json:"pontuacao"`
+	Observacao  string    `gorm:"type:text" json:"observacao,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (PontuacaoMarco) TableName() string {
+	return "pontuacoes_marco"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (p *PontuacaoMarco) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAplicacaoCurriculoRequest representa os dados necessários para iniciar uma aplicação de currículo
+type CreateAplicacaoCurriculoRequest struct {
+	CurriculoID uuid.UUID `json:"curriculo_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Data        time.Time `json:"data" binding:"required" example:"2024-03-01T00:00:00Z"`
+	Avaliador   string    `json:"avaliador" binding:"max=150" example:"Ana Souza"`
+	Observacoes string    `json:"observacoes" example:"Avaliação inicial"`
+}
+
+// UpdateAplicacaoCurriculoRequest representa os dados que podem ser atualizados em uma aplicação em andamento
+type UpdateAplicacaoCurriculoRequest struct {
+	Data        *time.Time `json:"data" example:"2024-03-01T00:00:00Z"`
+	Avaliador   *string    `json:"avaliador" binding:"omitempty,max=150" example:"Ana Souza"`
+	Observacoes *string    `json:"observacoes" example:"Avaliação inicial"`
+}
+
+// PontuacaoMarcoRequest representa a pontuação de um marco: 0, 0.5 ou 1
+type PontuacaoMarcoRequest struct {
+	MarcoID    uuid.UUID `json:"marco_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Pontuacao  *float64  `json:"pontuacao" binding:"required,min=0,max=1" example:"0.5"`
+	Observacao string    `json:"observacao" example:"Emite mandos apenas com dica ecoica"`
+}
+
+// PontuarMarcosRequest representa a pontuação de um ou mais marcos de uma aplicação. Marcos já
+// pontuados têm a pontuação substituída.
+type PontuarMarcosRequest struct {
+	Pontuacoes []PontuacaoMarcoRequest `json:"pontuacoes" binding:"required,min=1,dive"`
+}
+
+// CreateProgramaDeficitRequest representa a criação de um programa ABA para trabalhar um marco não atingido
+// em uma aplicação. Sem nome, o programa recebe o domínio e a descrição do marco.
+type CreateProgramaDeficitRequest struct {
+	Nome       string     `json:"nome" binding:"max=100" example:"Mando com dica ecoica"`
+	Descricao  string     `json:"descricao" example:"Ensinar mandos por itens preferidos"`
+	DataInicio *time.Time `json:"data_inicio" example:"2024-03-04T00:00:00Z"`
+}
+
+// ToAplicacaoCurriculo converte um CreateAplicacaoCurriculoRequest para um modelo AplicacaoCurriculo
+func (r *CreateAplicacaoCurriculoRequest) ToAplicacaoCurriculo(pacienteID uuid.UUID, usuarioID string) *AplicacaoCurriculo {
+	return &AplicacaoCurriculo{
+		PacienteID:  pacienteID,
+		CurriculoID: r.CurriculoID,
+		Data:        r.Data,
+		Avaliador:   r.Avaliador,
+		Status:      StatusAplicacaoCurriculoEmAndamento,
+		Observacoes: r.Observacoes,
+		UsuarioID:   usuarioID,
+	}
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateAplicacaoCurriculoRequest a um modelo AplicacaoCurriculo
+func (a *AplicacaoCurriculo) ApplyUpdates(req *UpdateAplicacaoCurriculoRequest) {
+	if req.Data != nil {
+		a.Data = *req.Data
+	}
+	if req.Avaliador != nil {
+		a.Avaliador = *req.Avaliador
+	}
+	if req.Observacoes != nil {
+		a.Observacoes = *req.Observacoes
+	}
+}
+
+// ResultadoDominio representa a pontuação de um domínio em um nível
+type ResultadoDominio struct {
+	DominioID       uuid.UUID `json:"dominio_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Nome            string    `json:"nome" example:"Mando"`
+	Pontuacao       float64   `json:"pontuacao" example:"3.5"`
+	Maximo          float64   `json:"maximo" example:"5"`
+	MarcosPontuados int       `json:"marcos_pontuados" example:"5"`
+}
+
+// ResultadoNivel representa a pontuação de um nível, com o detalhamento por domínio
+type ResultadoNivel struct {
+	Numero    int                `json:"numero" example:"1"`
+	Nome      string             `json:"nome" example:"Nível 1 (0 a 18 meses)"`
+	Pontuacao float64            `json:"pontuacao" example:"28.5"`
+	Maximo    float64            `json:"maximo" example:"45"`
+	Dominios  []ResultadoDominio `json:"dominios"`
+}
+
+// ResultadoAplicacaoCurriculo representa o resultado de uma aplicação: a pontuação total, por nível e por domínio
+type ResultadoAplicacaoCurriculo struct {
+	AplicacaoID     uuid.UUID                `json:"aplicacao_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CurriculoID     uuid.UUID                `json:"curriculo_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Curriculo       string                   `json:"curriculo" example:"VB-MAPP"`
+	Data            time.Time                `json:"data" example:"2024-03-01T00:00:00Z"`
+	Status          StatusAplicacaoCurriculo `json:"status" example:"concluida"`
+	Pontuacao       float64                  `json:"pontuacao" example:"52.5"`
+	Maximo          float64                  `json:"maximo" example:"170"`
+	MarcosPontuados int                      `json:"marcos_pontuados" example:"90"`
+	Niveis          []ResultadoNivel         `json:"niveis"`
+}
+
+// ComparacaoDominio representa a variação da pontuação de um domínio em um nível entre duas aplicações
+type ComparacaoDominio struct {
+	Nivel     int       `json:"nivel" example:"1"`
+	DominioID uuid.UUID `json:"dominio_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Nome      string    `json:"nome" example:"Mando"`
+	Base      float64   `json:"base" example:"2"`
+	Atual     float64   `json:"atual" example:"3.5"`
+	Variacao  float64   `json:"variacao" example:"1.5"`
+	Maximo    float64   `json:"maximo" example:"5"`
+}
+
+// MarcoComparado representa um marco cuja pontuação mudou entre duas aplicações. A pontuação fica nula
+// quando o marco não foi pontuado na aplicação.
+type MarcoComparado struct {
+	MarcoID   uuid.UUID `json:"marco_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Nivel     int       `json:"nivel" example:"1"`
+	Dominio   string    `json:"dominio" example:"Mando"`
+	Numero    int       `json:"numero" example:"3"`
+	Descricao string    `json:"descricao" example:"Emite 5 mandos diferentes sem dica"`
+	Base      *float64  `json:"base" example:"0.5"`
+	Atual     *float64  `json:"atual" example:"1"`
+}
+
+// ComparacaoAplicacoesCurriculo representa a comparação entre uma aplicação e uma aplicação anterior
+// (base) do mesmo currículo para o mesmo paciente
+type ComparacaoAplicacoesCurriculo struct {
+	Base           ResultadoAplicacaoCurriculo `json:"base"`
+	Atual          ResultadoAplicacaoCurriculo `json:"atual"`
+	Variacao       float64                     `json:"variacao" example:"12.5"`
+	Dominios       []ComparacaoDominio         `json:"dominios"`
+	MarcosGanhos   []MarcoComparado            `json:"marcos_ganhos"`
+	MarcosPerdidos []MarcoComparado            `json:"marcos_perdidos"`
+}
+
+// DeficitMarco representa um marco não atingido em uma aplicação, com os programas ABA criados para trabalhá-lo
+type DeficitMarco struct {
+	MarcoID   uuid.UUID      `json:"marco_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Nivel     int            `json:"nivel" example:"1"`
+	DominioID uuid.UUID      `json:"dominio_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Dominio   string         `json:"dominio" example:"Mando"`
+	Numero    int            `json:"numero" example:"3"`
+	Descricao string         `json:"descricao" example:"Emite 5 mandos diferentes sem dica"`
+	Pontuacao float64        `json:"pontuacao" example:"0.5"`
+	Programas []*ProgramaABA `json:"programas"`
+}
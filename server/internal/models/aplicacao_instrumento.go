@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatusAplicacaoInstrumento representa a situação de uma aplicação de questionário
+type StatusAplicacaoInstrumento string
+
+const (
+	StatusAplicacaoInstrumentoAguardandoSeguimento StatusAplicacaoInstrumento = "aguardando_seguimento"
+	StatusAplicacaoInstrumentoConcluida            StatusAplicacaoInstrumento = "concluida"
+)
+
+// AplicacaoInstrumento representa a aplicação de uma versão de um questionário a um paciente, com as
+// respostas e a pontuação calculada na aplicação. Quando a classificação inicial exige a entrevista de
+// seguimento, a aplicação só é concluída depois dela, e a classificação final passa a ser a do seguimento.
+type AplicacaoInstrumento struct {
+	ID                      uuid.UUID                      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PacienteID              uuid.UUID                      `gorm:"type:uuid;not null;index" json:"paciente_id"`
+	InstrumentoID           uuid.UUID                      `gorm:"type:uuid;not null;index" json:"instrumento_id"`
+	Codigo                  string                         `gorm:"size:50;not null;index" json:"codigo"`
+	Versao                  int                            `gorm:"not null" json:"versao"`
+	Data                    time.Time                      `gorm:"not null" json:"data"`
+	Informante              string                         `gorm:"size:100" json:"informante"`
+	Observacoes             string                         `gorm:"type:text" json:"observacoes"`
+	UsuarioID               string                         `gorm:"size:64" json:"usuario_id,omitempty"`
+	Status                  StatusAplicacaoInstrumento     `gorm:"type:varchar(30);not null" json:"status"`
+	Pontuacao               float64                        `gorm:"not null" json:"pontuacao"`
+	Classificacao           string                         `gorm:"size:50" json:"classificacao,omitempty"`
+	RequerSeguimento        bool                           `gorm:"not null;default:false" json:"requer_seguimento"`
+	PontuacaoSeguimento     *float64                       `json:"pontuacao_seguimento,omitempty"`
+	ClassificacaoSeguimento string                         `gorm:"size:50" json:"classificacao_seguimento,omitempty"`
+	SeguimentoEm            *time.Time                     `json:"seguimento_em,omitempty"`
+	Respostas               []RespostaAplicacaoInstrumento `gorm:"foreignKey:AplicacaoID" json:"respostas"`
+	Subescalas              []PontuacaoSubescalaAplicacao  `gorm:"foreignKey:AplicacaoID" json:"subescalas"`
+	CreatedAt               time.Time                      `json:"created_at"`
+	UpdatedAt               time.Time                      `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt                 `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (AplicacaoInstrumento) TableName() string {
+	return "aplicacoes_instrumento"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (a *AplicacaoInstrumento) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// ClassificacaoFinal retorna a classificação do seguimento, quando houver, ou a classificação inicial
+func (a *AplicacaoInstrumento) ClassificacaoFinal() string {
+	if a.ClassificacaoSeguimento != "" {
+		return a.ClassificacaoSeguimento
+	}
+	return a.Classificacao
+}
+
+// RespostaAplicacaoInstrumento representa a resposta a um item, com os pontos já considerando a inversão.
+// "seguimento_aprovado" guarda o resultado do item na entrevista de seguimento.
+type RespostaAplicacaoInstrumento struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AplicacaoID        uuid.UUID `gorm:"type:uuid;not null;index" json:"aplicacao_id"`
+	Numero             int       `gorm:"not null" json:"numero"`
+	Valor              float64   `gorm:"not null" json:"valor"`
+	Rotulo             string    `gorm:"size:100" json:"rotulo"`
+	Pontos             float64   `gorm:"not null" json:"pontos"`
+	SeguimentoAprovado *bool     `json:"seguimento_aprovado,omitempty"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (RespostaAplicacaoInstrumento) TableName() string {
+	return "respostas_aplicacao_instrumento"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (r *RespostaAplicacaoInstrumento) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// PontuacaoSubescalaAplicacao representa a pontuação de uma subescala em uma aplicação
+type PontuacaoSubescalaAplicacao struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AplicacaoID   uuid.UUID `gorm:"type:uuid;not null;index" json:"aplicacao_id"`
+	Codigo        string    `gorm:"size:50;not null" json:"codigo"`
+	Nome          string    `gorm:"size:150" json:"nome"`
+	Pontuacao     float64   `gorm:"not null" json:"pontuacao"`
+	Classificacao string    `gorm:"size:50" json:"classificacao,omitempty"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (PontuacaoSubescalaAplicacao) TableName() string {
+	return "pontuacoes_subescala_aplicacao"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (p *PontuacaoSubescalaAplicacao) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
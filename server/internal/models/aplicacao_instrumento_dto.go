@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RespostaItemRequest representa a resposta a um item: o valor de uma das opções da escala do item
+type RespostaItemRequest struct {
+	Numero int      `json:"numero" binding:"required,min=1" example:"1"`
+	Valor  *float64 `json:"valor" binding:"required" example:"0"`
+}
+
+// CreateAplicacaoInstrumentoRequest representa a aplicação de um questionário com as respostas de todos os
+// itens. Sem "instrumento_id", é usada a versão ativa mais recente do instrumento com o código informado.
+type CreateAplicacaoInstrumentoRequest struct {
+	InstrumentoID *uuid.UUID            `json:"instrumento_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Codigo        string                `json:"codigo" binding:"max=50" example:"mchat_rf"`
+	Data          time.Time             `json:"data" binding:"required" example:"2024-03-01T00:00:00Z"`
+	Informante    string                `json:"informante" binding:"max=100" example:"Mãe"`
+	Observacoes   string                `json:"observacoes" example:"Aplicado na consulta inicial"`
+	Respostas     []RespostaItemRequest `json:"respostas" binding:"required,min=1,dive"`
+}
+
+// ItemSeguimentoRequest representa o resultado de um item na entrevista de seguimento
+type ItemSeguimentoRequest struct {
+	Numero   int   `json:"numero" binding:"required,min=1" example:"2"`
+	Aprovado *bool `json:"aprovado" binding:"required" example:"false"`
+}
+
+// SeguimentoAplicacaoRequest representa a entrevista de seguimento de uma aplicação. Devem ser informados
+// todos os itens que pontuaram na etapa inicial, e apenas eles.
+type SeguimentoAplicacaoRequest struct {
+	Itens       []ItemSeguimentoRequest `json:"itens" binding:"required,min=1,dive"`
+	Observacoes *string                 `json:"observacoes" example:"Entrevista realizada por telefone"`
+}
+
+// PontuacaoSubescalaEvolucao representa a pontuação de uma subescala em um ponto da evolução
+type PontuacaoSubescalaEvolucao struct {
+	Codigo    string  `json:"codigo" example:"sociabilidade"`
+	Nome      string  `json:"nome" example:"Sociabilidade"`
+	Pontuacao float64 `json:"pontuacao" example:"12"`
+}
+
+// PontoEvolucaoInstrumento representa uma aplicação na evolução das pontuações de um instrumento
+type PontoEvolucaoInstrumento struct {
+	AplicacaoID   uuid.UUID                    `json:"aplicacao_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Data          time.Time                    `json:"data" example:"2024-03-01T00:00:00Z"`
+	Versao        int                          `json:"versao" example:"1"`
+	Status        StatusAplicacaoInstrumento   `json:"status" example:"concluida"`
+	Pontuacao     float64                      `json:"pontuacao" example:"45"`
+	Classificacao string                       `json:"classificacao,omitempty" example:"medio_risco"`
+	Variacao      *float64                     `json:"variacao,omitempty" example:"-5"`
+	Subescalas    []PontuacaoSubescalaEvolucao `json:"subescalas"`
+}
+
+// EvolucaoInstrumento representa as pontuações de um paciente em um instrumento ao longo do tempo, em
+// ordem cronológica. "variacao" é a diferença para a aplicação anterior.
+type EvolucaoInstrumento struct {
+	PacienteID uuid.UUID                  `json:"paciente_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Codigo     string                     `json:"codigo" example:"atec"`
+	Nome       string                     `json:"nome" example:"ATEC"`
+	Pontos     []PontoEvolucaoInstrumento `json:"pontos"`
+}
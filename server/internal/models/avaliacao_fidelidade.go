@@ -0,0 +1,99 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ResultadoItemFidelidade representa a pontuação de um item da lista de verificação
+type ResultadoItemFidelidade string
+
+const (
+	ResultadoItemFidelidadeCorreto      ResultadoItemFidelidade = "correto"
+	ResultadoItemFidelidadeIncorreto    ResultadoItemFidelidade = "incorreto"
+	ResultadoItemFidelidadeNaoAplicavel ResultadoItemFidelidade = "nao_aplicavel"
+)
+
+// LimiarFidelidadePadrao é o percentual mínimo de fidelidade usado quando a clínica não define outro
+const LimiarFidelidadePadrao = 80.0
+
+// AvaliacaoFidelidade representa uma lista de verificação preenchida por um supervisor para uma sessão.
+// O percentual considera apenas os itens aplicáveis e a avaliação é sinalizada quando fica abaixo do
+// limiar da clínica.
+type AvaliacaoFidelidade struct {
+	ID              uuid.UUID            `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ChecklistID     uuid.UUID            `gorm:"type:uuid;not null;index" json:"checklist_id"`
+	ProgramaID      uuid.UUID            `gorm:"type:uuid;not null;index" json:"programa_id"`
+	SessaoID        uuid.UUID            `gorm:"type:uuid;not null;index" json:"sessao_id"`
+	TerapeutaID     uuid.UUID            `gorm:"type:uuid;not null;index" json:"terapeuta_id"`
+	SupervisorID    string               `gorm:"size:64" json:"supervisor_id,omitempty"`
+	Data            time.Time            `gorm:"not null;index" json:"data"`
+	ItensAplicaveis int                  `gorm:"not null" json:"itens_aplicaveis"`
+	ItensCorretos   int                  `gorm:"not null" json:"itens_corretos"`
+	Percentual      float64              `gorm:"not null" json:"percentual"`
+	AbaixoDoLimiar  bool                 `gorm:"not null;index" json:"abaixo_do_limiar"`
+	Observacoes     string               `gorm:"type:text" json:"observacoes"`
+	Respostas       []RespostaFidelidade `gorm:"foreignKey:AvaliacaoID" json:"respostas"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt       `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (AvaliacaoFidelidade) TableName() string {
+	return "avaliacoes_fidelidade"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (a *AvaliacaoFidelidade) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// Calcular atualiza os totais, o percentual de fidelidade e a sinalização a partir das respostas
+func (a *AvaliacaoFidelidade) Calcular(limiar float64) {
+	a.ItensAplicaveis, a.ItensCorretos = 0, 0
+	for _, resposta := range a.Respostas {
+		switch resposta.Resultado {
+		case ResultadoItemFidelidadeCorreto:
+			a.ItensAplicaveis++
+			a.ItensCorretos++
+		case ResultadoItemFidelidadeIncorreto:
+			a.ItensAplicaveis++
+		}
+	}
+	a.Percentual = 0
+	if a.ItensAplicaveis > 0 {
+		a.Percentual = float64(a.ItensCorretos) / float64(a.ItensAplicaveis) * 100
+	}
+	a.AbaixoDoLimiar = a.ItensAplicaveis > 0 && a.Percentual < limiar
+}
+
+// RespostaFidelidade representa a pontuação de um item em uma avaliação. A descrição do item é copiada
+// para preservar o histórico quando a lista de verificação é alterada.
+type RespostaFidelidade struct {
+	ID          uuid.UUID               `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AvaliacaoID uuid.UUID               `gorm:"type:uuid;not null;index" json:"avaliacao_id"`
+	ItemID      uuid.UUID               `gorm:"type:uuid;not null" json:"item_id"`
+	Ordem       int                     `gorm:"not null" json:"ordem"`
+	Item        string                  `gorm:"type:text;not null" json:"item"`
+	Resultado   ResultadoItemFidelidade `gorm:"type:varchar(20);not null" json:"resultado"`
+	CreatedAt   time.Time               `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (RespostaFidelidade) TableName() string {
+	return "respostas_fidelidade"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (r *RespostaFidelidade) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RespostaFidelidadeRequest representa a pontuação de um item da lista de verificação
+type RespostaFidelidadeRequest struct {
+	ItemID    uuid.UUID               `json:"item_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Resultado ResultadoItemFidelidade `json:"resultado" binding:"required,oneof=correto incorreto nao_aplicavel" example:"correto"`
+}
+
+// CreateAvaliacaoFidelidadeRequest representa os dados de uma lista de verificação preenchida para uma sessão.
+// Todos os itens da lista devem ser pontuados exatamente uma vez.
+type CreateAvaliacaoFidelidadeRequest struct {
+	ChecklistID uuid.UUID                   `json:"checklist_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Respostas   []RespostaFidelidadeRequest `json:"respostas" binding:"required,min=1,dive"`
+	Observacoes string                      `json:"observacoes" example:"Atrasou a entrega do reforçador em duas tentativas"`
+}
+
+// UpdateAvaliacaoFidelidadeRequest representa os dados que podem ser atualizados em uma avaliação.
+// Quando "respostas" é informado, todos os itens devem ser pontuados novamente.
+type UpdateAvaliacaoFidelidadeRequest struct {
+	Respostas   []RespostaFidelidadeRequest `json:"respostas" binding:"omitempty,min=1,dive"`
+	Observacoes *string                     `json:"observacoes" example:"Atrasou a entrega do reforçador em duas tentativas"`
+}
+
+// PontoFidelidade representa a fidelidade média das avaliações de um período
+type PontoFidelidade struct {
+	Inicio         time.Time `json:"inicio" example:"2024-03-04T00:00:00Z"`
+	Fim            time.Time `json:"fim" example:"2024-03-11T00:00:00Z"`
+	Avaliacoes     int       `json:"avaliacoes" example:"3"`
+	Percentual     float64   `json:"percentual" example:"86.7"`
+	AbaixoDoLimiar int       `json:"abaixo_do_limiar" example:"1"`
+}
+
+// ResumoFidelidade representa a evolução da fidelidade de um terapeuta ou de um programa ao longo do tempo
+type ResumoFidelidade struct {
+	TerapeutaID        *uuid.UUID        `json:"terapeuta_id,omitempty"`
+	ProgramaID         *uuid.UUID        `json:"programa_id,omitempty"`
+	Limiar             float64           `json:"limiar" example:"80"`
+	Avaliacoes         int               `json:"avaliacoes" example:"12"`
+	PercentualMedio    float64           `json:"percentual_medio" example:"88.5"`
+	SessoesSinalizadas []uuid.UUID       `json:"sessoes_sinalizadas"`
+	Evolucao           []PontoFidelidade `json:"evolucao"`
+}
@@ -0,0 +1,340 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EntidadeAuditoriaAvaliacaoFuncional identifica as avaliações funcionais nos eventos de auditoria
+const EntidadeAuditoriaAvaliacaoFuncional = "avaliacao_funcional"
+
+// StatusAvaliacaoFuncional representa a situação de uma avaliação funcional do comportamento (FBA)
+type StatusAvaliacaoFuncional string
+
+const (
+	StatusAvaliacaoFuncionalRascunho  StatusAvaliacaoFuncional = "rascunho"
+	StatusAvaliacaoFuncionalEmRevisao StatusAvaliacaoFuncional = "em_revisao"
+	StatusAvaliacaoFuncionalAprovada  StatusAvaliacaoFuncional = "aprovada"
+)
+
+// ConfiancaHipotese representa o grau de confiança do avaliador na função hipotética
+type ConfiancaHipotese string
+
+const (
+	ConfiancaHipoteseBaixa    ConfiancaHipotese = "baixa"
+	ConfiancaHipoteseModerada ConfiancaHipotese = "moderada"
+	ConfiancaHipoteseAlta     ConfiancaHipotese = "alta"
+)
+
+// AvaliacaoFuncional representa a avaliação funcional (FBA) de um ou mais comportamentos inadequados
+// do paciente, feita antes da elaboração do plano de intervenção. Reúne as avaliações indiretas
+// (respostas de entrevistas), o período dos registros ABC usados como dados descritivos e a função
+// hipotética com o grau de confiança. A avaliação só pode ser editada como rascunho e, depois de
+// aprovada, guarda a assinatura do avaliador. Ao ser enviada à revisão, a análise ABC do período é
+// copiada para a avaliação, e é essa cópia que a revisão e a aprovação consideram.
+type AvaliacaoFuncional struct {
+	ID                   uuid.UUID                         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PacienteID           uuid.UUID                         `gorm:"type:uuid;not null;index" json:"paciente_id"`
+	Titulo               string                            `gorm:"size:150;not null" json:"titulo"`
+	Status               StatusAvaliacaoFuncional          `gorm:"type:varchar(20);not null;default:'rascunho';index" json:"status"`
+	PeriodoInicio        *time.Time                        `json:"periodo_inicio,omitempty"`
+	PeriodoFim           *time.Time                        `json:"periodo_fim,omitempty"`
+	FuncaoHipotetica     *FuncaoComportamento              `gorm:"type:varchar(20)" json:"funcao_hipotetica,omitempty"`
+	Confianca            *ConfiancaHipotese                `gorm:"type:varchar(20)" json:"confianca,omitempty"`
+	Hipotese             string                            `gorm:"type:text" json:"hipotese"`
+	Observacoes          string                            `gorm:"type:text" json:"observacoes"`
+	UsuarioID            string                            `gorm:"size:64" json:"usuario_id,omitempty"`
+	EnviadaEm            *time.Time                        `json:"enviada_em,omitempty"`
+	Avaliador            string                            `gorm:"size:150" json:"avaliador,omitempty"`
+	RegistroProfissional string                            `gorm:"size:50" json:"registro_profissional,omitempty"`
+	AssinadaPor          string                            `gorm:"size:64" json:"assinada_por,omitempty"`
+	AssinadaEm           *time.Time                        `json:"assinada_em,omitempty"`
+	Comportamentos       []ComportamentoAvaliacaoFuncional `gorm:"foreignKey:AvaliacaoID" json:"comportamentos"`
+	Respostas            []RespostaAvaliacaoFuncional      `gorm:"foreignKey:AvaliacaoID" json:"respostas"`
+	DadosABC             []AnaliseABC                      `gorm:"-" json:"dados_abc,omitempty"`
+	CopiaDadosABC        []DadosABCAvaliacaoFuncional      `gorm:"foreignKey:AvaliacaoID" json:"-"`
+	CreatedAt            time.Time                         `json:"created_at"`
+	UpdatedAt            time.Time                         `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt                    `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (AvaliacaoFuncional) TableName() string {
+	return "avaliacoes_funcionais"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (a *AvaliacaoFuncional) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// ComportamentoIDs retorna os IDs dos comportamentos avaliados, na ordem em que foram incluídos
+func (a *AvaliacaoFuncional) ComportamentoIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(a.Comportamentos))
+	for _, comportamento := range a.Comportamentos {
+		ids = append(ids, comportamento.ComportamentoID)
+	}
+	return ids
+}
+
+// ComportamentoAvaliacaoFuncional associa um comportamento alvo a uma avaliação funcional
+type ComportamentoAvaliacaoFuncional struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AvaliacaoID     uuid.UUID `gorm:"type:uuid;not null;index" json:"avaliacao_id"`
+	ComportamentoID uuid.UUID `gorm:"type:uuid;not null;index" json:"comportamento_id"`
+	Ordem           int       `gorm:"not null" json:"ordem"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ComportamentoAvaliacaoFuncional) TableName() string {
+	return "comportamentos_avaliacao_funcional"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (c *ComportamentoAvaliacaoFuncional) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// RespostaAvaliacaoFuncional representa uma resposta de uma avaliação indireta, como uma entrevista
+// com os pais ou um questionário (FAST, QABF, MAS). A função indica qual função a resposta sugere.
+type RespostaAvaliacaoFuncional struct {
+	ID          uuid.UUID            `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AvaliacaoID uuid.UUID            `gorm:"type:uuid;not null;index" json:"avaliacao_id"`
+	Ordem       int                  `gorm:"not null" json:"ordem"`
+	Instrumento string               `gorm:"size:100" json:"instrumento"`
+	Informante  string               `gorm:"size:100" json:"informante"`
+	Pergunta    string               `gorm:"type:text;not null" json:"pergunta"`
+	Resposta    string               `gorm:"type:text;not null" json:"resposta"`
+	Funcao      *FuncaoComportamento `gorm:"type:varchar(20)" json:"funcao,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (RespostaAvaliacaoFuncional) TableName() string {
+	return "respostas_avaliacao_funcional"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (r *RespostaAvaliacaoFuncional) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// SecaoDadosABC identifica a parte da análise ABC a que pertence uma categoria copiada para a avaliação
+type SecaoDadosABC string
+
+const (
+	SecaoDadosABCAntecedente             SecaoDadosABC = "antecedente"
+	SecaoDadosABCConsequencia            SecaoDadosABC = "consequencia"
+	SecaoDadosABCSequencia               SecaoDadosABC = "sequencia"
+	SecaoDadosABCConsequenciaAntecedente SecaoDadosABC = "consequencia_antecedente"
+)
+
+// DadosABCAvaliacaoFuncional representa a cópia da análise ABC de um comportamento no período da
+// avaliação, feita quando a avaliação é enviada à revisão para que registros ABC posteriores não
+// alterem uma avaliação revisada ou assinada
+type DadosABCAvaliacaoFuncional struct {
+	ID                    uuid.UUID            `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AvaliacaoID           uuid.UUID            `gorm:"type:uuid;not null;index" json:"avaliacao_id"`
+	ComportamentoID       uuid.UUID            `gorm:"type:uuid;not null" json:"comportamento_id"`
+	Ordem                 int                  `gorm:"not null" json:"ordem"`
+	Inicio                *time.Time           `json:"inicio,omitempty"`
+	Fim                   *time.Time           `json:"fim,omitempty"`
+	TotalRegistros        int                  `json:"total_registros"`
+	RegistrosAntecedente  int                  `json:"registros_antecedente"`
+	RegistrosConsequencia int                  `json:"registros_consequencia"`
+	FuncaoHipotetica      *FuncaoComportamento `gorm:"type:varchar(20)" json:"funcao_hipotetica,omitempty"`
+	AmostraSuficiente     bool                 `json:"amostra_suficiente"`
+	Categorias            []CategoriaDadosABC  `gorm:"foreignKey:DadosID" json:"categorias"`
+	Funcoes               []FuncaoDadosABC     `gorm:"foreignKey:DadosID" json:"funcoes"`
+	CreatedAt             time.Time            `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (DadosABCAvaliacaoFuncional) TableName() string {
+	return "dados_abc_avaliacao_funcional"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (d *DadosABCAvaliacaoFuncional) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return
+}
+
+// CategoriaDadosABC representa uma categoria copiada da análise ABC. Nas sequências, a linha da seção
+// "sequencia" é o antecedente e as linhas "consequencia_antecedente" com o mesmo AntecedenteID são as
+// consequências observadas depois dele.
+type CategoriaDadosABC struct {
+	ID            uuid.UUID            `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	DadosID       uuid.UUID            `gorm:"type:uuid;not null;index" json:"dados_id"`
+	Secao         SecaoDadosABC        `gorm:"type:varchar(30);not null" json:"secao"`
+	Ordem         int                  `gorm:"not null" json:"ordem"`
+	AntecedenteID *uuid.UUID           `gorm:"type:uuid" json:"antecedente_id,omitempty"`
+	CategoriaID   uuid.UUID            `gorm:"type:uuid;not null" json:"categoria_id"`
+	Nome          string               `gorm:"size:100" json:"nome"`
+	Funcao        *FuncaoComportamento `gorm:"type:varchar(20)" json:"funcao,omitempty"`
+	Ocorrencias   int                  `json:"ocorrencias"`
+	Probabilidade float64              `json:"probabilidade"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (CategoriaDadosABC) TableName() string {
+	return "categorias_dados_abc"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (c *CategoriaDadosABC) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// FuncaoDadosABC representa a pontuação de uma função copiada da análise ABC
+type FuncaoDadosABC struct {
+	ID            uuid.UUID           `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	DadosID       uuid.UUID           `gorm:"type:uuid;not null;index" json:"dados_id"`
+	Ordem         int                 `gorm:"not null" json:"ordem"`
+	Funcao        FuncaoComportamento `gorm:"type:varchar(20);not null" json:"funcao"`
+	Antecedentes  float64             `json:"antecedentes"`
+	Consequencias float64             `json:"consequencias"`
+	Pontuacao     float64             `json:"pontuacao"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (FuncaoDadosABC) TableName() string {
+	return "funcoes_dados_abc"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (f *FuncaoDadosABC) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
+
+// CopiarAnaliseABC converte a análise ABC de um comportamento na cópia guardada com a avaliação
+func CopiarAnaliseABC(analise AnaliseABC, ordem int) DadosABCAvaliacaoFuncional {
+	dados := DadosABCAvaliacaoFuncional{
+		ComportamentoID:       analise.ComportamentoID,
+		Ordem:                 ordem,
+		Inicio:                analise.Inicio,
+		Fim:                   analise.Fim,
+		TotalRegistros:        analise.TotalRegistros,
+		RegistrosAntecedente:  analise.RegistrosAntecedente,
+		RegistrosConsequencia: analise.RegistrosConsequencia,
+		FuncaoHipotetica:      analise.FuncaoHipotetica,
+		AmostraSuficiente:     analise.AmostraSuficiente,
+	}
+
+	incluir := func(secao SecaoDadosABC, antecedenteID *uuid.UUID, frequencia FrequenciaCategoriaABC) {
+		dados.Categorias = append(dados.Categorias, CategoriaDadosABC{
+			Secao:         secao,
+			Ordem:         len(dados.Categorias) + 1,
+			AntecedenteID: antecedenteID,
+			CategoriaID:   frequencia.CategoriaID,
+			Nome:          frequencia.Nome,
+			Funcao:        frequencia.Funcao,
+			Ocorrencias:   frequencia.Ocorrencias,
+			Probabilidade: frequencia.Probabilidade,
+		})
+	}
+	for _, frequencia := range analise.Antecedentes {
+		incluir(SecaoDadosABCAntecedente, nil, frequencia)
+	}
+	for _, frequencia := range analise.Consequencias {
+		incluir(SecaoDadosABCConsequencia, nil, frequencia)
+	}
+	for _, sequencia := range analise.SequenciasAntecedentes {
+		antecedenteID := sequencia.AntecedenteID
+		incluir(SecaoDadosABCSequencia, nil, FrequenciaCategoriaABC{
+			CategoriaID: antecedenteID,
+			Nome:        sequencia.Nome,
+			Ocorrencias: sequencia.Ocorrencias,
+		})
+		for _, frequencia := range sequencia.Consequencias {
+			incluir(SecaoDadosABCConsequenciaAntecedente, &antecedenteID, frequencia)
+		}
+	}
+	for i, pontuacao := range analise.Funcoes {
+		dados.Funcoes = append(dados.Funcoes, FuncaoDadosABC{
+			Ordem:         i + 1,
+			Funcao:        pontuacao.Funcao,
+			Antecedentes:  pontuacao.Antecedentes,
+			Consequencias: pontuacao.Consequencias,
+			Pontuacao:     pontuacao.Pontuacao,
+		})
+	}
+	return dados
+}
+
+// AnaliseABC reconstrói a análise ABC a partir da cópia guardada com a avaliação
+func (d *DadosABCAvaliacaoFuncional) AnaliseABC() AnaliseABC {
+	analise := AnaliseABC{
+		ComportamentoID:        d.ComportamentoID,
+		Inicio:                 d.Inicio,
+		Fim:                    d.Fim,
+		TotalRegistros:         d.TotalRegistros,
+		RegistrosAntecedente:   d.RegistrosAntecedente,
+		RegistrosConsequencia:  d.RegistrosConsequencia,
+		Antecedentes:           []FrequenciaCategoriaABC{},
+		Consequencias:          []FrequenciaCategoriaABC{},
+		SequenciasAntecedentes: []ConsequenciasAntecedente{},
+		Funcoes:                make([]PontuacaoFuncao, 0, len(d.Funcoes)),
+		FuncaoHipotetica:       d.FuncaoHipotetica,
+		AmostraSuficiente:      d.AmostraSuficiente,
+	}
+
+	for _, categoria := range d.Categorias {
+		frequencia := FrequenciaCategoriaABC{
+			CategoriaID:   categoria.CategoriaID,
+			Nome:          categoria.Nome,
+			Funcao:        categoria.Funcao,
+			Ocorrencias:   categoria.Ocorrencias,
+			Probabilidade: categoria.Probabilidade,
+		}
+		switch categoria.Secao {
+		case SecaoDadosABCAntecedente:
+			analise.Antecedentes = append(analise.Antecedentes, frequencia)
+		case SecaoDadosABCConsequencia:
+			analise.Consequencias = append(analise.Consequencias, frequencia)
+		case SecaoDadosABCSequencia:
+			analise.SequenciasAntecedentes = append(analise.SequenciasAntecedentes, ConsequenciasAntecedente{
+				AntecedenteID: categoria.CategoriaID,
+				Nome:          categoria.Nome,
+				Ocorrencias:   categoria.Ocorrencias,
+				Consequencias: []FrequenciaCategoriaABC{},
+			})
+		case SecaoDadosABCConsequenciaAntecedente:
+			for i := range analise.SequenciasAntecedentes {
+				sequencia := &analise.SequenciasAntecedentes[i]
+				if categoria.AntecedenteID != nil && sequencia.AntecedenteID == *categoria.AntecedenteID {
+					sequencia.Consequencias = append(sequencia.Consequencias, frequencia)
+				}
+			}
+		}
+	}
+	for _, funcao := range d.Funcoes {
+		analise.Funcoes = append(analise.Funcoes, PontuacaoFuncao{
+			Funcao:        funcao.Funcao,
+			Antecedentes:  funcao.Antecedentes,
+			Consequencias: funcao.Consequencias,
+			Pontuacao:     funcao.Pontuacao,
+		})
+	}
+	return analise
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RespostaAvaliacaoFuncionalRequest representa uma resposta de entrevista ou questionário indireto
+type RespostaAvaliacaoFuncionalRequest struct {
+	Instrumento string               `json:"instrumento" binding:"max=100" example:"Entrevista com os pais"`
+	Informante  string               `json:"informante" binding:"max=100" example:"Mãe"`
+	Pergunta    string               `json:"pergunta" binding:"required" example:"O que costuma acontecer logo antes do comportamento?"`
+	Resposta    string               `json:"resposta" binding:"required" example:"Quando é chamado para fazer a lição de casa"`
+	Funcao      *FuncaoComportamento `json:"funcao" binding:"omitempty,oneof=fuga atencao tangivel automatica" example:"fuga"`
+}
+
+// CreateAvaliacaoFuncionalRequest representa os dados necessários para abrir uma avaliação funcional.
+// Os comportamentos devem ser comportamentos inadequados do paciente. "periodo_inicio" e "periodo_fim"
+// delimitam os registros ABC usados como dados descritivos; sem eles, todos os registros são considerados.
+type CreateAvaliacaoFuncionalRequest struct {
+	Titulo           string                              `json:"titulo" binding:"required,max=150" example:"Avaliação funcional - agressão na escola"`
+	ComportamentoIDs []uuid.UUID                         `json:"comportamento_ids" binding:"required,min=1"`
+	PeriodoInicio    *time.Time                          `json:"periodo_inicio" example:"2024-03-01T00:00:00Z"`
+	PeriodoFim       *time.Time                          `json:"periodo_fim" example:"2024-04-01T00:00:00Z"`
+	Respostas        []RespostaAvaliacaoFuncionalRequest `json:"respostas" binding:"omitempty,dive"`
+	FuncaoHipotetica *FuncaoComportamento                `json:"funcao_hipotetica" binding:"omitempty,oneof=fuga atencao tangivel automatica" example:"fuga"`
+	Confianca        *ConfiancaHipotese                  `json:"confianca" binding:"omitempty,oneof=baixa moderada alta" example:"moderada"`
+	Hipotese         string                              `json:"hipotese" example:"Diante de demandas acadêmicas, o paciente agride para interromper a tarefa"`
+	Observacoes      string                              `json:"observacoes" example:"Dados coletados em casa e na escola"`
+}
+
+// UpdateAvaliacaoFuncionalRequest representa os dados que podem ser atualizados em uma avaliação em rascunho.
+// Quando "comportamento_ids" ou "respostas" é informado, a lista anterior é substituída. "funcao_hipotetica"
+// e "confianca" vazias removem a hipótese.
+type UpdateAvaliacaoFuncionalRequest struct {
+	Titulo           *string                             `json:"titulo" binding:"omitempty,max=150" example:"Avaliação funcional - agressão na escola"`
+	ComportamentoIDs []uuid.UUID                         `json:"comportamento_ids" binding:"omitempty,min=1"`
+	PeriodoInicio    *time.Time                          `json:"periodo_inicio" example:"2024-03-01T00:00:00Z"`
+	PeriodoFim       *time.Time                          `json:"periodo_fim" example:"2024-04-01T00:00:00Z"`
+	Respostas        []RespostaAvaliacaoFuncionalRequest `json:"respostas" binding:"omitempty,dive"`
+	FuncaoHipotetica *FuncaoComportamento                `json:"funcao_hipotetica" binding:"omitempty,oneof=fuga atencao tangivel automatica" example:"fuga"`
+	Confianca        *ConfiancaHipotese                  `json:"confianca" binding:"omitempty,oneof=baixa moderada alta" example:"moderada"`
+	Hipotese         *string                             `json:"hipotese" example:"Diante de demandas acadêmicas, o paciente agride para interromper a tarefa"`
+	Observacoes      *string                             `json:"observacoes" example:"Dados coletados em casa e na escola"`
+}
+
+// TransicaoAvaliacaoFuncionalRequest representa o envio para revisão ou a devolução de uma avaliação ao rascunho
+type TransicaoAvaliacaoFuncionalRequest struct {
+	Motivo string `json:"motivo" example:"Incluir entrevista com a professora"`
+}
+
+// AssinarAvaliacaoFuncionalRequest representa a aprovação de uma avaliação em revisão com a assinatura do avaliador
+type AssinarAvaliacaoFuncionalRequest struct {
+	Avaliador            string `json:"avaliador" binding:"required,max=150" example:"Ana Souza"`
+	RegistroProfissional string `json:"registro_profissional" binding:"max=50" example:"CRP 06/123456"`
+	Motivo               string `json:"motivo" example:"Hipótese confirmada pelos dados descritivos"`
+}
+
+// ToAvaliacaoFuncional converte um CreateAvaliacaoFuncionalRequest para um modelo AvaliacaoFuncional,
+// sem os comportamentos e as respostas
+func (r *CreateAvaliacaoFuncionalRequest) ToAvaliacaoFuncional(pacienteID uuid.UUID, usuarioID string) *AvaliacaoFuncional {
+	return &AvaliacaoFuncional{
+		PacienteID:       pacienteID,
+		Titulo:           r.Titulo,
+		Status:           StatusAvaliacaoFuncionalRascunho,
+		PeriodoInicio:    r.PeriodoInicio,
+		PeriodoFim:       r.PeriodoFim,
+		FuncaoHipotetica: r.FuncaoHipotetica,
+		Confianca:        r.Confianca,
+		Hipotese:         r.Hipotese,
+		Observacoes:      r.Observacoes,
+		UsuarioID:        usuarioID,
+	}
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateAvaliacaoFuncionalRequest a um modelo AvaliacaoFuncional,
+// exceto os comportamentos e as respostas
+func (a *AvaliacaoFuncional) ApplyUpdates(req *UpdateAvaliacaoFuncionalRequest) {
+	if req.Titulo != nil {
+		a.Titulo = *req.Titulo
+	}
+	if req.PeriodoInicio != nil {
+		a.PeriodoInicio = req.PeriodoInicio
+	}
+	if req.PeriodoFim != nil {
+		a.PeriodoFim = req.PeriodoFim
+	}
+	if req.FuncaoHipotetica != nil {
+		a.FuncaoHipotetica = req.FuncaoHipotetica
+		if *req.FuncaoHipotetica == "" {
+			a.FuncaoHipotetica = nil
+		}
+	}
+	if req.Confianca != nil {
+		a.Confianca = req.Confianca
+		if *req.Confianca == "" {
+			a.Confianca = nil
+		}
+	}
+	if req.Hipotese != nil {
+		a.Hipotese = *req.Hipotese
+	}
+	if req.Observacoes != nil {
+		a.Observacoes = *req.Observacoes
+	}
+}
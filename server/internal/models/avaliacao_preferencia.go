@@ -0,0 +1,173 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TipoAvaliacaoPreferencia representa o formato da avaliação de preferência de estímulos
+type TipoAvaliacaoPreferencia string
+
+const (
+	// TipoAvaliacaoPreferenciaMSWO apresenta múltiplos estímulos sem reposição: o item escolhido sai do arranjo
+	TipoAvaliacaoPreferenciaMSWO TipoAvaliacaoPreferencia = "mswo"
+	// TipoAvaliacaoPreferenciaPareada apresenta os estímulos dois a dois
+	TipoAvaliacaoPreferenciaPareada TipoAvaliacaoPreferencia = "pareada"
+	// TipoAvaliacaoPreferenciaOperanteLivre mede o tempo de engajamento com os itens disponíveis livremente
+	TipoAvaliacaoPreferenciaOperanteLivre TipoAvaliacaoPreferencia = "operante_livre"
+)
+
+// AvaliacaoPreferencia representa uma avaliação de preferência de reforçadores de um paciente.
+// Os itens guardam o resultado calculado (apresentações, seleções, percentual e posição no ranking).
+type AvaliacaoPreferencia struct {
+	ID              uuid.UUID                `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PacienteID      uuid.UUID                `gorm:"type:uuid;not null;index" json:"paciente_id"`
+	SessaoID        *uuid.UUID               `gorm:"type:uuid;index" json:"sessao_id,omitempty"`
+	Tipo            TipoAvaliacaoPreferencia `gorm:"type:varchar(20);not null" json:"tipo"`
+	Data            time.Time                `gorm:"not null;index" json:"data"`
+	DuracaoSegundos int                      `gorm:"not null;default:0" json:"duracao_segundos"`
+	UsuarioID       string                   `gorm:"size:64" json:"usuario_id,omitempty"`
+	Observacoes     string                   `gorm:"type:text" json:"observacoes"`
+	Itens           []ItemPreferencia        `gorm:"foreignKey:AvaliacaoID" json:"itens"`
+	Tentativas      []TentativaPreferencia   `gorm:"foreignKey:AvaliacaoID" json:"tentativas"`
+	CreatedAt       time.Time                `json:"created_at"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt           `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (AvaliacaoPreferencia) TableName() string {
+	return "avaliacoes_preferencia"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (a *AvaliacaoPreferencia) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// Calcular atualiza as apresentações, as seleções, o percentual e a posição de cada item.
+// No MSWO, cada tentativa apresenta os itens ainda não escolhidos na rodada; na avaliação pareada,
+// os dois itens do par; no operante livre, o percentual é o tempo de engajamento sobre a duração.
+func (a *AvaliacaoPreferencia) Calcular() {
+	indices := make(map[uuid.UUID]int, len(a.Itens))
+	for i := range a.Itens {
+		indices[a.Itens[i].ID] = i
+		a.Itens[i].Apresentacoes, a.Itens[i].Selecoes, a.Itens[i].Percentual = 0, 0, 0
+	}
+
+	switch a.Tipo {
+	case TipoAvaliacaoPreferenciaMSWO:
+		escolhidos := make(map[int]map[uuid.UUID]bool)
+		for _, tentativa := range a.Tentativas {
+			if escolhidos[tentativa.Rodada] == nil {
+				escolhidos[tentativa.Rodada] = make(map[uuid.UUID]bool)
+			}
+			for i := range a.Itens {
+				if !escolhidos[tentativa.Rodada][a.Itens[i].ID] {
+					a.Itens[i].Apresentacoes++
+				}
+			}
+			if tentativa.SelecionadoID != nil {
+				a.Itens[indices[*tentativa.SelecionadoID]].Selecoes++
+				escolhidos[tentativa.Rodada][*tentativa.SelecionadoID] = true
+			}
+		}
+	case TipoAvaliacaoPreferenciaPareada:
+		for _, tentativa := range a.Tentativas {
+			for _, id := range []*uuid.UUID{tentativa.ItemAID, tentativa.ItemBID} {
+				if id != nil {
+					a.Itens[indices[*id]].Apresentacoes++
+				}
+			}
+			if tentativa.SelecionadoID != nil {
+				a.Itens[indices[*tentativa.SelecionadoID]].Selecoes++
+			}
+		}
+	}
+
+	for i := range a.Itens {
+		item := &a.Itens[i]
+		if a.Tipo == TipoAvaliacaoPreferenciaOperanteLivre {
+			if a.DuracaoSegundos > 0 {
+				item.Percentual = float64(item.SegundosEngajamento) / float64(a.DuracaoSegundos) * 100
+			}
+		} else if item.Apresentacoes > 0 {
+			item.Percentual = float64(item.Selecoes) / float64(item.Apresentacoes) * 100
+		}
+	}
+
+	ordem := make([]*ItemPreferencia, len(a.Itens))
+	for i := range a.Itens {
+		ordem[i] = &a.Itens[i]
+	}
+	sort.SliceStable(ordem, func(i, j int) bool {
+		return ordem[i].Percentual > ordem[j].Percentual
+	})
+	for i, item := range ordem {
+		// Itens empatados compartilham a mesma posição
+		if i > 0 && item.Percentual == ordem[i-1].Percentual {
+			item.Posicao = ordem[i-1].Posicao
+		} else {
+			item.Posicao = i + 1
+		}
+	}
+}
+
+// ItemPreferencia representa um estímulo avaliado e o seu resultado na avaliação
+type ItemPreferencia struct {
+	ID                  uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AvaliacaoID         uuid.UUID `gorm:"type:uuid;not null;index" json:"avaliacao_id"`
+	Ordem               int       `gorm:"not null" json:"ordem"`
+	Nome                string    `gorm:"size:100;not null" json:"nome"`
+	SegundosEngajamento int       `gorm:"not null;default:0" json:"segundos_engajamento"`
+	Apresentacoes       int       `gorm:"not null;default:0" json:"apresentacoes"`
+	Selecoes            int       `gorm:"not null;default:0" json:"selecoes"`
+	Percentual          float64   `gorm:"not null;default:0" json:"percentual"`
+	Posicao             int       `gorm:"not null;default:0" json:"posicao"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ItemPreferencia) TableName() string {
+	return "itens_preferencia"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (i *ItemPreferencia) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return
+}
+
+// TentativaPreferencia representa uma apresentação de estímulos em uma avaliação MSWO ou pareada.
+// SelecionadoID é nulo quando o paciente não escolheu nenhum item.
+type TentativaPreferencia struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AvaliacaoID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"avaliacao_id"`
+	Numero        int        `gorm:"not null" json:"numero"`
+	Rodada        int        `gorm:"not null;default:1" json:"rodada"`
+	ItemAID       *uuid.UUID `gorm:"type:uuid" json:"item_a_id,omitempty"`
+	ItemBID       *uuid.UUID `gorm:"type:uuid" json:"item_b_id,omitempty"`
+	SelecionadoID *uuid.UUID `gorm:"type:uuid" json:"selecionado_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (TentativaPreferencia) TableName() string {
+	return "tentativas_preferencia"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (t *TentativaPreferencia) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return
+}
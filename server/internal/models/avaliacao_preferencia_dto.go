@@ -0,0 +1,109 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ItemPreferenciaRequest representa um estímulo avaliado. "segundos_engajamento" é usado apenas
+// na avaliação de operante livre.
+type ItemPreferenciaRequest struct {
+	Nome                string `json:"nome" binding:"required,max=100" example:"Bolhas de sabão"`
+	SegundosEngajamento int    `json:"segundos_engajamento" binding:"min=0" example:"120"`
+}
+
+// TentativaPreferenciaRequest representa uma apresentação de estímulos. Os itens são referenciados pela
+// posição em "itens", começando em zero: "opcoes" traz os dois itens do par na avaliação pareada e
+// "selecionado" o item escolhido, nulo quando nenhum foi escolhido. No MSWO, "rodada" agrupa as
+// tentativas de um mesmo arranjo.
+type TentativaPreferenciaRequest struct {
+	Rodada      int   `json:"rodada" binding:"min=0" example:"1"`
+	Opcoes      []int `json:"opcoes" binding:"omitempty,len=2"`
+	Selecionado *int  `json:"selecionado" example:"0"`
+}
+
+// CreateAvaliacaoPreferenciaRequest representa os dados de uma avaliação de preferência.
+// As avaliações MSWO e pareada exigem tentativas; a de operante livre exige a duração da observação.
+type CreateAvaliacaoPreferenciaRequest struct {
+	Tipo            TipoAvaliacaoPreferencia      `json:"tipo" binding:"required,oneof=mswo pareada operante_livre" example:"mswo"`
+	SessaoID        *uuid.UUID                    `json:"sessao_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Data            *time.Time                    `json:"data" example:"2024-03-10T14:00:00Z"`
+	DuracaoSegundos int                           `json:"duracao_segundos" binding:"min=0" example:"300"`
+	Itens           []ItemPreferenciaRequest      `json:"itens" binding:"required,min=2,dive"`
+	Tentativas      []TentativaPreferenciaRequest `json:"tentativas" binding:"omitempty,dive"`
+	Observacoes     string                        `json:"observacoes" example:"Avaliação realizada antes da sessão"`
+}
+
+// UpdateAvaliacaoPreferenciaRequest representa os dados que podem ser atualizados em uma avaliação.
+// Quando "itens" ou "tentativas" é informado, os dados anteriores são substituídos e o ranking é recalculado.
+type UpdateAvaliacaoPreferenciaRequest struct {
+	Data            *time.Time                    `json:"data" example:"2024-03-10T14:00:00Z"`
+	DuracaoSegundos *int                          `json:"duracao_segundos" binding:"omitempty,min=0" example:"300"`
+	Itens           []ItemPreferenciaRequest      `json:"itens" binding:"omitempty,min=2,dive"`
+	Tentativas      []TentativaPreferenciaRequest `json:"tentativas" binding:"omitempty,dive"`
+	Observacoes     *string                       `json:"observacoes" example:"Avaliação realizada antes da sessão"`
+}
+
+// ReforcadorPaciente representa um item do ranking de reforçadores de um paciente, consolidado
+// a partir das avaliações de preferência
+type ReforcadorPaciente struct {
+	Nome            string    `json:"nome" example:"Bolhas de sabão"`
+	Posicao         int       `json:"posicao" example:"1"`
+	PercentualMedio float64   `json:"percentual_medio" example:"83.3"`
+	Avaliacoes      int       `json:"avaliacoes" example:"3"`
+	UltimaPosicao   int       `json:"ultima_posicao" example:"1"`
+	UltimaAvaliacao time.Time `json:"ultima_avaliacao" example:"2024-03-10T14:00:00Z"`
+}
+
+// ReforcadoresPaciente representa o ranking de reforçadores de um paciente, usado como conjunto de
+// opções para o campo "reforco_utilizado" das coletas
+type ReforcadoresPaciente struct {
+	PacienteID   uuid.UUID            `json:"paciente_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Avaliacoes   int                  `json:"avaliacoes" example:"4"`
+	Reforcadores []ReforcadorPaciente `json:"reforcadores"`
+}
+
+// Reforcador busca no ranking o reforçador com o nome informado, sem diferenciar maiúsculas e minúsculas
+func (r *ReforcadoresPaciente) Reforcador(nome string) *ReforcadorPaciente {
+	nome = strings.TrimSpace(nome)
+	for i := range r.Reforcadores {
+		if strings.EqualFold(r.Reforcadores[i].Nome, nome) {
+			return &r.Reforcadores[i]
+		}
+	}
+	return nil
+}
+
+// ToAvaliacaoPreferencia converte um CreateAvaliacaoPreferenciaRequest para um modelo AvaliacaoPreferencia,
+// sem os itens e as tentativas
+func (r *CreateAvaliacaoPreferenciaRequest) ToAvaliacaoPreferencia(pacienteID uuid.UUID, usuarioID string) *AvaliacaoPreferencia {
+	avaliacao := &AvaliacaoPreferencia{
+		PacienteID:      pacienteID,
+		SessaoID:        r.SessaoID,
+		Tipo:            r.Tipo,
+		Data:            time.Now(),
+		DuracaoSegundos: r.DuracaoSegundos,
+		UsuarioID:       usuarioID,
+		Observacoes:     r.Observacoes,
+	}
+	if r.Data != nil {
+		avaliacao.Data = *r.Data
+	}
+	return avaliacao
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateAvaliacaoPreferenciaRequest a um modelo AvaliacaoPreferencia,
+// exceto os itens e as tentativas
+func (a *AvaliacaoPreferencia) ApplyUpdates(req *UpdateAvaliacaoPreferenciaRequest) {
+	if req.Data != nil {
+		a.Data = *req.Data
+	}
+	if req.DuracaoSegundos != nil {
+		a.DuracaoSegundos = *req.DuracaoSegundos
+	}
+	if req.Observacoes != nil {
+		a.Observacoes = *req.Observacoes
+	}
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAvaliacaoPreferenciaCalcular(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	escolha := func(rodada int, selecionado *uuid.UUID) TentativaPreferencia {
+		return TentativaPreferencia{Rodada: rodada, SelecionadoID: selecionado}
+	}
+	par := func(itemA, itemB uuid.UUID, selecionado *uuid.UUID) TentativaPreferencia {
+		return TentativaPreferencia{ItemAID: &itemA, ItemBID: &itemB, SelecionadoID: selecionado}
+	}
+
+	type resultado struct {
+		apresentacoes int
+		selecoes      int
+		percentual    float64
+		posicao       int
+	}
+	tests := []struct {
+		nome            string
+		tipo            TipoAvaliacaoPreferencia
+		duracaoSegundos int
+		engajamento     [3]int
+		tentativas      []TentativaPreferencia
+		want            [3]resultado
+	}{
+		{
+			nome:       "MSWO retira o item escolhido do arranjo",
+			tipo:       TipoAvaliacaoPreferenciaMSWO,
+			tentativas: []TentativaPreferencia{escolha(1, &b), escolha(1, &a), escolha(1, &c)},
+			want:       [3]resultado{{2, 1, 50, 2}, {1, 1, 100, 1}, {3, 1, 100.0 / 3, 3}},
+		},
+		{
+			nome: "MSWO devolve todos os itens em uma nova rodada",
+			tipo: TipoAvaliacaoPreferenciaMSWO,
+			tentativas: []TentativaPreferencia{
+				escolha(1, &a), escolha(1, &b), escolha(1, &c),
+				escolha(2, &a), escolha(2, &c), escolha(2, &b),
+			},
+			want: [3]resultado{{2, 2, 100, 1}, {5, 2, 40, 2}, {5, 2, 40, 2}},
+		},
+		{
+			nome:       "MSWO sem escolha mantém os itens no arranjo",
+			tipo:       TipoAvaliacaoPreferenciaMSWO,
+			tentativas: []TentativaPreferencia{escolha(1, nil), escolha(1, &c)},
+			want:       [3]resultado{{2, 0, 0, 2}, {2, 0, 0, 2}, {2, 1, 50, 1}},
+		},
+		{
+			nome:       "pareada conta as apresentações de cada par",
+			tipo:       TipoAvaliacaoPreferenciaPareada,
+			tentativas: []TentativaPreferencia{par(a, b, &a), par(a, c, &a), par(b, c, &b), par(c, a, nil)},
+			want:       [3]resultado{{3, 2, 200.0 / 3, 1}, {2, 1, 50, 2}, {3, 0, 0, 3}},
+		},
+		{
+			nome:            "operante livre usa o tempo de engajamento",
+			tipo:            TipoAvaliacaoPreferenciaOperanteLivre,
+			duracaoSegundos: 300,
+			engajamento:     [3]int{150, 60, 150},
+			want:            [3]resultado{{0, 0, 50, 1}, {0, 0, 20, 3}, {0, 0, 50, 1}},
+		},
+		{
+			nome:        "operante livre sem duração",
+			tipo:        TipoAvaliacaoPreferenciaOperanteLivre,
+			engajamento: [3]int{150, 60, 150},
+			want:        [3]resultado{{0, 0, 0, 1}, {0, 0, 0, 1}, {0, 0, 0, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			avaliacao := &AvaliacaoPreferencia{
+				Tipo:            tt.tipo,
+				DuracaoSegundos: tt.duracaoSegundos,
+				Tentativas:      tt.tentativas,
+			}
+			for i, id := range []uuid.UUID{a, b, c} {
+				// Valores de uma avaliação anterior não podem se acumular
+				avaliacao.Itens = append(avaliacao.Itens, ItemPreferencia{
+					ID:                  id,
+					SegundosEngajamento: tt.engajamento[i],
+					Apresentacoes:       9,
+					Selecoes:            9,
+				})
+			}
+
+			avaliacao.Calcular()
+
+			for i, item := range avaliacao.Itens {
+				want := tt.want[i]
+				if item.Apresentacoes != want.apresentacoes || item.Selecoes != want.selecoes ||
+					math.Abs(item.Percentual-want.percentual) > 1e-9 || item.Posicao != want.posicao {
+					t.Errorf("item %d = {%d %d %v %d}, want %v", i, item.Apresentacoes, item.Selecoes, item.Percentual, item.Posicao, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TipoCategoriaABC indica se a categoria descreve o antecedente ou a consequência de uma ocorrência
+type TipoCategoriaABC string
+
+const (
+	TipoCategoriaABCAntecedente  TipoCategoriaABC = "antecedente"
+	TipoCategoriaABCConsequencia TipoCategoriaABC = "consequencia"
+)
+
+// FuncaoComportamento representa a função hipotética de um comportamento
+type FuncaoComportamento string
+
+const (
+	FuncaoComportamentoFuga       FuncaoComportamento = "fuga"
+	FuncaoComportamentoAtencao    FuncaoComportamento = "atencao"
+	FuncaoComportamentoTangivel   FuncaoComportamento = "tangivel"
+	FuncaoComportamentoAutomatica FuncaoComportamento = "automatica"
+)
+
+// FuncoesComportamento lista as funções hipotéticas na ordem usada nos relatórios
+var FuncoesComportamento = []FuncaoComportamento{
+	FuncaoComportamentoFuga,
+	FuncaoComportamentoAtencao,
+	FuncaoComportamentoTangivel,
+	FuncaoComportamentoAutomatica,
+}
+
+// CategoriaABC representa um item da taxonomia configurável de antecedentes e consequências, por exemplo
+// "demanda apresentada" ou "fuga concedida". A função indica qual função do comportamento a categoria
+// sugere na análise ABC; categorias sem função são contadas, mas não pontuam nenhuma função.
+type CategoriaABC struct {
+	ID        uuid.UUID            `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Tipo      TipoCategoriaABC     `gorm:"type:varchar(20);not null;index" json:"tipo"`
+	Nome      string               `gorm:"size:100;not null" json:"nome"`
+	Descricao string               `gorm:"type:text" json:"descricao"`
+	Funcao    *FuncaoComportamento `gorm:"type:varchar(20)" json:"funcao,omitempty"`
+	Ativa     bool                 `gorm:"not null;default:true" json:"ativa"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	DeletedAt gorm.DeletedAt       `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (CategoriaABC) TableName() string {
+	return "categorias_abc"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (c *CategoriaABC) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
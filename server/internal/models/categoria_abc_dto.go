@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MinimoRegistrosAnaliseABC é o número mínimo de registros classificados para que a função sugerida
+// pela análise ABC seja considerada representativa
+const MinimoRegistrosAnaliseABC = 10
+
+// CreateCategoriaABCRequest representa os dados necessários para incluir uma categoria na taxonomia ABC
+type CreateCategoriaABCRequest struct {
+	Tipo      TipoCategoriaABC     `json:"tipo" binding:"required,oneof=antecedente consequencia" example:"antecedente"`
+	Nome      string               `json:"nome" binding:"required,max=100" example:"Demanda apresentada"`
+	Descricao string               `json:"descricao" example:"Uma tarefa ou instrução foi apresentada ao paciente"`
+	Funcao    *FuncaoComportamento `json:"funcao" binding:"omitempty,oneof=fuga atencao tangivel automatica" example:"fuga"`
+}
+
+// UpdateCategoriaABCRequest representa os dados que podem ser atualizados em uma categoria.
+// "funcao" vazia remove a função sugerida pela categoria. Categorias inativas continuam nos
+// registros e relatórios, mas não podem ser selecionadas em novos registros.
+type UpdateCategoriaABCRequest struct {
+	Nome      *string              `json:"nome" binding:"omitempty,max=100" example:"Demanda apresentada"`
+	Descricao *string              `json:"descricao" example:"Uma tarefa ou instrução foi apresentada ao paciente"`
+	Funcao    *FuncaoComportamento `json:"funcao" binding:"omitempty,oneof=fuga atencao tangivel automatica" example:"fuga"`
+	Ativa     *bool                `json:"ativa" example:"true"`
+}
+
+// FrequenciaCategoriaABC representa quantas vezes uma categoria foi observada e a sua probabilidade
+// condicional no conjunto considerado
+type FrequenciaCategoriaABC struct {
+	CategoriaID   uuid.UUID            `json:"categoria_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Nome          string               `json:"nome" example:"Demanda apresentada"`
+	Funcao        *FuncaoComportamento `json:"funcao,omitempty" example:"fuga"`
+	Ocorrencias   int                  `json:"ocorrencias" example:"12"`
+	Probabilidade float64              `json:"probabilidade" example:"60"`
+}
+
+// ConsequenciasAntecedente representa a distribuição das consequências após um antecedente,
+// ou seja, a probabilidade de cada consequência dado o antecedente
+type ConsequenciasAntecedente struct {
+	AntecedenteID uuid.UUID                `json:"antecedente_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Nome          string                   `json:"nome" example:"Demanda apresentada"`
+	Ocorrencias   int                      `json:"ocorrencias" example:"10"`
+	Consequencias []FrequenciaCategoriaABC `json:"consequencias"`
+}
+
+// PontuacaoFuncao representa o percentual dos antecedentes e das consequências observados que sugerem
+// uma função. A pontuação é a média dos dois percentuais, ou apenas um deles quando o outro lado não
+// foi classificado.
+type PontuacaoFuncao struct {
+	Funcao        FuncaoComportamento `json:"funcao" example:"fuga"`
+	Antecedentes  float64             `json:"antecedentes" example:"60"`
+	Consequencias float64             `json:"consequencias" example:"70"`
+	Pontuacao     float64             `json:"pontuacao" example:"65"`
+}
+
+// AnaliseABC representa a análise dos registros ABC de um comportamento em um período: a probabilidade
+// de cada antecedente e de cada consequência, a probabilidade das consequências dado cada antecedente e
+// a função hipotética sugerida. A função fica vazia quando há empate ou nenhuma categoria com função.
+type AnaliseABC struct {
+	ComportamentoID        uuid.UUID                  `json:"comportamento_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Inicio                 *time.Time                 `json:"inicio,omitempty" example:"2024-03-01T00:00:00Z"`
+	Fim                    *time.Time                 `json:"fim,omitempty" example:"2024-04-01T00:00:00Z"`
+	TotalRegistros         int                        `json:"total_registros" example:"25"`
+	RegistrosAntecedente   int                        `json:"registros_antecedente" example:"20"`
+	RegistrosConsequencia  int                        `json:"registros_consequencia" example:"18"`
+	Antecedentes           []FrequenciaCategoriaABC   `json:"antecedentes"`
+	Consequencias          []FrequenciaCategoriaABC   `json:"consequencias"`
+	SequenciasAntecedentes []ConsequenciasAntecedente `json:"sequencias_antecedentes"`
+	Funcoes                []PontuacaoFuncao          `json:"funcoes"`
+	FuncaoHipotetica       *FuncaoComportamento       `json:"funcao_hipotetica,omitempty" example:"fuga"`
+	AmostraSuficiente      bool                       `json:"amostra_suficiente" example:"true"`
+}
+
+// ToCategoriaABC converte um CreateCategoriaABCRequest para um modelo CategoriaABC
+func (r *CreateCategoriaABCRequest) ToCategoriaABC() *CategoriaABC {
+	return &CategoriaABC{
+		Tipo:      r.Tipo,
+		Nome:      r.Nome,
+		Descricao: r.Descricao,
+		Funcao:    r.Funcao,
+		Ativa:     true,
+	}
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateCategoriaABCRequest a um modelo CategoriaABC
+func (c *CategoriaABC) ApplyUpdates(req *UpdateCategoriaABCRequest) {
+	if req.Nome != nil {
+		c.Nome = *req.Nome
+	}
+	if req.Descricao != nil {
+		c.Descricao = *req.Descricao
+	}
+	if req.Funcao != nil {
+		c.Funcao = req.Funcao
+		if *req.Funcao == "" {
+			c.Funcao = nil
+		}
+	}
+	if req.Ativa != nil {
+		c.Ativa = *req.Ativa
+	}
+}
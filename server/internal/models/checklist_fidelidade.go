@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChecklistFidelidade representa uma lista de verificação da integridade do tratamento de um programa ABA.
+// Cada item descreve um passo do procedimento que o terapeuta deve implementar corretamente.
+type ChecklistFidelidade struct {
+	ID         uuid.UUID                 `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ProgramaID uuid.UUID                 `gorm:"type:uuid;not null;index" json:"programa_id"`
+	Nome       string                    `gorm:"size:200;not null" json:"nome"`
+	Descricao  string                    `gorm:"type:text" json:"descricao"`
+	Ativo      bool                      `gorm:"not null;default:true" json:"ativo"`
+	Itens      []ItemChecklistFidelidade `gorm:"foreignKey:ChecklistID" json:"itens"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	UpdatedAt  time.Time                 `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt            `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ChecklistFidelidade) TableName() string {
+	return "checklists_fidelidade"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (c *ChecklistFidelidade) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// ItemChecklistFidelidade representa um passo do procedimento avaliado na lista de verificação
+type ItemChecklistFidelidade struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ChecklistID uuid.UUID `gorm:"type:uuid;not null;index" json:"checklist_id"`
+	Ordem       int       `gorm:"not null" json:"ordem"`
+	Descricao   string    `gorm:"type:text;not null" json:"descricao"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ItemChecklistFidelidade) TableName() string {
+	return "itens_checklist_fidelidade"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (i *ItemChecklistFidelidade) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// CreateChecklistFidelidadeRequest representa os dados necessários para criar uma lista de verificação.
+// "itens" traz a descrição de cada passo do procedimento, na ordem de execução.
+type CreateChecklistFidelidadeRequest struct {
+	Nome      string   `json:"nome" binding:"required,max=200" example:"Fidelidade - Tentativas discretas"`
+	Descricao string   `json:"descricao" example:"Passos esperados na condução do ensino por tentativas discretas"`
+	Itens     []string `json:"itens" binding:"required,min=1,dive,required" example:"Obteve a atenção do paciente,Apresentou a instrução uma única vez"`
+}
+
+// UpdateChecklistFidelidadeRequest representa os dados que podem ser atualizados em uma lista de verificação.
+// Quando "itens" é informado, os passos anteriores são substituídos; avaliações já registradas preservam
+// a descrição dos itens respondidos.
+type UpdateChecklistFidelidadeRequest struct {
+	Nome      *string  `json:"nome" binding:"omitempty,max=200" example:"Fidelidade - Tentativas discretas"`
+	Descricao *string  `json:"descricao" example:"Passos esperados na condução do ensino por tentativas discretas"`
+	Ativo     *bool    `json:"ativo" example:"true"`
+	Itens     []string `json:"itens" binding:"omitempty,min=1,dive,required" example:"Obteve a atenção do paciente,Apresentou a instrução uma única vez"`
+}
+
+// ToChecklistFidelidade converte um CreateChecklistFidelidadeRequest para um modelo ChecklistFidelidade
+func (r *CreateChecklistFidelidadeRequest) ToChecklistFidelidade(programaID uuid.UUID) *ChecklistFidelidade {
+	return &ChecklistFidelidade{
+		ProgramaID: programaID,
+		Nome:       r.Nome,
+		Descricao:  r.Descricao,
+		Ativo:      true,
+		Itens:      ItensChecklistFidelidade(r.Itens),
+	}
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateChecklistFidelidadeRequest a um modelo ChecklistFidelidade.
+// A substituição dos itens é feita pelo repositório.
+func (c *ChecklistFidelidade) ApplyUpdates(req *UpdateChecklistFidelidadeRequest) {
+	if req.Nome != nil {
+		c.Nome = *req.Nome
+	}
+	if req.Descricao != nil {
+		c.Descricao = *req.Descricao
+	}
+	if req.Ativo != nil {
+		c.Ativo = *req.Ativo
+	}
+}
+
+// ItensChecklistFidelidade numera as descrições dos passos a partir de 1
+func ItensChecklistFidelidade(descricoes []string) []ItemChecklistFidelidade {
+	itens := make([]ItemChecklistFidelidade, len(descricoes))
+	for i, descricao := range descricoes {
+		itens[i] = ItemChecklistFidelidade{Ordem: i + 1, Descricao: descricao}
+	}
+	return itens
+}
@@ -20,11 +20,14 @@ const (
 type ColetaABA struct {
 	ID               uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	EtapaProgramaID  uuid.UUID       `gorm:"type:uuid;not null" json:"etapa_programa_id"`
+	AlvoID           *uuid.UUID      `gorm:"type:uuid;index" json:"alvo_id,omitempty"`
+	SondagemManutencaoID *uuid.UUID  `gorm:"type:uuid;index" json:"sondagem_manutencao_id,omitempty"`
 	SessaoID         uuid.UUID       `gorm:"type:uuid;not null" json:"sessao_id"`
 	Resultado        ResultadoColeta `gorm:"type:varchar(20);not null" json:"resultado"`
 	PromptUtilizadoID uuid.UUID      `gorm:"type:uuid" json:"prompt_utilizado_id"`
 	ReforcoUtilizado string          `gorm:"size:100" json:"reforco_utilizado"`
 	Observacoes      string          `gorm:"type:text" json:"observacoes"`
+	Secundario       bool            `gorm:"not null;default:false;index" json:"secundario"`
 	CreatedAt        time.Time       `json:"created_at"`
 	UpdatedAt        time.Time       `json:"updated_at"`
 	DeletedAt        gorm.DeletedAt  `gorm:"index" json:"-"`
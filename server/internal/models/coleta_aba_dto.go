@@ -0,0 +1,89 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// CreateColetaABARequest representa os dados necessários para registrar uma tentativa em uma sessão.
+// "secundario" indica que a tentativa foi registrada pelo observador secundário, para cálculo de IOA.
+// "reforco_utilizado" deve, sempre que possível, ser um dos itens de GET /sessoes/{id}/reforcadores,
+// "alvo_id" identifica o alvo da etapa trabalhado na tentativa e "sondagem_manutencao_id", a sondagem
+// de manutenção da sessão respondida pela tentativa.
+type CreateColetaABARequest struct {
+	EtapaProgramaID      uuid.UUID       `json:"etapa_programa_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AlvoID               *uuid.UUID      `json:"alvo_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SondagemManutencaoID *uuid.UUID      `json:"sondagem_manutencao_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Resultado            ResultadoColeta `json:"resultado" binding:"required,oneof=acerto erro ajuda" example:"acerto"`
+	PromptUtilizadoID    *uuid.UUID      `json:"prompt_utilizado_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ReforcoUtilizado     string          `json:"reforco_utilizado" binding:"max=100" example:"Elogio verbal"`
+	Observacoes          string          `json:"observacoes" example:"Respondeu após 3 segundos"`
+	Secundario           bool            `json:"secundario" example:"false"`
+}
+
+// UpdateColetaABARequest representa os dados que podem ser atualizados em uma coleta
+type UpdateColetaABARequest struct {
+	Resultado         *ResultadoColeta `json:"resultado" binding:"omitempty,oneof=acerto erro ajuda" example:"erro"`
+	PromptUtilizadoID *uuid.UUID       `json:"prompt_utilizado_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ReforcoUtilizado  *string          `json:"reforco_utilizado" binding:"omitempty,max=100" example:"Elogio verbal"`
+	Observacoes       *string          `json:"observacoes" example:"Respondeu após 3 segundos"`
+}
+
+// DistribuicaoPrompt representa a quantidade de tentativas realizadas com um determinado prompt
+type DistribuicaoPrompt struct {
+	PromptID   uuid.UUID `json:"prompt_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Tipo       string    `json:"tipo" example:"Verbal"`
+	Quantidade int       `json:"quantidade" example:"4"`
+	Percentual float64   `json:"percentual" example:"40"`
+}
+
+// ResumoEtapaColeta representa o resumo das tentativas de uma etapa em uma sessão
+type ResumoEtapaColeta struct {
+	EtapaProgramaID        uuid.UUID            `json:"etapa_programa_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TotalTentativas        int                  `json:"total_tentativas" example:"10"`
+	Acertos                int                  `json:"acertos" example:"8"`
+	Erros                  int                  `json:"erros" example:"1"`
+	Ajudas                 int                  `json:"ajudas" example:"1"`
+	Independentes          int                  `json:"independentes" example:"6"`
+	PercentualAcerto       float64              `json:"percentual_acerto" example:"80"`
+	PercentualIndependente float64              `json:"percentual_independente" example:"60"`
+	DistribuicaoPrompts    []DistribuicaoPrompt `json:"distribuicao_prompts"`
+}
+
+// ToColetaABA converte um CreateColetaABARequest para um modelo ColetaABA
+func (r *CreateColetaABARequest) ToColetaABA(sessaoID uuid.UUID) *ColetaABA {
+	coleta := &ColetaABA{
+		EtapaProgramaID:      r.EtapaProgramaID,
+		AlvoID:               r.AlvoID,
+		SondagemManutencaoID: r.SondagemManutencaoID,
+		SessaoID:             sessaoID,
+		Resultado:            r.Resultado,
+		ReforcoUtilizado:     r.ReforcoUtilizado,
+		Observacoes:          r.Observacoes,
+		Secundario:           r.Secundario,
+	}
+	if r.PromptUtilizadoID != nil {
+		coleta.PromptUtilizadoID = *r.PromptUtilizadoID
+	}
+	return coleta
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateColetaABARequest a um modelo ColetaABA
+func (c *ColetaABA) ApplyUpdates(updates *UpdateColetaABARequest) {
+	if updates.Resultado != nil {
+		c.Resultado = *updates.Resultado
+	}
+	if updates.PromptUtilizadoID != nil {
+		c.PromptUtilizadoID = *updates.PromptUtilizadoID
+	}
+	if updates.ReforcoUtilizado != nil {
+		c.ReforcoUtilizado = *updates.ReforcoUtilizado
+	}
+	if updates.Observacoes != nil {
+		c.Observacoes = *updates.Observacoes
+	}
+}
+
+// IsIndependente indica se a tentativa foi um acerto sem nenhum prompt
+func (c *ColetaABA) IsIndependente() bool {
+	return c.Resultado == ResultadoColetaAcerto && c.PromptUtilizadoID == uuid.Nil
+}
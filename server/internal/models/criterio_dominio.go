@@ -0,0 +1,32 @@
+package models
+
+// MetricaDominio representa qual resultado das tentativas é considerado no critério de domínio
+type MetricaDominio string
+
+const (
+	MetricaDominioIndependente MetricaDominio = "independente"
+	MetricaDominioAcerto       MetricaDominio = "acerto"
+)
+
+// CriterioDominio representa o critério estruturado para considerar uma etapa dominada,
+// por exemplo "80% independente em 3 sessões consecutivas com pelo menos 2 terapeutas"
+type CriterioDominio struct {
+	Metrica             MetricaDominio `gorm:"type:varchar(20)" json:"metrica" binding:"omitempty,oneof=independente acerto" example:"independente"`
+	PercentualMinimo    float64        `json:"percentual_minimo" binding:"omitempty,gt=0,lte=100" example:"80"`
+	SessoesConsecutivas int            `json:"sessoes_consecutivas" binding:"omitempty,min=1" example:"3"`
+	MinimoTerapeutas    int            `json:"minimo_terapeutas" binding:"omitempty,min=1" example:"2"`
+	MinimoTentativas    int            `json:"minimo_tentativas" binding:"omitempty,min=1" example:"5"`
+}
+
+// Configurado indica se o critério possui os campos mínimos para ser avaliado automaticamente
+func (c CriterioDominio) Configurado() bool {
+	return c.PercentualMinimo > 0 && c.SessoesConsecutivas > 0
+}
+
+// MetricaOuPadrao retorna a métrica configurada ou, se ausente, a resposta independente
+func (c CriterioDominio) MetricaOuPadrao() MetricaDominio {
+	if c.Metrica == "" {
+		return MetricaDominioIndependente
+	}
+	return c.Metrica
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TipoCronometro representa a medida registrada por um cronômetro
+type TipoCronometro string
+
+const (
+	// TipoCronometroDuracao mede do início ao fim de um episódio do comportamento
+	TipoCronometroDuracao TipoCronometro = "duracao"
+	// TipoCronometroLatencia mede da instrução até o início da resposta
+	TipoCronometroLatencia TipoCronometro = "latencia"
+)
+
+// CronometroComportamento representa um cronômetro controlado pelo servidor para um comportamento
+// alvo durante uma sessão. Enquanto EncerradoEm for nulo, o cronômetro está em andamento.
+type CronometroComportamento struct {
+	ID                uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ComportamentoID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"comportamento_id"`
+	SessaoID          uuid.UUID      `gorm:"type:uuid;not null;index" json:"sessao_id"`
+	Tipo              TipoCronometro `gorm:"type:varchar(20);not null" json:"tipo"`
+	IniciadoEm        time.Time      `gorm:"not null" json:"iniciado_em"`
+	EncerradoEm       *time.Time     `gorm:"index" json:"encerrado_em,omitempty"`
+	Segundos          *float64       `json:"segundos,omitempty"`
+	RegistroID        *uuid.UUID     `gorm:"type:uuid" json:"registro_id,omitempty"`
+	UsuarioID         string         `gorm:"size:64" json:"usuario_id,omitempty"`
+	Secundario        bool           `gorm:"not null;default:false" json:"secundario"`
+	DecorridoSegundos float64        `gorm:"-" json:"decorrido_segundos"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (CronometroComportamento) TableName() string {
+	return "cronometros_comportamento"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (c *CronometroComportamento) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// EmAndamento indica se o cronômetro ainda não foi encerrado
+func (c *CronometroComportamento) EmAndamento() bool {
+	return c.EncerradoEm == nil
+}
+
+// AtualizarDecorrido calcula o tempo decorrido até agora, ou até o encerramento quando já encerrado
+func (c *CronometroComportamento) AtualizarDecorrido(agora time.Time) {
+	fim := agora
+	if c.EncerradoEm != nil {
+		fim = *c.EncerradoEm
+	}
+	c.DecorridoSegundos = fim.Sub(c.IniciadoEm).Seconds()
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// IniciarCronometroRequest representa os dados para iniciar um cronômetro de duração ou latência.
+// "secundario" indica um cronômetro do observador secundário da sessão, usado no cálculo de IOA.
+type IniciarCronometroRequest struct {
+	SessaoID   uuid.UUID      `json:"sessao_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Tipo       TipoCronometro `json:"tipo" binding:"required,oneof=duracao latencia" example:"duracao"`
+	Secundario bool           `json:"secundario" example:"false"`
+}
+
+// EncerrarCronometroRequest representa os dados opcionais informados ao parar um cronômetro.
+// Para cronômetros de duração, o contexto e a consequência são copiados para o registro gerado.
+type EncerrarCronometroRequest struct {
+	Contexto     string `json:"contexto" example:"Durante a atividade de mesa"`
+	Consequencia string `json:"consequencia" example:"Redirecionamento verbal"`
+}
+
+// ResumoCronometrosComportamento resume os cronômetros encerrados de um comportamento em uma sessão.
+// O percentual da sessão considera a duração planejada da sessão.
+type ResumoCronometrosComportamento struct {
+	ComportamentoID  uuid.UUID `json:"comportamento_id"`
+	Descricao        string    `json:"descricao"`
+	Episodios        int       `json:"episodios"`
+	DuracaoTotal     float64   `json:"duracao_total"`
+	DuracaoMedia     float64   `json:"duracao_media"`
+	PercentualSessao float64   `json:"percentual_sessao"`
+	Latencias        int       `json:"latencias"`
+	LatenciaMedia    float64   `json:"latencia_media"`
+	LatenciaMinima   float64   `json:"latencia_minima"`
+	LatenciaMaxima   float64   `json:"latencia_maxima"`
+	EmAndamento      int       `json:"em_andamento"`
+}
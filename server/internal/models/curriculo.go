@@ -0,0 +1,116 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Valores aceitos na pontuação de um marco: não atingido, parcialmente atingido e atingido
+var PontuacoesMarco = []float64{0, 0.5, 1}
+
+// Curriculo representa um instrumento de avaliação baseado em marcos do desenvolvimento, no estilo do
+// VB-MAPP: níveis (faixas de desenvolvimento) × domínios × marcos. A estrutura é definida na criação e
+// não muda depois, para que as pontuações das aplicações continuem referindo os mesmos marcos.
+type Curriculo struct {
+	ID        uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Nome      string             `gorm:"size:100;not null" json:"nome"`
+	Descricao string             `gorm:"type:text" json:"descricao"`
+	Ativo     bool               `gorm:"not null;default:true" json:"ativo"`
+	Niveis    []NivelCurriculo   `gorm:"foreignKey:CurriculoID" json:"niveis"`
+	Dominios  []DominioCurriculo `gorm:"foreignKey:CurriculoID" json:"dominios"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	DeletedAt gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (Curriculo) TableName() string {
+	return "curriculos"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (c *Curriculo) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// Marcos retorna todos os marcos do currículo, na ordem dos domínios
+func (c *Curriculo) Marcos() []MarcoCurriculo {
+	var marcos []MarcoCurriculo
+	for _, dominio := range c.Dominios {
+		marcos = append(marcos, dominio.Marcos...)
+	}
+	return marcos
+}
+
+// NivelCurriculo representa um nível do currículo, como "Nível 1 (0 a 18 meses)"
+type NivelCurriculo struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CurriculoID uuid.UUID `gorm:"type:uuid;not null;index" json:"curriculo_id"`
+	Numero      int       `gorm:"not null" json:"numero"`
+	Nome        string    `gorm:"size:100;not null" json:"nome"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (NivelCurriculo) TableName() string {
+	return "niveis_curriculo"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (n *NivelCurriculo) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return
+}
+
+// DominioCurriculo representa um domínio do currículo, como mando, tato ou ouvinte, com os seus marcos em todos os níveis
+type DominioCurriculo struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CurriculoID uuid.UUID        `gorm:"type:uuid;not null;index" json:"curriculo_id"`
+	Ordem       int              `gorm:"not null" json:"ordem"`
+	Nome        string           `gorm:"size:100;not null" json:"nome"`
+	Marcos      []MarcoCurriculo `gorm:"foreignKey:DominioID" json:"marcos"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (DominioCurriculo) TableName() string {
+	return "dominios_curriculo"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (d *DominioCurriculo) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return
+}
+
+// MarcoCurriculo representa um marco de um domínio em um nível. O número indica a posição do marco
+// dentro do domínio e do nível, a partir de 1.
+type MarcoCurriculo struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CurriculoID uuid.UUID `gorm:"type:uuid;not null;index" json:"curriculo_id"`
+	DominioID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_marco_curriculo_posicao" json:"dominio_id"`
+	Nivel       int       `gorm:"not null;uniqueIndex:idx_marco_curriculo_posicao" json:"nivel"`
+	Numero      int       `gorm:"not null;uniqueIndex:idx_marco_curriculo_posicao" json:"numero"`
+	Descricao   string    `gorm:"type:text;not null" json:"descricao"`
+	Criterio    string    `gorm:"type:text" json:"criterio"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (MarcoCurriculo) TableName() string {
+	return "marcos_curriculo"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (m *MarcoCurriculo) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return
+}
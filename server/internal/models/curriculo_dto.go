@@ -0,0 +1,96 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// NivelCurriculoRequest representa um nível na definição de um currículo
+type NivelCurriculoRequest struct {
+	Numero int    `json:"numero" binding:"required,min=1" example:"1"`
+	Nome   string `json:"nome" binding:"required,max=100" example:"Nível 1 (0 a 18 meses)"`
+}
+
+// MarcoCurriculoRequest representa um marco de um domínio na definição de um currículo
+type MarcoCurriculoRequest struct {
+	Nivel     int    `json:"nivel" binding:"required,min=1" example:"1"`
+	Numero    int    `json:"numero" binding:"required,min=1" example:"1"`
+	Descricao string `json:"descricao" binding:"required" example:"Emite 2 mandos com dica ecoica"`
+	Criterio  string `json:"criterio" example:"Teste ou observação de 60 minutos"`
+}
+
+// DominioCurriculoRequest representa um domínio, com os seus marcos, na definição de um currículo
+type DominioCurriculoRequest struct {
+	Nome   string                  `json:"nome" binding:"required,max=100" example:"Mando"`
+	Marcos []MarcoCurriculoRequest `json:"marcos" binding:"required,min=1,dive"`
+}
+
+// CreateCurriculoRequest representa a definição completa de um currículo. Cada marco deve referir um dos
+// níveis informados e ocupar uma posição única no seu domínio e nível.
+type CreateCurriculoRequest struct {
+	Nome      string                    `json:"nome" binding:"required,max=100" example:"VB-MAPP"`
+	Descricao string                    `json:"descricao" example:"Marcos do comportamento verbal em três níveis"`
+	Niveis    []NivelCurriculoRequest   `json:"niveis" binding:"required,min=1,dive"`
+	Dominios  []DominioCurriculoRequest `json:"dominios" binding:"required,min=1,dive"`
+}
+
+// UpdateCurriculoRequest representa os dados que podem ser atualizados em um currículo. A estrutura de
+// níveis, domínios e marcos não pode ser alterada.
+type UpdateCurriculoRequest struct {
+	Nome      *string `json:"nome" binding:"omitempty,max=100" example:"VB-MAPP"`
+	Descricao *string `json:"descricao" example:"Marcos do comportamento verbal em três níveis"`
+	Ativo     *bool   `json:"ativo" example:"true"`
+}
+
+// ToCurriculo converte um CreateCurriculoRequest para um modelo Curriculo com os níveis, os domínios e os marcos.
+// Os IDs são gerados aqui para que os marcos possam referir o currículo e o domínio na mesma criação.
+func (r *CreateCurriculoRequest) ToCurriculo() *Curriculo {
+	curriculo := &Curriculo{
+		ID:        uuid.New(),
+		Nome:      r.Nome,
+		Descricao: r.Descricao,
+		Ativo:     true,
+		Niveis:    make([]NivelCurriculo, 0, len(r.Niveis)),
+		Dominios:  make([]DominioCurriculo, 0, len(r.Dominios)),
+	}
+	for _, nivel := range r.Niveis {
+		curriculo.Niveis = append(curriculo.Niveis, NivelCurriculo{
+			CurriculoID: curriculo.ID,
+			Numero:      nivel.Numero,
+			Nome:        nivel.Nome,
+		})
+	}
+	for i, d := range r.Dominios {
+		dominio := DominioCurriculo{
+			ID:          uuid.New(),
+			CurriculoID: curriculo.ID,
+			Ordem:       i + 1,
+			Nome:        d.Nome,
+			Marcos:      make([]MarcoCurriculo, 0, len(d.Marcos)),
+		}
+		for _, marco := range d.Marcos {
+			dominio.Marcos = append(dominio.Marcos, MarcoCurriculo{
+				CurriculoID: curriculo.ID,
+				DominioID:   dominio.ID,
+				Nivel:       marco.Nivel,
+				Numero:      marco.Numero,
+				Descricao:   marco.Descricao,
+				Criterio:    marco.Criterio,
+			})
+		}
+		curriculo.Dominios = append(curriculo.Dominios, dominio)
+	}
+	return curriculo
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateCurriculoRequest a um modelo Curriculo
+func (c *Curriculo) ApplyUpdates(req *UpdateCurriculoRequest) {
+	if req.Nome != nil {
+		c.Nome = *req.Nome
+	}
+	if req.Descricao != nil {
+		c.Descricao = *req.Descricao
+	}
+	if req.Ativo != nil {
+		c.Ativo = *req.Ativo
+	}
+}
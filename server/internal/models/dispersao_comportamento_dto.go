@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EixoDispersao indica como os dias são agrupados nas colunas do gráfico de dispersão
+type EixoDispersao string
+
+const (
+	EixoDispersaoDiaSemana EixoDispersao = "dia_semana"
+	EixoDispersaoData      EixoDispersao = "data"
+)
+
+// Limites do gráfico de dispersão
+const (
+	BlocoDispersaoPadrao   = 30
+	MaximoColunasDispersao = 93
+)
+
+// OpcoesDispersao representa a configuração do gráfico de dispersão: o agrupamento dos dias, o tamanho dos
+// blocos de horário em minutos, a faixa de horas exibida e se as ocorrências são divididas pelo tempo
+// observado. Sem faixa de horas, são exibidos os blocos entre o primeiro e o último com dados.
+type OpcoesDispersao struct {
+	Eixo         EixoDispersao
+	BlocoMinutos int
+	HoraInicio   *int
+	HoraFim      *int
+	Normalizar   bool
+}
+
+// CelulaDispersao representa um bloco de horário em um dia. "taxa" é o número de ocorrências por hora
+// observada e fica nula quando o gráfico não é normalizado ou quando não houve sessão no bloco.
+type CelulaDispersao struct {
+	Ocorrencias       float64  `json:"ocorrencias" example:"3"`
+	MinutosObservados float64  `json:"minutos_observados" example:"30"`
+	Taxa              *float64 `json:"taxa,omitempty" example:"6"`
+}
+
+// LinhaDispersao representa um bloco de horário com uma célula para cada coluna do gráfico
+type LinhaDispersao struct {
+	Inicio  string            `json:"inicio" example:"08:00"`
+	Fim     string            `json:"fim" example:"08:30"`
+	Celulas []CelulaDispersao `json:"celulas"`
+}
+
+// DispersaoComportamento representa o gráfico de dispersão (scatterplot) de um comportamento: as
+// ocorrências registradas agrupadas por dia ou dia da semana e por bloco de horário
+type DispersaoComportamento struct {
+	ComportamentoID  uuid.UUID        `json:"comportamento_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Descricao        string           `json:"descricao" example:"Agressão física"`
+	Inicio           *time.Time       `json:"inicio,omitempty" example:"2024-03-01T00:00:00Z"`
+	Fim              *time.Time       `json:"fim,omitempty" example:"2024-04-01T00:00:00Z"`
+	Eixo             EixoDispersao    `json:"eixo" example:"dia_semana"`
+	BlocoMinutos     int              `json:"bloco_minutos" example:"30"`
+	Normalizado      bool             `json:"normalizado" example:"false"`
+	Colunas          []string         `json:"colunas" example:"Dom,Seg,Ter,Qua,Qui,Sex,Sáb"`
+	Linhas           []LinhaDispersao `json:"linhas"`
+	TotalOcorrencias float64          `json:"total_ocorrencias" example:"42"`
+}
+
+// Valor retorna o valor exibido na célula: a taxa por hora observada, quando normalizado, ou o número de ocorrências
+func (c CelulaDispersao) Valor(normalizado bool) *float64 {
+	if normalizado {
+		return c.Taxa
+	}
+	valor := c.Ocorrencias
+	return &valor
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Limites dos níveis da escala de alcance de objetivos (GAS). O nível 0 é o resultado esperado; -2 e -1 ficam
+// abaixo do esperado e +1 e +2 acima.
+const (
+	NivelGASMinimo = -2
+	NivelGASMaximo = 2
+)
+
+// EscalaGAS representa a escala de alcance de objetivos (Goal Attainment Scaling) opcional de um objetivo
+// terapêutico, com a descrição de cada nível e o peso do objetivo no escore T do paciente
+type EscalaGAS struct {
+	ID         uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ObjetivoID uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex" json:"objetivo_id"`
+	Peso       float64          `gorm:"not null;default:1" json:"peso"`
+	Niveis     []NivelEscalaGAS `gorm:"foreignKey:EscalaID" json:"niveis"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (EscalaGAS) TableName() string {
+	return "escalas_gas"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (e *EscalaGAS) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
+
+// DescricaoNivel retorna a descrição do nível informado da escala
+func (e *EscalaGAS) DescricaoNivel(nivel int) string {
+	for _, n := range e.Niveis {
+		if n.Nivel == nivel {
+			return n.Descricao
+		}
+	}
+	return ""
+}
+
+// NivelEscalaGAS representa a descrição observável de um nível (-2 a +2) da escala de um objetivo
+type NivelEscalaGAS struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	EscalaID  uuid.UUID `gorm:"type:uuid;not null;index" json:"escala_id"`
+	Nivel     int       `gorm:"not null" json:"nivel"`
+	Descricao string    `gorm:"type:text;not null" json:"descricao"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (NivelEscalaGAS) TableName() string {
+	return "niveis_escala_gas"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (n *NivelEscalaGAS) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return
+}
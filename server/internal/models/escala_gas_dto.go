@@ -0,0 +1,89 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PeriodoRevisaoGAS representa o intervalo das revisões em que o escore T GAS do paciente é calculado
+type PeriodoRevisaoGAS string
+
+const (
+	PeriodoRevisaoGASMes       PeriodoRevisaoGAS = "mes"
+	PeriodoRevisaoGASTrimestre PeriodoRevisaoGAS = "trimestre"
+	PeriodoRevisaoGASSemestre  PeriodoRevisaoGAS = "semestre"
+)
+
+// NivelEscalaGASRequest representa a descrição de um nível da escala GAS
+type NivelEscalaGASRequest struct {
+	Nivel     *int   `json:"nivel" binding:"required,min=-2,max=2" example:"0"`
+	Descricao string `json:"descricao" binding:"required" example:"Faz pedidos com duas palavras em 80% das oportunidades"`
+}
+
+// DefinirEscalaGASRequest representa a escala GAS de um objetivo: a descrição de cada um dos cinco níveis,
+// de -2 a +2, e o peso do objetivo no escore T (padrão: 1)
+type DefinirEscalaGASRequest struct {
+	Peso   *float64                `json:"peso" binding:"omitempty,gt=0,lte=10" example:"2"`
+	Niveis []NivelEscalaGASRequest `json:"niveis" binding:"required,len=5,dive"`
+}
+
+// ToEscalaGAS converte um DefinirEscalaGASRequest para um modelo EscalaGAS com os níveis em ordem crescente
+func (r *DefinirEscalaGASRequest) ToEscalaGAS(objetivoID uuid.UUID) *EscalaGAS {
+	escala := &EscalaGAS{
+		ID:         uuid.New(),
+		ObjetivoID: objetivoID,
+		Peso:       1,
+		Niveis:     make([]NivelEscalaGAS, 0, len(r.Niveis)),
+	}
+	if r.Peso != nil {
+		escala.Peso = *r.Peso
+	}
+	for _, n := range r.Niveis {
+		escala.Niveis = append(escala.Niveis, NivelEscalaGAS{
+			EscalaID:  escala.ID,
+			Nivel:     *n.Nivel,
+			Descricao: n.Descricao,
+		})
+	}
+	sort.Slice(escala.Niveis, func(i, j int) bool { return escala.Niveis[i].Nivel < escala.Niveis[j].Nivel })
+	return escala
+}
+
+// ObjetivoPeriodoGAS representa o nível de um objetivo em um período de revisão: o nível do último registro
+// de progresso do período ou, quando o objetivo não foi avaliado no período, o último nível registrado antes
+// dele, indicado por "transportado". O nível fica nulo quando o objetivo ainda não foi avaliado.
+type ObjetivoPeriodoGAS struct {
+	ObjetivoID     uuid.UUID  `json:"objetivo_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Descricao      string     `json:"descricao" example:"Fazer pedidos com frases de duas palavras"`
+	Peso           float64    `json:"peso" example:"2"`
+	Nivel          *int       `json:"nivel" example:"1"`
+	DescricaoNivel string     `json:"descricao_nivel,omitempty" example:"Faz pedidos com duas palavras em 90% das oportunidades"`
+	Data           *time.Time `json:"data,omitempty" example:"2024-03-28T00:00:00Z"`
+	Transportado   bool       `json:"transportado" example:"false"`
+}
+
+// PeriodoGAS representa um período de revisão com o nível de cada objetivo com escala e o escore T GAS do
+// paciente. O escore considera os objetivos com nível; "completo" indica que todos os objetivos com escala
+// têm nível, e só os escores de períodos completos são comparáveis entre si.
+type PeriodoGAS struct {
+	Inicio       time.Time            `json:"inicio" example:"2024-01-01T00:00:00Z"`
+	Fim          time.Time            `json:"fim" example:"2024-04-01T00:00:00Z"`
+	Objetivos    []ObjetivoPeriodoGAS `json:"objetivos"`
+	NaoAvaliados int                  `json:"nao_avaliados" example:"0"`
+	Completo     bool                 `json:"completo" example:"true"`
+	EscoreT      float64              `json:"escore_t" example:"56.4"`
+}
+
+// ResultadoGASPaciente representa o escore T GAS de um paciente em cada período de revisão. O escore segue
+// a fórmula de Kiresuk e Sherman, T = 50 + 10·Σwx / √((1−ρ)·Σw² + ρ·(Σw)²), em que x é o nível alcançado,
+// w o peso de cada objetivo e ρ a correlação presumida entre os objetivos. Um escore de 50 indica que, em
+// média, os objetivos atingiram o resultado esperado.
+type ResultadoGASPaciente struct {
+	PacienteID         uuid.UUID         `json:"paciente_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Periodo            PeriodoRevisaoGAS `json:"periodo" example:"trimestre"`
+	Correlacao         float64           `json:"correlacao" example:"0.3"`
+	ObjetivosComEscala int               `json:"objetivos_com_escala" example:"4"`
+	Periodos           []PeriodoGAS      `json:"periodos"`
+}
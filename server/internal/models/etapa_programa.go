@@ -7,16 +7,31 @@ import (
 	"gorm.io/gorm"
 )
 
+// StatusEtapa representa a situação de uma etapa dentro da sequência do programa
+type StatusEtapa string
+
+const (
+	StatusEtapaNaoIniciada StatusEtapa = "nao iniciada"
+	StatusEtapaEmTreino    StatusEtapa = "em treino"
+	StatusEtapaDominada    StatusEtapa = "dominada"
+	StatusEtapaRetirada    StatusEtapa = "retirada"
+)
+
 // EtapaPrograma representa uma etapa de um programa ABA
 type EtapaPrograma struct {
-	ID              uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	ProgramaID      uuid.UUID      `gorm:"type:uuid;not null" json:"programa_id"`
-	Descricao       string         `gorm:"type:text;not null" json:"descricao"`
-	Ordem           int            `gorm:"not null" json:"ordem"`
-	CriterioSucesso string         `gorm:"type:text" json:"criterio_sucesso"`
-	CreatedAt       time.Time      `json:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at"`
-	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ProgramaID      uuid.UUID       `gorm:"type:uuid;not null" json:"programa_id"`
+	Descricao       string          `gorm:"type:text;not null" json:"descricao"`
+	Ordem           int             `gorm:"not null" json:"ordem"`
+	CriterioSucesso string          `gorm:"type:text" json:"criterio_sucesso"`
+	CriterioDominio CriterioDominio `gorm:"embedded;embeddedPrefix:criterio_" json:"criterio_dominio"`
+	RotacaoAlvos    RotacaoAlvos    `gorm:"embedded;embeddedPrefix:rotacao_" json:"rotacao_alvos"`
+	Status          StatusEtapa     `gorm:"type:varchar(20);not null;default:'nao iniciada'" json:"status"`
+	DominadaEm      *time.Time      `json:"dominada_em,omitempty"`
+	ReabertaEm      *time.Time      `json:"reaberta_em,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt  `gorm:"index" json:"-"`
 }
 
 // TableName especifica o nome da tabela no banco de dados
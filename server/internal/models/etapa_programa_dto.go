@@ -0,0 +1,89 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// CreateEtapaProgramaRequest representa os dados necessários para criar uma etapa em um programa.
+// Quando Ordem é informada, a etapa é inserida nessa posição e as seguintes são deslocadas;
+// caso contrário ela é adicionada ao final da sequência.
+type CreateEtapaProgramaRequest struct {
+	Descricao       string           `json:"descricao" binding:"required" example:"Imitar movimentos grossos com objetos"`
+	CriterioSucesso string           `json:"criterio_sucesso" example:"80% de acertos independentes em 3 sessões consecutivas"`
+	CriterioDominio *CriterioDominio `json:"criterio_dominio"`
+	RotacaoAlvos    *RotacaoAlvos    `json:"rotacao_alvos"`
+	Ordem           *int             `json:"ordem" binding:"omitempty,min=1" example:"2"`
+}
+
+// UpdateEtapaProgramaRequest representa os dados que podem ser atualizados em uma etapa
+type UpdateEtapaProgramaRequest struct {
+	Descricao       *string          `json:"descricao" example:"Imitar movimentos grossos sem objetos"`
+	CriterioSucesso *string          `json:"criterio_sucesso" example:"90% de acertos independentes em 2 sessões consecutivas"`
+	CriterioDominio *CriterioDominio `json:"criterio_dominio"`
+	RotacaoAlvos    *RotacaoAlvos    `json:"rotacao_alvos"`
+}
+
+// ReordenarEtapasRequest representa a nova sequência das etapas ativas de um programa
+type ReordenarEtapasRequest struct {
+	EtapaIDs []uuid.UUID `json:"etapa_ids" binding:"required,min=1"`
+}
+
+// ResultadoAvaliacaoDominio representa a avaliação do critério de domínio de uma etapa
+type ResultadoAvaliacaoDominio struct {
+	EtapaProgramaID  uuid.UUID       `json:"etapa_programa_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Criterio         CriterioDominio `json:"criterio"`
+	Atingido         bool            `json:"atingido" example:"false"`
+	SessoesAvaliadas []uuid.UUID     `json:"sessoes_avaliadas"`
+	Percentuais      []float64       `json:"percentuais"`
+	Terapeutas       int             `json:"terapeutas" example:"1"`
+	Motivo           string          `json:"motivo" example:"apenas 2 de 3 sessões consecutivas atingiram o percentual mínimo"`
+}
+
+// ProgressoProgramaResponse representa a situação das etapas de um programa ABA
+type ProgressoProgramaResponse struct {
+	ProgramaID         uuid.UUID        `json:"programa_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status             StatusPrograma   `json:"status" example:"ativo"`
+	EtapaAtual         *EtapaPrograma   `json:"etapa_atual"`
+	TotalEtapas        int              `json:"total_etapas" example:"5"`
+	EtapasDominadas    int              `json:"etapas_dominadas" example:"2"`
+	EtapasNaoIniciadas int              `json:"etapas_nao_iniciadas" example:"2"`
+	Etapas             []*EtapaPrograma `json:"etapas"`
+}
+
+// ToEtapaPrograma converte um CreateEtapaProgramaRequest para um modelo EtapaPrograma
+func (r *CreateEtapaProgramaRequest) ToEtapaPrograma(programaID uuid.UUID) *EtapaPrograma {
+	etapa := &EtapaPrograma{
+		ProgramaID:      programaID,
+		Descricao:       r.Descricao,
+		CriterioSucesso: r.CriterioSucesso,
+		Status:          StatusEtapaNaoIniciada,
+	}
+	if r.CriterioDominio != nil {
+		etapa.CriterioDominio = *r.CriterioDominio
+	}
+	if r.RotacaoAlvos != nil {
+		etapa.RotacaoAlvos = *r.RotacaoAlvos
+	}
+	return etapa
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateEtapaProgramaRequest a um modelo EtapaPrograma
+func (e *EtapaPrograma) ApplyUpdates(updates *UpdateEtapaProgramaRequest) {
+	if updates.Descricao != nil {
+		e.Descricao = *updates.Descricao
+	}
+	if updates.CriterioSucesso != nil {
+		e.CriterioSucesso = *updates.CriterioSucesso
+	}
+	if updates.CriterioDominio != nil {
+		e.CriterioDominio = *updates.CriterioDominio
+	}
+	if updates.RotacaoAlvos != nil {
+		e.RotacaoAlvos = *updates.RotacaoAlvos
+	}
+}
+
+// IsAtiva indica se a etapa faz parte da sequência de ensino do programa
+func (e *EtapaPrograma) IsAtiva() bool {
+	return e.Status != StatusEtapaRetirada
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tipos de entidade registrados na auditoria
+const (
+	EntidadeAuditoriaEtapaPrograma = "etapa_programa"
+	EntidadeAuditoriaProgramaABA   = "programa_aba"
+)
+
+// EventoAuditoria representa o registro de uma mudança de situação de uma entidade clínica.
+// Os eventos nunca são alterados depois de criados.
+type EventoAuditoria struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	EntidadeTipo   string     `gorm:"size:50;not null;index:idx_evento_auditoria_entidade" json:"entidade_tipo"`
+	EntidadeID     uuid.UUID  `gorm:"type:uuid;not null;index:idx_evento_auditoria_entidade" json:"entidade_id"`
+	StatusAnterior string     `gorm:"size:30" json:"status_anterior"`
+	StatusNovo     string     `gorm:"size:30;not null" json:"status_novo"`
+	Motivo         string     `gorm:"type:text" json:"motivo"`
+	SessaoID       *uuid.UUID `gorm:"type:uuid" json:"sessao_id,omitempty"`
+	UsuarioID      string     `gorm:"size:64" json:"usuario_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (EventoAuditoria) TableName() string {
+	return "eventos_auditoria"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (e *EventoAuditoria) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TipoFase representa a condição experimental de uma fase
+type TipoFase string
+
+const (
+	TipoFaseLinhaDeBase   TipoFase = "linha_de_base"
+	TipoFaseIntervencao   TipoFase = "intervencao"
+	TipoFaseManutencao    TipoFase = "manutencao"
+	TipoFaseGeneralizacao TipoFase = "generalizacao"
+)
+
+// Tipos de entidade que podem ter fases
+const (
+	EntidadeFaseProgramaABA       = "programa_aba"
+	EntidadeFaseComportamentoAlvo = "comportamento_alvo"
+)
+
+// FaseIntervencao representa uma fase (condição) de um programa ABA ou comportamento alvo.
+// A fase vale a partir de DataInicio até o início da fase seguinte da mesma entidade.
+type FaseIntervencao struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	EntidadeTipo string         `gorm:"size:50;not null;index:idx_fase_entidade" json:"entidade_tipo"`
+	EntidadeID   uuid.UUID      `gorm:"type:uuid;not null;index:idx_fase_entidade" json:"entidade_id"`
+	Tipo         TipoFase       `gorm:"type:varchar(20);not null" json:"tipo"`
+	DataInicio   time.Time      `gorm:"not null" json:"data_inicio"`
+	Descricao    string         `gorm:"type:text" json:"descricao"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (FaseIntervencao) TableName() string {
+	return "fases_intervencao"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (f *FaseIntervencao) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
+
+// Rotulo retorna o nome da condição usado em gráficos e relatórios
+func (t TipoFase) Rotulo() string {
+	switch t {
+	case TipoFaseLinhaDeBase:
+		return "Linha de base"
+	case TipoFaseIntervencao:
+		return "Intervenção"
+	case TipoFaseManutencao:
+		return "Manutenção"
+	case TipoFaseGeneralizacao:
+		return "Generalização"
+	default:
+		return string(t)
+	}
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateFaseIntervencaoRequest representa os dados necessários para registrar uma mudança de fase
+type CreateFaseIntervencaoRequest struct {
+	Tipo       TipoFase  `json:"tipo" binding:"required,oneof=linha_de_base intervencao manutencao generalizacao" example:"intervencao"`
+	DataInicio time.Time `json:"data_inicio" binding:"required" example:"2024-03-11T00:00:00Z"`
+	Descricao  string    `json:"descricao" example:"Início do treino com reforçamento diferencial"`
+}
+
+// UpdateFaseIntervencaoRequest representa os dados que podem ser atualizados em uma fase
+type UpdateFaseIntervencaoRequest struct {
+	Tipo       *TipoFase  `json:"tipo" binding:"omitempty,oneof=linha_de_base intervencao manutencao generalizacao" example:"manutencao"`
+	DataInicio *time.Time `json:"data_inicio" example:"2024-04-01T00:00:00Z"`
+	Descricao  *string    `json:"descricao" example:"Sondas semanais de manutenção"`
+}
+
+// ResumoFase representa as medidas dos pontos (sessões ou dias) de uma fase.
+// A inclinação é a variação por ponto obtida por regressão linear.
+type ResumoFase struct {
+	FaseID     uuid.UUID  `json:"fase_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Tipo       TipoFase   `json:"tipo" example:"intervencao"`
+	Descricao  string     `json:"descricao" example:"Início do treino com reforçamento diferencial"`
+	DataInicio time.Time  `json:"data_inicio" example:"2024-03-11T00:00:00Z"`
+	DataFim    *time.Time `json:"data_fim,omitempty" example:"2024-04-01T00:00:00Z"`
+	Pontos     int        `json:"pontos" example:"8"`
+	Media      float64    `json:"media" example:"62.5"`
+	Mediana    float64    `json:"mediana" example:"60"`
+	Inclinacao float64    `json:"inclinacao" example:"4.2"`
+	Primeiro   *float64   `json:"primeiro,omitempty" example:"40"`
+	Ultimo     *float64   `json:"ultimo,omitempty" example:"85"`
+}
+
+// ComparacaoFases representa a comparação entre duas fases adjacentes. MudancaNivel é a diferença
+// entre o primeiro ponto da fase e o último ponto da fase anterior.
+type ComparacaoFases struct {
+	FaseAnteriorID      uuid.UUID `json:"fase_anterior_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FaseID              uuid.UUID `json:"fase_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	DiferencaMedia      float64   `json:"diferenca_media" example:"35.2"`
+	DiferencaMediana    float64   `json:"diferenca_mediana" example:"32.5"`
+	MudancaNivel        *float64  `json:"mudanca_nivel,omitempty" example:"15"`
+	DiferencaInclinacao float64   `json:"diferenca_inclinacao" example:"3.8"`
+}
+
+// ResumoFasesResponse representa o resumo dos dados de uma entidade dividido por fase
+type ResumoFasesResponse struct {
+	EntidadeTipo  string            `json:"entidade_tipo" example:"programa_aba"`
+	EntidadeID    uuid.UUID         `json:"entidade_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Medida        string            `json:"medida" example:"% de respostas independentes por sessão"`
+	PontosSemFase int               `json:"pontos_sem_fase" example:"0"`
+	Fases         []ResumoFase      `json:"fases"`
+	Comparacoes   []ComparacaoFases `json:"comparacoes"`
+}
+
+// ToFaseIntervencao converte um CreateFaseIntervencaoRequest para um modelo FaseIntervencao
+func (r *CreateFaseIntervencaoRequest) ToFaseIntervencao(entidadeTipo string, entidadeID uuid.UUID) *FaseIntervencao {
+	return &FaseIntervencao{
+		EntidadeTipo: entidadeTipo,
+		EntidadeID:   entidadeID,
+		Tipo:         r.Tipo,
+		DataInicio:   r.DataInicio,
+		Descricao:    r.Descricao,
+	}
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateFaseIntervencaoRequest a um modelo FaseIntervencao
+func (f *FaseIntervencao) ApplyUpdates(req *UpdateFaseIntervencaoRequest) {
+	if req.Tipo != nil {
+		f.Tipo = *req.Tipo
+	}
+	if req.DataInicio != nil {
+		f.DataInicio = *req.DataInicio
+	}
+	if req.Descricao != nil {
+		f.Descricao = *req.Descricao
+	}
+}
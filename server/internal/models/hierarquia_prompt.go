@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DirecaoPrompt representa a estratégia de aplicação da hierarquia de prompts
+type DirecaoPrompt string
+
+const (
+	DirecaoPromptMaisParaMenos DirecaoPrompt = "mais_para_menos"
+	DirecaoPromptMenosParaMais DirecaoPrompt = "menos_para_mais"
+)
+
+// HierarquiaPrompt representa a hierarquia de prompts de um programa ABA e as regras de esvanecimento.
+// Os níveis são ordenados do mais intrusivo (posição 1) para o menos intrusivo; a resposta
+// independente é sempre o nível implícito seguinte ao último.
+type HierarquiaPrompt struct {
+	ID                uuid.UUID               `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ProgramaID        uuid.UUID               `gorm:"type:uuid;not null;uniqueIndex" json:"programa_id"`
+	Direcao           DirecaoPrompt           `gorm:"type:varchar(20);not null" json:"direcao"`
+	PercentualAvanco  float64                 `gorm:"not null;default:80" json:"percentual_avanco"`
+	SessoesAvanco     int                     `gorm:"not null;default:2" json:"sessoes_avanco"`
+	PercentualRetorno float64                 `gorm:"not null;default:50" json:"percentual_retorno"`
+	SessoesEstagnacao int                     `gorm:"not null;default:5" json:"sessoes_estagnacao"`
+	LimiteDependencia float64                 `gorm:"not null;default:20" json:"limite_dependencia"`
+	Niveis            []NivelHierarquiaPrompt `gorm:"foreignKey:HierarquiaID" json:"niveis"`
+	CreatedAt         time.Time               `json:"created_at"`
+	UpdatedAt         time.Time               `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt          `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (HierarquiaPrompt) TableName() string {
+	return "hierarquias_prompt"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (h *HierarquiaPrompt) BeforeCreate(tx *gorm.DB) (err error) {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return
+}
+
+// NivelHierarquiaPrompt representa um nível (tipo de prompt) dentro de uma hierarquia
+type NivelHierarquiaPrompt struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	HierarquiaID uuid.UUID `gorm:"type:uuid;not null;index" json:"hierarquia_id"`
+	TipoPromptID uuid.UUID `gorm:"type:uuid;not null" json:"tipo_prompt_id"`
+	Posicao      int       `gorm:"not null" json:"posicao"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (NivelHierarquiaPrompt) TableName() string {
+	return "niveis_hierarquia_prompt"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (n *NivelHierarquiaPrompt) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return
+}
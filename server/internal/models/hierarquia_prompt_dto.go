@@ -0,0 +1,84 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// AcaoPrompt representa a mudança de nível de prompt recomendada para a próxima sessão
+type AcaoPrompt string
+
+const (
+	AcaoPromptManter    AcaoPrompt = "manter"
+	AcaoPromptEsvanecer AcaoPrompt = "esvanecer"
+	AcaoPromptAumentar  AcaoPrompt = "aumentar_ajuda"
+	AcaoPromptIniciar   AcaoPrompt = "iniciar"
+)
+
+// DefinirHierarquiaPromptRequest representa os dados para definir a hierarquia de prompts de um programa.
+// TipoPromptIDs deve vir do mais intrusivo para o menos intrusivo, sem incluir a resposta independente.
+type DefinirHierarquiaPromptRequest struct {
+	Direcao           DirecaoPrompt `json:"direcao" binding:"required,oneof=mais_para_menos menos_para_mais" example:"mais_para_menos"`
+	TipoPromptIDs     []uuid.UUID   `json:"tipo_prompt_ids" binding:"required,min=1"`
+	PercentualAvanco  *float64      `json:"percentual_avanco" binding:"omitempty,gt=0,lte=100" example:"80"`
+	SessoesAvanco     *int          `json:"sessoes_avanco" binding:"omitempty,min=1" example:"2"`
+	PercentualRetorno *float64      `json:"percentual_retorno" binding:"omitempty,gte=0,lte=100" example:"50"`
+	SessoesEstagnacao *int          `json:"sessoes_estagnacao" binding:"omitempty,min=2" example:"5"`
+	LimiteDependencia *float64      `json:"limite_dependencia" binding:"omitempty,gte=0,lte=100" example:"20"`
+}
+
+// NivelPromptResumo identifica um nível da hierarquia; TipoPromptID nulo indica resposta independente
+type NivelPromptResumo struct {
+	Posicao      int        `json:"posicao" example:"3"`
+	TipoPromptID *uuid.UUID `json:"tipo_prompt_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Tipo         string     `json:"tipo" example:"Modelo"`
+}
+
+// RecomendacaoPromptResponse representa o nível de prompt recomendado para a próxima sessão de uma etapa
+type RecomendacaoPromptResponse struct {
+	ProgramaID             uuid.UUID          `json:"programa_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	EtapaProgramaID        uuid.UUID          `json:"etapa_programa_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Direcao                DirecaoPrompt      `json:"direcao" example:"mais_para_menos"`
+	NivelAtual             *NivelPromptResumo `json:"nivel_atual"`
+	NivelRecomendado       NivelPromptResumo  `json:"nivel_recomendado"`
+	Acao                   AcaoPrompt         `json:"acao" example:"esvanecer"`
+	SessoesAnalisadas      int                `json:"sessoes_analisadas" example:"5"`
+	PercentualIndependente float64            `json:"percentual_independente" example:"10"`
+	PresoNoNivel           bool               `json:"preso_no_nivel" example:"false"`
+	DependentePrompt       bool               `json:"dependente_prompt" example:"false"`
+	Justificativa          string             `json:"justificativa" example:"2 sessões com 85% de acerto no nível Modelo"`
+}
+
+// ToHierarquiaPrompt converte um DefinirHierarquiaPromptRequest para um modelo HierarquiaPrompt
+func (r *DefinirHierarquiaPromptRequest) ToHierarquiaPrompt(programaID uuid.UUID) *HierarquiaPrompt {
+	hierarquia := &HierarquiaPrompt{
+		ProgramaID:        programaID,
+		Direcao:           r.Direcao,
+		PercentualAvanco:  80,
+		SessoesAvanco:     2,
+		PercentualRetorno: 50,
+		SessoesEstagnacao: 5,
+		LimiteDependencia: 20,
+	}
+	if r.PercentualAvanco != nil {
+		hierarquia.PercentualAvanco = *r.PercentualAvanco
+	}
+	if r.SessoesAvanco != nil {
+		hierarquia.SessoesAvanco = *r.SessoesAvanco
+	}
+	if r.PercentualRetorno != nil {
+		hierarquia.PercentualRetorno = *r.PercentualRetorno
+	}
+	if r.SessoesEstagnacao != nil {
+		hierarquia.SessoesEstagnacao = *r.SessoesEstagnacao
+	}
+	if r.LimiteDependencia != nil {
+		hierarquia.LimiteDependencia = *r.LimiteDependencia
+	}
+	for i, tipoPromptID := range r.TipoPromptIDs {
+		hierarquia.Niveis = append(hierarquia.Niveis, NivelHierarquiaPrompt{
+			TipoPromptID: tipoPromptID,
+			Posicao:      i + 1,
+		})
+	}
+	return hierarquia
+}
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RegraPontuacao indica como os pontos dos itens são combinados na pontuação total e das subescalas
+type RegraPontuacao string
+
+const (
+	RegraPontuacaoSoma  RegraPontuacao = "soma"
+	RegraPontuacaoMedia RegraPontuacao = "media"
+)
+
+// EtapaFaixa indica a etapa da aplicação em que a faixa de classificação é usada: a pontuação inicial ou
+// a pontuação da entrevista de seguimento (como no M-CHAT-R/F)
+type EtapaFaixa string
+
+const (
+	EtapaFaixaInicial    EtapaFaixa = "inicial"
+	EtapaFaixaSeguimento EtapaFaixa = "seguimento"
+)
+
+// Instrumento representa a definição versionada de um questionário padronizado de rastreio ou de desfecho,
+// como o M-CHAT-R/F ou o ATEC: os itens, as escalas de resposta, as subescalas, a regra de pontuação e as
+// faixas de classificação. Cada código pode ter várias versões; a definição não muda depois de criada, para
+// que as aplicações continuem pontuadas pela versão em que foram feitas.
+type Instrumento struct {
+	ID         uuid.UUID              `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Codigo     string                 `gorm:"size:50;not null;uniqueIndex:idx_instrumento_versao" json:"codigo"`
+	Versao     int                    `gorm:"not null;uniqueIndex:idx_instrumento_versao" json:"versao"`
+	Nome       string                 `gorm:"size:150;not null" json:"nome"`
+	Descricao  string                 `gorm:"type:text" json:"descricao"`
+	Regra      RegraPontuacao         `gorm:"type:varchar(20);not null;default:'soma'" json:"regra"`
+	Ativo      bool                   `gorm:"not null;default:true" json:"ativo"`
+	Escalas    []EscalaInstrumento    `gorm:"foreignKey:InstrumentoID" json:"escalas"`
+	Subescalas []SubescalaInstrumento `gorm:"foreignKey:InstrumentoID" json:"subescalas"`
+	Itens      []ItemInstrumento      `gorm:"foreignKey:InstrumentoID" json:"itens"`
+	Faixas     []FaixaInstrumento     `gorm:"foreignKey:InstrumentoID" json:"faixas"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt         `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (Instrumento) TableName() string {
+	return "instrumentos"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (i *Instrumento) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return
+}
+
+// EscalaInstrumento representa um conjunto de opções de resposta compartilhado pelos itens, como
+// "sim/não" ou "não é um problema" a "problema grave"
+type EscalaInstrumento struct {
+	ID            uuid.UUID                `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	InstrumentoID uuid.UUID                `gorm:"type:uuid;not null;index" json:"instrumento_id"`
+	Codigo        string                   `gorm:"size:50;not null" json:"codigo"`
+	Opcoes        []OpcaoEscalaInstrumento `gorm:"foreignKey:EscalaID" json:"opcoes"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (EscalaInstrumento) TableName() string {
+	return "escalas_instrumento"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (e *EscalaInstrumento) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
+
+// Limites retorna o menor e o maior valor das opções da escala, usados na pontuação invertida
+func (e *EscalaInstrumento) Limites() (float64, float64) {
+	if len(e.Opcoes) == 0 {
+		return 0, 0
+	}
+	minimo, maximo := e.Opcoes[0].Valor, e.Opcoes[0].Valor
+	for _, opcao := range e.Opcoes[1:] {
+		if opcao.Valor < minimo {
+			minimo = opcao.Valor
+		}
+		if opcao.Valor > maximo {
+			maximo = opcao.Valor
+		}
+	}
+	return minimo, maximo
+}
+
+// OpcaoEscalaInstrumento representa uma opção de resposta com o seu valor
+type OpcaoEscalaInstrumento struct {
+	ID       uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	EscalaID uuid.UUID `gorm:"type:uuid;not null;index" json:"escala_id"`
+	Ordem    int       `gorm:"not null" json:"ordem"`
+	Rotulo   string    `gorm:"size:100;not null" json:"rotulo"`
+	Valor    float64   `gorm:"not null" json:"valor"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (OpcaoEscalaInstrumento) TableName() string {
+	return "opcoes_escala_instrumento"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (o *OpcaoEscalaInstrumento) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return
+}
+
+// SubescalaInstrumento representa uma subescala do instrumento, pontuada com os itens que a referem
+type SubescalaInstrumento struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	InstrumentoID uuid.UUID `gorm:"type:uuid;not null;index" json:"instrumento_id"`
+	Ordem         int       `gorm:"not null" json:"ordem"`
+	Codigo        string    `gorm:"size:50;not null" json:"codigo"`
+	Nome          string    `gorm:"size:150;not null" json:"nome"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (SubescalaInstrumento) TableName() string {
+	return "subescalas_instrumento"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (s *SubescalaInstrumento) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// ItemInstrumento representa um item do questionário. Nos itens invertidos, os pontos são o valor da
+// opção espelhado entre o menor e o maior valor da escala.
+type ItemInstrumento struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	InstrumentoID uuid.UUID `gorm:"type:uuid;not null;index" json:"instrumento_id"`
+	Numero        int       `gorm:"not null" json:"numero"`
+	Texto         string    `gorm:"type:text;not null" json:"texto"`
+	Escala        string    `gorm:"size:50;not null" json:"escala"`
+	Subescala     string    `gorm:"size:50" json:"subescala,omitempty"`
+	Invertido     bool      `gorm:"not null;default:false" json:"invertido"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ItemInstrumento) TableName() string {
+	return "itens_instrumento"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (i *ItemInstrumento) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return
+}
+
+// FaixaInstrumento representa uma faixa de classificação da pontuação total ou de uma subescala, com
+// limites inclusivos. Uma faixa da etapa inicial pode exigir a entrevista de seguimento.
+type FaixaInstrumento struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	InstrumentoID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"instrumento_id"`
+	Etapa            EtapaFaixa `gorm:"type:varchar(20);not null;default:'inicial'" json:"etapa"`
+	Subescala        string     `gorm:"size:50" json:"subescala,omitempty"`
+	Minimo           float64    `gorm:"not null" json:"minimo"`
+	Maximo           float64    `gorm:"not null" json:"maximo"`
+	Classificacao    string     `gorm:"size:50;not null" json:"classificacao"`
+	Descricao        string     `gorm:"type:text" json:"descricao"`
+	RequerSeguimento bool       `gorm:"not null;default:false" json:"requer_seguimento"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (FaixaInstrumento) TableName() string {
+	return "faixas_instrumento"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (f *FaixaInstrumento) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
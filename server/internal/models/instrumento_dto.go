@@ -0,0 +1,160 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// OpcaoEscalaRequest representa uma opção de resposta de uma escala
+type OpcaoEscalaRequest struct {
+	Rotulo string   `json:"rotulo" binding:"required,max=100" example:"Sim"`
+	Valor  *float64 `json:"valor" binding:"required" example:"0"`
+}
+
+// EscalaInstrumentoRequest representa uma escala de resposta na definição de um instrumento
+type EscalaInstrumentoRequest struct {
+	Codigo string               `json:"codigo" binding:"required,max=50" example:"sim_nao"`
+	Opcoes []OpcaoEscalaRequest `json:"opcoes" binding:"required,min=2,dive"`
+}
+
+// SubescalaInstrumentoRequest representa uma subescala na definição de um instrumento
+type SubescalaInstrumentoRequest struct {
+	Codigo string `json:"codigo" binding:"required,max=50" example:"sociabilidade"`
+	Nome   string `json:"nome" binding:"required,max=150" example:"Sociabilidade"`
+}
+
+// ItemInstrumentoRequest representa um item na definição de um instrumento. "escala" e "subescala" referem
+// os códigos informados na mesma definição.
+type ItemInstrumentoRequest struct {
+	Numero    int    `json:"numero" binding:"required,min=1" example:"1"`
+	Texto     string `json:"texto" binding:"required" example:"Seu filho olha para o objeto quando você aponta?"`
+	Escala    string `json:"escala" binding:"required,max=50" example:"sim_nao"`
+	Subescala string `json:"subescala" binding:"max=50" example:""`
+	Invertido bool   `json:"invertido" example:"false"`
+}
+
+// FaixaInstrumentoRequest representa uma faixa de classificação na definição de um instrumento. Sem
+// subescala, a faixa classifica a pontuação total.
+type FaixaInstrumentoRequest struct {
+	Etapa            EtapaFaixa `json:"etapa" binding:"omitempty,oneof=inicial seguimento" example:"inicial"`
+	Subescala        string     `json:"subescala" binding:"max=50" example:""`
+	Minimo           *float64   `json:"minimo" binding:"required" example:"3"`
+	Maximo           *float64   `json:"maximo" binding:"required" example:"7"`
+	Classificacao    string     `json:"classificacao" binding:"required,max=50" example:"medio_risco"`
+	Descricao        string     `json:"descricao" example:"Aplicar a entrevista de seguimento"`
+	RequerSeguimento bool       `json:"requer_seguimento" example:"true"`
+}
+
+// CreateInstrumentoRequest representa a definição completa de um instrumento. Uma definição com um código
+// já cadastrado cria a próxima versão desse instrumento.
+type CreateInstrumentoRequest struct {
+	Codigo     string                        `json:"codigo" binding:"required,max=50" example:"mchat_rf"`
+	Nome       string                        `json:"nome" binding:"required,max=150" example:"M-CHAT-R/F"`
+	Descricao  string                        `json:"descricao" example:"Rastreio de risco para TEA entre 16 e 30 meses"`
+	Regra      RegraPontuacao                `json:"regra" binding:"omitempty,oneof=soma media" example:"soma"`
+	Escalas    []EscalaInstrumentoRequest    `json:"escalas" binding:"required,min=1,dive"`
+	Subescalas []SubescalaInstrumentoRequest `json:"subescalas" binding:"omitempty,dive"`
+	Itens      []ItemInstrumentoRequest      `json:"itens" binding:"required,min=1,dive"`
+	Faixas     []FaixaInstrumentoRequest     `json:"faixas" binding:"omitempty,dive"`
+}
+
+// UpdateInstrumentoRequest representa os dados que podem ser atualizados em uma versão de instrumento. Os
+// itens, as escalas e as faixas não podem ser alterados; uma nova definição cria uma nova versão.
+type UpdateInstrumentoRequest struct {
+	Nome      *string `json:"nome" binding:"omitempty,max=150" example:"M-CHAT-R/F"`
+	Descricao *string `json:"descricao" example:"Rastreio de risco para TEA entre 16 e 30 meses"`
+	Ativo     *bool   `json:"ativo" example:"true"`
+}
+
+// ToInstrumento converte um CreateInstrumentoRequest para um modelo Instrumento com as escalas, as subescalas,
+// os itens e as faixas. A versão é definida pelo serviço.
+func (r *CreateInstrumentoRequest) ToInstrumento() *Instrumento {
+	instrumento := &Instrumento{
+		ID:         uuid.New(),
+		Codigo:     r.Codigo,
+		Nome:       r.Nome,
+		Descricao:  r.Descricao,
+		Regra:      r.Regra,
+		Ativo:      true,
+		Escalas:    make([]EscalaInstrumento, 0, len(r.Escalas)),
+		Subescalas: make([]SubescalaInstrumento, 0, len(r.Subescalas)),
+		Itens:      make([]ItemInstrumento, 0, len(r.Itens)),
+		Faixas:     make([]FaixaInstrumento, 0, len(r.Faixas)),
+	}
+	if instrumento.Regra == "" {
+		instrumento.Regra = RegraPontuacaoSoma
+	}
+	for _, e := range r.Escalas {
+		escala := EscalaInstrumento{
+			ID:            uuid.New(),
+			InstrumentoID: instrumento.ID,
+			Codigo:        e.Codigo,
+			Opcoes:        make([]OpcaoEscalaInstrumento, 0, len(e.Opcoes)),
+		}
+		for i, opcao := range e.Opcoes {
+			escala.Opcoes = append(escala.Opcoes, OpcaoEscalaInstrumento{
+				EscalaID: escala.ID,
+				Ordem:    i + 1,
+				Rotulo:   opcao.Rotulo,
+				Valor:    *opcao.Valor,
+			})
+		}
+		instrumento.Escalas = append(instrumento.Escalas, escala)
+	}
+	for i, subescala := range r.Subescalas {
+		instrumento.Subescalas = append(instrumento.Subescalas, SubescalaInstrumento{
+			InstrumentoID: instrumento.ID,
+			Ordem:         i + 1,
+			Codigo:        subescala.Codigo,
+			Nome:          subescala.Nome,
+		})
+	}
+	for _, item := range r.Itens {
+		instrumento.Itens = append(instrumento.Itens, ItemInstrumento{
+			InstrumentoID: instrumento.ID,
+			Numero:        item.Numero,
+			Texto:         item.Texto,
+			Escala:        item.Escala,
+			Subescala:     item.Subescala,
+			Invertido:     item.Invertido,
+		})
+	}
+	for _, faixa := range r.Faixas {
+		etapa := faixa.Etapa
+		if etapa == "" {
+			etapa = EtapaFaixaInicial
+		}
+		instrumento.Faixas = append(instrumento.Faixas, FaixaInstrumento{
+			InstrumentoID:    instrumento.ID,
+			Etapa:            etapa,
+			Subescala:        faixa.Subescala,
+			Minimo:           *faixa.Minimo,
+			Maximo:           *faixa.Maximo,
+			Classificacao:    faixa.Classificacao,
+			Descricao:        faixa.Descricao,
+			RequerSeguimento: faixa.RequerSeguimento,
+		})
+	}
+	return instrumento
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateInstrumentoRequest a um modelo Instrumento
+func (i *Instrumento) ApplyUpdates(req *UpdateInstrumentoRequest) {
+	if req.Nome != nil {
+		i.Nome = *req.Nome
+	}
+	if req.Descricao != nil {
+		i.Descricao = *req.Descricao
+	}
+	if req.Ativo != nil {
+		i.Ativo = *req.Ativo
+	}
+}
+
+// InstrumentoPadrao representa uma definição de instrumento distribuída com o serviço, que pode ser
+// instalada como uma nova versão
+type InstrumentoPadrao struct {
+	Codigo    string `json:"codigo" example:"mchat_rf"`
+	Nome      string `json:"nome" example:"M-CHAT-R/F"`
+	Descricao string `json:"descricao" example:"Rastreio de risco para TEA entre 16 e 30 meses"`
+	Itens     int    `json:"itens" example:"20"`
+}
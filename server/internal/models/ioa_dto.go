@@ -0,0 +1,65 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// Parâmetros padrão do cálculo de concordância entre observadores (IOA)
+const (
+	// DuracaoIntervaloIOAPadrao é a duração, em segundos, dos intervalos da contagem exata por intervalo
+	DuracaoIntervaloIOAPadrao = 10
+	// MetaCoberturaIOAPadrao é o percentual mínimo de sessões com IOA recomendado
+	MetaCoberturaIOAPadrao = 20.0
+	// IOAAceitavel é o percentual mínimo de concordância considerado aceitável
+	IOAAceitavel = 80.0
+)
+
+// IOATentativas representa a concordância tentativa a tentativa de uma etapa. As tentativas dos dois
+// observadores são comparadas na ordem de registro, considerando o resultado e o prompt utilizado.
+type IOATentativas struct {
+	EtapaProgramaID      uuid.UUID `json:"etapa_programa_id"`
+	TentativasPrincipal  int       `json:"tentativas_principal"`
+	TentativasSecundario int       `json:"tentativas_secundario"`
+	Concordancias        int       `json:"concordancias"`
+	Percentual           float64   `json:"percentual"`
+}
+
+// IOAComportamento representa a concordância entre observadores para um comportamento alvo na sessão.
+// As medidas não aplicáveis ao método de registro são omitidas.
+type IOAComportamento struct {
+	ComportamentoID           uuid.UUID      `json:"comportamento_id"`
+	Descricao                 string         `json:"descricao"`
+	MetodoRegistro            MetodoRegistro `json:"metodo_registro"`
+	TotalPrincipal            float64        `json:"total_principal"`
+	TotalSecundario           float64        `json:"total_secundario"`
+	ContagemTotal             *float64       `json:"contagem_total,omitempty"`
+	ContagemExataPorIntervalo *float64       `json:"contagem_exata_por_intervalo,omitempty"`
+	IntervaloAIntervalo       *float64       `json:"intervalo_a_intervalo,omitempty"`
+}
+
+// IOASessao reúne as medidas de concordância entre os observadores de uma sessão. IOAGeral é a média
+// das medidas calculadas e fica vazio quando não há dados do observador secundário.
+type IOASessao struct {
+	SessaoID               uuid.UUID          `json:"sessao_id"`
+	PacienteID             uuid.UUID          `json:"paciente_id"`
+	TerapeutaID            uuid.UUID          `json:"terapeuta_id"`
+	ObservadorSecundarioID *uuid.UUID         `json:"observador_secundario_id,omitempty"`
+	DuracaoIntervalo       int                `json:"duracao_intervalo"`
+	Tentativas             []IOATentativas    `json:"tentativas"`
+	Comportamentos         []IOAComportamento `json:"comportamentos"`
+	IOAGeral               *float64           `json:"ioa_geral,omitempty"`
+	Aceitavel              bool               `json:"aceitavel"`
+}
+
+// CoberturaIOA representa a cobertura de IOA das sessões realizadas de um paciente ou terapeuta
+type CoberturaIOA struct {
+	PacienteID               *uuid.UUID `json:"paciente_id,omitempty"`
+	TerapeutaID              *uuid.UUID `json:"terapeuta_id,omitempty"`
+	SessoesRealizadas        int        `json:"sessoes_realizadas"`
+	SessoesComIOA            int        `json:"sessoes_com_ioa"`
+	Cobertura                float64    `json:"cobertura"`
+	MetaCobertura            float64    `json:"meta_cobertura"`
+	AtingeMeta               bool       `json:"atinge_meta"`
+	IOAMedio                 *float64   `json:"ioa_medio,omitempty"`
+	SessoesAbaixoDoAceitavel int        `json:"sessoes_abaixo_do_aceitavel"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PromptModeloPrograma representa a configuração da hierarquia de prompts de um modelo de programa, com os
+// mesmos parâmetros de esvanecimento de HierarquiaPrompt. Sem direção, o modelo não define hierarquia.
+type PromptModeloPrograma struct {
+	Direcao           DirecaoPrompt `gorm:"type:varchar(20)" json:"direcao,omitempty"`
+	PercentualAvanco  float64       `json:"percentual_avanco"`
+	SessoesAvanco     int           `json:"sessoes_avanco"`
+	PercentualRetorno float64       `json:"percentual_retorno"`
+	SessoesEstagnacao int           `json:"sessoes_estagnacao"`
+	LimiteDependencia float64       `json:"limite_dependencia"`
+}
+
+// ModeloPrograma representa um programa da biblioteca da clínica (banco de programas), com as etapas
+// padrão, os alvos de cada etapa, os critérios de domínio e a hierarquia de prompts. Um modelo é copiado
+// para o paciente ao ser instanciado; alterações posteriores no modelo não afetam os programas já criados.
+type ModeloPrograma struct {
+	ID           uuid.UUID             `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Nome         string                `gorm:"size:100;not null" json:"nome"`
+	Descricao    string                `gorm:"type:text" json:"descricao"`
+	Area         string                `gorm:"size:100;index" json:"area"`
+	Ativo        bool                  `gorm:"not null;default:true" json:"ativo"`
+	Prompt       PromptModeloPrograma  `gorm:"embedded;embeddedPrefix:prompt_" json:"prompt"`
+	NiveisPrompt []NivelPromptModelo   `gorm:"foreignKey:ModeloID" json:"niveis_prompt"`
+	Etapas       []EtapaModeloPrograma `gorm:"foreignKey:ModeloID" json:"etapas"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt        `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ModeloPrograma) TableName() string {
+	return "modelos_programa"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (m *ModeloPrograma) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return
+}
+
+// NivelPromptModelo representa um nível da hierarquia de prompts de um modelo, do mais intrusivo (posição 1)
+// para o menos intrusivo
+type NivelPromptModelo struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ModeloID     uuid.UUID `gorm:"type:uuid;not null;index" json:"modelo_id"`
+	TipoPromptID uuid.UUID `gorm:"type:uuid;not null" json:"tipo_prompt_id"`
+	Posicao      int       `gorm:"not null" json:"posicao"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (NivelPromptModelo) TableName() string {
+	return "niveis_prompt_modelo"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (n *NivelPromptModelo) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return
+}
+
+// EtapaModeloPrograma representa uma etapa padrão de um modelo de programa, com os alvos iniciais
+type EtapaModeloPrograma struct {
+	ID              uuid.UUID            `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ModeloID        uuid.UUID            `gorm:"type:uuid;not null;index" json:"modelo_id"`
+	Ordem           int                  `gorm:"not null" json:"ordem"`
+	Descricao       string               `gorm:"type:text;not null" json:"descricao"`
+	CriterioSucesso string               `gorm:"type:text" json:"criterio_sucesso"`
+	CriterioDominio CriterioDominio      `gorm:"embedded;embeddedPrefix:criterio_" json:"criterio_dominio"`
+	RotacaoAlvos    RotacaoAlvos         `gorm:"embedded;embeddedPrefix:rotacao_" json:"rotacao_alvos"`
+	Alvos           []AlvoModeloPrograma `gorm:"foreignKey:EtapaID" json:"alvos"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (EtapaModeloPrograma) TableName() string {
+	return "etapas_modelo_programa"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (e *EtapaModeloPrograma) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
+
+// AlvoModeloPrograma representa um alvo padrão de uma etapa de modelo
+type AlvoModeloPrograma struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	EtapaID   uuid.UUID `gorm:"type:uuid;not null;index" json:"etapa_id"`
+	Ordem     int       `gorm:"not null" json:"ordem"`
+	Descricao string    `gorm:"type:text;not null" json:"descricao"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (AlvoModeloPrograma) TableName() string {
+	return "alvos_modelo_programa"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (a *AlvoModeloPrograma) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
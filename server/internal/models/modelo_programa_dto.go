@@ -0,0 +1,185 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EtapaModeloProgramaRequest representa uma etapa na definição de um modelo de programa, com os alvos na
+// ordem em que serão introduzidos no ensino
+type EtapaModeloProgramaRequest struct {
+	Descricao       string           `json:"descricao" binding:"required" example:"Mando por itens preferidos com dica ecoica"`
+	CriterioSucesso string           `json:"criterio_sucesso" example:"80% independente em 3 sessões consecutivas"`
+	CriterioDominio *CriterioDominio `json:"criterio_dominio"`
+	RotacaoAlvos    *RotacaoAlvos    `json:"rotacao_alvos"`
+	Alvos           []string         `json:"alvos" binding:"omitempty,dive,required" example:"bola,água,bolha de sabão"`
+}
+
+// CreateModeloProgramaRequest representa a definição de um modelo de programa da biblioteca da clínica
+type CreateModeloProgramaRequest struct {
+	Nome       string                          `json:"nome" binding:"required,max=100" example:"Mando por itens preferidos"`
+	Descricao  string                          `json:"descricao" example:"Ensino de mandos com fading de dica ecoica"`
+	Area       string                          `json:"area" binding:"max=100" example:"Mando"`
+	Etapas     []EtapaModeloProgramaRequest    `json:"etapas" binding:"required,min=1,dive"`
+	Hierarquia *DefinirHierarquiaPromptRequest `json:"hierarquia"`
+}
+
+// UpdateModeloProgramaRequest representa os dados que podem ser atualizados em um modelo de programa. Quando
+// informadas, as etapas e a hierarquia substituem as do modelo; os programas já instanciados não mudam.
+type UpdateModeloProgramaRequest struct {
+	Nome       *string                         `json:"nome" binding:"omitempty,max=100" example:"Mando por itens preferidos"`
+	Descricao  *string                         `json:"descricao" example:"Ensino de mandos com fading de dica ecoica"`
+	Area       *string                         `json:"area" binding:"omitempty,max=100" example:"Mando"`
+	Ativo      *bool                           `json:"ativo" example:"true"`
+	Etapas     []EtapaModeloProgramaRequest    `json:"etapas" binding:"omitempty,min=1,dive"`
+	Hierarquia *DefinirHierarquiaPromptRequest `json:"hierarquia"`
+}
+
+// InstanciarModeloProgramaRequest representa a criação de um programa ABA para um paciente a partir de um
+// modelo. Sem nome ou descrição, o programa recebe os do modelo.
+type InstanciarModeloProgramaRequest struct {
+	PacienteID uuid.UUID  `json:"paciente_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Nome       string     `json:"nome" binding:"max=100" example:"Mando por itens preferidos"`
+	Descricao  string     `json:"descricao" example:"Ensino de mandos com fading de dica ecoica"`
+	DataInicio *time.Time `json:"data_inicio" example:"2024-03-04T00:00:00Z"`
+}
+
+// ToModeloPrograma converte um CreateModeloProgramaRequest para um modelo ModeloPrograma com as etapas,
+// os alvos e a hierarquia de prompts
+func (r *CreateModeloProgramaRequest) ToModeloPrograma() *ModeloPrograma {
+	modelo := &ModeloPrograma{
+		ID:        uuid.New(),
+		Nome:      r.Nome,
+		Descricao: r.Descricao,
+		Area:      r.Area,
+		Ativo:     true,
+	}
+	modelo.DefinirEtapas(r.Etapas)
+	modelo.DefinirHierarquia(r.Hierarquia)
+	return modelo
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateModeloProgramaRequest a um modelo ModeloPrograma
+func (m *ModeloPrograma) ApplyUpdates(req *UpdateModeloProgramaRequest) {
+	if req.Nome != nil {
+		m.Nome = *req.Nome
+	}
+	if req.Descricao != nil {
+		m.Descricao = *req.Descricao
+	}
+	if req.Area != nil {
+		m.Area = *req.Area
+	}
+	if req.Ativo != nil {
+		m.Ativo = *req.Ativo
+	}
+	if req.Etapas != nil {
+		m.DefinirEtapas(req.Etapas)
+	}
+	if req.Hierarquia != nil {
+		m.DefinirHierarquia(req.Hierarquia)
+	}
+}
+
+// DefinirEtapas substitui as etapas e os alvos do modelo, numerados na ordem recebida
+func (m *ModeloPrograma) DefinirEtapas(etapas []EtapaModeloProgramaRequest) {
+	m.Etapas = make([]EtapaModeloPrograma, 0, len(etapas))
+	for i, e := range etapas {
+		etapa := EtapaModeloPrograma{
+			ID:              uuid.New(),
+			ModeloID:        m.ID,
+			Ordem:           i + 1,
+			Descricao:       e.Descricao,
+			CriterioSucesso: e.CriterioSucesso,
+			Alvos:           make([]AlvoModeloPrograma, 0, len(e.Alvos)),
+		}
+		if e.CriterioDominio != nil {
+			etapa.CriterioDominio = *e.CriterioDominio
+		}
+		if e.RotacaoAlvos != nil {
+			etapa.RotacaoAlvos = *e.RotacaoAlvos
+		}
+		for j, descricao := range e.Alvos {
+			etapa.Alvos = append(etapa.Alvos, AlvoModeloPrograma{
+				EtapaID:   etapa.ID,
+				Ordem:     j + 1,
+				Descricao: descricao,
+			})
+		}
+		m.Etapas = append(m.Etapas, etapa)
+	}
+}
+
+// DefinirHierarquia substitui a hierarquia de prompts do modelo, com os mesmos valores padrão de uma
+// hierarquia de programa. Sem requisição, o modelo fica sem hierarquia.
+func (m *ModeloPrograma) DefinirHierarquia(req *DefinirHierarquiaPromptRequest) {
+	m.Prompt = PromptModeloPrograma{}
+	m.NiveisPrompt = nil
+	if req == nil {
+		return
+	}
+
+	hierarquia := req.ToHierarquiaPrompt(uuid.Nil)
+	m.Prompt = PromptModeloPrograma{
+		Direcao:           hierarquia.Direcao,
+		PercentualAvanco:  hierarquia.PercentualAvanco,
+		SessoesAvanco:     hierarquia.SessoesAvanco,
+		PercentualRetorno: hierarquia.PercentualRetorno,
+		SessoesEstagnacao: hierarquia.SessoesEstagnacao,
+		LimiteDependencia: hierarquia.LimiteDependencia,
+	}
+	for _, nivel := range hierarquia.Niveis {
+		m.NiveisPrompt = append(m.NiveisPrompt, NivelPromptModelo{
+			ModeloID:     m.ID,
+			TipoPromptID: nivel.TipoPromptID,
+			Posicao:      nivel.Posicao,
+		})
+	}
+}
+
+// ToHierarquiaPrompt cria a hierarquia de prompts de um programa instanciado a partir do modelo, ou nil
+// quando o modelo não define hierarquia
+func (m *ModeloPrograma) ToHierarquiaPrompt(programaID uuid.UUID) *HierarquiaPrompt {
+	if m.Prompt.Direcao == "" {
+		return nil
+	}
+
+	hierarquia := &HierarquiaPrompt{
+		ID:                uuid.New(),
+		ProgramaID:        programaID,
+		Direcao:           m.Prompt.Direcao,
+		PercentualAvanco:  m.Prompt.PercentualAvanco,
+		SessoesAvanco:     m.Prompt.SessoesAvanco,
+		PercentualRetorno: m.Prompt.PercentualRetorno,
+		SessoesEstagnacao: m.Prompt.SessoesEstagnacao,
+		LimiteDependencia: m.Prompt.LimiteDependencia,
+	}
+	for _, nivel := range m.NiveisPrompt {
+		hierarquia.Niveis = append(hierarquia.Niveis, NivelHierarquiaPrompt{
+			HierarquiaID: hierarquia.ID,
+			TipoPromptID: nivel.TipoPromptID,
+			Posicao:      nivel.Posicao,
+		})
+	}
+	return hierarquia
+}
+
+// UsoModeloPrograma representa o uso de um modelo de programa: quantos programas e pacientes o utilizam e
+// quanto tempo as etapas e os programas levam para ser dominados. "media_dias_etapa" é a média de dias entre
+// o início do programa, ou o domínio da etapa anterior, e o domínio de cada etapa; "media_dias_programa" é a
+// média de dias até o domínio de todas as etapas, nos programas em que isso já ocorreu.
+type UsoModeloPrograma struct {
+	ModeloID           uuid.UUID `json:"modelo_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Nome               string    `json:"nome" example:"Mando por itens preferidos"`
+	Area               string    `json:"area" example:"Mando"`
+	Ativo              bool      `json:"ativo" example:"true"`
+	Programas          int       `json:"programas" example:"12"`
+	ProgramasAtivos    int       `json:"programas_ativos" example:"8"`
+	Pacientes          int       `json:"pacientes" example:"11"`
+	EtapasDominadas    int       `json:"etapas_dominadas" example:"27"`
+	EtapasTotais       int       `json:"etapas_totais" example:"48"`
+	ProgramasDominados int       `json:"programas_dominados" example:"3"`
+	MediaDiasEtapa     *float64  `json:"media_dias_etapa,omitempty" example:"9.5"`
+	MediaDiasPrograma  *float64  `json:"media_dias_programa,omitempty" example:"41.3"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ObjetivoPrograma representa o vínculo entre um objetivo terapêutico e um programa ABA do mesmo paciente.
+// Um programa pode servir a vários objetivos e um objetivo pode ser trabalhado por vários programas.
+type ObjetivoPrograma struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ObjetivoID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_objetivo_programa" json:"objetivo_id"`
+	ProgramaID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_objetivo_programa;index" json:"programa_id"`
+	UsuarioID  string    `gorm:"size:64" json:"usuario_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ObjetivoPrograma) TableName() string {
+	return "objetivos_programas"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (o *ObjetivoPrograma) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return
+}
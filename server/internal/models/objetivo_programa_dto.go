@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VincularProgramaObjetivoRequest representa o vínculo de um programa ABA a um objetivo terapêutico
+type VincularProgramaObjetivoRequest struct {
+	ProgramaID uuid.UUID `json:"programa_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// ProgressoProgramaObjetivo representa a contribuição de um programa vinculado para o progresso do objetivo.
+// Programas finalizados contam como 100%; os demais, pela proporção de etapas ativas dominadas.
+type ProgressoProgramaObjetivo struct {
+	ProgramaID      uuid.UUID      `json:"programa_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Nome            string         `json:"nome" example:"Mando por itens preferidos"`
+	Status          StatusPrograma `json:"status" example:"ativo"`
+	EtapasDominadas int            `json:"etapas_dominadas" example:"3"`
+	TotalEtapas     int            `json:"total_etapas" example:"5"`
+	Percentual      float64        `json:"percentual" example:"60"`
+}
+
+// ProgressoProgramasObjetivo representa o progresso de um objetivo derivado dos programas vinculados, com a
+// média dos percentuais dos programas
+type ProgressoProgramasObjetivo struct {
+	ObjetivoID           uuid.UUID                   `json:"objetivo_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status               StatusObjetivo              `json:"status" example:"em progresso"`
+	Programas            []ProgressoProgramaObjetivo `json:"programas"`
+	TotalProgramas       int                         `json:"total_programas" example:"3"`
+	ProgramasFinalizados int                         `json:"programas_finalizados" example:"1"`
+	Percentual           float64                     `json:"percentual" example:"53.3"`
+	ConclusaoPendente    bool                        `json:"conclusao_pendente" example:"false"`
+	ConclusaoPendenteEm  *time.Time                  `json:"conclusao_pendente_em,omitempty"`
+}
@@ -7,6 +7,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// EntidadeAuditoriaObjetivoTerapeutico identifica os objetivos terapêuticos nos eventos de auditoria
+const EntidadeAuditoriaObjetivoTerapeutico = "objetivo_terapeutico"
+
 // StatusObjetivo representa o status de um objetivo terapêutico
 type StatusObjetivo string
 
@@ -16,7 +19,9 @@ const (
 	StatusObjetivoSuspenso    StatusObjetivo = "suspenso"
 )
 
-// ObjetivoTerapeutico representa um objetivo terapêutico para um paciente
+// ObjetivoTerapeutico representa um objetivo terapêutico para um paciente. O progresso do objetivo também
+// é derivado dos programas ABA vinculados a ele; quando todos são finalizados, a conclusão fica pendente
+// até a confirmação do supervisor.
 type ObjetivoTerapeutico struct {
 	ID         uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	PacienteID uuid.UUID      `gorm:"type:uuid;not null" json:"paciente_id"`
@@ -24,9 +29,17 @@ type ObjetivoTerapeutico struct {
 	DataInicio time.Time      `gorm:"not null" json:"data_inicio"`
 	DataFim    time.Time      `json:"data_fim"`
 	Status     StatusObjetivo `gorm:"type:varchar(20);not null" json:"status"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	// Sinalizado indica que a tendência do progresso permanece negativa; é calculado pelo sistema
+	Sinalizado   bool       `gorm:"not null;default:false" json:"sinalizado"`
+	SinalizadoEm *time.Time `json:"sinalizado_em,omitempty"`
+	// ConclusaoPendente indica que todos os programas vinculados foram finalizados; é calculado pelo sistema
+	ConclusaoPendente   bool           `gorm:"not null;default:false" json:"conclusao_pendente"`
+	ConclusaoPendenteEm *time.Time     `json:"conclusao_pendente_em,omitempty"`
+	ConcluidoPor        string         `gorm:"size:64" json:"concluido_por,omitempty"`
+	ConcluidoEm         *time.Time     `json:"concluido_em,omitempty"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // TableName especifica o nome da tabela no banco de dados
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TipoRegistroIntervalo representa a regra de pontuação de uma observação por intervalos
+type TipoRegistroIntervalo string
+
+const (
+	TipoRegistroIntervaloParcial    TipoRegistroIntervalo = "parcial"
+	TipoRegistroIntervaloTotal      TipoRegistroIntervalo = "total"
+	TipoRegistroIntervaloMomentaneo TipoRegistroIntervalo = "momentaneo"
+)
+
+// ObservacaoIntervalo representa uma sessão de observação por intervalos de um comportamento alvo.
+// Cada intervalo é pontuado conforme o tipo: ocorrência em qualquer momento (parcial), durante todo o
+// intervalo (total) ou no instante final do intervalo (amostragem momentânea).
+type ObservacaoIntervalo struct {
+	ID                      uuid.UUID             `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ComportamentoID         uuid.UUID             `gorm:"type:uuid;not null;index" json:"comportamento_id"`
+	SessaoID                *uuid.UUID            `gorm:"type:uuid;index" json:"sessao_id,omitempty"`
+	Tipo                    TipoRegistroIntervalo `gorm:"type:varchar(20);not null" json:"tipo"`
+	DuracaoIntervalo        int                   `gorm:"not null" json:"duracao_intervalo"`
+	Inicio                  time.Time             `gorm:"not null;index" json:"inicio"`
+	TotalIntervalos         int                   `gorm:"not null" json:"total_intervalos"`
+	IntervalosComOcorrencia int                   `gorm:"not null" json:"intervalos_com_ocorrencia"`
+	Percentual              float64               `gorm:"not null" json:"percentual"`
+	Observacoes             string                `gorm:"type:text" json:"observacoes"`
+	UsuarioID               string                `gorm:"size:64" json:"usuario_id,omitempty"`
+	Secundario              bool                  `gorm:"not null;default:false;index" json:"secundario"`
+	Intervalos              []IntervaloObservado  `gorm:"foreignKey:ObservacaoID" json:"intervalos"`
+	CreatedAt               time.Time             `json:"created_at"`
+	UpdatedAt               time.Time             `json:"updated_at"`
+	DeletedAt               gorm.DeletedAt        `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ObservacaoIntervalo) TableName() string {
+	return "observacoes_intervalo"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (o *ObservacaoIntervalo) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return
+}
+
+// Calcular atualiza os totais da observação a partir dos intervalos pontuados
+func (o *ObservacaoIntervalo) Calcular() {
+	o.TotalIntervalos = len(o.Intervalos)
+	o.IntervalosComOcorrencia = 0
+	for _, intervalo := range o.Intervalos {
+		if intervalo.Ocorreu {
+			o.IntervalosComOcorrencia++
+		}
+	}
+	o.Percentual = 0
+	if o.TotalIntervalos > 0 {
+		o.Percentual = float64(o.IntervalosComOcorrencia) / float64(o.TotalIntervalos) * 100
+	}
+}
+
+// InicioIntervalo retorna o instante de início do intervalo de número n (a partir de 1)
+func (o *ObservacaoIntervalo) InicioIntervalo(n int) time.Time {
+	return o.Inicio.Add(time.Duration(n-1) * time.Duration(o.DuracaoIntervalo) * time.Second)
+}
+
+// IntervaloObservado representa a pontuação de um intervalo de uma observação
+type IntervaloObservado struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ObservacaoID uuid.UUID `gorm:"type:uuid;not null;index" json:"observacao_id"`
+	Numero       int       `gorm:"not null" json:"numero"`
+	Ocorreu      bool      `gorm:"not null" json:"ocorreu"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (IntervaloObservado) TableName() string {
+	return "intervalos_observados"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (i *IntervaloObservado) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return
+}
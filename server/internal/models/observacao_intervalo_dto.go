@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateObservacaoIntervaloRequest representa os dados de uma observação por intervalos.
+// "intervalos" traz a pontuação de cada intervalo, na ordem em que foram observados, e "secundario"
+// indica uma observação do observador secundário da sessão, usada apenas no cálculo de IOA.
+type CreateObservacaoIntervaloRequest struct {
+	Tipo             TipoRegistroIntervalo `json:"tipo" binding:"required,oneof=parcial total momentaneo" example:"parcial"`
+	DuracaoIntervalo int                   `json:"duracao_intervalo" binding:"required,min=1" example:"10"`
+	Inicio           *time.Time            `json:"inicio" example:"2024-03-10T14:00:00Z"`
+	SessaoID         *uuid.UUID            `json:"sessao_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Intervalos       []bool                `json:"intervalos" binding:"required,min=1" example:"true,false,true"`
+	Observacoes      string                `json:"observacoes" example:"Observação durante o recreio"`
+	Secundario       bool                  `json:"secundario" example:"false"`
+}
+
+// UpdateObservacaoIntervaloRequest representa os dados que podem ser atualizados em uma observação.
+// Quando "intervalos" é informado, a pontuação anterior é substituída por completo.
+type UpdateObservacaoIntervaloRequest struct {
+	Tipo             *TipoRegistroIntervalo `json:"tipo" binding:"omitempty,oneof=parcial total momentaneo" example:"total"`
+	DuracaoIntervalo *int                   `json:"duracao_intervalo" binding:"omitempty,min=1" example:"15"`
+	Inicio           *time.Time             `json:"inicio" example:"2024-03-10T14:00:00Z"`
+	Intervalos       []bool                 `json:"intervalos" binding:"omitempty,min=1" example:"true,false,true"`
+	Observacoes      *string                `json:"observacoes" example:"Observação durante o recreio"`
+}
+
+// ToObservacaoIntervalo converte um CreateObservacaoIntervaloRequest para um modelo ObservacaoIntervalo
+func (r *CreateObservacaoIntervaloRequest) ToObservacaoIntervalo(comportamentoID uuid.UUID, usuarioID string) *ObservacaoIntervalo {
+	observacao := &ObservacaoIntervalo{
+		ComportamentoID:  comportamentoID,
+		SessaoID:         r.SessaoID,
+		Tipo:             r.Tipo,
+		DuracaoIntervalo: r.DuracaoIntervalo,
+		Inicio:           time.Now(),
+		Observacoes:      r.Observacoes,
+		UsuarioID:        usuarioID,
+		Secundario:       r.Secundario,
+		Intervalos:       intervalosObservados(r.Intervalos),
+	}
+	if r.Inicio != nil {
+		observacao.Inicio = *r.Inicio
+	}
+	observacao.Calcular()
+	return observacao
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateObservacaoIntervaloRequest a um modelo ObservacaoIntervalo
+func (o *ObservacaoIntervalo) ApplyUpdates(req *UpdateObservacaoIntervaloRequest) {
+	if req.Tipo != nil {
+		o.Tipo = *req.Tipo
+	}
+	if req.DuracaoIntervalo != nil {
+		o.DuracaoIntervalo = *req.DuracaoIntervalo
+	}
+	if req.Inicio != nil {
+		o.Inicio = *req.Inicio
+	}
+	if req.Intervalos != nil {
+		o.Intervalos = intervalosObservados(req.Intervalos)
+	}
+	if req.Observacoes != nil {
+		o.Observacoes = *req.Observacoes
+	}
+	o.Calcular()
+}
+
+// intervalosObservados numera a pontuação dos intervalos a partir de 1
+func intervalosObservados(pontuacao []bool) []IntervaloObservado {
+	intervalos := make([]IntervaloObservado, len(pontuacao))
+	for i, ocorreu := range pontuacao {
+		intervalos[i] = IntervaloObservado{Numero: i + 1, Ocorreu: ocorreu}
+	}
+	return intervalos
+}
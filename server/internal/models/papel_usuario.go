@@ -0,0 +1,5 @@
+package models
+
+// PapelSupervisor identifica, no token de acesso, os usuários que aprovam os planos terapêuticos e confirmam
+// a conclusão dos objetivos
+const PapelSupervisor = "supervisor"
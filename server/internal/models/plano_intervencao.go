@@ -0,0 +1,177 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EntidadeAuditoriaVersaoPlanoIntervencao identifica as versões dos planos de intervenção nos eventos de auditoria
+const EntidadeAuditoriaVersaoPlanoIntervencao = "versao_plano_intervencao"
+
+// StatusVersaoPlano representa a situação de uma versão do plano de intervenção comportamental
+type StatusVersaoPlano string
+
+const (
+	StatusVersaoPlanoRascunho    StatusVersaoPlano = "rascunho"
+	StatusVersaoPlanoVigente     StatusVersaoPlano = "vigente"
+	StatusVersaoPlanoSubstituida StatusVersaoPlano = "substituida"
+)
+
+// TipoCienciaPlano indica quem tomou ciência de uma versão do plano
+type TipoCienciaPlano string
+
+const (
+	TipoCienciaPlanoResponsavel TipoCienciaPlano = "responsavel"
+	TipoCienciaPlanoSupervisor  TipoCienciaPlano = "supervisor"
+)
+
+// PlanoIntervencao representa o plano de intervenção comportamental (BIP) de um paciente, escrito a partir
+// da função hipotética dos comportamentos inadequados. O conteúdo do plano fica nas versões: apenas a
+// versão em rascunho pode ser alterada e, ao ser publicada, passa a ser a vigente e substitui a anterior.
+type PlanoIntervencao struct {
+	ID                   uuid.UUID                `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PacienteID           uuid.UUID                `gorm:"type:uuid;not null;index" json:"paciente_id"`
+	Titulo               string                   `gorm:"size:150;not null" json:"titulo"`
+	AvaliacaoFuncionalID *uuid.UUID               `gorm:"type:uuid;index" json:"avaliacao_funcional_id,omitempty"`
+	UsuarioID            string                   `gorm:"size:64" json:"usuario_id,omitempty"`
+	Versoes              []VersaoPlanoIntervencao `gorm:"foreignKey:PlanoID" json:"versoes,omitempty"`
+	CreatedAt            time.Time                `json:"created_at"`
+	UpdatedAt            time.Time                `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt           `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (PlanoIntervencao) TableName() string {
+	return "planos_intervencao"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (p *PlanoIntervencao) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+// VersaoPlanoIntervencao representa uma versão do conteúdo do plano: os comportamentos alvo tratados,
+// os programas ABA de habilidades substitutas, as estratégias antecedentes e de consequência, os
+// comportamentos substitutos e os procedimentos de crise, além das ciências dos responsáveis e do supervisor
+type VersaoPlanoIntervencao struct {
+	ID                        uuid.UUID                       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PlanoID                   uuid.UUID                       `gorm:"type:uuid;not null;uniqueIndex:idx_versao_plano_numero" json:"plano_id"`
+	Numero                    int                             `gorm:"not null;uniqueIndex:idx_versao_plano_numero" json:"numero"`
+	Status                    StatusVersaoPlano               `gorm:"type:varchar(20);not null;default:'rascunho'" json:"status"`
+	FuncaoHipotetica          *FuncaoComportamento            `gorm:"type:varchar(20)" json:"funcao_hipotetica,omitempty"`
+	EstrategiasAntecedentes   string                          `gorm:"type:text" json:"estrategias_antecedentes"`
+	ComportamentosSubstitutos string                          `gorm:"type:text" json:"comportamentos_substitutos"`
+	EstrategiasConsequencia   string                          `gorm:"type:text" json:"estrategias_consequencia"`
+	ProcedimentosCrise        string                          `gorm:"type:text" json:"procedimentos_crise"`
+	Observacoes               string                          `gorm:"type:text" json:"observacoes"`
+	UsuarioID                 string                          `gorm:"size:64" json:"usuario_id,omitempty"`
+	PublicadaEm               *time.Time                      `json:"publicada_em,omitempty"`
+	SubstituidaEm             *time.Time                      `json:"substituida_em,omitempty"`
+	Comportamentos            []ComportamentoPlanoIntervencao `gorm:"foreignKey:VersaoID" json:"comportamentos"`
+	Programas                 []ProgramaPlanoIntervencao      `gorm:"foreignKey:VersaoID" json:"programas"`
+	Ciencias                  []CienciaPlanoIntervencao       `gorm:"foreignKey:VersaoID" json:"ciencias"`
+	CreatedAt                 time.Time                       `json:"created_at"`
+	UpdatedAt                 time.Time                       `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (VersaoPlanoIntervencao) TableName() string {
+	return "versoes_plano_intervencao"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (v *VersaoPlanoIntervencao) BeforeCreate(tx *gorm.DB) (err error) {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return
+}
+
+// CienciaCompleta indica se a versão já recebeu a ciência de ao menos um responsável e de um supervisor
+func (v *VersaoPlanoIntervencao) CienciaCompleta() bool {
+	var responsavel, supervisor bool
+	for _, ciencia := range v.Ciencias {
+		switch ciencia.Tipo {
+		case TipoCienciaPlanoResponsavel:
+			responsavel = true
+		case TipoCienciaPlanoSupervisor:
+			supervisor = true
+		}
+	}
+	return responsavel && supervisor
+}
+
+// ComportamentoPlanoIntervencao associa um comportamento alvo a uma versão do plano
+type ComportamentoPlanoIntervencao struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VersaoID        uuid.UUID `gorm:"type:uuid;not null;index" json:"versao_id"`
+	ComportamentoID uuid.UUID `gorm:"type:uuid;not null;index" json:"comportamento_id"`
+	Ordem           int       `gorm:"not null" json:"ordem"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ComportamentoPlanoIntervencao) TableName() string {
+	return "comportamentos_plano_intervencao"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (c *ComportamentoPlanoIntervencao) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// ProgramaPlanoIntervencao associa um programa ABA de habilidade substituta a uma versão do plano
+type ProgramaPlanoIntervencao struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VersaoID   uuid.UUID `gorm:"type:uuid;not null;index" json:"versao_id"`
+	ProgramaID uuid.UUID `gorm:"type:uuid;not null;index" json:"programa_id"`
+	Ordem      int       `gorm:"not null" json:"ordem"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ProgramaPlanoIntervencao) TableName() string {
+	return "programas_plano_intervencao"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (p *ProgramaPlanoIntervencao) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+// CienciaPlanoIntervencao representa a ciência de um responsável ou do supervisor em uma versão publicada do plano.
+// As ciências nunca são alteradas depois de registradas.
+type CienciaPlanoIntervencao struct {
+	ID          uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VersaoID    uuid.UUID        `gorm:"type:uuid;not null;index" json:"versao_id"`
+	Tipo        TipoCienciaPlano `gorm:"type:varchar(20);not null" json:"tipo"`
+	Nome        string           `gorm:"size:150;not null" json:"nome"`
+	Vinculo     string           `gorm:"size:100" json:"vinculo"`
+	Observacoes string           `gorm:"type:text" json:"observacoes"`
+	UsuarioID   string           `gorm:"size:64" json:"usuario_id,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (CienciaPlanoIntervencao) TableName() string {
+	return "ciencias_plano_intervencao"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (c *CienciaPlanoIntervencao) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
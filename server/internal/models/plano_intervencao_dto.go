@@ -0,0 +1,140 @@
+package models
+
+import "github.com/google/uuid"
+
+// CreatePlanoIntervencaoRequest representa os dados necessários para criar um plano de intervenção com a
+// sua primeira versão em rascunho. Quando "avaliacao_funcional_id" é informado, a avaliação deve estar
+// aprovada e, na ausência de comportamentos ou da função, são usados os da avaliação.
+type CreatePlanoIntervencaoRequest struct {
+	Titulo                    string               `json:"titulo" binding:"required,max=150" example:"Plano de intervenção - agressão"`
+	AvaliacaoFuncionalID      *uuid.UUID           `json:"avaliacao_funcional_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ComportamentoIDs          []uuid.UUID          `json:"comportamento_ids"`
+	ProgramaIDs               []uuid.UUID          `json:"programa_ids"`
+	FuncaoHipotetica          *FuncaoComportamento `json:"funcao_hipotetica" binding:"omitempty,oneof=fuga atencao tangivel automatica" example:"fuga"`
+	EstrategiasAntecedentes   string               `json:"estrategias_antecedentes" example:"Antecipar as demandas com quadro de rotina e oferecer pausas programadas"`
+	ComportamentosSubstitutos string               `json:"comportamentos_substitutos" example:"Pedir pausa com o cartão 'pausa'"`
+	EstrategiasConsequencia   string               `json:"estrategias_consequencia" example:"Reforçar o pedido de pausa; manter a demanda diante da agressão"`
+	ProcedimentosCrise        string               `json:"procedimentos_crise" example:"Afastar objetos e outras crianças, manter distância segura e acionar a supervisão"`
+	Observacoes               string               `json:"observacoes" example:"Aplicar em casa e na escola"`
+}
+
+// UpdatePlanoIntervencaoRequest representa os dados do plano que podem ser atualizados fora das versões
+type UpdatePlanoIntervencaoRequest struct {
+	Titulo *string `json:"titulo" binding:"omitempty,max=150" example:"Plano de intervenção - agressão"`
+}
+
+// VersaoPlanoIntervencaoRequest representa o conteúdo de uma nova versão ou as alterações de uma versão em
+// rascunho. Campos omitidos mantêm o conteúdo da versão anterior; "programa_ids" vazio remove os programas
+// e "funcao_hipotetica" vazia remove a função.
+type VersaoPlanoIntervencaoRequest struct {
+	ComportamentoIDs          []uuid.UUID          `json:"comportamento_ids" binding:"omitempty,min=1"`
+	ProgramaIDs               []uuid.UUID          `json:"programa_ids"`
+	FuncaoHipotetica          *FuncaoComportamento `json:"funcao_hipotetica" binding:"omitempty,oneof=fuga atencao tangivel automatica" example:"fuga"`
+	EstrategiasAntecedentes   *string              `json:"estrategias_antecedentes" example:"Antecipar as demandas com quadro de rotina e oferecer pausas programadas"`
+	ComportamentosSubstitutos *string              `json:"comportamentos_substitutos" example:"Pedir pausa com o cartão 'pausa'"`
+	EstrategiasConsequencia   *string              `json:"estrategias_consequencia" example:"Reforçar o pedido de pausa; manter a demanda diante da agressão"`
+	ProcedimentosCrise        *string              `json:"procedimentos_crise" example:"Afastar objetos e outras crianças, manter distância segura e acionar a supervisão"`
+	Observacoes               *string              `json:"observacoes" example:"Aplicar em casa e na escola"`
+}
+
+// CienciaPlanoIntervencaoRequest representa a ciência de um responsável ou do supervisor em uma versão do plano
+type CienciaPlanoIntervencaoRequest struct {
+	Tipo        TipoCienciaPlano `json:"tipo" binding:"required,oneof=responsavel supervisor" example:"responsavel"`
+	Nome        string           `json:"nome" binding:"required,max=150" example:"Maria Silva"`
+	Vinculo     string           `json:"vinculo" binding:"max=100" example:"Mãe"`
+	Observacoes string           `json:"observacoes" example:"Recebeu orientação sobre os procedimentos de crise"`
+}
+
+// ToPlanoIntervencao converte um CreatePlanoIntervencaoRequest para um modelo PlanoIntervencao
+func (r *CreatePlanoIntervencaoRequest) ToPlanoIntervencao(pacienteID uuid.UUID, usuarioID string) *PlanoIntervencao {
+	return &PlanoIntervencao{
+		PacienteID:           pacienteID,
+		Titulo:               r.Titulo,
+		AvaliacaoFuncionalID: r.AvaliacaoFuncionalID,
+		UsuarioID:            usuarioID,
+	}
+}
+
+// ToVersaoPlanoIntervencao converte um CreatePlanoIntervencaoRequest na primeira versão do plano,
+// sem os comportamentos e os programas
+func (r *CreatePlanoIntervencaoRequest) ToVersaoPlanoIntervencao(usuarioID string) *VersaoPlanoIntervencao {
+	return &VersaoPlanoIntervencao{
+		Numero:                    1,
+		Status:                    StatusVersaoPlanoRascunho,
+		FuncaoHipotetica:          r.FuncaoHipotetica,
+		EstrategiasAntecedentes:   r.EstrategiasAntecedentes,
+		ComportamentosSubstitutos: r.ComportamentosSubstitutos,
+		EstrategiasConsequencia:   r.EstrategiasConsequencia,
+		ProcedimentosCrise:        r.ProcedimentosCrise,
+		Observacoes:               r.Observacoes,
+		UsuarioID:                 usuarioID,
+	}
+}
+
+// ToCienciaPlanoIntervencao converte um CienciaPlanoIntervencaoRequest para um modelo CienciaPlanoIntervencao
+func (r *CienciaPlanoIntervencaoRequest) ToCienciaPlanoIntervencao(versaoID uuid.UUID, usuarioID string) *CienciaPlanoIntervencao {
+	return &CienciaPlanoIntervencao{
+		VersaoID:    versaoID,
+		Tipo:        r.Tipo,
+		Nome:        r.Nome,
+		Vinculo:     r.Vinculo,
+		Observacoes: r.Observacoes,
+		UsuarioID:   usuarioID,
+	}
+}
+
+// ApplyUpdates aplica as atualizações de um UpdatePlanoIntervencaoRequest a um modelo PlanoIntervencao
+func (p *PlanoIntervencao) ApplyUpdates(req *UpdatePlanoIntervencaoRequest) {
+	if req.Titulo != nil {
+		p.Titulo = *req.Titulo
+	}
+}
+
+// ApplyUpdates aplica as atualizações de um VersaoPlanoIntervencaoRequest a um modelo VersaoPlanoIntervencao,
+// exceto os comportamentos e os programas
+func (v *VersaoPlanoIntervencao) ApplyUpdates(req *VersaoPlanoIntervencaoRequest) {
+	if req.FuncaoHipotetica != nil {
+		v.FuncaoHipotetica = req.FuncaoHipotetica
+		if *req.FuncaoHipotetica == "" {
+			v.FuncaoHipotetica = nil
+		}
+	}
+	if req.EstrategiasAntecedentes != nil {
+		v.EstrategiasAntecedentes = *req.EstrategiasAntecedentes
+	}
+	if req.ComportamentosSubstitutos != nil {
+		v.ComportamentosSubstitutos = *req.ComportamentosSubstitutos
+	}
+	if req.EstrategiasConsequencia != nil {
+		v.EstrategiasConsequencia = *req.EstrategiasConsequencia
+	}
+	if req.ProcedimentosCrise != nil {
+		v.ProcedimentosCrise = *req.ProcedimentosCrise
+	}
+	if req.Observacoes != nil {
+		v.Observacoes = *req.Observacoes
+	}
+}
+
+// NovaVersao cria o rascunho da próxima versão a partir do conteúdo desta versão, sem as ciências
+func (v *VersaoPlanoIntervencao) NovaVersao(usuarioID string) *VersaoPlanoIntervencao {
+	nova := &VersaoPlanoIntervencao{
+		PlanoID:                   v.PlanoID,
+		Numero:                    v.Numero + 1,
+		Status:                    StatusVersaoPlanoRascunho,
+		FuncaoHipotetica:          v.FuncaoHipotetica,
+		EstrategiasAntecedentes:   v.EstrategiasAntecedentes,
+		ComportamentosSubstitutos: v.ComportamentosSubstitutos,
+		EstrategiasConsequencia:   v.EstrategiasConsequencia,
+		ProcedimentosCrise:        v.ProcedimentosCrise,
+		Observacoes:               v.Observacoes,
+		UsuarioID:                 usuarioID,
+	}
+	for _, comportamento := range v.Comportamentos {
+		nova.Comportamentos = append(nova.Comportamentos, ComportamentoPlanoIntervencao{ComportamentoID: comportamento.ComportamentoID, Ordem: comportamento.Ordem})
+	}
+	for _, programa := range v.Programas {
+		nova.Programas = append(nova.Programas, ProgramaPlanoIntervencao{ProgramaID: programa.ProgramaID, Ordem: programa.Ordem})
+	}
+	return nova
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EntidadeAuditoriaVersaoPlanoTerapeutico identifica as versões dos planos terapêuticos nos eventos de auditoria
+const EntidadeAuditoriaVersaoPlanoTerapeutico = "versao_plano_terapeutico"
+
+// StatusVersaoPlanoTerapeutico representa a situação de uma versão do plano terapêutico singular
+type StatusVersaoPlanoTerapeutico string
+
+const (
+	StatusVersaoPlanoTerapeuticoRascunho    StatusVersaoPlanoTerapeutico = "rascunho"
+	StatusVersaoPlanoTerapeuticoEmAprovacao StatusVersaoPlanoTerapeutico = "em_aprovacao"
+	StatusVersaoPlanoTerapeuticoVigente     StatusVersaoPlanoTerapeutico = "vigente"
+	StatusVersaoPlanoTerapeuticoSubstituida StatusVersaoPlanoTerapeutico = "substituida"
+)
+
+// PlanoTerapeutico representa o plano terapêutico singular (PTS) de um paciente, exigido pelos convênios e
+// pelas famílias. O conteúdo do plano fica nas versões: a versão em rascunho é enviada para aprovação do
+// supervisor e, ao ser aprovada, passa a ser a vigente e substitui a anterior.
+type PlanoTerapeutico struct {
+	ID         uuid.UUID                `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PacienteID uuid.UUID                `gorm:"type:uuid;not null;index" json:"paciente_id"`
+	Titulo     string                   `gorm:"size:150;not null" json:"titulo"`
+	UsuarioID  string                   `gorm:"size:64" json:"usuario_id,omitempty"`
+	Versoes    []VersaoPlanoTerapeutico `gorm:"foreignKey:PlanoID" json:"versoes,omitempty"`
+	CreatedAt  time.Time                `json:"created_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt           `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (PlanoTerapeutico) TableName() string {
+	return "planos_terapeuticos"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (p *PlanoTerapeutico) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+// VersaoPlanoTerapeutico representa uma versão do plano terapêutico: a síntese da avaliação, a cópia dos
+// objetivos terapêuticos e dos programas ABA do paciente no momento da versão, a carga horária semanal de
+// cada terapia e a data da próxima revisão, além da aprovação do supervisor e dos aceites dos responsáveis.
+// Os objetivos e os programas são copiados ao criar a versão e novamente ao enviá-la para aprovação.
+type VersaoPlanoTerapeutico struct {
+	ID                   uuid.UUID                      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PlanoID              uuid.UUID                      `gorm:"type:uuid;not null;uniqueIndex:idx_versao_plano_terapeutico_numero" json:"plano_id"`
+	Numero               int                            `gorm:"not null;uniqueIndex:idx_versao_plano_terapeutico_numero" json:"numero"`
+	Status               StatusVersaoPlanoTerapeutico   `gorm:"type:varchar(20);not null;default:'rascunho';index" json:"status"`
+	Avaliacao            string                         `gorm:"type:text" json:"avaliacao"`
+	Observacoes          string                         `gorm:"type:text" json:"observacoes"`
+	DataRevisao          time.Time                      `gorm:"not null;index" json:"data_revisao"`
+	UsuarioID            string                         `gorm:"size:64" json:"usuario_id,omitempty"`
+	EnviadaEm            *time.Time                     `json:"enviada_em,omitempty"`
+	MotivoDevolucao      string                         `gorm:"type:text" json:"motivo_devolucao,omitempty"`
+	Supervisor           string                         `gorm:"size:150" json:"supervisor,omitempty"`
+	RegistroProfissional string                         `gorm:"size:50" json:"registro_profissional,omitempty"`
+	AprovadaPor          string                         `gorm:"size:64" json:"aprovada_por,omitempty"`
+	AprovadaEm           *time.Time                     `json:"aprovada_em,omitempty"`
+	SubstituidaEm        *time.Time                     `json:"substituida_em,omitempty"`
+	Objetivos            []ObjetivoPlanoTerapeutico     `gorm:"foreignKey:VersaoID" json:"objetivos"`
+	Programas            []ProgramaPlanoTerapeutico     `gorm:"foreignKey:VersaoID" json:"programas"`
+	CargasHorarias       []CargaHorariaPlanoTerapeutico `gorm:"foreignKey:VersaoID" json:"cargas_horarias"`
+	Aceites              []AceitePlanoTerapeutico       `gorm:"foreignKey:VersaoID" json:"aceites"`
+	CreatedAt            time.Time                      `json:"created_at"`
+	UpdatedAt            time.Time                      `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (VersaoPlanoTerapeutico) TableName() string {
+	return "versoes_plano_terapeutico"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (v *VersaoPlanoTerapeutico) BeforeCreate(tx *gorm.DB) (err error) {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return
+}
+
+// HorasSemanais retorna a carga horária semanal total da versão, somando todas as terapias
+func (v *VersaoPlanoTerapeutico) HorasSemanais() float64 {
+	var total float64
+	for _, carga := range v.CargasHorarias {
+		total += carga.HorasSemanais
+	}
+	return total
+}
+
+// ObjetivoPlanoTerapeutico representa a cópia de um objetivo terapêutico na versão do plano, com o
+// percentual de progresso derivado dos programas vinculados ao objetivo
+type ObjetivoPlanoTerapeutico struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VersaoID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"versao_id"`
+	ObjetivoID uuid.UUID      `gorm:"type:uuid;not null;index" json:"objetivo_id"`
+	Ordem      int            `gorm:"not null" json:"ordem"`
+	Descricao  string         `gorm:"type:text;not null" json:"descricao"`
+	Status     StatusObjetivo `gorm:"type:varchar(20);not null" json:"status"`
+	DataInicio time.Time      `json:"data_inicio"`
+	Percentual float64        `json:"percentual"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ObjetivoPlanoTerapeutico) TableName() string {
+	return "objetivos_plano_terapeutico"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (o *ObjetivoPlanoTerapeutico) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return
+}
+
+// ProgramaPlanoTerapeutico representa a cópia de um programa ABA na versão do plano, com as etapas dominadas
+type ProgramaPlanoTerapeutico struct {
+	ID              uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VersaoID        uuid.UUID      `gorm:"type:uuid;not null;index" json:"versao_id"`
+	ProgramaID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"programa_id"`
+	Ordem           int            `gorm:"not null" json:"ordem"`
+	Nome            string         `gorm:"size:100;not null" json:"nome"`
+	Descricao       string         `gorm:"type:text" json:"descricao"`
+	Status          StatusPrograma `gorm:"type:varchar(20);not null" json:"status"`
+	DataInicio      time.Time      `json:"data_inicio"`
+	EtapasDominadas int            `json:"etapas_dominadas"`
+	TotalEtapas     int            `json:"total_etapas"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (ProgramaPlanoTerapeutico) TableName() string {
+	return "programas_plano_terapeutico"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (p *ProgramaPlanoTerapeutico) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+// CargaHorariaPlanoTerapeutico representa as horas semanais previstas de uma terapia na versão do plano
+type CargaHorariaPlanoTerapeutico struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VersaoID      uuid.UUID `gorm:"type:uuid;not null;index" json:"versao_id"`
+	TerapiaID     uuid.UUID `gorm:"type:uuid;not null" json:"terapia_id"`
+	Terapia       string    `gorm:"size:100;not null" json:"terapia"`
+	HorasSemanais float64   `gorm:"not null" json:"horas_semanais"`
+	Observacoes   string    `gorm:"type:text" json:"observacoes"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (CargaHorariaPlanoTerapeutico) TableName() string {
+	return "cargas_horarias_plano_terapeutico"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (c *CargaHorariaPlanoTerapeutico) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
+
+// AceitePlanoTerapeutico representa o aceite de um responsável na versão vigente do plano.
+// Os aceites nunca são alterados depois de registrados.
+type AceitePlanoTerapeutico struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	VersaoID    uuid.UUID `gorm:"type:uuid;not null;index" json:"versao_id"`
+	Nome        string    `gorm:"size:150;not null" json:"nome"`
+	Vinculo     string    `gorm:"size:100" json:"vinculo"`
+	Observacoes string    `gorm:"type:text" json:"observacoes"`
+	UsuarioID   string    `gorm:"size:64" json:"usuario_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (AceitePlanoTerapeutico) TableName() string {
+	return "aceites_plano_terapeutico"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (a *AceitePlanoTerapeutico) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CargaHorariaPlanoTerapeuticoRequest representa as horas semanais previstas de uma terapia no plano
+type CargaHorariaPlanoTerapeuticoRequest struct {
+	TerapiaID     uuid.UUID `json:"terapia_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	HorasSemanais float64   `json:"horas_semanais" binding:"required,gt=0,lte=60" example:"10"`
+	Observacoes   string    `json:"observacoes" example:"Atendimento em clínica e em casa"`
+}
+
+// CreatePlanoTerapeuticoRequest representa os dados necessários para criar um plano terapêutico com a sua
+// primeira versão em rascunho. Sem "objetivo_ids", o plano inclui os objetivos em progresso do paciente;
+// sem "programa_ids", inclui os programas ABA ativos.
+type CreatePlanoTerapeuticoRequest struct {
+	Titulo         string                                `json:"titulo" binding:"required,max=150" example:"Plano terapêutico singular 2024"`
+	ObjetivoIDs    []uuid.UUID                           `json:"objetivo_ids"`
+	ProgramaIDs    []uuid.UUID                           `json:"programa_ids"`
+	CargasHorarias []CargaHorariaPlanoTerapeuticoRequest `json:"cargas_horarias" binding:"omitempty,dive"`
+	Avaliacao      string                                `json:"avaliacao" example:"Criança com atraso de linguagem e baixa tolerância a esperas"`
+	Observacoes    string                                `json:"observacoes" example:"Orientação parental quinzenal"`
+	DataRevisao    time.Time                             `json:"data_revisao" binding:"required" example:"2024-09-01T00:00:00Z"`
+}
+
+// UpdatePlanoTerapeuticoRequest representa os dados do plano que podem ser atualizados fora das versões
+type UpdatePlanoTerapeuticoRequest struct {
+	Titulo *string `json:"titulo" binding:"omitempty,max=150" example:"Plano terapêutico singular 2024"`
+}
+
+// VersaoPlanoTerapeuticoRequest representa o conteúdo de uma nova versão ou as alterações de uma versão em
+// rascunho. Campos omitidos mantêm o conteúdo da versão anterior; listas vazias removem os itens.
+type VersaoPlanoTerapeuticoRequest struct {
+	ObjetivoIDs    []uuid.UUID                           `json:"objetivo_ids"`
+	ProgramaIDs    []uuid.UUID                           `json:"programa_ids"`
+	CargasHorarias []CargaHorariaPlanoTerapeuticoRequest `json:"cargas_horarias" binding:"omitempty,dive"`
+	Avaliacao      *string                               `json:"avaliacao" example:"Criança com atraso de linguagem e baixa tolerância a esperas"`
+	Observacoes    *string                               `json:"observacoes" example:"Orientação parental quinzenal"`
+	DataRevisao    *time.Time                            `json:"data_revisao" example:"2024-09-01T00:00:00Z"`
+}
+
+// AprovarVersaoPlanoTerapeuticoRequest representa a aprovação do supervisor em uma versão enviada para aprovação
+type AprovarVersaoPlanoTerapeuticoRequest struct {
+	Supervisor           string `json:"supervisor" binding:"required,max=150" example:"Ana Souza"`
+	RegistroProfissional string `json:"registro_profissional" binding:"max=50" example:"CRP 06/12345"`
+}
+
+// DevolverVersaoPlanoTerapeuticoRequest representa a devolução de uma versão para ajustes pelo supervisor
+type DevolverVersaoPlanoTerapeuticoRequest struct {
+	Motivo string `json:"motivo" binding:"required" example:"Incluir a carga horária de fonoaudiologia"`
+}
+
+// AceitePlanoTerapeuticoRequest representa o aceite de um responsável na versão vigente do plano
+type AceitePlanoTerapeuticoRequest struct {
+	Nome        string `json:"nome" binding:"required,max=150" example:"Maria Silva"`
+	Vinculo     string `json:"vinculo" binding:"max=100" example:"Mãe"`
+	Observacoes string `json:"observacoes" example:"Recebeu uma cópia do plano"`
+}
+
+// LembreteRevisaoPlanoTerapeutico representa a revisão próxima ou vencida da versão vigente de um plano
+type LembreteRevisaoPlanoTerapeutico struct {
+	PlanoID       uuid.UUID `json:"plano_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PacienteID    uuid.UUID `json:"paciente_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Paciente      string    `json:"paciente" example:"João Silva"`
+	Titulo        string    `json:"titulo" example:"Plano terapêutico singular 2024"`
+	Versao        int       `json:"versao" example:"2"`
+	DataRevisao   time.Time `json:"data_revisao" example:"2024-09-01T00:00:00Z"`
+	DiasRestantes int       `json:"dias_restantes" example:"12"`
+	Vencida       bool      `json:"vencida" example:"false"`
+}
+
+// ToPlanoTerapeutico converte um CreatePlanoTerapeuticoRequest para um modelo PlanoTerapeutico
+func (r *CreatePlanoTerapeuticoRequest) ToPlanoTerapeutico(pacienteID uuid.UUID, usuarioID string) *PlanoTerapeutico {
+	return &PlanoTerapeutico{
+		PacienteID: pacienteID,
+		Titulo:     r.Titulo,
+		UsuarioID:  usuarioID,
+	}
+}
+
+// ToVersaoPlanoTerapeutico converte um CreatePlanoTerapeuticoRequest na primeira versão do plano, sem os
+// objetivos, os programas e as cargas horárias
+func (r *CreatePlanoTerapeuticoRequest) ToVersaoPlanoTerapeutico(usuarioID string) *VersaoPlanoTerapeutico {
+	return &VersaoPlanoTerapeutico{
+		Numero:      1,
+		Status:      StatusVersaoPlanoTerapeuticoRascunho,
+		Avaliacao:   r.Avaliacao,
+		Observacoes: r.Observacoes,
+		DataRevisao: r.DataRevisao,
+		UsuarioID:   usuarioID,
+	}
+}
+
+// ToAceitePlanoTerapeutico converte um AceitePlanoTerapeuticoRequest para um modelo AceitePlanoTerapeutico
+func (r *AceitePlanoTerapeuticoRequest) ToAceitePlanoTerapeutico(versaoID uuid.UUID, usuarioID string) *AceitePlanoTerapeutico {
+	return &AceitePlanoTerapeutico{
+		VersaoID:    versaoID,
+		Nome:        r.Nome,
+		Vinculo:     r.Vinculo,
+		Observacoes: r.Observacoes,
+		UsuarioID:   usuarioID,
+	}
+}
+
+// ApplyUpdates aplica as atualizações de um UpdatePlanoTerapeuticoRequest a um modelo PlanoTerapeutico
+func (p *PlanoTerapeutico) ApplyUpdates(req *UpdatePlanoTerapeuticoRequest) {
+	if req.Titulo != nil {
+		p.Titulo = *req.Titulo
+	}
+}
+
+// ApplyUpdates aplica as atualizações de um VersaoPlanoTerapeuticoRequest a um modelo VersaoPlanoTerapeutico,
+// exceto os objetivos, os programas e as cargas horárias
+func (v *VersaoPlanoTerapeutico) ApplyUpdates(req *VersaoPlanoTerapeuticoRequest) {
+	if req.Avaliacao != nil {
+		v.Avaliacao = *req.Avaliacao
+	}
+	if req.Observacoes != nil {
+		v.Observacoes = *req.Observacoes
+	}
+	if req.DataRevisao != nil {
+		v.DataRevisao = *req.DataRevisao
+	}
+}
+
+// NovaVersao cria o rascunho da próxima versão a partir do conteúdo desta versão, sem a aprovação e os aceites
+func (v *VersaoPlanoTerapeutico) NovaVersao(usuarioID string) *VersaoPlanoTerapeutico {
+	nova := &VersaoPlanoTerapeutico{
+		PlanoID:     v.PlanoID,
+		Numero:      v.Numero + 1,
+		Status:      StatusVersaoPlanoTerapeuticoRascunho,
+		Avaliacao:   v.Avaliacao,
+		Observacoes: v.Observacoes,
+		DataRevisao: v.DataRevisao,
+		UsuarioID:   usuarioID,
+	}
+	for _, objetivo := range v.Objetivos {
+		nova.Objetivos = append(nova.Objetivos, ObjetivoPlanoTerapeutico{ObjetivoID: objetivo.ObjetivoID, Ordem: objetivo.Ordem})
+	}
+	for _, programa := range v.Programas {
+		nova.Programas = append(nova.Programas, ProgramaPlanoTerapeutico{ProgramaID: programa.ProgramaID, Ordem: programa.Ordem})
+	}
+	for _, carga := range v.CargasHorarias {
+		nova.CargasHorarias = append(nova.CargasHorarias, CargaHorariaPlanoTerapeutico{
+			TerapiaID:     carga.TerapiaID,
+			Terapia:       carga.Terapia,
+			HorasSemanais: carga.HorasSemanais,
+			Observacoes:   carga.Observacoes,
+		})
+	}
+	return nova
+}
@@ -16,18 +16,23 @@ const (
 	StatusProgramaFinalizado StatusPrograma = "finalizado"
 )
 
-// ProgramaABA representa um programa ABA para um paciente
+// ProgramaABA representa um programa ABA para um paciente. Programas criados a partir de um marco não
+// atingido em uma aplicação de currículo guardam a aplicação e o marco de origem, e programas instanciados
+// da biblioteca guardam o modelo de origem.
 type ProgramaABA struct {
-	ID         uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	Nome       string         `gorm:"size:100;not null" json:"nome"`
-	Descricao  string         `gorm:"type:text" json:"descricao"`
-	PacienteID uuid.UUID      `gorm:"type:uuid;not null" json:"paciente_id"`
-	DataInicio time.Time      `gorm:"not null" json:"data_inicio"`
-	DataFim    time.Time      `json:"data_fim"`
-	Status     StatusPrograma `gorm:"type:varchar(20);not null" json:"status"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                   uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Nome                 string         `gorm:"size:100;not null" json:"nome"`
+	Descricao            string         `gorm:"type:text" json:"descricao"`
+	PacienteID           uuid.UUID      `gorm:"type:uuid;not null" json:"paciente_id"`
+	DataInicio           time.Time      `gorm:"not null" json:"data_inicio"`
+	DataFim              time.Time      `json:"data_fim"`
+	Status               StatusPrograma `gorm:"type:varchar(20);not null" json:"status"`
+	AplicacaoCurriculoID *uuid.UUID     `gorm:"type:uuid;index" json:"aplicacao_curriculo_id,omitempty"`
+	MarcoCurriculoID     *uuid.UUID     `gorm:"type:uuid;index" json:"marco_curriculo_id,omitempty"`
+	ModeloProgramaID     *uuid.UUID     `gorm:"type:uuid;index" json:"modelo_programa_id,omitempty"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // TableName especifica o nome da tabela no banco de dados
@@ -7,12 +7,14 @@ import (
 	"gorm.io/gorm"
 )
 
-// ProgressoObjetivo representa o progresso de um objetivo terapêutico
+// ProgressoObjetivo representa o progresso de um objetivo terapêutico. Quando o objetivo tem escala GAS, o
+// registro informa também o nível alcançado na escala.
 type ProgressoObjetivo struct {
 	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	ObjetivoID  uuid.UUID      `gorm:"type:uuid;not null" json:"objetivo_id"`
+	ObjetivoID  uuid.UUID      `gorm:"type:uuid;not null;index" json:"objetivo_id"`
 	Data        time.Time      `gorm:"not null" json:"data"`
 	Nota        int            `gorm:"not null" json:"nota"`
+	NivelGAS    *int           `json:"nivel_gas,omitempty"`
 	Observacoes string         `gorm:"type:text" json:"observacoes"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
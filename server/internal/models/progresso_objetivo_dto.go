@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClassificacaoTendencia representa a direção da tendência do progresso de um objetivo
+type ClassificacaoTendencia string
+
+const (
+	TendenciaMelhorando   ClassificacaoTendencia = "melhorando"
+	TendenciaEstavel      ClassificacaoTendencia = "estavel"
+	TendenciaRegredindo   ClassificacaoTendencia = "regredindo"
+	TendenciaInsuficiente ClassificacaoTendencia = "dados_insuficientes"
+)
+
+// Limites da nota atribuída ao progresso de um objetivo
+const (
+	NotaProgressoMinima = 0
+	NotaProgressoMaxima = 10
+)
+
+// CreateProgressoObjetivoRequest representa os dados necessários para registrar o progresso de um objetivo.
+// "nivel_gas" é o nível alcançado na escala GAS (-2 a +2) e é obrigatório quando o objetivo tem escala.
+type CreateProgressoObjetivoRequest struct {
+	Data        *time.Time `json:"data" example:"2024-03-10T00:00:00Z"`
+	Nota        *int       `json:"nota" binding:"required,min=0,max=10" example:"7"`
+	NivelGAS    *int       `json:"nivel_gas" binding:"omitempty,min=-2,max=2" example:"0"`
+	Observacoes string     `json:"observacoes" example:"Realizou a tarefa com apoio mínimo"`
+}
+
+// UpdateProgressoObjetivoRequest representa os dados que podem ser atualizados em um registro de progresso
+type UpdateProgressoObjetivoRequest struct {
+	Data        *time.Time `json:"data" example:"2024-03-10T00:00:00Z"`
+	Nota        *int       `json:"nota" binding:"omitempty,min=0,max=10" example:"8"`
+	NivelGAS    *int       `json:"nivel_gas" binding:"omitempty,min=-2,max=2" example:"1"`
+	Observacoes *string    `json:"observacoes" example:"Realizou a tarefa com apoio mínimo"`
+}
+
+// TendenciaObjetivoResponse representa a tendência do progresso de um objetivo na janela analisada.
+// A inclinação é obtida por regressão linear das notas e expressa em pontos por semana.
+type TendenciaObjetivoResponse struct {
+	ObjetivoID    uuid.UUID              `json:"objetivo_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Janela        int                    `json:"janela" example:"5"`
+	Registros     int                    `json:"registros" example:"5"`
+	Inclinacao    float64                `json:"inclinacao" example:"0.8"`
+	Classificacao ClassificacaoTendencia `json:"classificacao" example:"melhorando"`
+	MediaNota     float64                `json:"media_nota" example:"6.4"`
+	UltimaNota    *int                   `json:"ultima_nota,omitempty" example:"8"`
+	Sinalizado    bool                   `json:"sinalizado" example:"false"`
+	SinalizadoEm  *time.Time             `json:"sinalizado_em,omitempty"`
+	LimiarEstavel float64                `json:"limiar_estavel" example:"0.25"`
+}
+
+// ToProgressoObjetivo converte um CreateProgressoObjetivoRequest para um modelo ProgressoObjetivo
+func (r *CreateProgressoObjetivoRequest) ToProgressoObjetivo(objetivoID uuid.UUID) *ProgressoObjetivo {
+	progresso := &ProgressoObjetivo{
+		ObjetivoID:  objetivoID,
+		Data:        time.Now(),
+		Nota:        *r.Nota,
+		NivelGAS:    r.NivelGAS,
+		Observacoes: r.Observacoes,
+	}
+	if r.Data != nil {
+		progresso.Data = *r.Data
+	}
+	return progresso
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateProgressoObjetivoRequest a um modelo ProgressoObjetivo
+func (p *ProgressoObjetivo) ApplyUpdates(req *UpdateProgressoObjetivoRequest) {
+	if req.Data != nil {
+		p.Data = *req.Data
+	}
+	if req.Nota != nil {
+		p.Nota = *req.Nota
+	}
+	if req.NivelGAS != nil {
+		p.NivelGAS = req.NivelGAS
+	}
+	if req.Observacoes != nil {
+		p.Observacoes = *req.Observacoes
+	}
+}
@@ -7,14 +7,19 @@ import (
 	"gorm.io/gorm"
 )
 
-// RegistroComportamento representa um registro de ocorrência de comportamento
+// RegistroComportamento representa um registro de ocorrência de comportamento. Além do texto livre de
+// contexto e consequência, o antecedente e a consequência podem ser classificados pela taxonomia ABC.
 type RegistroComportamento struct {
 	ID              uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	ComportamentoID uuid.UUID      `gorm:"type:uuid;not null" json:"comportamento_id"`
-	DataHora        time.Time      `gorm:"not null" json:"data_hora"`
+	ComportamentoID uuid.UUID      `gorm:"type:uuid;not null;index" json:"comportamento_id"`
+	SessaoID        *uuid.UUID     `gorm:"type:uuid;index" json:"sessao_id,omitempty"`
+	DataHora        time.Time      `gorm:"not null;index" json:"data_hora"`
 	Valor           float64        `gorm:"not null" json:"valor"`
 	Contexto        string         `gorm:"type:text" json:"contexto"`
 	Consequencia    string         `gorm:"type:text" json:"consequencia"`
+	AntecedenteID   *uuid.UUID     `gorm:"type:uuid;index" json:"antecedente_id,omitempty"`
+	ConsequenciaID  *uuid.UUID     `gorm:"type:uuid;index" json:"consequencia_id,omitempty"`
+	Secundario      bool           `gorm:"not null;default:false;index" json:"secundario"`
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
 	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
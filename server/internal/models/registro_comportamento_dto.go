@@ -0,0 +1,131 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PeriodoAgregacao representa o intervalo usado para agregar os registros de comportamento
+type PeriodoAgregacao string
+
+const (
+	PeriodoAgregacaoDia    PeriodoAgregacao = "dia"
+	PeriodoAgregacaoSemana PeriodoAgregacao = "semana"
+)
+
+// Limites da escala de intensidade usada pelo método de registro por intensidade
+const (
+	IntensidadeMinima = 1
+	IntensidadeMaxima = 5
+)
+
+// CreateRegistroComportamentoRequest representa os dados necessários para registrar uma ocorrência.
+// O significado de "valor" depende do método de registro do comportamento: quantidade de ocorrências
+// (frequência), segundos (duração), nível da escala 1 a 5 (intensidade) ou 1/0 para intervalo
+// com/sem ocorrência (intervalo). "secundario" indica um registro do observador secundário da sessão,
+// usado apenas no cálculo de IOA. "antecedente_id" e "consequencia_id" são categorias ativas da
+// taxonomia ABC (GET /categorias-abc) do tipo correspondente.
+type CreateRegistroComportamentoRequest struct {
+	SessaoID       *uuid.UUID `json:"sessao_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	DataHora       *time.Time `json:"data_hora" example:"2024-03-10T14:30:00Z"`
+	Valor          *float64   `json:"valor" binding:"required" example:"3"`
+	Contexto       string     `json:"contexto" example:"Durante a atividade de mesa"`
+	Consequencia   string     `json:"consequencia" example:"Redirecionamento verbal"`
+	AntecedenteID  *uuid.UUID `json:"antecedente_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ConsequenciaID *uuid.UUID `json:"consequencia_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Secundario     bool       `json:"secundario" example:"false"`
+}
+
+// UpdateRegistroComportamentoRequest representa os dados que podem ser atualizados em um registro.
+// Um ID nulo (00000000-0000-0000-0000-000000000000) em "antecedente_id" ou "consequencia_id" remove a classificação.
+type UpdateRegistroComportamentoRequest struct {
+	DataHora       *time.Time `json:"data_hora" example:"2024-03-10T14:30:00Z"`
+	Valor          *float64   `json:"valor" example:"2"`
+	Contexto       *string    `json:"contexto" example:"Durante a atividade de mesa"`
+	Consequencia   *string    `json:"consequencia" example:"Redirecionamento verbal"`
+	AntecedenteID  *uuid.UUID `json:"antecedente_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ConsequenciaID *uuid.UUID `json:"consequencia_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// AgregadoRegistroComportamento representa os registros de um comportamento agregados em um período.
+// "valor" é a medida principal do método de registro: total de ocorrências (frequência), total de
+// segundos (duração), intensidade média (intensidade) ou percentual de intervalos com ocorrência (intervalo).
+// No método por intervalos, "registros" conta os intervalos pontuados.
+type AgregadoRegistroComportamento struct {
+	Inicio    time.Time `json:"inicio" example:"2024-03-04T00:00:00Z"`
+	Fim       time.Time `json:"fim" example:"2024-03-11T00:00:00Z"`
+	Registros int       `json:"registros" example:"5"`
+	Soma      float64   `json:"soma" example:"12"`
+	Media     float64   `json:"media" example:"2.4"`
+	Minimo    float64   `json:"minimo" example:"1"`
+	Maximo    float64   `json:"maximo" example:"4"`
+	Valor     float64   `json:"valor" example:"12"`
+}
+
+// ToRegistroComportamento converte um CreateRegistroComportamentoRequest para um modelo RegistroComportamento
+func (r *CreateRegistroComportamentoRequest) ToRegistroComportamento(comportamentoID uuid.UUID) *RegistroComportamento {
+	registro := &RegistroComportamento{
+		ComportamentoID: comportamentoID,
+		SessaoID:        r.SessaoID,
+		DataHora:        time.Now(),
+		Valor:           *r.Valor,
+		Contexto:        r.Contexto,
+		Consequencia:    r.Consequencia,
+		AntecedenteID:   r.AntecedenteID,
+		ConsequenciaID:  r.ConsequenciaID,
+		Secundario:      r.Secundario,
+	}
+	if r.DataHora != nil {
+		registro.DataHora = *r.DataHora
+	}
+	return registro
+}
+
+// ApplyUpdates aplica as atualizações de um UpdateRegistroComportamentoRequest a um modelo RegistroComportamento
+func (r *RegistroComportamento) ApplyUpdates(req *UpdateRegistroComportamentoRequest) {
+	if req.DataHora != nil {
+		r.DataHora = *req.DataHora
+	}
+	if req.Valor != nil {
+		r.Valor = *req.Valor
+	}
+	if req.Contexto != nil {
+		r.Contexto = *req.Contexto
+	}
+	if req.Consequencia != nil {
+		r.Consequencia = *req.Consequencia
+	}
+	if req.AntecedenteID != nil {
+		r.AntecedenteID = categoriaOuNula(*req.AntecedenteID)
+	}
+	if req.ConsequenciaID != nil {
+		r.ConsequenciaID = categoriaOuNula(*req.ConsequenciaID)
+	}
+}
+
+// categoriaOuNula retorna a referência à categoria ABC, ou nulo quando o ID informado é nulo
+func categoriaOuNula(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// ValidarValor verifica se um valor registrado é coerente com o método de registro
+func (m MetodoRegistro) ValidarValor(valor float64) bool {
+	inteiro := valor == math.Trunc(valor)
+	switch m {
+	case MetodoRegistroFrequencia:
+		return inteiro && valor >= 0
+	case MetodoRegistroDuracao:
+		return valor > 0
+	case MetodoRegistroIntensidade:
+		return inteiro && valor >= IntensidadeMinima && valor <= IntensidadeMaxima
+	case MetodoRegistroIntervalo:
+		return valor == 0 || valor == 1
+	default:
+		return false
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+// RotacaoAlvos representa a configuração de rotação dos alvos de uma etapa, por exemplo
+// "3 alvos em aquisição por vez, dominados com 100% independente em 2 sessões e sondados
+// em manutenção a cada 3 sessões"
+type RotacaoAlvos struct {
+	AlvosSimultaneos    int     `json:"alvos_simultaneos" binding:"omitempty,min=1" example:"3"`
+	PercentualDominio   float64 `json:"percentual_dominio" binding:"omitempty,gt=0,lte=100" example:"100"`
+	SessoesDominio      int     `json:"sessoes_dominio" binding:"omitempty,min=1" example:"2"`
+	SondagemACada       int     `json:"sondagem_a_cada" binding:"omitempty,min=1" example:"3"`
+	ExigirGeneralizacao bool    `json:"exigir_generalizacao" example:"true"`
+}
+
+// Configurada indica se a rotação possui o critério de domínio dos alvos para ser aplicada automaticamente
+func (r RotacaoAlvos) Configurada() bool {
+	return r.PercentualDominio > 0 && r.SessoesDominio > 0
+}
+
+// SondagemACadaOuPadrao retorna o intervalo configurado entre sondagens de manutenção, em sessões,
+// ou uma sondagem por sessão quando ausente
+func (r RotacaoAlvos) SondagemACadaOuPadrao() int {
+	if r.SondagemACada < 1 {
+		return 1
+	}
+	return r.SondagemACada
+}
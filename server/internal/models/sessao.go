@@ -16,19 +16,21 @@ const (
 	StatusSessaoCancelada StatusSessao = "cancelada"
 )
 
-// Sessao representa uma sessão de terapia
+// Sessao representa uma sessão de terapia. ObservadorSecundarioID identifica, quando definido, o segundo
+// observador que registra dados na sessão para o cálculo de concordância entre observadores (IOA).
 type Sessao struct {
-	ID             uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	PacienteID     uuid.UUID      `gorm:"type:uuid;not null" json:"paciente_id"`
-	TerapeutaID    uuid.UUID      `gorm:"type:uuid;not null" json:"terapeuta_id"`
-	TerapiaID      uuid.UUID      `gorm:"type:uuid;not null" json:"terapia_id"`
-	Data           time.Time      `gorm:"not null" json:"data"`
-	DuracaoMinutos int            `gorm:"not null" json:"duracao_minutos"`
-	Status         StatusSessao   `gorm:"type:varchar(20);not null" json:"status"`
-	ResumoSessao   string         `gorm:"type:text" json:"resumo_sessao"`
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
-	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                     uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PacienteID             uuid.UUID      `gorm:"type:uuid;not null" json:"paciente_id"`
+	TerapeutaID            uuid.UUID      `gorm:"type:uuid;not null" json:"terapeuta_id"`
+	ObservadorSecundarioID *uuid.UUID     `gorm:"type:uuid;index" json:"observador_secundario_id,omitempty"`
+	TerapiaID              uuid.UUID      `gorm:"type:uuid;not null" json:"terapia_id"`
+	Data                   time.Time      `gorm:"not null" json:"data"`
+	DuracaoMinutos         int            `gorm:"not null" json:"duracao_minutos"`
+	Status                 StatusSessao   `gorm:"type:varchar(20);not null" json:"status"`
+	ResumoSessao           string         `gorm:"type:text" json:"resumo_sessao"`
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // TableName especifica o nome da tabela no banco de dados
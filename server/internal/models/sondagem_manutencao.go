@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IntervalosManutencaoPadrao são os intervalos, em dias, entre o domínio de uma habilidade e as sondagens
+// de manutenção sucessivas. Depois do último intervalo, as sondagens seguem com o último intervalo.
+var IntervalosManutencaoPadrao = []int{7, 14, 30}
+
+// PercentualManutencaoPadrao é o percentual mínimo de uma sondagem de manutenção quando a habilidade
+// não possui critério de domínio configurado
+const PercentualManutencaoPadrao = 80.0
+
+// StatusSondagemManutencao representa a situação de uma sondagem de manutenção
+type StatusSondagemManutencao string
+
+const (
+	StatusSondagemManutencaoPendente  StatusSondagemManutencao = "pendente"
+	StatusSondagemManutencaoAprovada  StatusSondagemManutencao = "aprovada"
+	StatusSondagemManutencaoReprovada StatusSondagemManutencao = "reprovada"
+	StatusSondagemManutencaoCancelada StatusSondagemManutencao = "cancelada"
+)
+
+// SondagemManutencao representa uma sondagem de manutenção de uma etapa dominada ou de um alvo em
+// manutenção, agendada para um dia e alocada na primeira sessão planejada do paciente a partir dele.
+// Quando AlvoID é nulo, a sondagem é da etapa. Cada etapa ou alvo tem no máximo uma sondagem pendente.
+type SondagemManutencao struct {
+	ID              uuid.UUID                `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	PacienteID      uuid.UUID                `gorm:"type:uuid;not null;index" json:"paciente_id"`
+	ProgramaID      uuid.UUID                `gorm:"type:uuid;not null" json:"programa_id"`
+	EtapaProgramaID uuid.UUID                `gorm:"type:uuid;not null;index;uniqueIndex:idx_sondagem_manutencao_pendente_etapa,where:status = 'pendente' AND alvo_id IS NULL AND deleted_at IS NULL" json:"etapa_programa_id"`
+	AlvoID          *uuid.UUID               `gorm:"type:uuid;index;uniqueIndex:idx_sondagem_manutencao_pendente_alvo,where:status = 'pendente' AND deleted_at IS NULL" json:"alvo_id,omitempty"`
+	Sequencia       int                      `gorm:"not null" json:"sequencia"`
+	AgendadaPara    time.Time                `gorm:"not null;index" json:"agendada_para"`
+	SessaoID        *uuid.UUID               `gorm:"type:uuid;index" json:"sessao_id,omitempty"`
+	Status          StatusSondagemManutencao `gorm:"type:varchar(20);not null;default:'pendente';index" json:"status"`
+	Tentativas      int                      `json:"tentativas"`
+	Percentual      float64                  `json:"percentual"`
+	RealizadaEm     *time.Time               `json:"realizada_em,omitempty"`
+	CreatedAt       time.Time                `json:"created_at"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt           `gorm:"index" json:"-"`
+}
+
+// TableName especifica o nome da tabela no banco de dados
+func (SondagemManutencao) TableName() string {
+	return "sondagens_manutencao"
+}
+
+// BeforeCreate é um hook do GORM que é executado antes de criar um registro
+func (s *SondagemManutencao) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// MesmaHabilidade indica se a sondagem é da etapa e do alvo informados
+func (s *SondagemManutencao) MesmaHabilidade(etapaID uuid.UUID, alvoID *uuid.UUID) bool {
+	if s.EtapaProgramaID != etapaID {
+		return false
+	}
+	if s.AlvoID == nil || alvoID == nil {
+		return s.AlvoID == nil && alvoID == nil
+	}
+	return *s.AlvoID == *alvoID
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// AlvoEtapaRepository define a interface para operações de repositório do banco de alvos das etapas
+type AlvoEtapaRepository interface {
+	Create(ctx context.Context, alvo *models.AlvoEtapa) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.AlvoEtapa, error)
+	Update(ctx context.Context, alvo *models.AlvoEtapa) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByEtapa(ctx context.Context, etapaID uuid.UUID) ([]*models.AlvoEtapa, error)
+	ListByEtapas(ctx context.Context, etapaIDs []uuid.UUID) ([]*models.AlvoEtapa, error)
+	SaveAll(ctx context.Context, alvos []*models.AlvoEtapa) error
+}
+
+// GormAlvoEtapaRepository implementa AlvoEtapaRepository usando GORM
+type GormAlvoEtapaRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAlvoEtapaRepository cria uma nova instância de GormAlvoEtapaRepository
+func NewGormAlvoEtapaRepository(db *gorm.DB) *GormAlvoEtapaRepository {
+	return &GormAlvoEtapaRepository{db: db}
+}
+
+// Create cria um novo alvo no banco de dados
+func (r *GormAlvoEtapaRepository) Create(ctx context.Context, alvo *models.AlvoEtapa) error {
+	return conexao(ctx, r.db).Create(alvo).Error
+}
+
+// GetByID busca um alvo pelo ID
+func (r *GormAlvoEtapaRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.AlvoEtapa, error) {
+	var alvo models.AlvoEtapa
+	if err := conexao(ctx, r.db).First(&alvo, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &alvo, nil
+}
+
+// Update atualiza um alvo existente
+func (r *GormAlvoEtapaRepository) Update(ctx context.Context, alvo *models.AlvoEtapa) error {
+	return conexao(ctx, r.db).Save(alvo).Error
+}
+
+// Delete exclui um alvo pelo ID (soft delete)
+func (r *GormAlvoEtapaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.AlvoEtapa{}, "id = ?", id).Error
+}
+
+// ListByEtapa retorna os alvos de uma etapa na ordem de introdução
+func (r *GormAlvoEtapaRepository) ListByEtapa(ctx context.Context, etapaID uuid.UUID) ([]*models.AlvoEtapa, error) {
+	var alvos []*models.AlvoEtapa
+	if err := conexao(ctx, r.db).Where("etapa_programa_id = ?", etapaID).Order("ordem").Find(&alvos).Error; err != nil {
+		return nil, err
+	}
+	return alvos, nil
+}
+
+// ListByEtapas retorna os alvos das etapas informadas, agrupados por etapa na ordem de introdução
+func (r *GormAlvoEtapaRepository) ListByEtapas(ctx context.Context, etapaIDs []uuid.UUID) ([]*models.AlvoEtapa, error) {
+	var alvos []*models.AlvoEtapa
+	if len(etapaIDs) == 0 {
+		return alvos, nil
+	}
+	if err := conexao(ctx, r.db).Where("etapa_programa_id IN ?", etapaIDs).Order("etapa_programa_id, ordem").Find(&alvos).Error; err != nil {
+		return nil, err
+	}
+	return alvos, nil
+}
+
+// SaveAll salva os alvos informados em uma única transação
+func (r *GormAlvoEtapaRepository) SaveAll(ctx context.Context, alvos []*models.AlvoEtapa) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, alvo := range alvos {
+			if err := tx.Save(alvo).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// AplicacaoCurriculoRepository define a interface para operações de repositório de aplicações de currículo
+type AplicacaoCurriculoRepository interface {
+	Create(ctx context.Context, aplicacao *models.AplicacaoCurriculo) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.AplicacaoCurriculo, error)
+	Update(ctx context.Context, aplicacao *models.AplicacaoCurriculo) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, curriculoID *uuid.UUID, limit, offset int) ([]*models.AplicacaoCurriculo, error)
+	CountByPaciente(ctx context.Context, pacienteID uuid.UUID, curriculoID *uuid.UUID) (int64, error)
+	ListAnteriores(ctx context.Context, pacienteID, curriculoID uuid.UUID, data time.Time, excluirID uuid.UUID) ([]*models.AplicacaoCurriculo, error)
+	CountByCurriculo(ctx context.Context, curriculoID uuid.UUID) (int64, error)
+	SalvarPontuacoes(ctx context.Context, aplicacaoID uuid.UUID, pontuacoes []models.PontuacaoMarco) error
+}
+
+// GormAplicacaoCurriculoRepository implementa AplicacaoCurriculoRepository usando GORM
+type GormAplicacaoCurriculoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAplicacaoCurriculoRepository cria uma nova instância de GormAplicacaoCurriculoRepository
+func NewGormAplicacaoCurriculoRepository(db *gorm.DB) *GormAplicacaoCurriculoRepository {
+	return &GormAplicacaoCurriculoRepository{db: db}
+}
+
+// Create cria uma nova aplicação de currículo no banco de dados
+func (r *GormAplicacaoCurriculoRepository) Create(ctx context.Context, aplicacao *models.AplicacaoCurriculo) error {
+	return conexao(ctx, r.db).Create(aplicacao).Error
+}
+
+// GetByID busca uma aplicação pelo ID, com as pontuações
+func (r *GormAplicacaoCurriculoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.AplicacaoCurriculo, error) {
+	var aplicacao models.AplicacaoCurriculo
+	if err := conexao(ctx, r.db).Preload("Pontuacoes").First(&aplicacao, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &aplicacao, nil
+}
+
+// Update atualiza os dados de uma aplicação existente, sem alterar as pontuações
+func (r *GormAplicacaoCurriculoRepository) Update(ctx context.Context, aplicacao *models.AplicacaoCurriculo) error {
+	return conexao(ctx, r.db).Omit("Pontuacoes").Save(aplicacao).Error
+}
+
+// Delete exclui uma aplicação pelo ID (soft delete)
+func (r *GormAplicacaoCurriculoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.AplicacaoCurriculo{}, "id = ?", id).Error
+}
+
+// ListByPaciente retorna uma lista paginada das aplicações de um paciente, opcionalmente de um currículo,
+// das mais recentes para as mais antigas e sem as pontuações
+func (r *GormAplicacaoCurriculoRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, curriculoID *uuid.UUID, limit, offset int) ([]*models.AplicacaoCurriculo, error) {
+	var aplicacoes []*models.AplicacaoCurriculo
+	query := r.filtrarCurriculo(conexao(ctx, r.db).Where("paciente_id = ?", pacienteID), curriculoID)
+	if err := query.Order("data DESC").Limit(limit).Offset(offset).Find(&aplicacoes).Error; err != nil {
+		return nil, err
+	}
+	return aplicacoes, nil
+}
+
+// CountByPaciente retorna o número total de aplicações de um paciente, opcionalmente de um currículo
+func (r *GormAplicacaoCurriculoRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID, curriculoID *uuid.UUID) (int64, error) {
+	var count int64
+	query := r.filtrarCurriculo(conexao(ctx, r.db).Model(&models.AplicacaoCurriculo{}).Where("paciente_id = ?", pacienteID), curriculoID)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListAnteriores retorna, com as pontuações, as aplicações do mesmo currículo para o paciente feitas até a
+// data informada, exceto a aplicação excluída, das mais recentes para as mais antigas
+func (r *GormAplicacaoCurriculoRepository) ListAnteriores(ctx context.Context, pacienteID, curriculoID uuid.UUID, data time.Time, excluirID uuid.UUID) ([]*models.AplicacaoCurriculo, error) {
+	var aplicacoes []*models.AplicacaoCurriculo
+	err := conexao(ctx, r.db).
+		Where("paciente_id = ? AND curriculo_id = ? AND data <= ? AND id <> ?", pacienteID, curriculoID, data, excluirID).
+		Preload("Pontuacoes").
+		Order("data DESC, created_at DESC").
+		Find(&aplicacoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return aplicacoes, nil
+}
+
+// CountByCurriculo retorna o número de aplicações de um currículo
+func (r *GormAplicacaoCurriculoRepository) CountByCurriculo(ctx context.Context, curriculoID uuid.UUID) (int64, error) {
+	var count int64
+	if err := conexao(ctx, r.db).Model(&models.AplicacaoCurriculo{}).Where("curriculo_id = ?", curriculoID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SalvarPontuacoes grava as pontuações de uma aplicação, substituindo as pontuações já existentes dos mesmos marcos
+func (r *GormAplicacaoCurriculoRepository) SalvarPontuacoes(ctx context.Context, aplicacaoID uuid.UUID, pontuacoes []models.PontuacaoMarco) error {
+	if len(pontuacoes) == 0 {
+		return nil
+	}
+	marcoIDs := make([]uuid.UUID, 0, len(pontuacoes))
+	for i := range pontuacoes {
+		pontuacoes[i].ID = uuid.Nil
+		pontuacoes[i].AplicacaoID = aplicacaoID
+		marcoIDs = append(marcoIDs, pontuacoes[i].MarcoID)
+	}
+
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("aplicacao_id = ? AND marco_id IN ?", aplicacaoID, marcoIDs).Delete(&models.PontuacaoMarco{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&pontuacoes).Error
+	})
+}
+
+// filtrarCurriculo restringe a consulta às aplicações do currículo informado
+func (r *GormAplicacaoCurriculoRepository) filtrarCurriculo(query *gorm.DB, curriculoID *uuid.UUID) *gorm.DB {
+	if curriculoID != nil {
+		query = query.Where("curriculo_id = ?", *curriculoID)
+	}
+	return query
+}
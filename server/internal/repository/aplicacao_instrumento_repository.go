@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// AplicacaoInstrumentoRepository define a interface para operações de repositório de aplicações de questionários
+type AplicacaoInstrumentoRepository interface {
+	Create(ctx context.Context, aplicacao *models.AplicacaoInstrumento) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.AplicacaoInstrumento, error)
+	RegistrarSeguimento(ctx context.Context, aplicacao *models.AplicacaoInstrumento) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, codigo string, limit, offset int) ([]*models.AplicacaoInstrumento, error)
+	CountByPaciente(ctx context.Context, pacienteID uuid.UUID, codigo string) (int64, error)
+	ListEvolucao(ctx context.Context, pacienteID uuid.UUID, codigo string) ([]*models.AplicacaoInstrumento, error)
+	CountByInstrumento(ctx context.Context, instrumentoID uuid.UUID) (int64, error)
+}
+
+// GormAplicacaoInstrumentoRepository implementa AplicacaoInstrumentoRepository usando GORM
+type GormAplicacaoInstrumentoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAplicacaoInstrumentoRepository cria uma nova instância de GormAplicacaoInstrumentoRepository
+func NewGormAplicacaoInstrumentoRepository(db *gorm.DB) *GormAplicacaoInstrumentoRepository {
+	return &GormAplicacaoInstrumentoRepository{db: db}
+}
+
+// Create cria uma nova aplicação com as respostas e as pontuações das subescalas
+func (r *GormAplicacaoInstrumentoRepository) Create(ctx context.Context, aplicacao *models.AplicacaoInstrumento) error {
+	return conexao(ctx, r.db).Create(aplicacao).Error
+}
+
+// GetByID busca uma aplicação pelo ID, com as respostas e as pontuações das subescalas
+func (r *GormAplicacaoInstrumentoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.AplicacaoInstrumento, error) {
+	var aplicacao models.AplicacaoInstrumento
+	err := conexao(ctx, r.db).
+		Preload("Respostas", func(db *gorm.DB) *gorm.DB { return db.Order("numero") }).
+		Preload("Subescalas").
+		First(&aplicacao, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &aplicacao, nil
+}
+
+// RegistrarSeguimento grava o resultado da entrevista de seguimento: os dados da aplicação e o resultado
+// de cada item entrevistado
+func (r *GormAplicacaoInstrumentoRepository) RegistrarSeguimento(ctx context.Context, aplicacao *models.AplicacaoInstrumento) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Respostas", "Subescalas").Save(aplicacao).Error; err != nil {
+			return err
+		}
+		for _, resposta := range aplicacao.Respostas {
+			if resposta.SeguimentoAprovado == nil {
+				continue
+			}
+			err := tx.Model(&models.RespostaAplicacaoInstrumento{}).
+				Where("id = ?", resposta.ID).
+				Update("seguimento_aprovado", *resposta.SeguimentoAprovado).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete exclui uma aplicação pelo ID (soft delete)
+func (r *GormAplicacaoInstrumentoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.AplicacaoInstrumento{}, "id = ?", id).Error
+}
+
+// ListByPaciente retorna uma lista paginada das aplicações de um paciente, opcionalmente de um instrumento,
+// das mais recentes para as mais antigas, com as pontuações das subescalas e sem as respostas
+func (r *GormAplicacaoInstrumentoRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, codigo string, limit, offset int) ([]*models.AplicacaoInstrumento, error) {
+	var aplicacoes []*models.AplicacaoInstrumento
+	query := r.filtrarCodigo(conexao(ctx, r.db).Where("paciente_id = ?", pacienteID), codigo)
+	if err := query.Preload("Subescalas").Order("data DESC").Limit(limit).Offset(offset).Find(&aplicacoes).Error; err != nil {
+		return nil, err
+	}
+	return aplicacoes, nil
+}
+
+// CountByPaciente retorna o número total de aplicações de um paciente, opcionalmente de um instrumento
+func (r *GormAplicacaoInstrumentoRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID, codigo string) (int64, error) {
+	var count int64
+	query := r.filtrarCodigo(conexao(ctx, r.db).Model(&models.AplicacaoInstrumento{}).Where("paciente_id = ?", pacienteID), codigo)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListEvolucao retorna todas as aplicações de um instrumento para o paciente em ordem cronológica, com as
+// pontuações das subescalas
+func (r *GormAplicacaoInstrumentoRepository) ListEvolucao(ctx context.Context, pacienteID uuid.UUID, codigo string) ([]*models.AplicacaoInstrumento, error) {
+	var aplicacoes []*models.AplicacaoInstrumento
+	err := conexao(ctx, r.db).
+		Where("paciente_id = ? AND codigo = ?", pacienteID, codigo).
+		Preload("Subescalas").
+		Order("data, created_at").
+		Find(&aplicacoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return aplicacoes, nil
+}
+
+// CountByInstrumento retorna o número de aplicações de uma versão de instrumento
+func (r *GormAplicacaoInstrumentoRepository) CountByInstrumento(ctx context.Context, instrumentoID uuid.UUID) (int64, error) {
+	var count int64
+	if err := conexao(ctx, r.db).Model(&models.AplicacaoInstrumento{}).Where("instrumento_id = ?", instrumentoID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// filtrarCodigo restringe a consulta às aplicações do instrumento com o código informado
+func (r *GormAplicacaoInstrumentoRepository) filtrarCodigo(query *gorm.DB, codigo string) *gorm.DB {
+	if codigo != "" {
+		query = query.Where("codigo = ?", codigo)
+	}
+	return query
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// AvaliacaoFidelidadeRepository define a interface para operações de repositório de avaliações de fidelidade
+type AvaliacaoFidelidadeRepository interface {
+	Create(ctx context.Context, avaliacao *models.AvaliacaoFidelidade) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.AvaliacaoFidelidade, error)
+	Update(ctx context.Context, avaliacao *models.AvaliacaoFidelidade) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.AvaliacaoFidelidade, error)
+	ListByTerapeuta(ctx context.Context, terapeutaID uuid.UUID, inicio, fim *time.Time) ([]*models.AvaliacaoFidelidade, error)
+	ListByPrograma(ctx context.Context, programaID uuid.UUID, inicio, fim *time.Time) ([]*models.AvaliacaoFidelidade, error)
+	ListSinalizadas(ctx context.Context, inicio, fim *time.Time, limit, offset int) ([]*models.AvaliacaoFidelidade, error)
+	CountSinalizadas(ctx context.Context, inicio, fim *time.Time) (int64, error)
+}
+
+// GormAvaliacaoFidelidadeRepository implementa AvaliacaoFidelidadeRepository usando GORM
+type GormAvaliacaoFidelidadeRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAvaliacaoFidelidadeRepository cria uma nova instância de GormAvaliacaoFidelidadeRepository
+func NewGormAvaliacaoFidelidadeRepository(db *gorm.DB) *GormAvaliacaoFidelidadeRepository {
+	return &GormAvaliacaoFidelidadeRepository{db: db}
+}
+
+// Create cria uma nova avaliação de fidelidade com as suas respostas
+func (r *GormAvaliacaoFidelidadeRepository) Create(ctx context.Context, avaliacao *models.AvaliacaoFidelidade) error {
+	return conexao(ctx, r.db).Create(avaliacao).Error
+}
+
+// GetByID busca uma avaliação de fidelidade pelo ID, com as respostas em ordem
+func (r *GormAvaliacaoFidelidadeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.AvaliacaoFidelidade, error) {
+	var avaliacao models.AvaliacaoFidelidade
+	err := conexao(ctx, r.db).
+		Preload("Respostas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		First(&avaliacao, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &avaliacao, nil
+}
+
+// Update atualiza uma avaliação existente, substituindo todas as suas respostas
+func (r *GormAvaliacaoFidelidadeRepository) Update(ctx context.Context, avaliacao *models.AvaliacaoFidelidade) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("avaliacao_id = ?", avaliacao.ID).Delete(&models.RespostaFidelidade{}).Error; err != nil {
+			return err
+		}
+
+		respostas := avaliacao.Respostas
+		avaliacao.Respostas = nil
+		if err := tx.Save(avaliacao).Error; err != nil {
+			return err
+		}
+		for i := range respostas {
+			respostas[i].ID = uuid.Nil
+			respostas[i].AvaliacaoID = avaliacao.ID
+		}
+		if len(respostas) > 0 {
+			if err := tx.Create(&respostas).Error; err != nil {
+				return err
+			}
+		}
+		avaliacao.Respostas = respostas
+		return nil
+	})
+}
+
+// Delete exclui uma avaliação de fidelidade pelo ID (soft delete)
+func (r *GormAvaliacaoFidelidadeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.AvaliacaoFidelidade{}, "id = ?", id).Error
+}
+
+// ListBySessao retorna as avaliações de fidelidade de uma sessão, com as respostas
+func (r *GormAvaliacaoFidelidadeRepository) ListBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.AvaliacaoFidelidade, error) {
+	var avaliacoes []*models.AvaliacaoFidelidade
+	err := conexao(ctx, r.db).
+		Where("sessao_id = ?", sessaoID).
+		Preload("Respostas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Order("created_at").
+		Find(&avaliacoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return avaliacoes, nil
+}
+
+// ListByTerapeuta retorna as avaliações de um terapeuta com data em [inicio, fim), em ordem cronológica
+func (r *GormAvaliacaoFidelidadeRepository) ListByTerapeuta(ctx context.Context, terapeutaID uuid.UUID, inicio, fim *time.Time) ([]*models.AvaliacaoFidelidade, error) {
+	var avaliacoes []*models.AvaliacaoFidelidade
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Where("terapeuta_id = ?", terapeutaID), inicio, fim)
+	if err := query.Order("data").Find(&avaliacoes).Error; err != nil {
+		return nil, err
+	}
+	return avaliacoes, nil
+}
+
+// ListByPrograma retorna as avaliações de um programa com data em [inicio, fim), em ordem cronológica
+func (r *GormAvaliacaoFidelidadeRepository) ListByPrograma(ctx context.Context, programaID uuid.UUID, inicio, fim *time.Time) ([]*models.AvaliacaoFidelidade, error) {
+	var avaliacoes []*models.AvaliacaoFidelidade
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Where("programa_id = ?", programaID), inicio, fim)
+	if err := query.Order("data").Find(&avaliacoes).Error; err != nil {
+		return nil, err
+	}
+	return avaliacoes, nil
+}
+
+// ListSinalizadas retorna uma lista paginada das avaliações abaixo do limiar, das mais recentes para as mais antigas
+func (r *GormAvaliacaoFidelidadeRepository) ListSinalizadas(ctx context.Context, inicio, fim *time.Time, limit, offset int) ([]*models.AvaliacaoFidelidade, error) {
+	var avaliacoes []*models.AvaliacaoFidelidade
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Where("abaixo_do_limiar = ?", true), inicio, fim)
+	if err := query.Order("data DESC").Limit(limit).Offset(offset).Find(&avaliacoes).Error; err != nil {
+		return nil, err
+	}
+	return avaliacoes, nil
+}
+
+// CountSinalizadas retorna o número total de avaliações abaixo do limiar
+func (r *GormAvaliacaoFidelidadeRepository) CountSinalizadas(ctx context.Context, inicio, fim *time.Time) (int64, error) {
+	var count int64
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Model(&models.AvaliacaoFidelidade{}).Where("abaixo_do_limiar = ?", true), inicio, fim)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// filtrarPeriodo restringe a consulta às avaliações com data em [inicio, fim)
+func (r *GormAvaliacaoFidelidadeRepository) filtrarPeriodo(query *gorm.DB, inicio, fim *time.Time) *gorm.DB {
+	if inicio != nil {
+		query = query.Where("data >= ?", *inicio)
+	}
+	if fim != nil {
+		query = query.Where("data < ?", *fim)
+	}
+	return query
+}
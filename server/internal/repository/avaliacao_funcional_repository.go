@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"msd-service/server/internal/models"
+)
+
+// AvaliacaoFuncionalRepository define a interface para operações de repositório de avaliações funcionais
+type AvaliacaoFuncionalRepository interface {
+	Create(ctx context.Context, avaliacao *models.AvaliacaoFuncional) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.AvaliacaoFuncional, error)
+	Update(ctx context.Context, avaliacao *models.AvaliacaoFuncional) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, status *models.StatusAvaliacaoFuncional, limit, offset int) ([]*models.AvaliacaoFuncional, error)
+	CountByPaciente(ctx context.Context, pacienteID uuid.UUID, status *models.StatusAvaliacaoFuncional) (int64, error)
+	ListByComportamento(ctx context.Context, comportamentoID uuid.UUID) ([]*models.AvaliacaoFuncional, error)
+	LockStatus(ctx context.Context, id uuid.UUID) (models.StatusAvaliacaoFuncional, error)
+}
+
+// GormAvaliacaoFuncionalRepository implementa AvaliacaoFuncionalRepository usando GORM
+type GormAvaliacaoFuncionalRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAvaliacaoFuncionalRepository cria uma nova instância de GormAvaliacaoFuncionalRepository
+func NewGormAvaliacaoFuncionalRepository(db *gorm.DB) *GormAvaliacaoFuncionalRepository {
+	return &GormAvaliacaoFuncionalRepository{db: db}
+}
+
+// Create cria uma nova avaliação funcional com os seus comportamentos e respostas
+func (r *GormAvaliacaoFuncionalRepository) Create(ctx context.Context, avaliacao *models.AvaliacaoFuncional) error {
+	return conexao(ctx, r.db).Create(avaliacao).Error
+}
+
+// GetByID busca uma avaliação funcional pelo ID, com os comportamentos, as respostas e a cópia dos dados ABC em ordem
+func (r *GormAvaliacaoFuncionalRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.AvaliacaoFuncional, error) {
+	var avaliacao models.AvaliacaoFuncional
+	err := conexao(ctx, r.db).
+		Preload("Comportamentos", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Respostas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("CopiaDadosABC", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("CopiaDadosABC.Categorias", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("CopiaDadosABC.Funcoes", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		First(&avaliacao, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &avaliacao, nil
+}
+
+// Update atualiza uma avaliação funcional existente, substituindo todos os seus comportamentos, respostas
+// e a cópia dos dados ABC
+func (r *GormAvaliacaoFuncionalRepository) Update(ctx context.Context, avaliacao *models.AvaliacaoFuncional) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("avaliacao_id = ?", avaliacao.ID).Delete(&models.ComportamentoAvaliacaoFuncional{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("avaliacao_id = ?", avaliacao.ID).Delete(&models.RespostaAvaliacaoFuncional{}).Error; err != nil {
+			return err
+		}
+		copias := tx.Model(&models.DadosABCAvaliacaoFuncional{}).Select("id").Where("avaliacao_id = ?", avaliacao.ID)
+		if err := tx.Where("dados_id IN (?)", copias).Delete(&models.CategoriaDadosABC{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("dados_id IN (?)", copias).Delete(&models.FuncaoDadosABC{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("avaliacao_id = ?", avaliacao.ID).Delete(&models.DadosABCAvaliacaoFuncional{}).Error; err != nil {
+			return err
+		}
+
+		comportamentos, respostas, dadosABC := avaliacao.Comportamentos, avaliacao.Respostas, avaliacao.CopiaDadosABC
+		avaliacao.Comportamentos, avaliacao.Respostas, avaliacao.CopiaDadosABC = nil, nil, nil
+		if err := tx.Save(avaliacao).Error; err != nil {
+			return err
+		}
+		for i := range comportamentos {
+			comportamentos[i].ID = uuid.Nil
+			comportamentos[i].AvaliacaoID = avaliacao.ID
+		}
+		for i := range respostas {
+			respostas[i].ID = uuid.Nil
+			respostas[i].AvaliacaoID = avaliacao.ID
+		}
+		if len(comportamentos) > 0 {
+			if err := tx.Create(&comportamentos).Error; err != nil {
+				return err
+			}
+		}
+		if len(respostas) > 0 {
+			if err := tx.Create(&respostas).Error; err != nil {
+				return err
+			}
+		}
+		for i := range dadosABC {
+			dadosABC[i].ID = uuid.Nil
+			dadosABC[i].AvaliacaoID = avaliacao.ID
+			for j := range dadosABC[i].Categorias {
+				dadosABC[i].Categorias[j].ID = uuid.Nil
+			}
+			for j := range dadosABC[i].Funcoes {
+				dadosABC[i].Funcoes[j].ID = uuid.Nil
+			}
+		}
+		if len(dadosABC) > 0 {
+			if err := tx.Create(&dadosABC).Error; err != nil {
+				return err
+			}
+		}
+		avaliacao.Comportamentos, avaliacao.Respostas, avaliacao.CopiaDadosABC = comportamentos, respostas, dadosABC
+		return nil
+	})
+}
+
+// Delete exclui uma avaliação funcional pelo ID (soft delete)
+func (r *GormAvaliacaoFuncionalRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.AvaliacaoFuncional{}, "id = ?", id).Error
+}
+
+// ListByPaciente retorna uma lista paginada das avaliações funcionais de um paciente, opcionalmente
+// filtradas pela situação, das mais recentes para as mais antigas
+func (r *GormAvaliacaoFuncionalRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, status *models.StatusAvaliacaoFuncional, limit, offset int) ([]*models.AvaliacaoFuncional, error) {
+	var avaliacoes []*models.AvaliacaoFuncional
+	query := r.filtrarStatus(conexao(ctx, r.db).Where("paciente_id = ?", pacienteID), status)
+	err := query.
+		Preload("Comportamentos", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&avaliacoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return avaliacoes, nil
+}
+
+// CountByPaciente retorna o número total de avaliações funcionais de um paciente
+func (r *GormAvaliacaoFuncionalRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID, status *models.StatusAvaliacaoFuncional) (int64, error) {
+	var count int64
+	query := r.filtrarStatus(conexao(ctx, r.db).Model(&models.AvaliacaoFuncional{}).Where("paciente_id = ?", pacienteID), status)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListByComportamento retorna as avaliações funcionais que incluem o comportamento, das mais recentes
+// para as mais antigas
+func (r *GormAvaliacaoFuncionalRepository) ListByComportamento(ctx context.Context, comportamentoID uuid.UUID) ([]*models.AvaliacaoFuncional, error) {
+	var avaliacoes []*models.AvaliacaoFuncional
+	err := conexao(ctx, r.db).
+		Where("id IN (?)", r.db.Model(&models.ComportamentoAvaliacaoFuncional{}).Select("avaliacao_id").Where("comportamento_id = ?", comportamentoID)).
+		Preload("Comportamentos", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Order("created_at DESC").
+		Find(&avaliacoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return avaliacoes, nil
+}
+
+// filtrarStatus restringe a consulta às avaliações na situação informada
+func (r *GormAvaliacaoFuncionalRepository) filtrarStatus(query *gorm.DB, status *models.StatusAvaliacaoFuncional) *gorm.DB {
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	return query
+}
+
+// LockStatus bloqueia a avaliação para mudanças de situação concorrentes e retorna a situação gravada.
+// Só tem efeito quando chamado dentro de uma transação do Transactor.
+func (r *GormAvaliacaoFuncionalRepository) LockStatus(ctx context.Context, id uuid.UUID) (models.StatusAvaliacaoFuncional, error) {
+	var avaliacao models.AvaliacaoFuncional
+	err := conexao(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "status").
+		First(&avaliacao, "id = ?", id).Error
+	return avaliacao.Status, err
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// AvaliacaoPreferenciaRepository define a interface para operações de repositório de avaliações de preferência
+type AvaliacaoPreferenciaRepository interface {
+	Create(ctx context.Context, avaliacao *models.AvaliacaoPreferencia) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.AvaliacaoPreferencia, error)
+	Update(ctx context.Context, avaliacao *models.AvaliacaoPreferencia) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time, limit, offset int) ([]*models.AvaliacaoPreferencia, error)
+	ListAllByPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time) ([]*models.AvaliacaoPreferencia, error)
+	CountByPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time) (int64, error)
+}
+
+// GormAvaliacaoPreferenciaRepository implementa AvaliacaoPreferenciaRepository usando GORM
+type GormAvaliacaoPreferenciaRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAvaliacaoPreferenciaRepository cria uma nova instância de GormAvaliacaoPreferenciaRepository
+func NewGormAvaliacaoPreferenciaRepository(db *gorm.DB) *GormAvaliacaoPreferenciaRepository {
+	return &GormAvaliacaoPreferenciaRepository{db: db}
+}
+
+// Create cria uma nova avaliação de preferência com os seus itens e tentativas
+func (r *GormAvaliacaoPreferenciaRepository) Create(ctx context.Context, avaliacao *models.AvaliacaoPreferencia) error {
+	return conexao(ctx, r.db).Create(avaliacao).Error
+}
+
+// GetByID busca uma avaliação de preferência pelo ID, com os itens e as tentativas em ordem
+func (r *GormAvaliacaoPreferenciaRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.AvaliacaoPreferencia, error) {
+	var avaliacao models.AvaliacaoPreferencia
+	err := conexao(ctx, r.db).
+		Preload("Itens", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Tentativas", func(db *gorm.DB) *gorm.DB { return db.Order("numero") }).
+		First(&avaliacao, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &avaliacao, nil
+}
+
+// Update atualiza uma avaliação existente, substituindo todos os seus itens e tentativas.
+// Os IDs dos itens são preservados, pois são referenciados pelas tentativas.
+func (r *GormAvaliacaoPreferenciaRepository) Update(ctx context.Context, avaliacao *models.AvaliacaoPreferencia) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("avaliacao_id = ?", avaliacao.ID).Delete(&models.TentativaPreferencia{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("avaliacao_id = ?", avaliacao.ID).Delete(&models.ItemPreferencia{}).Error; err != nil {
+			return err
+		}
+
+		itens, tentativas := avaliacao.Itens, avaliacao.Tentativas
+		avaliacao.Itens, avaliacao.Tentativas = nil, nil
+		if err := tx.Save(avaliacao).Error; err != nil {
+			return err
+		}
+		for i := range itens {
+			itens[i].AvaliacaoID = avaliacao.ID
+		}
+		for i := range tentativas {
+			tentativas[i].ID = uuid.Nil
+			tentativas[i].AvaliacaoID = avaliacao.ID
+		}
+		if len(itens) > 0 {
+			if err := tx.Create(&itens).Error; err != nil {
+				return err
+			}
+		}
+		if len(tentativas) > 0 {
+			if err := tx.Create(&tentativas).Error; err != nil {
+				return err
+			}
+		}
+		avaliacao.Itens, avaliacao.Tentativas = itens, tentativas
+		return nil
+	})
+}
+
+// Delete exclui uma avaliação de preferência pelo ID (soft delete)
+func (r *GormAvaliacaoPreferenciaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.AvaliacaoPreferencia{}, "id = ?", id).Error
+}
+
+// ListByPaciente retorna uma lista paginada das avaliações de um paciente, das mais recentes para as
+// mais antigas, com os itens
+func (r *GormAvaliacaoPreferenciaRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time, limit, offset int) ([]*models.AvaliacaoPreferencia, error) {
+	var avaliacoes []*models.AvaliacaoPreferencia
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Where("paciente_id = ?", pacienteID), inicio, fim)
+	err := query.
+		Preload("Itens", func(db *gorm.DB) *gorm.DB { return db.Order("posicao, ordem") }).
+		Order("data DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&avaliacoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return avaliacoes, nil
+}
+
+// ListAllByPaciente retorna todas as avaliações do paciente no intervalo, com os itens, em ordem cronológica
+func (r *GormAvaliacaoPreferenciaRepository) ListAllByPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time) ([]*models.AvaliacaoPreferencia, error) {
+	var avaliacoes []*models.AvaliacaoPreferencia
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Where("paciente_id = ?", pacienteID), inicio, fim)
+	err := query.
+		Preload("Itens", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Order("data").
+		Find(&avaliacoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return avaliacoes, nil
+}
+
+// CountByPaciente retorna o número total de avaliações do paciente no intervalo
+func (r *GormAvaliacaoPreferenciaRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time) (int64, error) {
+	var count int64
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Model(&models.AvaliacaoPreferencia{}).Where("paciente_id = ?", pacienteID), inicio, fim)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// filtrarPeriodo restringe a consulta às avaliações realizadas em [inicio, fim)
+func (r *GormAvaliacaoPreferenciaRepository) filtrarPeriodo(query *gorm.DB, inicio, fim *time.Time) *gorm.DB {
+	if inicio != nil {
+		query = query.Where("data >= ?", *inicio)
+	}
+	if fim != nil {
+		query = query.Where("data < ?", *fim)
+	}
+	return query
+}
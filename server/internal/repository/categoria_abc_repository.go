@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// CategoriaABCRepository define a interface para operações de repositório da taxonomia ABC
+type CategoriaABCRepository interface {
+	Create(ctx context.Context, categoria *models.CategoriaABC) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CategoriaABC, error)
+	Update(ctx context.Context, categoria *models.CategoriaABC) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, tipo *models.TipoCategoriaABC, apenasAtivas bool) ([]*models.CategoriaABC, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.CategoriaABC, error)
+}
+
+// GormCategoriaABCRepository implementa CategoriaABCRepository usando GORM
+type GormCategoriaABCRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCategoriaABCRepository cria uma nova instância de GormCategoriaABCRepository
+func NewGormCategoriaABCRepository(db *gorm.DB) *GormCategoriaABCRepository {
+	return &GormCategoriaABCRepository{db: db}
+}
+
+// Create cria uma nova categoria no banco de dados
+func (r *GormCategoriaABCRepository) Create(ctx context.Context, categoria *models.CategoriaABC) error {
+	return conexao(ctx, r.db).Create(categoria).Error
+}
+
+// GetByID busca uma categoria pelo ID
+func (r *GormCategoriaABCRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CategoriaABC, error) {
+	var categoria models.CategoriaABC
+	if err := conexao(ctx, r.db).First(&categoria, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &categoria, nil
+}
+
+// Update atualiza uma categoria existente
+func (r *GormCategoriaABCRepository) Update(ctx context.Context, categoria *models.CategoriaABC) error {
+	return conexao(ctx, r.db).Save(categoria).Error
+}
+
+// Delete exclui uma categoria pelo ID (soft delete)
+func (r *GormCategoriaABCRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.CategoriaABC{}, "id = ?", id).Error
+}
+
+// List retorna as categorias da taxonomia, opcionalmente filtradas por tipo e apenas as ativas,
+// ordenadas por tipo e nome
+func (r *GormCategoriaABCRepository) List(ctx context.Context, tipo *models.TipoCategoriaABC, apenasAtivas bool) ([]*models.CategoriaABC, error) {
+	var categorias []*models.CategoriaABC
+	query := conexao(ctx, r.db)
+	if tipo != nil {
+		query = query.Where("tipo = ?", *tipo)
+	}
+	if apenasAtivas {
+		query = query.Where("ativa = ?", true)
+	}
+	if err := query.Order("tipo, nome").Find(&categorias).Error; err != nil {
+		return nil, err
+	}
+	return categorias, nil
+}
+
+// ListByIDs retorna as categorias informadas, incluindo as excluídas, para que registros antigos
+// continuem identificados nos relatórios
+func (r *GormCategoriaABCRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.CategoriaABC, error) {
+	var categorias []*models.CategoriaABC
+	if len(ids) == 0 {
+		return categorias, nil
+	}
+	if err := conexao(ctx, r.db).Unscoped().Where("id IN ?", ids).Find(&categorias).Error; err != nil {
+		return nil, err
+	}
+	return categorias, nil
+}
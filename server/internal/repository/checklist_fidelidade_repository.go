@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// ChecklistFidelidadeRepository define a interface para operações de repositório de listas de verificação de fidelidade
+type ChecklistFidelidadeRepository interface {
+	Create(ctx context.Context, checklist *models.ChecklistFidelidade) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ChecklistFidelidade, error)
+	Update(ctx context.Context, checklist *models.ChecklistFidelidade, itens []models.ItemChecklistFidelidade) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.ChecklistFidelidade, error)
+}
+
+// GormChecklistFidelidadeRepository implementa ChecklistFidelidadeRepository usando GORM
+type GormChecklistFidelidadeRepository struct {
+	db *gorm.DB
+}
+
+// NewGormChecklistFidelidadeRepository cria uma nova instância de GormChecklistFidelidadeRepository
+func NewGormChecklistFidelidadeRepository(db *gorm.DB) *GormChecklistFidelidadeRepository {
+	return &GormChecklistFidelidadeRepository{db: db}
+}
+
+// Create cria uma nova lista de verificação com os seus itens
+func (r *GormChecklistFidelidadeRepository) Create(ctx context.Context, checklist *models.ChecklistFidelidade) error {
+	return conexao(ctx, r.db).Create(checklist).Error
+}
+
+// GetByID busca uma lista de verificação pelo ID, com os itens em ordem
+func (r *GormChecklistFidelidadeRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChecklistFidelidade, error) {
+	var checklist models.ChecklistFidelidade
+	err := conexao(ctx, r.db).
+		Preload("Itens", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		First(&checklist, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &checklist, nil
+}
+
+// Update atualiza uma lista de verificação. Quando itens não é nil, os itens anteriores são substituídos.
+func (r *GormChecklistFidelidadeRepository) Update(ctx context.Context, checklist *models.ChecklistFidelidade, itens []models.ItemChecklistFidelidade) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Itens").Save(checklist).Error; err != nil {
+			return err
+		}
+		if itens == nil {
+			return nil
+		}
+
+		if err := tx.Where("checklist_id = ?", checklist.ID).Delete(&models.ItemChecklistFidelidade{}).Error; err != nil {
+			return err
+		}
+		for i := range itens {
+			itens[i].ID = uuid.Nil
+			itens[i].ChecklistID = checklist.ID
+		}
+		if err := tx.Create(&itens).Error; err != nil {
+			return err
+		}
+		checklist.Itens = itens
+		return nil
+	})
+}
+
+// Delete exclui uma lista de verificação pelo ID (soft delete)
+func (r *GormChecklistFidelidadeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.ChecklistFidelidade{}, "id = ?", id).Error
+}
+
+// ListByPrograma retorna as listas de verificação de um programa, com os itens em ordem
+func (r *GormChecklistFidelidadeRepository) ListByPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.ChecklistFidelidade, error) {
+	var checklists []*models.ChecklistFidelidade
+	err := conexao(ctx, r.db).
+		Where("programa_id = ?", programaID).
+		Preload("Itens", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Order("created_at").
+		Find(&checklists).Error
+	if err != nil {
+		return nil, err
+	}
+	return checklists, nil
+}
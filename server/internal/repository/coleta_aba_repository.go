@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// ColetaABARepository define a interface para operações de repositório de coletas ABA.
+// As listagens e contagens consideram apenas as coletas do observador principal da sessão.
+type ColetaABARepository interface {
+	Create(ctx context.Context, coleta *models.ColetaABA) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ColetaABA, error)
+	Update(ctx context.Context, coleta *models.ColetaABA) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListBySessao(ctx context.Context, sessaoID uuid.UUID, limit, offset int) ([]*models.ColetaABA, error)
+	ListBySessaoAndEtapa(ctx context.Context, sessaoID, etapaID uuid.UUID, limit, offset int) ([]*models.ColetaABA, error)
+	ListAllBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.ColetaABA, error)
+	ListByEtapa(ctx context.Context, etapaID uuid.UUID) ([]*models.ColetaABA, error)
+	ListByAlvo(ctx context.Context, alvoID uuid.UUID) ([]*models.ColetaABA, error)
+	ListTreinoByEtapa(ctx context.Context, etapaID uuid.UUID, desde *time.Time) ([]*models.ColetaABA, error)
+	ListTreinoByAlvo(ctx context.Context, alvoID uuid.UUID, desde *time.Time) ([]*models.ColetaABA, error)
+	ListBySondagemManutencao(ctx context.Context, sondagemID uuid.UUID) ([]*models.ColetaABA, error)
+	CountBySessao(ctx context.Context, sessaoID uuid.UUID) (int64, error)
+	CountBySessaoAndEtapa(ctx context.Context, sessaoID, etapaID uuid.UUID) (int64, error)
+	ListObservadoresBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.ColetaABA, error)
+}
+
+// GormColetaABARepository implementa ColetaABARepository usando GORM
+type GormColetaABARepository struct {
+	db *gorm.DB
+}
+
+// NewGormColetaABARepository cria uma nova instância de GormColetaABARepository
+func NewGormColetaABARepository(db *gorm.DB) *GormColetaABARepository {
+	return &GormColetaABARepository{db: db}
+}
+
+// Create cria uma nova coleta ABA no banco de dados
+func (r *GormColetaABARepository) Create(ctx context.Context, coleta *models.ColetaABA) error {
+	return conexao(ctx, r.db).Create(coleta).Error
+}
+
+// GetByID busca uma coleta ABA pelo ID
+func (r *GormColetaABARepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ColetaABA, error) {
+	var coleta models.ColetaABA
+	if err := conexao(ctx, r.db).First(&coleta, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &coleta, nil
+}
+
+// Update atualiza uma coleta ABA existente
+func (r *GormColetaABARepository) Update(ctx context.Context, coleta *models.ColetaABA) error {
+	return conexao(ctx, r.db).Save(coleta).Error
+}
+
+// Delete exclui uma coleta ABA pelo ID (soft delete)
+func (r *GormColetaABARepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.ColetaABA{}, "id = ?", id).Error
+}
+
+// ListBySessao retorna uma lista paginada de coletas ABA de uma sessão, na ordem em que foram registradas
+func (r *GormColetaABARepository) ListBySessao(ctx context.Context, sessaoID uuid.UUID, limit, offset int) ([]*models.ColetaABA, error) {
+	var coletas []*models.ColetaABA
+	if err := conexao(ctx, r.db).Where("sessao_id = ? AND secundario = ?", sessaoID, false).Order("created_at").Limit(limit).Offset(offset).Find(&coletas).Error; err != nil {
+		return nil, err
+	}
+	return coletas, nil
+}
+
+// ListBySessaoAndEtapa retorna uma lista paginada de coletas ABA de uma etapa dentro de uma sessão
+func (r *GormColetaABARepository) ListBySessaoAndEtapa(ctx context.Context, sessaoID, etapaID uuid.UUID, limit, offset int) ([]*models.ColetaABA, error) {
+	var coletas []*models.ColetaABA
+	if err := conexao(ctx, r.db).Where("sessao_id = ? AND etapa_programa_id = ? AND secundario = ?", sessaoID, etapaID, false).Order("created_at").Limit(limit).Offset(offset).Find(&coletas).Error; err != nil {
+		return nil, err
+	}
+	return coletas, nil
+}
+
+// ListAllBySessao retorna todas as coletas ABA de uma sessão, sem paginação
+func (r *GormColetaABARepository) ListAllBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.ColetaABA, error) {
+	var coletas []*models.ColetaABA
+	if err := conexao(ctx, r.db).Where("sessao_id = ? AND secundario = ?", sessaoID, false).Order("created_at").Find(&coletas).Error; err != nil {
+		return nil, err
+	}
+	return coletas, nil
+}
+
+// ListByEtapa retorna todas as coletas ABA de uma etapa, em todas as sessões
+func (r *GormColetaABARepository) ListByEtapa(ctx context.Context, etapaID uuid.UUID) ([]*models.ColetaABA, error) {
+	var coletas []*models.ColetaABA
+	if err := conexao(ctx, r.db).Where("etapa_programa_id = ? AND secundario = ?", etapaID, false).Order("created_at").Find(&coletas).Error; err != nil {
+		return nil, err
+	}
+	return coletas, nil
+}
+
+// ListByAlvo retorna todas as coletas ABA de um alvo, em ordem cronológica
+func (r *GormColetaABARepository) ListByAlvo(ctx context.Context, alvoID uuid.UUID) ([]*models.ColetaABA, error) {
+	var coletas []*models.ColetaABA
+	if err := conexao(ctx, r.db).Where("alvo_id = ? AND secundario = ?", alvoID, false).Order("created_at").Find(&coletas).Error; err != nil {
+		return nil, err
+	}
+	return coletas, nil
+}
+
+// ListTreinoByEtapa retorna as coletas ABA de treino de uma etapa, em ordem cronológica: as coletas de
+// sondagens de manutenção ficam de fora e, quando desde é informado, apenas as coletas posteriores entram
+func (r *GormColetaABARepository) ListTreinoByEtapa(ctx context.Context, etapaID uuid.UUID, desde *time.Time) ([]*models.ColetaABA, error) {
+	var coletas []*models.ColetaABA
+	query := conexao(ctx, r.db).Where("etapa_programa_id = ? AND secundario = ? AND sondagem_manutencao_id IS NULL", etapaID, false)
+	if desde != nil {
+		query = query.Where("created_at > ?", *desde)
+	}
+	if err := query.Order("created_at").Find(&coletas).Error; err != nil {
+		return nil, err
+	}
+	return coletas, nil
+}
+
+// ListTreinoByAlvo retorna as coletas ABA de treino de um alvo, em ordem cronológica: as coletas de
+// sondagens de manutenção ficam de fora e, quando desde é informado, apenas as coletas posteriores entram
+func (r *GormColetaABARepository) ListTreinoByAlvo(ctx context.Context, alvoID uuid.UUID, desde *time.Time) ([]*models.ColetaABA, error) {
+	var coletas []*models.ColetaABA
+	query := conexao(ctx, r.db).Where("alvo_id = ? AND secundario = ? AND sondagem_manutencao_id IS NULL", alvoID, false)
+	if desde != nil {
+		query = query.Where("created_at > ?", *desde)
+	}
+	if err := query.Order("created_at").Find(&coletas).Error; err != nil {
+		return nil, err
+	}
+	return coletas, nil
+}
+
+// ListBySondagemManutencao retorna todas as coletas ABA que respondem a uma sondagem de manutenção, em ordem cronológica
+func (r *GormColetaABARepository) ListBySondagemManutencao(ctx context.Context, sondagemID uuid.UUID) ([]*models.ColetaABA, error) {
+	var coletas []*models.ColetaABA
+	if err := conexao(ctx, r.db).Where("sondagem_manutencao_id = ? AND secundario = ?", sondagemID, false).Order("created_at").Find(&coletas).Error; err != nil {
+		return nil, err
+	}
+	return coletas, nil
+}
+
+// CountBySessao retorna o número total de coletas ABA de uma sessão
+func (r *GormColetaABARepository) CountBySessao(ctx context.Context, sessaoID uuid.UUID) (int64, error) {
+	var count int64
+	if err := conexao(ctx, r.db).Model(&models.ColetaABA{}).Where("sessao_id = ? AND secundario = ?", sessaoID, false).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountBySessaoAndEtapa retorna o número total de coletas ABA de uma etapa dentro de uma sessão
+func (r *GormColetaABARepository) CountBySessaoAndEtapa(ctx context.Context, sessaoID, etapaID uuid.UUID) (int64, error) {
+	var count int64
+	if err := conexao(ctx, r.db).Model(&models.ColetaABA{}).Where("sessao_id = ? AND etapa_programa_id = ? AND secundario = ?", sessaoID, etapaID, false).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListObservadoresBySessao retorna as coletas dos observadores principal e secundário de uma sessão,
+// na ordem em que foram registradas
+func (r *GormColetaABARepository) ListObservadoresBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.ColetaABA, error) {
+	var coletas []*models.ColetaABA
+	if err := conexao(ctx, r.db).Where("sessao_id = ?", sessaoID).Order("created_at").Find(&coletas).Error; err != nil {
+		return nil, err
+	}
+	return coletas, nil
+}
@@ -13,7 +13,6 @@ import (
 // ComportamentoAlvoRepository define a interface para operações de repositório de comportamentos alvo
 type ComportamentoAlvoRepository interface {
 	Create(ctx context.Context, comportamento *models.ComportamentoAlvo) error
-	GetByID(ctx context.Context, id uuid.UUID) (*models.ComportamentoAlvo  error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.ComportamentoAlvo, error)
 	Update(ctx context.Context, comportamento *models.ComportamentoAlvo) error
 	Delete(ctx context.Context, id uuid.UUID) error
@@ -35,13 +34,13 @@ func NewGormComportamentoAlvoRepository(db *gorm.DB) *GormComportamentoAlvoRepos
 
 // Create cria um novo comportamento alvo no banco de dados
 func (r *GormComportamentoAlvoRepository) Create(ctx context.Context, comportamento *models.ComportamentoAlvo) error {
-	return r.db.WithContext(ctx).Create(comportamento).Error
+	return conexao(ctx, r.db).Create(comportamento).Error
 }
 
 // GetByID busca um comportamento alvo pelo ID
 func (r *GormComportamentoAlvoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ComportamentoAlvo, error) {
 	var comportamento models.ComportamentoAlvo
-	if err := r.db.WithContext(ctx).First(&comportamento, "id = ?", id).Error; err != nil {
+	if err := conexao(ctx, r.db).First(&comportamento, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -52,18 +51,18 @@ func (r *GormComportamentoAlvoRepository) GetByID(ctx context.Context, id uuid.U
 
 // Update atualiza um comportamento alvo existente
 func (r *GormComportamentoAlvoRepository) Update(ctx context.Context, comportamento *models.ComportamentoAlvo) error {
-	return r.db.WithContext(ctx).Save(comportamento).Error
+	return conexao(ctx, r.db).Save(comportamento).Error
 }
 
 // Delete exclui um comportamento alvo pelo ID (soft delete)
 func (r *GormComportamentoAlvoRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.ComportamentoAlvo{}, "id = ?", id).Error
+	return conexao(ctx, r.db).Delete(&models.ComportamentoAlvo{}, "id = ?", id).Error
 }
 
 // List retorna uma lista paginada de comportamentos alvo
 func (r *GormComportamentoAlvoRepository) List(ctx context.Context, limit, offset int) ([]*models.ComportamentoAlvo, error) {
 	var comportamentos []*models.ComportamentoAlvo
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&comportamentos).Error; err != nil {
+	if err := conexao(ctx, r.db).Limit(limit).Offset(offset).Find(&comportamentos).Error; err != nil {
 		return nil, err
 	}
 	return comportamentos, nil
@@ -72,7 +71,7 @@ func (r *GormComportamentoAlvoRepository) List(ctx context.Context, limit, offse
 // ListByPaciente retorna uma lista paginada de comportamentos alvo de um paciente específico
 func (r *GormComportamentoAlvoRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.ComportamentoAlvo, error) {
 	var comportamentos []*models.ComportamentoAlvo
-	if err := r.db.WithContext(ctx).Where("paciente_id = ?", pacienteID).Limit(limit).Offset(offset).Find(&comportamentos).Error; err != nil {
+	if err := conexao(ctx, r.db).Where("paciente_id = ?", pacienteID).Limit(limit).Offset(offset).Find(&comportamentos).Error; err != nil {
 		return nil, err
 	}
 	return comportamentos, nil
@@ -81,7 +80,7 @@ func (r *GormComportamentoAlvoRepository) ListByPaciente(ctx context.Context, pa
 // Count retorna o número total de comportamentos alvo
 func (r *GormComportamentoAlvoRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.ComportamentoAlvo{}).Count(&count).Error; err != nil {
+	if err := conexao(ctx, r.db).Model(&models.ComportamentoAlvo{}).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -90,7 +89,7 @@ func (r *GormComportamentoAlvoRepository) Count(ctx context.Context) (int64, err
 // CountByPaciente retorna o número total de comportamentos alvo de um paciente específico
 func (r *GormComportamentoAlvoRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.ComportamentoAlvo{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
+	if err := conexao(ctx, r.db).Model(&models.ComportamentoAlvo{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
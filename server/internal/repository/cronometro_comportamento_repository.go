@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// CronometroComportamentoRepository define a interface para operações de repositório de cronômetros
+type CronometroComportamentoRepository interface {
+	Create(ctx context.Context, cronometro *models.CronometroComportamento) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CronometroComportamento, error)
+	Encerrar(ctx context.Context, cronometro *models.CronometroComportamento, registro *models.RegistroComportamento) (bool, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListBySessao(ctx context.Context, sessaoID uuid.UUID, apenasAbertos bool) ([]*models.CronometroComportamento, error)
+	CountAbertosBySessao(ctx context.Context, sessaoID uuid.UUID) (int64, error)
+	GetAberto(ctx context.Context, comportamentoID, sessaoID uuid.UUID, tipo models.TipoCronometro, secundario bool) (*models.CronometroComportamento, error)
+}
+
+// GormCronometroComportamentoRepository implementa CronometroComportamentoRepository usando GORM
+type GormCronometroComportamentoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCronometroComportamentoRepository cria uma nova instância de GormCronometroComportamentoRepository
+func NewGormCronometroComportamentoRepository(db *gorm.DB) *GormCronometroComportamentoRepository {
+	return &GormCronometroComportamentoRepository{db: db}
+}
+
+// Create cria um novo cronômetro no banco de dados
+func (r *GormCronometroComportamentoRepository) Create(ctx context.Context, cronometro *models.CronometroComportamento) error {
+	return conexao(ctx, r.db).Create(cronometro).Error
+}
+
+// GetByID busca um cronômetro pelo ID
+func (r *GormCronometroComportamentoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CronometroComportamento, error) {
+	var cronometro models.CronometroComportamento
+	if err := conexao(ctx, r.db).First(&cronometro, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cronometro, nil
+}
+
+// Encerrar grava o encerramento do cronômetro e, quando informado, o registro de comportamento gerado,
+// em uma única transação. Retorna false quando o cronômetro já havia sido encerrado por outra requisição.
+func (r *GormCronometroComportamentoRepository) Encerrar(ctx context.Context, cronometro *models.CronometroComportamento, registro *models.RegistroComportamento) (bool, error) {
+	encerrado := false
+	err := conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if registro != nil {
+			if err := tx.Create(registro).Error; err != nil {
+				return err
+			}
+			cronometro.RegistroID = &registro.ID
+		}
+
+		result := tx.Model(&models.CronometroComportamento{}).
+			Where("id = ? AND encerrado_em IS NULL", cronometro.ID).
+			Updates(map[string]interface{}{
+				"encerrado_em": cronometro.EncerradoEm,
+				"segundos":     cronometro.Segundos,
+				"registro_id":  cronometro.RegistroID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Desfaz o registro criado, pois o cronômetro já estava encerrado
+			return gorm.ErrRecordNotFound
+		}
+		encerrado = true
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return encerrado, err
+}
+
+// Delete exclui um cronômetro pelo ID (soft delete)
+func (r *GormCronometroComportamentoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.CronometroComportamento{}, "id = ?", id).Error
+}
+
+// ListBySessao retorna os cronômetros de uma sessão em ordem de início
+func (r *GormCronometroComportamentoRepository) ListBySessao(ctx context.Context, sessaoID uuid.UUID, apenasAbertos bool) ([]*models.CronometroComportamento, error) {
+	var cronometros []*models.CronometroComportamento
+	query := conexao(ctx, r.db).Where("sessao_id = ?", sessaoID)
+	if apenasAbertos {
+		query = query.Where("encerrado_em IS NULL")
+	}
+	if err := query.Order("iniciado_em").Find(&cronometros).Error; err != nil {
+		return nil, err
+	}
+	return cronometros, nil
+}
+
+// CountAbertosBySessao retorna o número de cronômetros em andamento na sessão
+func (r *GormCronometroComportamentoRepository) CountAbertosBySessao(ctx context.Context, sessaoID uuid.UUID) (int64, error) {
+	var count int64
+	if err := conexao(ctx, r.db).Model(&models.CronometroComportamento{}).
+		Where("sessao_id = ? AND encerrado_em IS NULL", sessaoID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetAberto busca o cronômetro em andamento de um comportamento na sessão para o tipo e o observador informados
+func (r *GormCronometroComportamentoRepository) GetAberto(ctx context.Context, comportamentoID, sessaoID uuid.UUID, tipo models.TipoCronometro, secundario bool) (*models.CronometroComportamento, error) {
+	var cronometro models.CronometroComportamento
+	err := conexao(ctx, r.db).
+		Where("comportamento_id = ? AND sessao_id = ? AND tipo = ? AND secundario = ? AND encerrado_em IS NULL", comportamentoID, sessaoID, tipo, secundario).
+		First(&cronometro).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cronometro, nil
+}
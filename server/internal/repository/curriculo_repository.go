@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// CurriculoRepository define a interface para operações de repositório de currículos de avaliação
+type CurriculoRepository interface {
+	Create(ctx context.Context, curriculo *models.Curriculo) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Curriculo, error)
+	Update(ctx context.Context, curriculo *models.Curriculo) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, apenasAtivos bool) ([]*models.Curriculo, error)
+}
+
+// GormCurriculoRepository implementa CurriculoRepository usando GORM
+type GormCurriculoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormCurriculoRepository cria uma nova instância de GormCurriculoRepository
+func NewGormCurriculoRepository(db *gorm.DB) *GormCurriculoRepository {
+	return &GormCurriculoRepository{db: db}
+}
+
+// Create cria um novo currículo com os níveis, os domínios e os marcos
+func (r *GormCurriculoRepository) Create(ctx context.Context, curriculo *models.Curriculo) error {
+	return conexao(ctx, r.db).Create(curriculo).Error
+}
+
+// GetByID busca um currículo pelo ID com a estrutura completa, em ordem de nível, domínio e marco.
+// Currículos excluídos também são retornados para que aplicações antigas continuem legíveis.
+func (r *GormCurriculoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Curriculo, error) {
+	var curriculo models.Curriculo
+	err := conexao(ctx, r.db).Unscoped().
+		Preload("Niveis", func(db *gorm.DB) *gorm.DB { return db.Order("numero") }).
+		Preload("Dominios", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Dominios.Marcos", func(db *gorm.DB) *gorm.DB { return db.Order("nivel, numero") }).
+		First(&curriculo, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &curriculo, nil
+}
+
+// Update atualiza os dados de um currículo existente, sem alterar a estrutura
+func (r *GormCurriculoRepository) Update(ctx context.Context, curriculo *models.Curriculo) error {
+	return conexao(ctx, r.db).Omit("Niveis", "Dominios").Save(curriculo).Error
+}
+
+// Delete exclui um currículo pelo ID (soft delete)
+func (r *GormCurriculoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.Curriculo{}, "id = ?", id).Error
+}
+
+// List retorna os currículos ordenados pelo nome, opcionalmente apenas os ativos, sem os marcos
+func (r *GormCurriculoRepository) List(ctx context.Context, apenasAtivos bool) ([]*models.Curriculo, error) {
+	var curriculos []*models.Curriculo
+	query := conexao(ctx, r.db)
+	if apenasAtivos {
+		query = query.Where("ativo = ?", true)
+	}
+	err := query.
+		Preload("Niveis", func(db *gorm.DB) *gorm.DB { return db.Order("numero") }).
+		Preload("Dominios", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Order("nome").
+		Find(&curriculos).Error
+	if err != nil {
+		return nil, err
+	}
+	return curriculos, nil
+}
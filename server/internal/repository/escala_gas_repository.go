@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// EscalaGASRepository define a interface para operações de repositório das escalas GAS dos objetivos
+type EscalaGASRepository interface {
+	Definir(ctx context.Context, escala *models.EscalaGAS) error
+	GetByObjetivo(ctx context.Context, objetivoID uuid.UUID) (*models.EscalaGAS, error)
+	DeleteByObjetivo(ctx context.Context, objetivoID uuid.UUID) error
+	ListByPaciente(ctx context.Context, pacienteID uuid.UUID) ([]*models.EscalaGAS, error)
+}
+
+// GormEscalaGASRepository implementa EscalaGASRepository usando GORM
+type GormEscalaGASRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEscalaGASRepository cria uma nova instância de GormEscalaGASRepository
+func NewGormEscalaGASRepository(db *gorm.DB) *GormEscalaGASRepository {
+	return &GormEscalaGASRepository{db: db}
+}
+
+// Definir grava a escala do objetivo com os seus níveis, substituindo a escala existente na mesma transação
+func (r *GormEscalaGASRepository) Definir(ctx context.Context, escala *models.EscalaGAS) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := deleteEscalaGAS(tx, escala.ObjetivoID); err != nil {
+			return err
+		}
+		return tx.Create(escala).Error
+	})
+}
+
+// GetByObjetivo busca a escala de um objetivo, com os níveis em ordem crescente
+func (r *GormEscalaGASRepository) GetByObjetivo(ctx context.Context, objetivoID uuid.UUID) (*models.EscalaGAS, error) {
+	var escala models.EscalaGAS
+	err := conexao(ctx, r.db).
+		Preload("Niveis", func(db *gorm.DB) *gorm.DB { return db.Order("nivel") }).
+		First(&escala, "objetivo_id = ?", objetivoID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &escala, nil
+}
+
+// DeleteByObjetivo exclui a escala de um objetivo e os seus níveis
+func (r *GormEscalaGASRepository) DeleteByObjetivo(ctx context.Context, objetivoID uuid.UUID) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return deleteEscalaGAS(tx, objetivoID)
+	})
+}
+
+// ListByPaciente retorna as escalas dos objetivos de um paciente, com os níveis em ordem crescente
+func (r *GormEscalaGASRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID) ([]*models.EscalaGAS, error) {
+	var escalas []*models.EscalaGAS
+	objetivos := r.db.Model(&models.ObjetivoTerapeutico{}).Select("id").Where("paciente_id = ?", pacienteID)
+	err := conexao(ctx, r.db).
+		Preload("Niveis", func(db *gorm.DB) *gorm.DB { return db.Order("nivel") }).
+		Where("objetivo_id IN (?)", objetivos).
+		Order("created_at").
+		Find(&escalas).Error
+	if err != nil {
+		return nil, err
+	}
+	return escalas, nil
+}
+
+// deleteEscalaGAS remove a escala de um objetivo e os seus níveis dentro de uma transação
+func deleteEscalaGAS(tx *gorm.DB, objetivoID uuid.UUID) error {
+	escalas := tx.Model(&models.EscalaGAS{}).Select("id").Where("objetivo_id = ?", objetivoID)
+	if err := tx.Where("escala_id IN (?)", escalas).Delete(&models.NivelEscalaGAS{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("objetivo_id = ?", objetivoID).Delete(&models.EscalaGAS{}).Error
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"msd-service/server/internal/models"
+)
+
+// EtapaProgramaRepository define a interface para operações de repositório de etapas de programa
+type EtapaProgramaRepository interface {
+	Create(ctx context.Context, etapa *models.EtapaPrograma) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.EtapaPrograma, error)
+	Update(ctx context.Context, etapa *models.EtapaPrograma) error
+	ListByPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.EtapaPrograma, error)
+	ListByProgramas(ctx context.Context, programaIDs []uuid.UUID) ([]*models.EtapaPrograma, error)
+	SaveAll(ctx context.Context, etapas []*models.EtapaPrograma) error
+	LockPrograma(ctx context.Context, programaID uuid.UUID) error
+}
+
+// GormEtapaProgramaRepository implementa EtapaProgramaRepository usando GORM
+type GormEtapaProgramaRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEtapaProgramaRepository cria uma nova instância de GormEtapaProgramaRepository
+func NewGormEtapaProgramaRepository(db *gorm.DB) *GormEtapaProgramaRepository {
+	return &GormEtapaProgramaRepository{db: db}
+}
+
+// Create cria uma nova etapa de programa no banco de dados
+func (r *GormEtapaProgramaRepository) Create(ctx context.Context, etapa *models.EtapaPrograma) error {
+	return conexao(ctx, r.db).Create(etapa).Error
+}
+
+// GetByID busca uma etapa de programa pelo ID
+func (r *GormEtapaProgramaRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.EtapaPrograma, error) {
+	var etapa models.EtapaPrograma
+	if err := conexao(ctx, r.db).First(&etapa, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &etapa, nil
+}
+
+// Update atualiza os dados editáveis de uma etapa de programa existente: a descrição, os critérios e a
+// rotação de alvos. A situação, a ordem e as datas de domínio e reabertura são mantidas pela sequência.
+func (r *GormEtapaProgramaRepository) Update(ctx context.Context, etapa *models.EtapaPrograma) error {
+	return conexao(ctx, r.db).Model(etapa).
+		Select("descricao", "criterio_sucesso",
+			"criterio_metrica", "criterio_percentual_minimo", "criterio_sessoes_consecutivas",
+			"criterio_minimo_terapeutas", "criterio_minimo_tentativas",
+			"rotacao_alvos_simultaneos", "rotacao_percentual_dominio", "rotacao_sessoes_dominio",
+			"rotacao_sondagem_a_cada", "rotacao_exigir_generalizacao", "updated_at").
+		Updates(etapa).Error
+}
+
+// ListByPrograma retorna todas as etapas de um programa, as ativas em ordem e as retiradas ao final
+func (r *GormEtapaProgramaRepository) ListByPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.EtapaPrograma, error) {
+	var etapas []*models.EtapaPrograma
+	if err := conexao(ctx, r.db).
+		Where("programa_id = ?", programaID).
+		Order("CASE WHEN ordem > 0 THEN 0 ELSE 1 END, ordem, created_at").
+		Find(&etapas).Error; err != nil {
+		return nil, err
+	}
+	return etapas, nil
+}
+
+// ListByProgramas retorna as etapas dos programas informados, agrupadas por programa na ordem de ensino
+func (r *GormEtapaProgramaRepository) ListByProgramas(ctx context.Context, programaIDs []uuid.UUID) ([]*models.EtapaPrograma, error) {
+	var etapas []*models.EtapaPrograma
+	if len(programaIDs) == 0 {
+		return etapas, nil
+	}
+	if err := conexao(ctx, r.db).Where("programa_id IN ?", programaIDs).Order("programa_id, ordem").Find(&etapas).Error; err != nil {
+		return nil, err
+	}
+	return etapas, nil
+}
+
+// SaveAll grava um conjunto de etapas de uma só vez
+func (r *GormEtapaProgramaRepository) SaveAll(ctx context.Context, etapas []*models.EtapaPrograma) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, etapa := range etapas {
+			if err := tx.Save(etapa).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LockPrograma bloqueia o programa para alterações concorrentes na sequência de etapas.
+// Só tem efeito quando chamado dentro de uma transação do Transactor.
+func (r *GormEtapaProgramaRepository) LockPrograma(ctx context.Context, programaID uuid.UUID) error {
+	var programa models.ProgramaABA
+	return conexao(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&programa, "id = ?", programaID).Error
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// EventoAuditoriaRepository define a interface para operações de repositório de eventos de auditoria
+type EventoAuditoriaRepository interface {
+	Create(ctx context.Context, evento *models.EventoAuditoria) error
+	ListByEntidades(ctx context.Context, entidadeIDs []uuid.UUID) ([]*models.EventoAuditoria, error)
+}
+
+// GormEventoAuditoriaRepository implementa EventoAuditoriaRepository usando GORM
+type GormEventoAuditoriaRepository struct {
+	db *gorm.DB
+}
+
+// NewGormEventoAuditoriaRepository cria uma nova instância de GormEventoAuditoriaRepository
+func NewGormEventoAuditoriaRepository(db *gorm.DB) *GormEventoAuditoriaRepository {
+	return &GormEventoAuditoriaRepository{db: db}
+}
+
+// Create registra um novo evento de auditoria
+func (r *GormEventoAuditoriaRepository) Create(ctx context.Context, evento *models.EventoAuditoria) error {
+	return conexao(ctx, r.db).Create(evento).Error
+}
+
+// ListByEntidades retorna os eventos das entidades informadas, do mais recente para o mais antigo
+func (r *GormEventoAuditoriaRepository) ListByEntidades(ctx context.Context, entidadeIDs []uuid.UUID) ([]*models.EventoAuditoria, error) {
+	var eventos []*models.EventoAuditoria
+	if len(entidadeIDs) == 0 {
+		return eventos, nil
+	}
+	if err := conexao(ctx, r.db).Where("entidade_id IN ?", entidadeIDs).Order("created_at DESC").Find(&eventos).Error; err != nil {
+		return nil, err
+	}
+	return eventos, nil
+}
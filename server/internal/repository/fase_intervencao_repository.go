@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// FaseIntervencaoRepository define a interface para operações de repositório de fases
+type FaseIntervencaoRepository interface {
+	Create(ctx context.Context, fase *models.FaseIntervencao) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.FaseIntervencao, error)
+	Update(ctx context.Context, fase *models.FaseIntervencao) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByEntidade(ctx context.Context, entidadeTipo string, entidadeID uuid.UUID) ([]*models.FaseIntervencao, error)
+}
+
+// GormFaseIntervencaoRepository implementa FaseIntervencaoRepository usando GORM
+type GormFaseIntervencaoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormFaseIntervencaoRepository cria uma nova instância de GormFaseIntervencaoRepository
+func NewGormFaseIntervencaoRepository(db *gorm.DB) *GormFaseIntervencaoRepository {
+	return &GormFaseIntervencaoRepository{db: db}
+}
+
+// Create cria uma nova fase no banco de dados
+func (r *GormFaseIntervencaoRepository) Create(ctx context.Context, fase *models.FaseIntervencao) error {
+	return conexao(ctx, r.db).Create(fase).Error
+}
+
+// GetByID busca uma fase pelo ID
+func (r *GormFaseIntervencaoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.FaseIntervencao, error) {
+	var fase models.FaseIntervencao
+	if err := conexao(ctx, r.db).First(&fase, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &fase, nil
+}
+
+// Update atualiza uma fase existente
+func (r *GormFaseIntervencaoRepository) Update(ctx context.Context, fase *models.FaseIntervencao) error {
+	return conexao(ctx, r.db).Save(fase).Error
+}
+
+// Delete exclui uma fase pelo ID (soft delete)
+func (r *GormFaseIntervencaoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.FaseIntervencao{}, "id = ?", id).Error
+}
+
+// ListByEntidade retorna as fases de uma entidade em ordem cronológica
+func (r *GormFaseIntervencaoRepository) ListByEntidade(ctx context.Context, entidadeTipo string, entidadeID uuid.UUID) ([]*models.FaseIntervencao, error) {
+	var fases []*models.FaseIntervencao
+	if err := conexao(ctx, r.db).Where("entidade_tipo = ? AND entidade_id = ?", entidadeTipo, entidadeID).Order("data_inicio").Find(&fases).Error; err != nil {
+		return nil, err
+	}
+	return fases, nil
+}
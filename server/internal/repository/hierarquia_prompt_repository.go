@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// HierarquiaPromptRepository define a interface para operações de repositório de hierarquias de prompt
+type HierarquiaPromptRepository interface {
+	GetByPrograma(ctx context.Context, programaID uuid.UUID) (*models.HierarquiaPrompt, error)
+	Replace(ctx context.Context, hierarquia *models.HierarquiaPrompt) error
+}
+
+// GormHierarquiaPromptRepository implementa HierarquiaPromptRepository usando GORM
+type GormHierarquiaPromptRepository struct {
+	db *gorm.DB
+}
+
+// NewGormHierarquiaPromptRepository cria uma nova instância de GormHierarquiaPromptRepository
+func NewGormHierarquiaPromptRepository(db *gorm.DB) *GormHierarquiaPromptRepository {
+	return &GormHierarquiaPromptRepository{db: db}
+}
+
+// GetByPrograma busca a hierarquia de prompts de um programa, com os níveis em ordem
+func (r *GormHierarquiaPromptRepository) GetByPrograma(ctx context.Context, programaID uuid.UUID) (*models.HierarquiaPrompt, error) {
+	var hierarquia models.HierarquiaPrompt
+	err := conexao(ctx, r.db).
+		Preload("Niveis", func(db *gorm.DB) *gorm.DB { return db.Order("posicao") }).
+		First(&hierarquia, "programa_id = ?", programaID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &hierarquia, nil
+}
+
+// Replace grava a hierarquia de um programa, substituindo a anterior e todos os seus níveis
+func (r *GormHierarquiaPromptRepository) Replace(ctx context.Context, hierarquia *models.HierarquiaPrompt) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var existente models.HierarquiaPrompt
+		err := tx.Unscoped().First(&existente, "programa_id = ?", hierarquia.ProgramaID).Error
+		switch {
+		case err == nil:
+			if err := tx.Where("hierarquia_id = ?", existente.ID).Delete(&models.NivelHierarquiaPrompt{}).Error; err != nil {
+				return err
+			}
+			hierarquia.ID = existente.ID
+			hierarquia.CreatedAt = existente.CreatedAt
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		niveis := hierarquia.Niveis
+		hierarquia.Niveis = nil
+		if err := tx.Unscoped().Save(hierarquia).Error; err != nil {
+			return err
+		}
+		for i := range niveis {
+			niveis[i].HierarquiaID = hierarquia.ID
+		}
+		if len(niveis) > 0 {
+			if err := tx.Create(&niveis).Error; err != nil {
+				return err
+			}
+		}
+		hierarquia.Niveis = niveis
+		return nil
+	})
+}
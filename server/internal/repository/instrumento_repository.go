@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// InstrumentoRepository define a interface para operações de repositório de instrumentos padronizados
+type InstrumentoRepository interface {
+	Create(ctx context.Context, instrumento *models.Instrumento) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Instrumento, error)
+	GetAtivoMaisRecente(ctx context.Context, codigo string) (*models.Instrumento, error)
+	UltimaVersao(ctx context.Context, codigo string) (int, error)
+	Update(ctx context.Context, instrumento *models.Instrumento) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, codigo string, apenasAtivos bool) ([]*models.Instrumento, error)
+}
+
+// GormInstrumentoRepository implementa InstrumentoRepository usando GORM
+type GormInstrumentoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormInstrumentoRepository cria uma nova instância de GormInstrumentoRepository
+func NewGormInstrumentoRepository(db *gorm.DB) *GormInstrumentoRepository {
+	return &GormInstrumentoRepository{db: db}
+}
+
+// Create cria uma nova versão de instrumento com as escalas, as subescalas, os itens e as faixas
+func (r *GormInstrumentoRepository) Create(ctx context.Context, instrumento *models.Instrumento) error {
+	return conexao(ctx, r.db).Create(instrumento).Error
+}
+
+// GetByID busca uma versão de instrumento pelo ID com a definição completa. Versões excluídas também são
+// retornadas para que aplicações antigas continuem legíveis.
+func (r *GormInstrumentoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Instrumento, error) {
+	var instrumento models.Instrumento
+	if err := r.definicao(conexao(ctx, r.db).Unscoped()).First(&instrumento, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &instrumento, nil
+}
+
+// GetAtivoMaisRecente busca a versão ativa mais recente de um instrumento pelo código, com a definição completa
+func (r *GormInstrumentoRepository) GetAtivoMaisRecente(ctx context.Context, codigo string) (*models.Instrumento, error) {
+	var instrumento models.Instrumento
+	err := r.definicao(conexao(ctx, r.db)).
+		Where("codigo = ? AND ativo = ?", codigo, true).
+		Order("versao DESC").
+		First(&instrumento).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &instrumento, nil
+}
+
+// UltimaVersao retorna o maior número de versão já usado por um código, incluindo versões excluídas, ou 0
+func (r *GormInstrumentoRepository) UltimaVersao(ctx context.Context, codigo string) (int, error) {
+	var versao int
+	err := conexao(ctx, r.db).Unscoped().Model(&models.Instrumento{}).
+		Where("codigo = ?", codigo).
+		Select("COALESCE(MAX(versao), 0)").
+		Scan(&versao).Error
+	if err != nil {
+		return 0, err
+	}
+	return versao, nil
+}
+
+// Update atualiza os dados de uma versão de instrumento, sem alterar a definição
+func (r *GormInstrumentoRepository) Update(ctx context.Context, instrumento *models.Instrumento) error {
+	return conexao(ctx, r.db).Omit("Escalas", "Subescalas", "Itens", "Faixas").Save(instrumento).Error
+}
+
+// Delete exclui uma versão de instrumento pelo ID (soft delete)
+func (r *GormInstrumentoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.Instrumento{}, "id = ?", id).Error
+}
+
+// List retorna as versões de instrumentos ordenadas por código e versão, opcionalmente de um código e apenas
+// as ativas, sem os itens
+func (r *GormInstrumentoRepository) List(ctx context.Context, codigo string, apenasAtivos bool) ([]*models.Instrumento, error) {
+	var instrumentos []*models.Instrumento
+	query := conexao(ctx, r.db)
+	if codigo != "" {
+		query = query.Where("codigo = ?", codigo)
+	}
+	if apenasAtivos {
+		query = query.Where("ativo = ?", true)
+	}
+	err := query.
+		Preload("Subescalas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Order("codigo, versao DESC").
+		Find(&instrumentos).Error
+	if err != nil {
+		return nil, err
+	}
+	return instrumentos, nil
+}
+
+// definicao carrega a definição completa do instrumento, em ordem de opção, subescala e item
+func (r *GormInstrumentoRepository) definicao(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Escalas").
+		Preload("Escalas.Opcoes", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Subescalas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Itens", func(db *gorm.DB) *gorm.DB { return db.Order("numero") }).
+		Preload("Faixas", func(db *gorm.DB) *gorm.DB { return db.Order("etapa, subescala, minimo") })
+}
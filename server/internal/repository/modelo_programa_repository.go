@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// ModeloProgramaRepository define a interface para operações de repositório da biblioteca de modelos de programa
+type ModeloProgramaRepository interface {
+	Create(ctx context.Context, modelo *models.ModeloPrograma) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ModeloPrograma, error)
+	Update(ctx context.Context, modelo *models.ModeloPrograma, substituirEstrutura bool) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, area string, apenasAtivos bool) ([]*models.ModeloPrograma, error)
+	Instanciar(ctx context.Context, programa *models.ProgramaABA, etapas []*models.EtapaPrograma, alvos []*models.AlvoEtapa, hierarquia *models.HierarquiaPrompt) error
+}
+
+// GormModeloProgramaRepository implementa ModeloProgramaRepository usando GORM
+type GormModeloProgramaRepository struct {
+	db *gorm.DB
+}
+
+// NewGormModeloProgramaRepository cria uma nova instância de GormModeloProgramaRepository
+func NewGormModeloProgramaRepository(db *gorm.DB) *GormModeloProgramaRepository {
+	return &GormModeloProgramaRepository{db: db}
+}
+
+// Create cria um novo modelo de programa com as etapas, os alvos e os níveis de prompt
+func (r *GormModeloProgramaRepository) Create(ctx context.Context, modelo *models.ModeloPrograma) error {
+	return conexao(ctx, r.db).Create(modelo).Error
+}
+
+// GetByID busca um modelo de programa pelo ID, com as etapas, os alvos e os níveis de prompt em ordem
+func (r *GormModeloProgramaRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ModeloPrograma, error) {
+	var modelo models.ModeloPrograma
+	err := conexao(ctx, r.db).
+		Preload("NiveisPrompt", func(db *gorm.DB) *gorm.DB { return db.Order("posicao") }).
+		Preload("Etapas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Etapas.Alvos", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		First(&modelo, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &modelo, nil
+}
+
+// Update atualiza um modelo de programa. Com substituirEstrutura, as etapas, os alvos e os níveis de prompt
+// existentes são removidos e os do modelo são gravados na mesma transação.
+func (r *GormModeloProgramaRepository) Update(ctx context.Context, modelo *models.ModeloPrograma, substituirEstrutura bool) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("NiveisPrompt", "Etapas").Save(modelo).Error; err != nil {
+			return err
+		}
+		if !substituirEstrutura {
+			return nil
+		}
+
+		etapas := tx.Model(&models.EtapaModeloPrograma{}).Select("id").Where("modelo_id = ?", modelo.ID)
+		if err := tx.Where("etapa_id IN (?)", etapas).Delete(&models.AlvoModeloPrograma{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("modelo_id = ?", modelo.ID).Delete(&models.EtapaModeloPrograma{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("modelo_id = ?", modelo.ID).Delete(&models.NivelPromptModelo{}).Error; err != nil {
+			return err
+		}
+		if len(modelo.Etapas) > 0 {
+			if err := tx.Create(&modelo.Etapas).Error; err != nil {
+				return err
+			}
+		}
+		if len(modelo.NiveisPrompt) > 0 {
+			if err := tx.Create(&modelo.NiveisPrompt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete exclui um modelo de programa pelo ID (soft delete)
+func (r *GormModeloProgramaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.ModeloPrograma{}, "id = ?", id).Error
+}
+
+// List retorna os modelos de programa ordenados por área e nome, opcionalmente de uma área e apenas os
+// ativos, sem as etapas
+func (r *GormModeloProgramaRepository) List(ctx context.Context, area string, apenasAtivos bool) ([]*models.ModeloPrograma, error) {
+	var modelos []*models.ModeloPrograma
+	query := conexao(ctx, r.db)
+	if area != "" {
+		query = query.Where("area = ?", area)
+	}
+	if apenasAtivos {
+		query = query.Where("ativo = ?", true)
+	}
+	if err := query.Order("area, nome").Find(&modelos).Error; err != nil {
+		return nil, err
+	}
+	return modelos, nil
+}
+
+// Instanciar grava um programa criado a partir de um modelo, com as etapas, os alvos e a hierarquia de
+// prompts, em uma única transação
+func (r *GormModeloProgramaRepository) Instanciar(ctx context.Context, programa *models.ProgramaABA, etapas []*models.EtapaPrograma, alvos []*models.AlvoEtapa, hierarquia *models.HierarquiaPrompt) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(programa).Error; err != nil {
+			return err
+		}
+		if len(etapas) > 0 {
+			if err := tx.Create(&etapas).Error; err != nil {
+				return err
+			}
+		}
+		if len(alvos) > 0 {
+			if err := tx.Create(&alvos).Error; err != nil {
+				return err
+			}
+		}
+		if hierarquia != nil {
+			if err := tx.Create(hierarquia).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// ObjetivoProgramaRepository define a interface para operações de repositório dos vínculos entre objetivos
+// terapêuticos e programas ABA
+type ObjetivoProgramaRepository interface {
+	Create(ctx context.Context, vinculo *models.ObjetivoPrograma) error
+	Get(ctx context.Context, objetivoID, programaID uuid.UUID) (*models.ObjetivoPrograma, error)
+	Delete(ctx context.Context, objetivoID, programaID uuid.UUID) error
+	ListProgramasByObjetivo(ctx context.Context, objetivoID uuid.UUID) ([]*models.ProgramaABA, error)
+	ListObjetivosByPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.ObjetivoTerapeutico, error)
+}
+
+// GormObjetivoProgramaRepository implementa ObjetivoProgramaRepository usando GORM
+type GormObjetivoProgramaRepository struct {
+	db *gorm.DB
+}
+
+// NewGormObjetivoProgramaRepository cria uma nova instância de GormObjetivoProgramaRepository
+func NewGormObjetivoProgramaRepository(db *gorm.DB) *GormObjetivoProgramaRepository {
+	return &GormObjetivoProgramaRepository{db: db}
+}
+
+// Create cria um novo vínculo entre objetivo e programa
+func (r *GormObjetivoProgramaRepository) Create(ctx context.Context, vinculo *models.ObjetivoPrograma) error {
+	return conexao(ctx, r.db).Create(vinculo).Error
+}
+
+// Get busca o vínculo entre um objetivo e um programa
+func (r *GormObjetivoProgramaRepository) Get(ctx context.Context, objetivoID, programaID uuid.UUID) (*models.ObjetivoPrograma, error) {
+	var vinculo models.ObjetivoPrograma
+	if err := conexao(ctx, r.db).First(&vinculo, "objetivo_id = ? AND programa_id = ?", objetivoID, programaID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &vinculo, nil
+}
+
+// Delete remove o vínculo entre um objetivo e um programa
+func (r *GormObjetivoProgramaRepository) Delete(ctx context.Context, objetivoID, programaID uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.ObjetivoPrograma{}, "objetivo_id = ? AND programa_id = ?", objetivoID, programaID).Error
+}
+
+// ListProgramasByObjetivo retorna os programas vinculados a um objetivo, ordenados pela data de início
+func (r *GormObjetivoProgramaRepository) ListProgramasByObjetivo(ctx context.Context, objetivoID uuid.UUID) ([]*models.ProgramaABA, error) {
+	var programas []*models.ProgramaABA
+	err := conexao(ctx, r.db).
+		Joins("JOIN objetivos_programas ON objetivos_programas.programa_id = programas_aba.id").
+		Where("objetivos_programas.objetivo_id = ?", objetivoID).
+		Order("programas_aba.data_inicio").
+		Find(&programas).Error
+	if err != nil {
+		return nil, err
+	}
+	return programas, nil
+}
+
+// ListObjetivosByPrograma retorna os objetivos aos quais um programa está vinculado
+func (r *GormObjetivoProgramaRepository) ListObjetivosByPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.ObjetivoTerapeutico, error) {
+	var objetivos []*models.ObjetivoTerapeutico
+	err := conexao(ctx, r.db).
+		Joins("JOIN objetivos_programas ON objetivos_programas.objetivo_id = objetivos_terapeuticos.id").
+		Where("objetivos_programas.programa_id = ?", programaID).
+		Order("objetivos_terapeuticos.data_inicio").
+		Find(&objetivos).Error
+	if err != nil {
+		return nil, err
+	}
+	return objetivos, nil
+}
@@ -18,8 +18,12 @@ type ObjetivoTerapeuticoRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, limit, offset int) ([]*models.ObjetivoTerapeutico, error)
 	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.ObjetivoTerapeutico, error)
+	ListByPacienteAndStatus(ctx context.Context, pacienteID uuid.UUID, status models.StatusObjetivo) ([]*models.ObjetivoTerapeutico, error)
 	Count(ctx context.Context) (int64, error)
 	CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error)
+	ListSinalizadosByPaciente(ctx context.Context, pacienteID uuid.UUID) ([]*models.ObjetivoTerapeutico, error)
+	ConfirmarConclusao(ctx context.Context, objetivo *models.ObjetivoTerapeutico) (bool, error)
+	UpdateSinalizacao(ctx context.Context, objetivo *models.ObjetivoTerapeutico) error
 }
 
 // GormObjetivoTerapeuticoRepository implementa ObjetivoTerapeuticoRepository usando GORM
@@ -34,13 +38,13 @@ func NewGormObjetivoTerapeuticoRepository(db *gorm.DB) *GormObjetivoTerapeuticoR
 
 // Create cria um novo objetivo terapêutico no banco de dados
 func (r *GormObjetivoTerapeuticoRepository) Create(ctx context.Context, objetivo *models.ObjetivoTerapeutico) error {
-	return r.db.WithContext(ctx).Create(objetivo).Error
+	return conexao(ctx, r.db).Create(objetivo).Error
 }
 
 // GetByID busca um objetivo terapêutico pelo ID
 func (r *GormObjetivoTerapeuticoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ObjetivoTerapeutico, error) {
 	var objetivo models.ObjetivoTerapeutico
-	if err := r.db.WithContext(ctx).First(&objetivo, "id = ?", id).Error; err != nil {
+	if err := conexao(ctx, r.db).First(&objetivo, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -51,18 +55,18 @@ func (r *GormObjetivoTerapeuticoRepository) GetByID(ctx context.Context, id uuid
 
 // Update atualiza um objetivo terapêutico existente
 func (r *GormObjetivoTerapeuticoRepository) Update(ctx context.Context, objetivo *models.ObjetivoTerapeutico) error {
-	return r.db.WithContext(ctx).Save(objetivo).Error
+	return conexao(ctx, r.db).Save(objetivo).Error
 }
 
 // Delete exclui um objetivo terapêutico pelo ID (soft delete)
 func (r *GormObjetivoTerapeuticoRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.ObjetivoTerapeutico{}, "id = ?", id).Error
+	return conexao(ctx, r.db).Delete(&models.ObjetivoTerapeutico{}, "id = ?", id).Error
 }
 
 // List retorna uma lista paginada de objetivos terapêuticos
 func (r *GormObjetivoTerapeuticoRepository) List(ctx context.Context, limit, offset int) ([]*models.ObjetivoTerapeutico, error) {
 	var objetivos []*models.ObjetivoTerapeutico
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&objetivos).Error; err != nil {
+	if err := conexao(ctx, r.db).Limit(limit).Offset(offset).Find(&objetivos).Error; err != nil {
 		return nil, err
 	}
 	return objetivos, nil
@@ -71,7 +75,17 @@ func (r *GormObjetivoTerapeuticoRepository) List(ctx context.Context, limit, off
 // ListByPaciente retorna uma lista paginada de objetivos terapêuticos de um paciente específico
 func (r *GormObjetivoTerapeuticoRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.ObjetivoTerapeutico, error) {
 	var objetivos []*models.ObjetivoTerapeutico
-	if err := r.db.WithContext(ctx).Where("paciente_id = ?", pacienteID).Limit(limit).Offset(offset).Find(&objetivos).Error; err != nil {
+	if err := conexao(ctx, r.db).Where("paciente_id = ?", pacienteID).Limit(limit).Offset(offset).Find(&objetivos).Error; err != nil {
+		return nil, err
+	}
+	return objetivos, nil
+}
+
+// ListByPacienteAndStatus retorna os objetivos terapêuticos de um paciente com a situação informada,
+// ordenados pela data de início
+func (r *GormObjetivoTerapeuticoRepository) ListByPacienteAndStatus(ctx context.Context, pacienteID uuid.UUID, status models.StatusObjetivo) ([]*models.ObjetivoTerapeutico, error) {
+	var objetivos []*models.ObjetivoTerapeutico
+	if err := conexao(ctx, r.db).Where("paciente_id = ? AND status = ?", pacienteID, status).Order("data_inicio").Find(&objetivos).Error; err != nil {
 		return nil, err
 	}
 	return objetivos, nil
@@ -80,7 +94,7 @@ func (r *GormObjetivoTerapeuticoRepository) ListByPaciente(ctx context.Context,
 // Count retorna o número total de objetivos terapêuticos
 func (r *GormObjetivoTerapeuticoRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.ObjetivoTerapeutico{}).Count(&count).Error; err != nil {
+	if err := conexao(ctx, r.db).Model(&models.ObjetivoTerapeutico{}).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -89,8 +103,37 @@ func (r *GormObjetivoTerapeuticoRepository) Count(ctx context.Context) (int64, e
 // CountByPaciente retorna o número total de objetivos terapêuticos de um paciente específico
 func (r *GormObjetivoTerapeuticoRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.ObjetivoTerapeutico{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
+	if err := conexao(ctx, r.db).Model(&models.ObjetivoTerapeutico{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
+
+// ListSinalizadosByPaciente retorna os objetivos terapêuticos de um paciente sinalizados por tendência negativa
+func (r *GormObjetivoTerapeuticoRepository) ListSinalizadosByPaciente(ctx context.Context, pacienteID uuid.UUID) ([]*models.ObjetivoTerapeutico, error) {
+	var objetivos []*models.ObjetivoTerapeutico
+	if err := conexao(ctx, r.db).Where("paciente_id = ? AND sinalizado = ?", pacienteID, true).Order("sinalizado_em").Find(&objetivos).Error; err != nil {
+		return nil, err
+	}
+	return objetivos, nil
+}
+
+// ConfirmarConclusao grava a conclusão de um objetivo somente se a conclusão ainda estiver pendente, sem
+// tocar nos demais campos. Retorna false quando a conclusão já foi confirmada ou deixou de estar pendente.
+func (r *GormObjetivoTerapeuticoRepository) ConfirmarConclusao(ctx context.Context, objetivo *models.ObjetivoTerapeutico) (bool, error) {
+	result := conexao(ctx, r.db).Model(objetivo).
+		Where("conclusao_pendente = ?", true).
+		Select("status", "data_fim", "conclusao_pendente", "concluido_por", "concluido_em", "updated_at").
+		Updates(objetivo)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateSinalizacao grava apenas a sinalização de um objetivo, sem tocar na situação e na conclusão
+func (r *GormObjetivoTerapeuticoRepository) UpdateSinalizacao(ctx context.Context, objetivo *models.ObjetivoTerapeutico) error {
+	return conexao(ctx, r.db).Model(objetivo).
+		Select("sinalizado", "sinalizado_em", "updated_at").
+		Updates(objetivo).Error
+}
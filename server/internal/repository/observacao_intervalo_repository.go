@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// ObservacaoIntervaloRepository define a interface para operações de repositório de observações por intervalos.
+// As listagens por comportamento consideram apenas as observações do observador principal.
+type ObservacaoIntervaloRepository interface {
+	Create(ctx context.Context, observacao *models.ObservacaoIntervalo) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ObservacaoIntervalo, error)
+	Update(ctx context.Context, observacao *models.ObservacaoIntervalo) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, limit, offset int) ([]*models.ObservacaoIntervalo, error)
+	ListAllByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time) ([]*models.ObservacaoIntervalo, error)
+	CountByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time) (int64, error)
+	ListObservadoresBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.ObservacaoIntervalo, error)
+}
+
+// GormObservacaoIntervaloRepository implementa ObservacaoIntervaloRepository usando GORM
+type GormObservacaoIntervaloRepository struct {
+	db *gorm.DB
+}
+
+// NewGormObservacaoIntervaloRepository cria uma nova instância de GormObservacaoIntervaloRepository
+func NewGormObservacaoIntervaloRepository(db *gorm.DB) *GormObservacaoIntervaloRepository {
+	return &GormObservacaoIntervaloRepository{db: db}
+}
+
+// Create cria uma nova observação e seus intervalos no banco de dados
+func (r *GormObservacaoIntervaloRepository) Create(ctx context.Context, observacao *models.ObservacaoIntervalo) error {
+	return conexao(ctx, r.db).Create(observacao).Error
+}
+
+// GetByID busca uma observação pelo ID, com os intervalos em ordem
+func (r *GormObservacaoIntervaloRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ObservacaoIntervalo, error) {
+	var observacao models.ObservacaoIntervalo
+	err := conexao(ctx, r.db).
+		Preload("Intervalos", func(db *gorm.DB) *gorm.DB { return db.Order("numero") }).
+		First(&observacao, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &observacao, nil
+}
+
+// Update atualiza uma observação existente, substituindo todos os seus intervalos
+func (r *GormObservacaoIntervaloRepository) Update(ctx context.Context, observacao *models.ObservacaoIntervalo) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("observacao_id = ?", observacao.ID).Delete(&models.IntervaloObservado{}).Error; err != nil {
+			return err
+		}
+
+		intervalos := observacao.Intervalos
+		observacao.Intervalos = nil
+		if err := tx.Save(observacao).Error; err != nil {
+			return err
+		}
+		for i := range intervalos {
+			intervalos[i].ID = uuid.Nil
+			intervalos[i].ObservacaoID = observacao.ID
+		}
+		if len(intervalos) > 0 {
+			if err := tx.Create(&intervalos).Error; err != nil {
+				return err
+			}
+		}
+		observacao.Intervalos = intervalos
+		return nil
+	})
+}
+
+// Delete exclui uma observação pelo ID (soft delete)
+func (r *GormObservacaoIntervaloRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.ObservacaoIntervalo{}, "id = ?", id).Error
+}
+
+// ListByComportamento retorna uma lista paginada das observações de um comportamento, das mais recentes
+// para as mais antigas, sem os intervalos
+func (r *GormObservacaoIntervaloRepository) ListByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, limit, offset int) ([]*models.ObservacaoIntervalo, error) {
+	var observacoes []*models.ObservacaoIntervalo
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Where("comportamento_id = ? AND secundario = ?", comportamentoID, false), inicio, fim)
+	if err := query.Order("inicio DESC").Limit(limit).Offset(offset).Find(&observacoes).Error; err != nil {
+		return nil, err
+	}
+	return observacoes, nil
+}
+
+// ListAllByComportamento retorna todas as observações do comportamento no intervalo, com os intervalos,
+// em ordem cronológica
+func (r *GormObservacaoIntervaloRepository) ListAllByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time) ([]*models.ObservacaoIntervalo, error) {
+	var observacoes []*models.ObservacaoIntervalo
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Where("comportamento_id = ? AND secundario = ?", comportamentoID, false), inicio, fim)
+	err := query.
+		Preload("Intervalos", func(db *gorm.DB) *gorm.DB { return db.Order("numero") }).
+		Order("inicio").
+		Find(&observacoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return observacoes, nil
+}
+
+// CountByComportamento retorna o número total de observações do comportamento no intervalo
+func (r *GormObservacaoIntervaloRepository) CountByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time) (int64, error) {
+	var count int64
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Model(&models.ObservacaoIntervalo{}).Where("comportamento_id = ? AND secundario = ?", comportamentoID, false), inicio, fim)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListObservadoresBySessao retorna as observações dos observadores principal e secundário de uma sessão,
+// com os intervalos, em ordem cronológica
+func (r *GormObservacaoIntervaloRepository) ListObservadoresBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.ObservacaoIntervalo, error) {
+	var observacoes []*models.ObservacaoIntervalo
+	err := conexao(ctx, r.db).
+		Where("sessao_id = ?", sessaoID).
+		Preload("Intervalos", func(db *gorm.DB) *gorm.DB { return db.Order("numero") }).
+		Order("inicio").
+		Find(&observacoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return observacoes, nil
+}
+
+// filtrarPeriodo restringe a consulta às observações iniciadas em [inicio, fim)
+func (r *GormObservacaoIntervaloRepository) filtrarPeriodo(query *gorm.DB, inicio, fim *time.Time) *gorm.DB {
+	if inicio != nil {
+		query = query.Where("inicio >= ?", *inicio)
+	}
+	if fim != nil {
+		query = query.Where("inicio < ?", *fim)
+	}
+	return query
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// PlanoIntervencaoRepository define a interface para operações de repositório de planos de intervenção e suas versões
+type PlanoIntervencaoRepository interface {
+	Create(ctx context.Context, plano *models.PlanoIntervencao) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PlanoIntervencao, error)
+	Update(ctx context.Context, plano *models.PlanoIntervencao) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.PlanoIntervencao, error)
+	CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error)
+	ListByComportamento(ctx context.Context, comportamentoID uuid.UUID) ([]*models.PlanoIntervencao, error)
+	ListByPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.PlanoIntervencao, error)
+	CreateVersao(ctx context.Context, versao *models.VersaoPlanoIntervencao) error
+	GetVersao(ctx context.Context, planoID uuid.UUID, numero int) (*models.VersaoPlanoIntervencao, error)
+	UpdateVersao(ctx context.Context, versao *models.VersaoPlanoIntervencao) error
+	UpdateSituacaoVersao(ctx context.Context, versao *models.VersaoPlanoIntervencao, anterior models.StatusVersaoPlano) (bool, error)
+	CreateCiencia(ctx context.Context, ciencia *models.CienciaPlanoIntervencao) error
+}
+
+// GormPlanoIntervencaoRepository implementa PlanoIntervencaoRepository usando GORM
+type GormPlanoIntervencaoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPlanoIntervencaoRepository cria uma nova instância de GormPlanoIntervencaoRepository
+func NewGormPlanoIntervencaoRepository(db *gorm.DB) *GormPlanoIntervencaoRepository {
+	return &GormPlanoIntervencaoRepository{db: db}
+}
+
+// Create cria um novo plano com as suas versões, comportamentos e programas
+func (r *GormPlanoIntervencaoRepository) Create(ctx context.Context, plano *models.PlanoIntervencao) error {
+	return conexao(ctx, r.db).Create(plano).Error
+}
+
+// GetByID busca um plano pelo ID, com as versões da mais recente para a mais antiga
+func (r *GormPlanoIntervencaoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.PlanoIntervencao, error) {
+	var plano models.PlanoIntervencao
+	err := r.preloadVersoes(conexao(ctx, r.db)).First(&plano, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &plano, nil
+}
+
+// Update atualiza os dados do plano, sem alterar as versões
+func (r *GormPlanoIntervencaoRepository) Update(ctx context.Context, plano *models.PlanoIntervencao) error {
+	return conexao(ctx, r.db).Omit("Versoes").Save(plano).Error
+}
+
+// Delete exclui um plano pelo ID (soft delete)
+func (r *GormPlanoIntervencaoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.PlanoIntervencao{}, "id = ?", id).Error
+}
+
+// ListByPaciente retorna uma lista paginada dos planos de um paciente, dos mais recentes para os mais antigos
+func (r *GormPlanoIntervencaoRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.PlanoIntervencao, error) {
+	var planos []*models.PlanoIntervencao
+	err := r.preloadVersoes(conexao(ctx, r.db)).
+		Where("paciente_id = ?", pacienteID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&planos).Error
+	if err != nil {
+		return nil, err
+	}
+	return planos, nil
+}
+
+// CountByPaciente retorna o número total de planos de um paciente
+func (r *GormPlanoIntervencaoRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error) {
+	var count int64
+	if err := conexao(ctx, r.db).Model(&models.PlanoIntervencao{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListByComportamento retorna os planos com alguma versão que trata o comportamento alvo
+func (r *GormPlanoIntervencaoRepository) ListByComportamento(ctx context.Context, comportamentoID uuid.UUID) ([]*models.PlanoIntervencao, error) {
+	versoes := r.db.Model(&models.ComportamentoPlanoIntervencao{}).
+		Select("versoes_plano_intervencao.plano_id").
+		Joins("JOIN versoes_plano_intervencao ON versoes_plano_intervencao.id = comportamentos_plano_intervencao.versao_id").
+		Where("comportamentos_plano_intervencao.comportamento_id = ?", comportamentoID)
+	return r.listByVersoes(ctx, versoes)
+}
+
+// ListByPrograma retorna os planos com alguma versão que inclui o programa ABA como habilidade substituta
+func (r *GormPlanoIntervencaoRepository) ListByPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.PlanoIntervencao, error) {
+	versoes := r.db.Model(&models.ProgramaPlanoIntervencao{}).
+		Select("versoes_plano_intervencao.plano_id").
+		Joins("JOIN versoes_plano_intervencao ON versoes_plano_intervencao.id = programas_plano_intervencao.versao_id").
+		Where("programas_plano_intervencao.programa_id = ?", programaID)
+	return r.listByVersoes(ctx, versoes)
+}
+
+// CreateVersao cria uma nova versão do plano com os seus comportamentos e programas
+func (r *GormPlanoIntervencaoRepository) CreateVersao(ctx context.Context, versao *models.VersaoPlanoIntervencao) error {
+	return conexao(ctx, r.db).Create(versao).Error
+}
+
+// GetVersao busca uma versão do plano pelo número, com os comportamentos, os programas e as ciências
+func (r *GormPlanoIntervencaoRepository) GetVersao(ctx context.Context, planoID uuid.UUID, numero int) (*models.VersaoPlanoIntervencao, error) {
+	var versao models.VersaoPlanoIntervencao
+	err := conexao(ctx, r.db).
+		Preload("Comportamentos", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Programas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Ciencias", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		First(&versao, "plano_id = ? AND numero = ?", planoID, numero).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &versao, nil
+}
+
+// UpdateVersao atualiza uma versão existente, substituindo os seus comportamentos e programas.
+// As ciências não são alteradas.
+func (r *GormPlanoIntervencaoRepository) UpdateVersao(ctx context.Context, versao *models.VersaoPlanoIntervencao) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("versao_id = ?", versao.ID).Delete(&models.ComportamentoPlanoIntervencao{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("versao_id = ?", versao.ID).Delete(&models.ProgramaPlanoIntervencao{}).Error; err != nil {
+			return err
+		}
+
+		comportamentos, programas := versao.Comportamentos, versao.Programas
+		if err := tx.Omit("Comportamentos", "Programas", "Ciencias").Save(versao).Error; err != nil {
+			return err
+		}
+		for i := range comportamentos {
+			comportamentos[i].ID = uuid.Nil
+			comportamentos[i].VersaoID = versao.ID
+		}
+		for i := range programas {
+			programas[i].ID = uuid.Nil
+			programas[i].VersaoID = versao.ID
+		}
+		if len(comportamentos) > 0 {
+			if err := tx.Create(&comportamentos).Error; err != nil {
+				return err
+			}
+		}
+		if len(programas) > 0 {
+			if err := tx.Create(&programas).Error; err != nil {
+				return err
+			}
+		}
+		versao.Comportamentos, versao.Programas = comportamentos, programas
+		return nil
+	})
+}
+
+// UpdateSituacaoVersao grava apenas a situação e as datas de publicação e substituição de uma versão,
+// sem tocar no seu conteúdo. A versão só é alterada se ainda estiver na situação anterior informada; retorna
+// false quando outra operação já a alterou.
+func (r *GormPlanoIntervencaoRepository) UpdateSituacaoVersao(ctx context.Context, versao *models.VersaoPlanoIntervencao, anterior models.StatusVersaoPlano) (bool, error) {
+	result := conexao(ctx, r.db).Model(versao).
+		Where("status = ?", anterior).
+		Select("status", "publicada_em", "substituida_em", "updated_at").
+		Updates(versao)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CreateCiencia registra a ciência de uma versão do plano
+func (r *GormPlanoIntervencaoRepository) CreateCiencia(ctx context.Context, ciencia *models.CienciaPlanoIntervencao) error {
+	return conexao(ctx, r.db).Create(ciencia).Error
+}
+
+// listByVersoes retorna os planos identificados pela subconsulta, dos mais recentes para os mais antigos
+func (r *GormPlanoIntervencaoRepository) listByVersoes(ctx context.Context, planoIDs *gorm.DB) ([]*models.PlanoIntervencao, error) {
+	var planos []*models.PlanoIntervencao
+	err := r.preloadVersoes(conexao(ctx, r.db)).
+		Where("id IN (?)", planoIDs).
+		Order("created_at DESC").
+		Find(&planos).Error
+	if err != nil {
+		return nil, err
+	}
+	return planos, nil
+}
+
+// preloadVersoes carrega as versões dos planos, da mais recente para a mais antiga, com os comportamentos
+// e os programas de cada uma
+func (r *GormPlanoIntervencaoRepository) preloadVersoes(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Versoes", func(db *gorm.DB) *gorm.DB { return db.Order("numero DESC") }).
+		Preload("Versoes.Comportamentos", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Versoes.Programas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Versoes.Ciencias", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") })
+}
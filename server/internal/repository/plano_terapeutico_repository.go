@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// PlanoTerapeuticoRepository define a interface para operações de repositório de planos terapêuticos e suas versões
+type PlanoTerapeuticoRepository interface {
+	Create(ctx context.Context, plano *models.PlanoTerapeutico) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.PlanoTerapeutico, error)
+	Update(ctx context.Context, plano *models.PlanoTerapeutico) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.PlanoTerapeutico, error)
+	CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error)
+	ListRevisoesAte(ctx context.Context, ate time.Time) ([]*models.PlanoTerapeutico, error)
+	CreateVersao(ctx context.Context, versao *models.VersaoPlanoTerapeutico) error
+	GetVersao(ctx context.Context, planoID uuid.UUID, numero int) (*models.VersaoPlanoTerapeutico, error)
+	UpdateVersao(ctx context.Context, versao *models.VersaoPlanoTerapeutico) error
+	UpdateSituacaoVersao(ctx context.Context, versao *models.VersaoPlanoTerapeutico, anterior models.StatusVersaoPlanoTerapeutico) (bool, error)
+	CreateAceite(ctx context.Context, aceite *models.AceitePlanoTerapeutico) error
+}
+
+// GormPlanoTerapeuticoRepository implementa PlanoTerapeuticoRepository usando GORM
+type GormPlanoTerapeuticoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormPlanoTerapeuticoRepository cria uma nova instância de GormPlanoTerapeuticoRepository
+func NewGormPlanoTerapeuticoRepository(db *gorm.DB) *GormPlanoTerapeuticoRepository {
+	return &GormPlanoTerapeuticoRepository{db: db}
+}
+
+// Create cria um novo plano com as suas versões, objetivos, programas e cargas horárias
+func (r *GormPlanoTerapeuticoRepository) Create(ctx context.Context, plano *models.PlanoTerapeutico) error {
+	return conexao(ctx, r.db).Create(plano).Error
+}
+
+// GetByID busca um plano pelo ID, com as versões da mais recente para a mais antiga
+func (r *GormPlanoTerapeuticoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.PlanoTerapeutico, error) {
+	var plano models.PlanoTerapeutico
+	err := r.preloadVersoes(conexao(ctx, r.db)).First(&plano, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &plano, nil
+}
+
+// Update atualiza os dados do plano, sem alterar as versões
+func (r *GormPlanoTerapeuticoRepository) Update(ctx context.Context, plano *models.PlanoTerapeutico) error {
+	return conexao(ctx, r.db).Omit("Versoes").Save(plano).Error
+}
+
+// Delete exclui um plano pelo ID (soft delete)
+func (r *GormPlanoTerapeuticoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.PlanoTerapeutico{}, "id = ?", id).Error
+}
+
+// ListByPaciente retorna uma lista paginada dos planos de um paciente, dos mais recentes para os mais antigos
+func (r *GormPlanoTerapeuticoRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.PlanoTerapeutico, error) {
+	var planos []*models.PlanoTerapeutico
+	err := r.preloadVersoes(conexao(ctx, r.db)).
+		Where("paciente_id = ?", pacienteID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&planos).Error
+	if err != nil {
+		return nil, err
+	}
+	return planos, nil
+}
+
+// CountByPaciente retorna o número total de planos de um paciente
+func (r *GormPlanoTerapeuticoRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error) {
+	var count int64
+	if err := conexao(ctx, r.db).Model(&models.PlanoTerapeutico{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListRevisoesAte retorna os planos cuja versão vigente tem revisão prevista até a data informada, apenas
+// com a versão vigente
+func (r *GormPlanoTerapeuticoRepository) ListRevisoesAte(ctx context.Context, ate time.Time) ([]*models.PlanoTerapeutico, error) {
+	var planos []*models.PlanoTerapeutico
+	vigentes := r.db.Model(&models.VersaoPlanoTerapeutico{}).
+		Select("plano_id").
+		Where("status = ? AND data_revisao <= ?", models.StatusVersaoPlanoTerapeuticoVigente, ate)
+	err := conexao(ctx, r.db).
+		Preload("Versoes", "status = ?", models.StatusVersaoPlanoTerapeuticoVigente).
+		Where("id IN (?)", vigentes).
+		Find(&planos).Error
+	if err != nil {
+		return nil, err
+	}
+	return planos, nil
+}
+
+// CreateVersao cria uma nova versão do plano com os seus objetivos, programas e cargas horárias
+func (r *GormPlanoTerapeuticoRepository) CreateVersao(ctx context.Context, versao *models.VersaoPlanoTerapeutico) error {
+	return conexao(ctx, r.db).Create(versao).Error
+}
+
+// GetVersao busca uma versão do plano pelo número, com os objetivos, os programas, as cargas horárias e os aceites
+func (r *GormPlanoTerapeuticoRepository) GetVersao(ctx context.Context, planoID uuid.UUID, numero int) (*models.VersaoPlanoTerapeutico, error) {
+	var versao models.VersaoPlanoTerapeutico
+	err := conexao(ctx, r.db).
+		Preload("Objetivos", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Programas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("CargasHorarias", func(db *gorm.DB) *gorm.DB { return db.Order("terapia") }).
+		Preload("Aceites", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		First(&versao, "plano_id = ? AND numero = ?", planoID, numero).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &versao, nil
+}
+
+// UpdateVersao atualiza uma versão existente, substituindo os seus objetivos, programas e cargas horárias.
+// Os aceites não são alterados.
+func (r *GormPlanoTerapeuticoRepository) UpdateVersao(ctx context.Context, versao *models.VersaoPlanoTerapeutico) error {
+	return conexao(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("versao_id = ?", versao.ID).Delete(&models.ObjetivoPlanoTerapeutico{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("versao_id = ?", versao.ID).Delete(&models.ProgramaPlanoTerapeutico{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("versao_id = ?", versao.ID).Delete(&models.CargaHorariaPlanoTerapeutico{}).Error; err != nil {
+			return err
+		}
+
+		objetivos, programas, cargas := versao.Objetivos, versao.Programas, versao.CargasHorarias
+		if err := tx.Omit("Objetivos", "Programas", "CargasHorarias", "Aceites").Save(versao).Error; err != nil {
+			return err
+		}
+		for i := range objetivos {
+			objetivos[i].ID = uuid.Nil
+			objetivos[i].VersaoID = versao.ID
+		}
+		for i := range programas {
+			programas[i].ID = uuid.Nil
+			programas[i].VersaoID = versao.ID
+		}
+		for i := range cargas {
+			cargas[i].ID = uuid.Nil
+			cargas[i].VersaoID = versao.ID
+		}
+		if len(objetivos) > 0 {
+			if err := tx.Create(&objetivos).Error; err != nil {
+				return err
+			}
+		}
+		if len(programas) > 0 {
+			if err := tx.Create(&programas).Error; err != nil {
+				return err
+			}
+		}
+		if len(cargas) > 0 {
+			if err := tx.Create(&cargas).Error; err != nil {
+				return err
+			}
+		}
+		versao.Objetivos, versao.Programas, versao.CargasHorarias = objetivos, programas, cargas
+		return nil
+	})
+}
+
+// UpdateSituacaoVersao grava apenas a situação de uma versão e os dados do envio, da devolução, da
+// aprovação e da substituição, sem tocar nos objetivos, programas e cargas horárias copiados. A versão só é
+// alterada se ainda estiver na situação anterior informada; retorna false quando outra operação já a alterou.
+func (r *GormPlanoTerapeuticoRepository) UpdateSituacaoVersao(ctx context.Context, versao *models.VersaoPlanoTerapeutico, anterior models.StatusVersaoPlanoTerapeutico) (bool, error) {
+	result := conexao(ctx, r.db).Model(versao).
+		Where("status = ?", anterior).
+		Select("status", "enviada_em", "motivo_devolucao", "supervisor", "registro_profissional",
+			"aprovada_por", "aprovada_em", "substituida_em", "updated_at").
+		Updates(versao)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CreateAceite registra o aceite de um responsável em uma versão do plano
+func (r *GormPlanoTerapeuticoRepository) CreateAceite(ctx context.Context, aceite *models.AceitePlanoTerapeutico) error {
+	return conexao(ctx, r.db).Create(aceite).Error
+}
+
+// preloadVersoes carrega as versões dos planos, da mais recente para a mais antiga, com os objetivos, os
+// programas, as cargas horárias e os aceites de cada uma
+func (r *GormPlanoTerapeuticoRepository) preloadVersoes(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Versoes", func(db *gorm.DB) *gorm.DB { return db.Order("numero DESC") }).
+		Preload("Versoes.Objetivos", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Versoes.Programas", func(db *gorm.DB) *gorm.DB { return db.Order("ordem") }).
+		Preload("Versoes.CargasHorarias", func(db *gorm.DB) *gorm.DB { return db.Order("terapia") }).
+		Preload("Versoes.Aceites", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") })
+}
@@ -18,6 +18,9 @@ type ProgramaABARepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, limit, offset int) ([]*models.ProgramaABA, error)
 	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.ProgramaABA, error)
+	ListByPacienteAndStatus(ctx context.Context, pacienteID uuid.UUID, status models.StatusPrograma) ([]*models.ProgramaABA, error)
+	ListByAplicacaoCurriculo(ctx context.Context, aplicacaoID uuid.UUID) ([]*models.ProgramaABA, error)
+	ListByModeloPrograma(ctx context.Context, modeloID uuid.UUID) ([]*models.ProgramaABA, error)
 	Count(ctx context.Context) (int64, error)
 	CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error)
 }
@@ -34,13 +37,13 @@ func NewGormProgramaABARepository(db *gorm.DB) *GormProgramaABARepository {
 
 // Create cria um novo programa ABA no banco de dados
 func (r *GormProgramaABARepository) Create(ctx context.Context, programa *models.ProgramaABA) error {
-	return r.db.WithContext(ctx).Create(programa).Error
+	return conexao(ctx, r.db).Create(programa).Error
 }
 
 // GetByID busca um programa ABA pelo ID
 func (r *GormProgramaABARepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ProgramaABA, error) {
 	var programa models.ProgramaABA
-	if err := r.db.WithContext(ctx).First(&programa, "id = ?", id).Error; err != nil {
+	if err := conexao(ctx, r.db).First(&programa, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -51,18 +54,18 @@ func (r *GormProgramaABARepository) GetByID(ctx context.Context, id uuid.UUID) (
 
 // Update atualiza um programa ABA existente
 func (r *GormProgramaABARepository) Update(ctx context.Context, programa *models.ProgramaABA) error {
-	return r.db.WithContext(ctx).Save(programa).Error
+	return conexao(ctx, r.db).Save(programa).Error
 }
 
 // Delete exclui um programa ABA pelo ID (soft delete)
 func (r *GormProgramaABARepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.ProgramaABA{}, "id = ?", id).Error
+	return conexao(ctx, r.db).Delete(&models.ProgramaABA{}, "id = ?", id).Error
 }
 
 // List retorna uma lista paginada de programas ABA
 func (r *GormProgramaABARepository) List(ctx context.Context, limit, offset int) ([]*models.ProgramaABA, error) {
 	var programas []*models.ProgramaABA
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&programas).Error; err != nil {
+	if err := conexao(ctx, r.db).Limit(limit).Offset(offset).Find(&programas).Error; err != nil {
 		return nil, err
 	}
 	return programas, nil
@@ -71,7 +74,34 @@ func (r *GormProgramaABARepository) List(ctx context.Context, limit, offset int)
 // ListByPaciente retorna uma lista paginada de programas ABA de um paciente específico
 func (r *GormProgramaABARepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.ProgramaABA, error) {
 	var programas []*models.ProgramaABA
-	if err := r.db.WithContext(ctx).Where("paciente_id = ?", pacienteID).Limit(limit).Offset(offset).Find(&programas).Error; err != nil {
+	if err := conexao(ctx, r.db).Where("paciente_id = ?", pacienteID).Limit(limit).Offset(offset).Find(&programas).Error; err != nil {
+		return nil, err
+	}
+	return programas, nil
+}
+
+// ListByPacienteAndStatus retorna todos os programas ABA de um paciente com a situação informada
+func (r *GormProgramaABARepository) ListByPacienteAndStatus(ctx context.Context, pacienteID uuid.UUID, status models.StatusPrograma) ([]*models.ProgramaABA, error) {
+	var programas []*models.ProgramaABA
+	if err := conexao(ctx, r.db).Where("paciente_id = ? AND status = ?", pacienteID, status).Order("data_inicio").Find(&programas).Error; err != nil {
+		return nil, err
+	}
+	return programas, nil
+}
+
+// ListByAplicacaoCurriculo retorna os programas ABA criados a partir dos marcos de uma aplicação de currículo
+func (r *GormProgramaABARepository) ListByAplicacaoCurriculo(ctx context.Context, aplicacaoID uuid.UUID) ([]*models.ProgramaABA, error) {
+	var programas []*models.ProgramaABA
+	if err := conexao(ctx, r.db).Where("aplicacao_curriculo_id = ?", aplicacaoID).Order("created_at").Find(&programas).Error; err != nil {
+		return nil, err
+	}
+	return programas, nil
+}
+
+// ListByModeloPrograma retorna os programas ABA instanciados a partir de um modelo da biblioteca
+func (r *GormProgramaABARepository) ListByModeloPrograma(ctx context.Context, modeloID uuid.UUID) ([]*models.ProgramaABA, error) {
+	var programas []*models.ProgramaABA
+	if err := conexao(ctx, r.db).Where("modelo_programa_id = ?", modeloID).Order("data_inicio").Find(&programas).Error; err != nil {
 		return nil, err
 	}
 	return programas, nil
@@ -80,7 +110,7 @@ func (r *GormProgramaABARepository) ListByPaciente(ctx context.Context, paciente
 // Count retorna o número total de programas ABA
 func (r *GormProgramaABARepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.ProgramaABA{}).Count(&count).Error; err != nil {
+	if err := conexao(ctx, r.db).Model(&models.ProgramaABA{}).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -89,7 +119,7 @@ func (r *GormProgramaABARepository) Count(ctx context.Context) (int64, error) {
 // CountByPaciente retorna o número total de programas ABA de um paciente específico
 func (r *GormProgramaABARepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.ProgramaABA{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
+	if err := conexao(ctx, r.db).Model(&models.ProgramaABA{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
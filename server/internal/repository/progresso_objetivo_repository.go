@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// ProgressoObjetivoRepository define a interface para operações de repositório de progresso de objetivos
+type ProgressoObjetivoRepository interface {
+	Create(ctx context.Context, progresso *models.ProgressoObjetivo) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ProgressoObjetivo, error)
+	Update(ctx context.Context, progresso *models.ProgressoObjetivo) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByObjetivo(ctx context.Context, objetivoID uuid.UUID, limit, offset int) ([]*models.ProgressoObjetivo, error)
+	ListAllByObjetivo(ctx context.Context, objetivoID uuid.UUID) ([]*models.ProgressoObjetivo, error)
+	CountByObjetivo(ctx context.Context, objetivoID uuid.UUID) (int64, error)
+	ListComNivelGAS(ctx context.Context, objetivoIDs []uuid.UUID, inicio, fim *time.Time) ([]*models.ProgressoObjetivo, error)
+}
+
+// GormProgressoObjetivoRepository implementa ProgressoObjetivoRepository usando GORM
+type GormProgressoObjetivoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormProgressoObjetivoRepository cria uma nova instância de GormProgressoObjetivoRepository
+func NewGormProgressoObjetivoRepository(db *gorm.DB) *GormProgressoObjetivoRepository {
+	return &GormProgressoObjetivoRepository{db: db}
+}
+
+// Create cria um novo registro de progresso no banco de dados
+func (r *GormProgressoObjetivoRepository) Create(ctx context.Context, progresso *models.ProgressoObjetivo) error {
+	return conexao(ctx, r.db).Create(progresso).Error
+}
+
+// GetByID busca um registro de progresso pelo ID
+func (r *GormProgressoObjetivoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ProgressoObjetivo, error) {
+	var progresso models.ProgressoObjetivo
+	if err := conexao(ctx, r.db).First(&progresso, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &progresso, nil
+}
+
+// Update atualiza um registro de progresso existente
+func (r *GormProgressoObjetivoRepository) Update(ctx context.Context, progresso *models.ProgressoObjetivo) error {
+	return conexao(ctx, r.db).Save(progresso).Error
+}
+
+// Delete exclui um registro de progresso pelo ID (soft delete)
+func (r *GormProgressoObjetivoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.ProgressoObjetivo{}, "id = ?", id).Error
+}
+
+// ListByObjetivo retorna uma lista paginada do histórico de progresso de um objetivo, do mais recente ao mais antigo
+func (r *GormProgressoObjetivoRepository) ListByObjetivo(ctx context.Context, objetivoID uuid.UUID, limit, offset int) ([]*models.ProgressoObjetivo, error) {
+	var progressos []*models.ProgressoObjetivo
+	if err := conexao(ctx, r.db).Where("objetivo_id = ?", objetivoID).Order("data DESC, created_at DESC").Limit(limit).Offset(offset).Find(&progressos).Error; err != nil {
+		return nil, err
+	}
+	return progressos, nil
+}
+
+// ListAllByObjetivo retorna todo o histórico de progresso de um objetivo em ordem cronológica
+func (r *GormProgressoObjetivoRepository) ListAllByObjetivo(ctx context.Context, objetivoID uuid.UUID) ([]*models.ProgressoObjetivo, error) {
+	var progressos []*models.ProgressoObjetivo
+	if err := conexao(ctx, r.db).Where("objetivo_id = ?", objetivoID).Order("data, created_at").Find(&progressos).Error; err != nil {
+		return nil, err
+	}
+	return progressos, nil
+}
+
+// CountByObjetivo retorna o número total de registros de progresso de um objetivo
+func (r *GormProgressoObjetivoRepository) CountByObjetivo(ctx context.Context, objetivoID uuid.UUID) (int64, error) {
+	var count int64
+	if err := conexao(ctx, r.db).Model(&models.ProgressoObjetivo{}).Where("objetivo_id = ?", objetivoID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListComNivelGAS retorna, em ordem cronológica, os registros de progresso dos objetivos informados que têm
+// nível GAS, opcionalmente no intervalo [inicio, fim)
+func (r *GormProgressoObjetivoRepository) ListComNivelGAS(ctx context.Context, objetivoIDs []uuid.UUID, inicio, fim *time.Time) ([]*models.ProgressoObjetivo, error) {
+	var progressos []*models.ProgressoObjetivo
+	if len(objetivoIDs) == 0 {
+		return progressos, nil
+	}
+
+	query := conexao(ctx, r.db).Where("objetivo_id IN ? AND nivel_gas IS NOT NULL", objetivoIDs)
+	if inicio != nil {
+		query = query.Where("data >= ?", *inicio)
+	}
+	if fim != nil {
+		query = query.Where("data < ?", *fim)
+	}
+	if err := query.Order("data, created_at").Find(&progressos).Error; err != nil {
+		return nil, err
+	}
+	return progressos, nil
+}
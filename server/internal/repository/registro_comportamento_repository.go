@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// RegistroComportamentoRepository define a interface para operações de repositório de registros de comportamento.
+// As listagens por comportamento consideram apenas os registros do observador principal.
+type RegistroComportamentoRepository interface {
+	Create(ctx context.Context, registro *models.RegistroComportamento) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.RegistroComportamento, error)
+	Update(ctx context.Context, registro *models.RegistroComportamento) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, limit, offset int) ([]*models.RegistroComportamento, error)
+	ListAllByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time) ([]*models.RegistroComportamento, error)
+	CountByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time) (int64, error)
+	ListObservadoresBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.RegistroComportamento, error)
+}
+
+// GormRegistroComportamentoRepository implementa RegistroComportamentoRepository usando GORM
+type GormRegistroComportamentoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormRegistroComportamentoRepository cria uma nova instância de GormRegistroComportamentoRepository
+func NewGormRegistroComportamentoRepository(db *gorm.DB) *GormRegistroComportamentoRepository {
+	return &GormRegistroComportamentoRepository{db: db}
+}
+
+// Create cria um novo registro de comportamento no banco de dados
+func (r *GormRegistroComportamentoRepository) Create(ctx context.Context, registro *models.RegistroComportamento) error {
+	return conexao(ctx, r.db).Create(registro).Error
+}
+
+// GetByID busca um registro de comportamento pelo ID
+func (r *GormRegistroComportamentoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.RegistroComportamento, error) {
+	var registro models.RegistroComportamento
+	if err := conexao(ctx, r.db).First(&registro, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &registro, nil
+}
+
+// Update atualiza um registro de comportamento existente
+func (r *GormRegistroComportamentoRepository) Update(ctx context.Context, registro *models.RegistroComportamento) error {
+	return conexao(ctx, r.db).Save(registro).Error
+}
+
+// Delete exclui um registro de comportamento pelo ID (soft delete)
+func (r *GormRegistroComportamentoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.RegistroComportamento{}, "id = ?", id).Error
+}
+
+// ListByComportamento retorna uma lista paginada de registros de um comportamento, dos mais recentes
+// para os mais antigos, opcionalmente limitada a um intervalo de datas
+func (r *GormRegistroComportamentoRepository) ListByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, limit, offset int) ([]*models.RegistroComportamento, error) {
+	var registros []*models.RegistroComportamento
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Where("comportamento_id = ? AND secundario = ?", comportamentoID, false), inicio, fim)
+	if err := query.Order("data_hora DESC").Limit(limit).Offset(offset).Find(&registros).Error; err != nil {
+		return nil, err
+	}
+	return registros, nil
+}
+
+// ListAllByComportamento retorna todos os registros de um comportamento no intervalo, em ordem cronológica
+func (r *GormRegistroComportamentoRepository) ListAllByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time) ([]*models.RegistroComportamento, error) {
+	var registros []*models.RegistroComportamento
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Where("comportamento_id = ? AND secundario = ?", comportamentoID, false), inicio, fim)
+	if err := query.Order("data_hora").Find(&registros).Error; err != nil {
+		return nil, err
+	}
+	return registros, nil
+}
+
+// CountByComportamento retorna o número total de registros de um comportamento no intervalo
+func (r *GormRegistroComportamentoRepository) CountByComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time) (int64, error) {
+	var count int64
+	query := r.filtrarPeriodo(conexao(ctx, r.db).Model(&models.RegistroComportamento{}).Where("comportamento_id = ? AND secundario = ?", comportamentoID, false), inicio, fim)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListObservadoresBySessao retorna os registros dos observadores principal e secundário de uma sessão,
+// em ordem cronológica
+func (r *GormRegistroComportamentoRepository) ListObservadoresBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.RegistroComportamento, error) {
+	var registros []*models.RegistroComportamento
+	if err := conexao(ctx, r.db).Where("sessao_id = ?", sessaoID).Order("data_hora").Find(&registros).Error; err != nil {
+		return nil, err
+	}
+	return registros, nil
+}
+
+// filtrarPeriodo restringe a consulta aos registros com data_hora em [inicio, fim)
+func (r *GormRegistroComportamentoRepository) filtrarPeriodo(query *gorm.DB, inicio, fim *time.Time) *gorm.DB {
+	if inicio != nil {
+		query = query.Where("data_hora >= ?", *inicio)
+	}
+	if fim != nil {
+		query = query.Where("data_hora < ?", *fim)
+	}
+	return query
+}
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"msd-service/server/internal/models"
 )
@@ -18,8 +20,13 @@ type SessaoRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, limit, offset int) ([]*models.Sessao, error)
 	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.Sessao, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Sessao, error)
 	Count(ctx context.Context) (int64, error)
 	CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error)
+	ListRealizadasByPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time) ([]*models.Sessao, error)
+	ListRealizadasByTerapeuta(ctx context.Context, terapeutaID uuid.UUID, inicio, fim *time.Time) ([]*models.Sessao, error)
+	ListPlanejadasByPaciente(ctx context.Context, pacienteID uuid.UUID, aPartirDe time.Time) ([]*models.Sessao, error)
+	LockSessao(ctx context.Context, id uuid.UUID) error
 }
 
 // GormSessaoRepository implementa SessaoRepository usando GORM
@@ -34,13 +41,13 @@ func NewGormSessaoRepository(db *gorm.DB) *GormSessaoRepository {
 
 // Create cria uma nova sessão no banco de dados
 func (r *GormSessaoRepository) Create(ctx context.Context, sessao *models.Sessao) error {
-	return r.db.WithContext(ctx).Create(sessao).Error
+	return conexao(ctx, r.db).Create(sessao).Error
 }
 
 // GetByID busca uma sessão pelo ID
 func (r *GormSessaoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Sessao, error) {
 	var sessao models.Sessao
-	if err := r.db.WithContext(ctx).First(&sessao, "id = ?", id).Error; err != nil {
+	if err := conexao(ctx, r.db).First(&sessao, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -51,18 +58,18 @@ func (r *GormSessaoRepository) GetByID(ctx context.Context, id uuid.UUID) (*mode
 
 // Update atualiza uma sessão existente
 func (r *GormSessaoRepository) Update(ctx context.Context, sessao *models.Sessao) error {
-	return r.db.WithContext(ctx).Save(sessao).Error
+	return conexao(ctx, r.db).Save(sessao).Error
 }
 
 // Delete exclui uma sessão pelo ID (soft delete)
 func (r *GormSessaoRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.Sessao{}, "id = ?", id).Error
+	return conexao(ctx, r.db).Delete(&models.Sessao{}, "id = ?", id).Error
 }
 
 // List retorna uma lista paginada de sessões
 func (r *GormSessaoRepository) List(ctx context.Context, limit, offset int) ([]*models.Sessao, error) {
 	var sessoes []*models.Sessao
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&sessoes).Error; err != nil {
+	if err := conexao(ctx, r.db).Limit(limit).Offset(offset).Find(&sessoes).Error; err != nil {
 		return nil, err
 	}
 	return sessoes, nil
@@ -71,7 +78,19 @@ func (r *GormSessaoRepository) List(ctx context.Context, limit, offset int) ([]*
 // ListByPaciente retorna uma lista paginada de sessões de um paciente específico
 func (r *GormSessaoRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, limit, offset int) ([]*models.Sessao, error) {
 	var sessoes []*models.Sessao
-	if err := r.db.WithContext(ctx).Where("paciente_id = ?", pacienteID).Limit(limit).Offset(offset).Find(&sessoes).Error; err != nil {
+	if err := conexao(ctx, r.db).Where("paciente_id = ?", pacienteID).Limit(limit).Offset(offset).Find(&sessoes).Error; err != nil {
+		return nil, err
+	}
+	return sessoes, nil
+}
+
+// ListByIDs retorna as sessões correspondentes aos IDs informados, ordenadas por data
+func (r *GormSessaoRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Sessao, error) {
+	var sessoes []*models.Sessao
+	if len(ids) == 0 {
+		return sessoes, nil
+	}
+	if err := conexao(ctx, r.db).Where("id IN ?", ids).Order("data").Find(&sessoes).Error; err != nil {
 		return nil, err
 	}
 	return sessoes, nil
@@ -80,7 +99,7 @@ func (r *GormSessaoRepository) ListByPaciente(ctx context.Context, pacienteID uu
 // Count retorna o número total de sessões
 func (r *GormSessaoRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.Sessao{}).Count(&count).Error; err != nil {
+	if err := conexao(ctx, r.db).Model(&models.Sessao{}).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -89,8 +108,61 @@ func (r *GormSessaoRepository) Count(ctx context.Context) (int64, error) {
 // CountByPaciente retorna o número total de sessões de um paciente específico
 func (r *GormSessaoRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID) (int64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.Sessao{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
+	if err := conexao(ctx, r.db).Model(&models.Sessao{}).Where("paciente_id = ?", pacienteID).Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
+
+// ListRealizadasByPaciente retorna as sessões realizadas de um paciente no intervalo [inicio, fim), ordenadas por data
+func (r *GormSessaoRepository) ListRealizadasByPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time) ([]*models.Sessao, error) {
+	return r.listRealizadas(conexao(ctx, r.db).Where("paciente_id = ?", pacienteID), inicio, fim)
+}
+
+// ListRealizadasByTerapeuta retorna as sessões realizadas de um terapeuta no intervalo [inicio, fim), ordenadas por data
+func (r *GormSessaoRepository) ListRealizadasByTerapeuta(ctx context.Context, terapeutaID uuid.UUID, inicio, fim *time.Time) ([]*models.Sessao, error) {
+	return r.listRealizadas(conexao(ctx, r.db).Where("terapeuta_id = ?", terapeutaID), inicio, fim)
+}
+
+// ListPlanejadasByPaciente retorna as sessões planejadas de um paciente a partir da data informada, ordenadas por data
+func (r *GormSessaoRepository) ListPlanejadasByPaciente(ctx context.Context, pacienteID uuid.UUID, aPartirDe time.Time) ([]*models.Sessao, error) {
+	var sessoes []*models.Sessao
+	err := conexao(ctx, r.db).
+		Where("paciente_id = ? AND status = ? AND data >= ?", pacienteID, models.StatusSessaoPlanejada, aPartirDe).
+		Order("data").
+		Find(&sessoes).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessoes, nil
+}
+
+// LockSessao bloqueia a sessão para que a mudança de situação e o início de cronômetros não ocorram ao mesmo
+// tempo. Só tem efeito quando chamado dentro de uma transação do Transactor; uma sessão inexistente não é bloqueada.
+func (r *GormSessaoRepository) LockSessao(ctx context.Context, id uuid.UUID) error {
+	var sessao models.Sessao
+	err := conexao(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&sessao, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+// listRealizadas aplica o filtro de status e de período à consulta de sessões
+func (r *GormSessaoRepository) listRealizadas(query *gorm.DB, inicio, fim *time.Time) ([]*models.Sessao, error) {
+	var sessoes []*models.Sessao
+	query = query.Where("status = ?", models.StatusSessaoRealizada)
+	if inicio != nil {
+		query = query.Where("data >= ?", *inicio)
+	}
+	if fim != nil {
+		query = query.Where("data < ?", *fim)
+	}
+	if err := query.Order("data").Find(&sessoes).Error; err != nil {
+		return nil, err
+	}
+	return sessoes, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// SondagemGeneralizacaoRepository define a interface para operações de repositório de sondagens de generalização
+type SondagemGeneralizacaoRepository interface {
+	Create(ctx context.Context, sondagem *models.SondagemGeneralizacao) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.SondagemGeneralizacao, error)
+	Update(ctx context.Context, sondagem *models.SondagemGeneralizacao) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	ListByAlvo(ctx context.Context, alvoID uuid.UUID) ([]*models.SondagemGeneralizacao, error)
+	ListPendentesByAlvos(ctx context.Context, alvoIDs []uuid.UUID, ate time.Time) ([]*models.SondagemGeneralizacao, error)
+}
+
+// GormSondagemGeneralizacaoRepository implementa SondagemGeneralizacaoRepository usando GORM
+type GormSondagemGeneralizacaoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSondagemGeneralizacaoRepository cria uma nova instância de GormSondagemGeneralizacaoRepository
+func NewGormSondagemGeneralizacaoRepository(db *gorm.DB) *GormSondagemGeneralizacaoRepository {
+	return &GormSondagemGeneralizacaoRepository{db: db}
+}
+
+// Create cria uma nova sondagem no banco de dados
+func (r *GormSondagemGeneralizacaoRepository) Create(ctx context.Context, sondagem *models.SondagemGeneralizacao) error {
+	return conexao(ctx, r.db).Create(sondagem).Error
+}
+
+// GetByID busca uma sondagem pelo ID
+func (r *GormSondagemGeneralizacaoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SondagemGeneralizacao, error) {
+	var sondagem models.SondagemGeneralizacao
+	if err := conexao(ctx, r.db).First(&sondagem, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sondagem, nil
+}
+
+// Update atualiza uma sondagem existente
+func (r *GormSondagemGeneralizacaoRepository) Update(ctx context.Context, sondagem *models.SondagemGeneralizacao) error {
+	return conexao(ctx, r.db).Save(sondagem).Error
+}
+
+// Delete exclui uma sondagem pelo ID (soft delete)
+func (r *GormSondagemGeneralizacaoRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.SondagemGeneralizacao{}, "id = ?", id).Error
+}
+
+// ListByAlvo retorna as sondagens de um alvo em ordem de agendamento
+func (r *GormSondagemGeneralizacaoRepository) ListByAlvo(ctx context.Context, alvoID uuid.UUID) ([]*models.SondagemGeneralizacao, error) {
+	var sondagens []*models.SondagemGeneralizacao
+	if err := conexao(ctx, r.db).Where("alvo_id = ?", alvoID).Order("agendada_para").Find(&sondagens).Error; err != nil {
+		return nil, err
+	}
+	return sondagens, nil
+}
+
+// ListPendentesByAlvos retorna as sondagens ainda não realizadas dos alvos, agendadas antes de ate
+func (r *GormSondagemGeneralizacaoRepository) ListPendentesByAlvos(ctx context.Context, alvoIDs []uuid.UUID, ate time.Time) ([]*models.SondagemGeneralizacao, error) {
+	var sondagens []*models.SondagemGeneralizacao
+	if len(alvoIDs) == 0 {
+		return sondagens, nil
+	}
+	err := conexao(ctx, r.db).
+		Where("alvo_id IN ? AND resultado IS NULL AND agendada_para < ?", alvoIDs, ate).
+		Order("agendada_para").
+		Find(&sondagens).Error
+	if err != nil {
+		return nil, err
+	}
+	return sondagens, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"msd-service/server/internal/models"
+)
+
+// SondagemManutencaoRepository define a interface para operações de repositório de sondagens de manutenção
+type SondagemManutencaoRepository interface {
+	Create(ctx context.Context, sondagem *models.SondagemManutencao) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.SondagemManutencao, error)
+	Update(ctx context.Context, sondagem *models.SondagemManutencao) error
+	ListByPaciente(ctx context.Context, pacienteID uuid.UUID, status *models.StatusSondagemManutencao, limit, offset int) ([]*models.SondagemManutencao, error)
+	CountByPaciente(ctx context.Context, pacienteID uuid.UUID, status *models.StatusSondagemManutencao) (int64, error)
+	ListAllByPaciente(ctx context.Context, pacienteID uuid.UUID) ([]*models.SondagemManutencao, error)
+	ListBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.SondagemManutencao, error)
+	LockPaciente(ctx context.Context, pacienteID uuid.UUID) error
+}
+
+// GormSondagemManutencaoRepository implementa SondagemManutencaoRepository usando GORM
+type GormSondagemManutencaoRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSondagemManutencaoRepository cria uma nova instância de GormSondagemManutencaoRepository
+func NewGormSondagemManutencaoRepository(db *gorm.DB) *GormSondagemManutencaoRepository {
+	return &GormSondagemManutencaoRepository{db: db}
+}
+
+// Create cria uma nova sondagem no banco de dados
+func (r *GormSondagemManutencaoRepository) Create(ctx context.Context, sondagem *models.SondagemManutencao) error {
+	return conexao(ctx, r.db).Create(sondagem).Error
+}
+
+// GetByID busca uma sondagem pelo ID
+func (r *GormSondagemManutencaoRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.SondagemManutencao, error) {
+	var sondagem models.SondagemManutencao
+	if err := conexao(ctx, r.db).First(&sondagem, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sondagem, nil
+}
+
+// Update atualiza uma sondagem existente
+func (r *GormSondagemManutencaoRepository) Update(ctx context.Context, sondagem *models.SondagemManutencao) error {
+	return conexao(ctx, r.db).Save(sondagem).Error
+}
+
+// ListByPaciente retorna uma lista paginada das sondagens do paciente, opcionalmente filtrada pela situação,
+// das mais recentes para as mais antigas
+func (r *GormSondagemManutencaoRepository) ListByPaciente(ctx context.Context, pacienteID uuid.UUID, status *models.StatusSondagemManutencao, limit, offset int) ([]*models.SondagemManutencao, error) {
+	var sondagens []*models.SondagemManutencao
+	query := r.filtrarStatus(conexao(ctx, r.db).Where("paciente_id = ?", pacienteID), status)
+	if err := query.Order("agendada_para DESC").Limit(limit).Offset(offset).Find(&sondagens).Error; err != nil {
+		return nil, err
+	}
+	return sondagens, nil
+}
+
+// CountByPaciente retorna o número total de sondagens do paciente, opcionalmente filtradas pela situação
+func (r *GormSondagemManutencaoRepository) CountByPaciente(ctx context.Context, pacienteID uuid.UUID, status *models.StatusSondagemManutencao) (int64, error) {
+	var count int64
+	query := r.filtrarStatus(conexao(ctx, r.db).Model(&models.SondagemManutencao{}).Where("paciente_id = ?", pacienteID), status)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListAllByPaciente retorna todas as sondagens do paciente em ordem de agendamento
+func (r *GormSondagemManutencaoRepository) ListAllByPaciente(ctx context.Context, pacienteID uuid.UUID) ([]*models.SondagemManutencao, error) {
+	var sondagens []*models.SondagemManutencao
+	if err := conexao(ctx, r.db).Where("paciente_id = ?", pacienteID).Order("agendada_para, sequencia").Find(&sondagens).Error; err != nil {
+		return nil, err
+	}
+	return sondagens, nil
+}
+
+// ListBySessao retorna as sondagens alocadas em uma sessão em ordem de agendamento
+func (r *GormSondagemManutencaoRepository) ListBySessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.SondagemManutencao, error) {
+	var sondagens []*models.SondagemManutencao
+	if err := conexao(ctx, r.db).Where("sessao_id = ?", sessaoID).Order("agendada_para, sequencia").Find(&sondagens).Error; err != nil {
+		return nil, err
+	}
+	return sondagens, nil
+}
+
+// LockPaciente bloqueia o paciente para alterações concorrentes no agendamento das suas sondagens.
+// Só tem efeito quando chamado dentro de uma transação do Transactor; um paciente inexistente não é bloqueado.
+func (r *GormSondagemManutencaoRepository) LockPaciente(ctx context.Context, pacienteID uuid.UUID) error {
+	var paciente models.Paciente
+	err := conexao(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&paciente, "id = ?", pacienteID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+// filtrarStatus restringe a consulta à situação informada, quando houver
+func (r *GormSondagemManutencaoRepository) filtrarStatus(query *gorm.DB, status *models.StatusSondagemManutencao) *gorm.DB {
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	return query
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"msd-service/server/internal/models"
+)
+
+// TipoPromptRepository define a interface para operações de repositório de tipos de prompt
+type TipoPromptRepository interface {
+	Create(ctx context.Context, prompt *models.TipoPrompt) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.TipoPrompt, error)
+	Update(ctx context.Context, prompt *models.TipoPrompt) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, limit, offset int) ([]*models.TipoPrompt, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.TipoPrompt, error)
+	Count(ctx context.Context) (int64, error)
+}
+
+// GormTipoPromptRepository implementa TipoPromptRepository usando GORM
+type GormTipoPromptRepository struct {
+	db *gorm.DB
+}
+
+// NewGormTipoPromptRepository cria uma nova instância de GormTipoPromptRepository
+func NewGormTipoPromptRepository(db *gorm.DB) *GormTipoPromptRepository {
+	return &GormTipoPromptRepository{db: db}
+}
+
+// Create cria um novo tipo de prompt no banco de dados
+func (r *GormTipoPromptRepository) Create(ctx context.Context, prompt *models.TipoPrompt) error {
+	return conexao(ctx, r.db).Create(prompt).Error
+}
+
+// GetByID busca um tipo de prompt pelo ID
+func (r *GormTipoPromptRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.TipoPrompt, error) {
+	var prompt models.TipoPrompt
+	if err := conexao(ctx, r.db).First(&prompt, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &prompt, nil
+}
+
+// Update atualiza um tipo de prompt existente
+func (r *GormTipoPromptRepository) Update(ctx context.Context, prompt *models.TipoPrompt) error {
+	return conexao(ctx, r.db).Save(prompt).Error
+}
+
+// Delete exclui um tipo de prompt pelo ID (soft delete)
+func (r *GormTipoPromptRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conexao(ctx, r.db).Delete(&models.TipoPrompt{}, "id = ?", id).Error
+}
+
+// List retorna uma lista paginada de tipos de prompt
+func (r *GormTipoPromptRepository) List(ctx context.Context, limit, offset int) ([]*models.TipoPrompt, error) {
+	var prompts []*models.TipoPrompt
+	if err := conexao(ctx, r.db).Order("tipo").Limit(limit).Offset(offset).Find(&prompts).Error; err != nil {
+		return nil, err
+	}
+	return prompts, nil
+}
+
+// ListByIDs retorna os tipos de prompt correspondentes aos IDs informados
+func (r *GormTipoPromptRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.TipoPrompt, error) {
+	var prompts []*models.TipoPrompt
+	if len(ids) == 0 {
+		return prompts, nil
+	}
+	if err := conexao(ctx, r.db).Where("id IN ?", ids).Find(&prompts).Error; err != nil {
+		return nil, err
+	}
+	return prompts, nil
+}
+
+// Count retorna o número total de tipos de prompt
+func (r *GormTipoPromptRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	if err := conexao(ctx, r.db).Model(&models.TipoPrompt{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// transacaoKey é a chave do contexto que carrega a transação em andamento
+type transacaoKey struct{}
+
+// Transactor executa operações de vários repositórios em uma única transação. A transação é propagada pelo
+// contexto recebido por fn: os repositórios que obtêm a conexão por meio de conexao participam dela, e
+// uma chamada aninhada reaproveita a transação em andamento.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// GormTransactor implementa Transactor usando GORM
+type GormTransactor struct {
+	db *gorm.DB
+}
+
+// NewGormTransactor cria uma nova instância de GormTransactor
+func NewGormTransactor(db *gorm.DB) *GormTransactor {
+	return &GormTransactor{db: db}
+}
+
+// Transaction executa fn dentro de uma transação, confirmada quando fn não retorna erro
+func (t *GormTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(transacaoKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, transacaoKey{}, tx))
+	})
+}
+
+// conexao retorna a transação em andamento no contexto ou, fora de uma transação, a conexão do repositório
+func conexao(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transacaoKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
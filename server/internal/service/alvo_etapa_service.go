@@ -0,0 +1,590 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrAlvoNotFound            = errors.New("alvo não encontrado")
+	ErrAlvoForaDaEtapa         = errors.New("o alvo não pertence à etapa da tentativa")
+	ErrAlvoAguardando          = errors.New("o alvo ainda não foi introduzido no ensino")
+	ErrSequenciaAlvosInvalida  = errors.New("a nova ordem deve conter todos os alvos da etapa exatamente uma vez")
+	ErrSondagemNotFound        = errors.New("sondagem de generalização não encontrada")
+	ErrSondagemRealizada       = errors.New("a sondagem de generalização já foi realizada")
+	ErrSondagemAlvoNaoDominado = errors.New("apenas alvos dominados podem ter sondagens de generalização")
+)
+
+// AlvoEtapaService encapsula a lógica de negócio do banco de alvos das etapas: rotação dos alvos
+// entre aquisição, generalização e manutenção e sondagens de generalização
+type AlvoEtapaService struct {
+	repo         repository.AlvoEtapaRepository
+	sondagemRepo repository.SondagemGeneralizacaoRepository
+	etapaRepo    repository.EtapaProgramaRepository
+	programaRepo repository.ProgramaABARepository
+	coletaRepo   repository.ColetaABARepository
+	sessaoRepo   repository.SessaoRepository
+}
+
+// NewAlvoEtapaService cria uma nova instância de AlvoEtapaService
+func NewAlvoEtapaService(
+	repo repository.AlvoEtapaRepository,
+	sondagemRepo repository.SondagemGeneralizacaoRepository,
+	etapaRepo repository.EtapaProgramaRepository,
+	programaRepo repository.ProgramaABARepository,
+	coletaRepo repository.ColetaABARepository,
+	sessaoRepo repository.SessaoRepository,
+) *AlvoEtapaService {
+	return &AlvoEtapaService{
+		repo:         repo,
+		sondagemRepo: sondagemRepo,
+		etapaRepo:    etapaRepo,
+		programaRepo: programaRepo,
+		coletaRepo:   coletaRepo,
+		sessaoRepo:   sessaoRepo,
+	}
+}
+
+// CreateAlvo inclui um alvo ao final do banco de alvos da etapa e o introduz no ensino quando há vaga na rotação
+func (s *AlvoEtapaService) CreateAlvo(ctx context.Context, programaID, etapaID uuid.UUID, req *models.CreateAlvoEtapaRequest) (*models.AlvoEtapa, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	etapa, err := s.getEtapa(ctx, programaID, etapaID)
+	if err != nil {
+		return nil, err
+	}
+	alvos, err := s.repo.ListByEtapa(ctx, etapaID)
+	if err != nil {
+		return nil, err
+	}
+
+	alvo := req.ToAlvoEtapa(etapaID)
+	alvo.Ordem = 1
+	if len(alvos) > 0 {
+		alvo.Ordem = alvos[len(alvos)-1].Ordem + 1
+	}
+	if err := s.repo.Create(ctx, alvo); err != nil {
+		return nil, err
+	}
+
+	if err := s.rotacionar(ctx, etapa, append(alvos, alvo)); err != nil {
+		return nil, err
+	}
+	return alvo, nil
+}
+
+// GetAlvo busca um alvo pelo ID, garantindo que pertence à etapa e ao programa informados
+func (s *AlvoEtapaService) GetAlvo(ctx context.Context, programaID, etapaID, id uuid.UUID) (*models.AlvoEtapa, error) {
+	if _, err := s.getEtapa(ctx, programaID, etapaID); err != nil {
+		return nil, err
+	}
+	alvo, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if alvo == nil || alvo.EtapaProgramaID != etapaID {
+		return nil, ErrAlvoNotFound
+	}
+	return alvo, nil
+}
+
+// UpdateAlvo atualiza um alvo. A mudança manual de situação registra ou limpa a data de domínio
+// e abre a vaga do alvo na rotação quando ele sai da aquisição.
+func (s *AlvoEtapaService) UpdateAlvo(ctx context.Context, programaID, etapaID, id uuid.UUID, req *models.UpdateAlvoEtapaRequest) (*models.AlvoEtapa, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	alvo, err := s.GetAlvo(ctx, programaID, etapaID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Descricao != nil {
+		alvo.Descricao = *req.Descricao
+	}
+	if req.Status != nil && *req.Status != alvo.Status {
+		alvo.Status = *req.Status
+		switch alvo.Status {
+		case models.StatusAlvoGeneralizacao, models.StatusAlvoManutencao:
+			if alvo.DominadoEm == nil {
+				agora := time.Now()
+				alvo.DominadoEm = &agora
+			}
+		default:
+			alvo.DominadoEm = nil
+		}
+	}
+	if err := s.repo.Update(ctx, alvo); err != nil {
+		return nil, err
+	}
+
+	if err := s.rotacionarEtapa(ctx, etapaID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// DeleteAlvo exclui um alvo e abre a sua vaga na rotação. As coletas já registradas são preservadas.
+func (s *AlvoEtapaService) DeleteAlvo(ctx context.Context, programaID, etapaID, id uuid.UUID) error {
+	if _, err := s.GetAlvo(ctx, programaID, etapaID, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.rotacionarEtapa(ctx, etapaID)
+}
+
+// ListAlvos retorna o banco de alvos de uma etapa na ordem de introdução
+func (s *AlvoEtapaService) ListAlvos(ctx context.Context, programaID, etapaID uuid.UUID) ([]*models.AlvoEtapa, error) {
+	if _, err := s.getEtapa(ctx, programaID, etapaID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByEtapa(ctx, etapaID)
+}
+
+// ReordenarAlvos redefine a ordem de introdução dos alvos de uma etapa
+func (s *AlvoEtapaService) ReordenarAlvos(ctx context.Context, programaID, etapaID uuid.UUID, req *models.ReordenarAlvosRequest) ([]*models.AlvoEtapa, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	etapa, err := s.getEtapa(ctx, programaID, etapaID)
+	if err != nil {
+		return nil, err
+	}
+	alvos, err := s.repo.ListByEtapa(ctx, etapaID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.AlvoIDs) != len(alvos) {
+		return nil, ErrSequenciaAlvosInvalida
+	}
+	porID := make(map[uuid.UUID]*models.AlvoEtapa, len(alvos))
+	for _, alvo := range alvos {
+		porID[alvo.ID] = alvo
+	}
+
+	sequencia := make([]*models.AlvoEtapa, 0, len(alvos))
+	for i, id := range req.AlvoIDs {
+		alvo, ok := porID[id]
+		if !ok {
+			return nil, ErrSequenciaAlvosInvalida
+		}
+		delete(porID, id)
+		alvo.Ordem = i + 1
+		sequencia = append(sequencia, alvo)
+	}
+	if err := s.repo.SaveAll(ctx, sequencia); err != nil {
+		return nil, err
+	}
+
+	if err := s.rotacionar(ctx, etapa, sequencia); err != nil {
+		return nil, err
+	}
+	return sequencia, nil
+}
+
+// AvaliarSessao avalia o critério de domínio dos alvos em aquisição trabalhados em uma sessão encerrada.
+// Os alvos dominados passam para generalização ou manutenção e os próximos alvos da etapa são introduzidos.
+func (s *AlvoEtapaService) AvaliarSessao(ctx context.Context, sessao *models.Sessao) ([]*models.AlvoEtapa, error) {
+	coletas, err := s.coletaRepo.ListAllBySessao(ctx, sessao.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var alvoIDs []uuid.UUID
+	vistos := make(map[uuid.UUID]bool)
+	for _, coleta := range coletas {
+		if coleta.AlvoID != nil && !vistos[*coleta.AlvoID] {
+			vistos[*coleta.AlvoID] = true
+			alvoIDs = append(alvoIDs, *coleta.AlvoID)
+		}
+	}
+
+	dominados := make([]*models.AlvoEtapa, 0)
+	etapas := make(map[uuid.UUID]*models.EtapaPrograma)
+	for _, alvoID := range alvoIDs {
+		alvo, err := s.repo.GetByID(ctx, alvoID)
+		if err != nil {
+			return nil, err
+		}
+		if alvo == nil || alvo.Status != models.StatusAlvoAquisicao {
+			continue
+		}
+		etapa, ok := etapas[alvo.EtapaProgramaID]
+		if !ok {
+			if etapa, err = s.etapaRepo.GetByID(ctx, alvo.EtapaProgramaID); err != nil {
+				return nil, err
+			}
+			etapas[alvo.EtapaProgramaID] = etapa
+		}
+		if etapa == nil || !etapa.RotacaoAlvos.Configurada() {
+			continue
+		}
+
+		atingido, err := s.criterioAtingido(ctx, etapa, alvo)
+		if err != nil {
+			return nil, err
+		}
+		if !atingido {
+			continue
+		}
+
+		dominadoEm := sessao.Data
+		alvo.DominadoEm = &dominadoEm
+		alvo.Status = models.StatusAlvoManutencao
+		if etapa.RotacaoAlvos.ExigirGeneralizacao {
+			alvo.Status = models.StatusAlvoGeneralizacao
+		}
+		if err := s.repo.Update(ctx, alvo); err != nil {
+			return nil, err
+		}
+		dominados = append(dominados, alvo)
+	}
+
+	for etapaID, etapa := range etapas {
+		if etapa == nil {
+			continue
+		}
+		if err := s.rotacionarEtapa(ctx, etapaID); err != nil {
+			return nil, err
+		}
+	}
+	return dominados, nil
+}
+
+// PlanoSessao monta o plano de alvos da sessão para os programas ativos do paciente: os alvos em aquisição,
+// os alvos em manutenção cuja sondagem intermitente cabe nesta sessão e as sondagens de generalização
+// agendadas até o dia da sessão
+func (s *AlvoEtapaService) PlanoSessao(ctx context.Context, sessaoID uuid.UUID) (*models.PlanoAlvosSessao, error) {
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sessao == nil {
+		return nil, ErrSessaoNotFound
+	}
+
+	programas, err := s.programaRepo.ListByPacienteAndStatus(ctx, sessao.PacienteID, models.StatusProgramaAtivo)
+	if err != nil {
+		return nil, err
+	}
+	plano := &models.PlanoAlvosSessao{SessaoID: sessao.ID, Etapas: make([]models.PlanoAlvosEtapa, 0)}
+	ate := time.Date(sessao.Data.Year(), sessao.Data.Month(), sessao.Data.Day()+1, 0, 0, 0, 0, sessao.Data.Location())
+	for _, programa := range programas {
+		etapas, err := s.etapaRepo.ListByPrograma(ctx, programa.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, etapa := range filtrarAtivas(etapas) {
+			item, err := s.planoEtapa(ctx, etapa, ate)
+			if err != nil {
+				return nil, err
+			}
+			if len(item.Aquisicao)+len(item.Manutencao)+len(item.Generalizacao) > 0 {
+				plano.Etapas = append(plano.Etapas, *item)
+			}
+		}
+	}
+	return plano, nil
+}
+
+// CreateSondagem agenda uma sondagem de generalização para um alvo dominado
+func (s *AlvoEtapaService) CreateSondagem(ctx context.Context, programaID, etapaID, alvoID uuid.UUID, req *models.CreateSondagemGeneralizacaoRequest) (*models.SondagemGeneralizacao, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	alvo, err := s.GetAlvo(ctx, programaID, etapaID, alvoID)
+	if err != nil {
+		return nil, err
+	}
+	if alvo.Status != models.StatusAlvoGeneralizacao && alvo.Status != models.StatusAlvoManutencao {
+		return nil, ErrSondagemAlvoNaoDominado
+	}
+
+	sondagem := req.ToSondagemGeneralizacao(alvoID)
+	if err := s.sondagemRepo.Create(ctx, sondagem); err != nil {
+		return nil, err
+	}
+	return sondagem, nil
+}
+
+// ListSondagens retorna as sondagens de generalização de um alvo em ordem de agendamento
+func (s *AlvoEtapaService) ListSondagens(ctx context.Context, programaID, etapaID, alvoID uuid.UUID) ([]*models.SondagemGeneralizacao, error) {
+	if _, err := s.GetAlvo(ctx, programaID, etapaID, alvoID); err != nil {
+		return nil, err
+	}
+	return s.sondagemRepo.ListByAlvo(ctx, alvoID)
+}
+
+// RegistrarSondagem registra o resultado de uma sondagem de generalização. Quando todas as sondagens de um
+// alvo em generalização foram realizadas com acerto, o alvo passa para a manutenção.
+func (s *AlvoEtapaService) RegistrarSondagem(ctx context.Context, programaID, etapaID, alvoID, id uuid.UUID, req *models.RegistrarSondagemGeneralizacaoRequest) (*models.SondagemGeneralizacao, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	alvo, sondagem, err := s.getSondagem(ctx, programaID, etapaID, alvoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if sondagem.Realizada() {
+		return nil, ErrSondagemRealizada
+	}
+	if req.SessaoID != nil {
+		if err := s.validarSessao(ctx, etapaID, *req.SessaoID); err != nil {
+			return nil, err
+		}
+	}
+
+	resultado := req.Resultado
+	agora := time.Now()
+	sondagem.Resultado = &resultado
+	sondagem.SessaoID = req.SessaoID
+	sondagem.RealizadaEm = &agora
+	sondagem.Observacoes = req.Observacoes
+	if err := s.sondagemRepo.Update(ctx, sondagem); err != nil {
+		return nil, err
+	}
+
+	if alvo.Status != models.StatusAlvoGeneralizacao {
+		return sondagem, nil
+	}
+	sondagens, err := s.sondagemRepo.ListByAlvo(ctx, alvoID)
+	if err != nil {
+		return nil, err
+	}
+	for _, outra := range sondagens {
+		if !outra.Realizada() || *outra.Resultado != models.ResultadoColetaAcerto {
+			return sondagem, nil
+		}
+	}
+	alvo.Status = models.StatusAlvoManutencao
+	if err := s.repo.Update(ctx, alvo); err != nil {
+		return nil, err
+	}
+	return sondagem, nil
+}
+
+// DeleteSondagem exclui uma sondagem de generalização
+func (s *AlvoEtapaService) DeleteSondagem(ctx context.Context, programaID, etapaID, alvoID, id uuid.UUID) error {
+	if _, _, err := s.getSondagem(ctx, programaID, etapaID, alvoID, id); err != nil {
+		return err
+	}
+	return s.sondagemRepo.Delete(ctx, id)
+}
+
+// planoEtapa separa os alvos de uma etapa entre aquisição, manutenção e generalização para uma sessão.
+// Os alvos em manutenção são sondados em rodízio: a cada sessão entram os sondados há mais tempo, em
+// quantidade suficiente para que cada um seja sondado a cada "sondagem_a_cada" sessões.
+func (s *AlvoEtapaService) planoEtapa(ctx context.Context, etapa *models.EtapaPrograma, ate time.Time) (*models.PlanoAlvosEtapa, error) {
+	item := &models.PlanoAlvosEtapa{
+		ProgramaID:    etapa.ProgramaID,
+		EtapaID:       etapa.ID,
+		Etapa:         etapa.Descricao,
+		Aquisicao:     make([]*models.AlvoEtapa, 0),
+		Manutencao:    make([]*models.AlvoEtapa, 0),
+		Generalizacao: make([]*models.SondagemGeneralizacao, 0),
+	}
+
+	alvos, err := s.repo.ListByEtapa(ctx, etapa.ID)
+	if err != nil {
+		return nil, err
+	}
+	var manutencao []*models.AlvoEtapa
+	var dominados []uuid.UUID
+	for _, alvo := range alvos {
+		switch alvo.Status {
+		case models.StatusAlvoAquisicao:
+			item.Aquisicao = append(item.Aquisicao, alvo)
+		case models.StatusAlvoManutencao:
+			manutencao = append(manutencao, alvo)
+			dominados = append(dominados, alvo.ID)
+		case models.StatusAlvoGeneralizacao:
+			dominados = append(dominados, alvo.ID)
+		}
+	}
+
+	sort.SliceStable(manutencao, func(i, j int) bool {
+		a, b := manutencao[i].UltimaColetaEm, manutencao[j].UltimaColetaEm
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+	aCada := etapa.RotacaoAlvos.SondagemACadaOuPadrao()
+	quantidade := (len(manutencao) + aCada - 1) / aCada
+	item.Manutencao = append(item.Manutencao, manutencao[:quantidade]...)
+
+	sondagens, err := s.sondagemRepo.ListPendentesByAlvos(ctx, dominados, ate)
+	if err != nil {
+		return nil, err
+	}
+	item.Generalizacao = append(item.Generalizacao, sondagens...)
+	return item, nil
+}
+
+// criterioAtingido verifica se o alvo atingiu o percentual de domínio da rotação nas sessões realizadas
+// mais recentes em que foi trabalhado, usando a métrica e o mínimo de tentativas do critério da etapa.
+// Sondagens de manutenção não contam e, num alvo reaberto, só contam as coletas posteriores à reabertura.
+func (s *AlvoEtapaService) criterioAtingido(ctx context.Context, etapa *models.EtapaPrograma, alvo *models.AlvoEtapa) (bool, error) {
+	rotacao := etapa.RotacaoAlvos
+	coletas, err := s.coletaRepo.ListTreinoByAlvo(ctx, alvo.ID, alvo.ReabertoEm)
+	if err != nil {
+		return false, err
+	}
+	porSessao := make(map[uuid.UUID][]*models.ColetaABA)
+	var sessaoIDs []uuid.UUID
+	for _, coleta := range coletas {
+		if _, ok := porSessao[coleta.SessaoID]; !ok {
+			sessaoIDs = append(sessaoIDs, coleta.SessaoID)
+		}
+		porSessao[coleta.SessaoID] = append(porSessao[coleta.SessaoID], coleta)
+	}
+
+	sessoes, err := s.sessaoRepo.ListByIDs(ctx, sessaoIDs)
+	if err != nil {
+		return false, err
+	}
+	sort.SliceStable(sessoes, func(i, j int) bool {
+		return sessoes[i].Data.After(sessoes[j].Data)
+	})
+
+	avaliadas := 0
+	for _, sessao := range sessoes {
+		if avaliadas == rotacao.SessoesDominio {
+			break
+		}
+		tentativas := porSessao[sessao.ID]
+		if sessao.Status != models.StatusSessaoRealizada || len(tentativas) < etapa.CriterioDominio.MinimoTentativas {
+			continue
+		}
+		if percentualCriterio(tentativas, etapa.CriterioDominio.MetricaOuPadrao()) < rotacao.PercentualDominio {
+			return false, nil
+		}
+		avaliadas++
+	}
+	return avaliadas == rotacao.SessoesDominio, nil
+}
+
+// rotacionarEtapa recarrega a etapa e o seu banco de alvos e aplica a rotação
+func (s *AlvoEtapaService) rotacionarEtapa(ctx context.Context, etapaID uuid.UUID) error {
+	etapa, err := s.etapaRepo.GetByID(ctx, etapaID)
+	if err != nil {
+		return err
+	}
+	if etapa == nil {
+		return nil
+	}
+	alvos, err := s.repo.ListByEtapa(ctx, etapaID)
+	if err != nil {
+		return err
+	}
+	return s.rotacionar(ctx, etapa, alvos)
+}
+
+// rotacionar introduz no ensino os próximos alvos aguardando e grava os alvos introduzidos
+func (s *AlvoEtapaService) rotacionar(ctx context.Context, etapa *models.EtapaPrograma, alvos []*models.AlvoEtapa) error {
+	introduzidos := introduzirAlvos(etapa, alvos)
+	if len(introduzidos) == 0 {
+		return nil
+	}
+	return s.repo.SaveAll(ctx, introduzidos)
+}
+
+// introduzirAlvos coloca em aquisição os próximos alvos aguardando, na ordem do banco, até preencher o número
+// de alvos simultâneos da etapa, e retorna os alvos alterados. Sem limite configurado, todos os alvos entram
+// em aquisição.
+func introduzirAlvos(etapa *models.EtapaPrograma, alvos []*models.AlvoEtapa) []*models.AlvoEtapa {
+	emAquisicao := 0
+	for _, alvo := range alvos {
+		if alvo.Status == models.StatusAlvoAquisicao {
+			emAquisicao++
+		}
+	}
+
+	var introduzidos []*models.AlvoEtapa
+	limite := etapa.RotacaoAlvos.AlvosSimultaneos
+	for _, alvo := range alvos {
+		if limite > 0 && emAquisicao >= limite {
+			break
+		}
+		if alvo.Status == models.StatusAlvoAguardando {
+			alvo.Status = models.StatusAlvoAquisicao
+			introduzidos = append(introduzidos, alvo)
+			emAquisicao++
+		}
+	}
+	return introduzidos
+}
+
+// getEtapa busca a etapa e garante que pertence ao programa informado
+func (s *AlvoEtapaService) getEtapa(ctx context.Context, programaID, etapaID uuid.UUID) (*models.EtapaPrograma, error) {
+	etapa, err := s.etapaRepo.GetByID(ctx, etapaID)
+	if err != nil {
+		return nil, err
+	}
+	if etapa == nil || etapa.ProgramaID != programaID {
+		return nil, ErrEtapaNotFound
+	}
+	return etapa, nil
+}
+
+// getSondagem busca o alvo e a sondagem, garantindo que a sondagem pertence ao alvo
+func (s *AlvoEtapaService) getSondagem(ctx context.Context, programaID, etapaID, alvoID, id uuid.UUID) (*models.AlvoEtapa, *models.SondagemGeneralizacao, error) {
+	alvo, err := s.GetAlvo(ctx, programaID, etapaID, alvoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sondagem, err := s.sondagemRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sondagem == nil || sondagem.AlvoID != alvoID {
+		return nil, nil, ErrSondagemNotFound
+	}
+	return alvo, sondagem, nil
+}
+
+// validarSessao garante que a sessão existe, não foi cancelada e pertence ao paciente do programa da etapa
+func (s *AlvoEtapaService) validarSessao(ctx context.Context, etapaID, sessaoID uuid.UUID) error {
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return err
+	}
+	if sessao == nil {
+		return ErrSessaoNotFound
+	}
+	if sessao.Status == models.StatusSessaoCancelada {
+		return ErrSessaoCancelada
+	}
+
+	etapa, err := s.etapaRepo.GetByID(ctx, etapaID)
+	if err != nil {
+		return err
+	}
+	if etapa == nil {
+		return ErrEtapaNotFound
+	}
+	programa, err := s.programaRepo.GetByID(ctx, etapa.ProgramaID)
+	if err != nil {
+		return err
+	}
+	if programa == nil || programa.PacienteID != sessao.PacienteID {
+		return ErrEtapaForaDoPaciente
+	}
+	return nil
+}
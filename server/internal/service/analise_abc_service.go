@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrCategoriaABCNotFound = errors.New("categoria ABC não encontrada")
+	ErrCategoriaABCTipo     = errors.New("a categoria informada não é do tipo esperado (antecedente ou consequência)")
+	ErrCategoriaABCInativa  = errors.New("a categoria ABC está inativa e não pode ser selecionada")
+)
+
+// AnaliseABCService encapsula a taxonomia configurável de antecedentes e consequências e a análise ABC
+// dos registros de comportamento, com a sugestão da função hipotética
+type AnaliseABCService struct {
+	repo              repository.CategoriaABCRepository
+	registroRepo      repository.RegistroComportamentoRepository
+	comportamentoRepo repository.ComportamentoAlvoRepository
+}
+
+// NewAnaliseABCService cria uma nova instância de AnaliseABCService
+func NewAnaliseABCService(repo repository.CategoriaABCRepository, registroRepo repository.RegistroComportamentoRepository, comportamentoRepo repository.ComportamentoAlvoRepository) *AnaliseABCService {
+	return &AnaliseABCService{repo: repo, registroRepo: registroRepo, comportamentoRepo: comportamentoRepo}
+}
+
+// CreateCategoria inclui uma categoria de antecedente ou de consequência na taxonomia
+func (s *AnaliseABCService) CreateCategoria(ctx context.Context, req *models.CreateCategoriaABCRequest) (*models.CategoriaABC, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	categoria := req.ToCategoriaABC()
+	if err := s.repo.Create(ctx, categoria); err != nil {
+		return nil, err
+	}
+	return categoria, nil
+}
+
+// GetCategoria busca uma categoria pelo ID
+func (s *AnaliseABCService) GetCategoria(ctx context.Context, id uuid.UUID) (*models.CategoriaABC, error) {
+	categoria, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if categoria == nil {
+		return nil, ErrCategoriaABCNotFound
+	}
+	return categoria, nil
+}
+
+// UpdateCategoria atualiza uma categoria existente. O tipo da categoria não pode ser alterado.
+func (s *AnaliseABCService) UpdateCategoria(ctx context.Context, id uuid.UUID, req *models.UpdateCategoriaABCRequest) (*models.CategoriaABC, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	categoria, err := s.GetCategoria(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	categoria.ApplyUpdates(req)
+	if err := s.repo.Update(ctx, categoria); err != nil {
+		return nil, err
+	}
+	return categoria, nil
+}
+
+// DeleteCategoria exclui uma categoria. Os registros já classificados com ela são preservados.
+func (s *AnaliseABCService) DeleteCategoria(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetCategoria(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListCategorias retorna as categorias da taxonomia, opcionalmente filtradas por tipo e apenas as ativas
+func (s *AnaliseABCService) ListCategorias(ctx context.Context, tipo *models.TipoCategoriaABC, apenasAtivas bool) ([]*models.CategoriaABC, error) {
+	return s.repo.List(ctx, tipo, apenasAtivas)
+}
+
+// AnalisarComportamento calcula, a partir dos registros do observador principal no intervalo [inicio, fim),
+// a probabilidade de cada antecedente e de cada consequência dado o comportamento, a probabilidade de cada
+// consequência dado o antecedente e a pontuação de cada função hipotética
+func (s *AnaliseABCService) AnalisarComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time) (*models.AnaliseABC, error) {
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, ErrPeriodoInvalido
+	}
+	comportamento, err := s.comportamentoRepo.GetByID(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if comportamento == nil {
+		return nil, ErrComportamentoNotFound
+	}
+
+	registros, err := s.registroRepo.ListAllByComportamento(ctx, comportamentoID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+
+	analise := &models.AnaliseABC{
+		ComportamentoID:        comportamentoID,
+		Inicio:                 inicio,
+		Fim:                    fim,
+		TotalRegistros:         len(registros),
+		Antecedentes:           []models.FrequenciaCategoriaABC{},
+		Consequencias:          []models.FrequenciaCategoriaABC{},
+		SequenciasAntecedentes: []models.ConsequenciasAntecedente{},
+		Funcoes:                []models.PontuacaoFuncao{},
+	}
+
+	antecedentes := make(map[uuid.UUID]int)
+	consequencias := make(map[uuid.UUID]int)
+	sequencias := make(map[uuid.UUID]map[uuid.UUID]int)
+	var ids []uuid.UUID
+	vistas := make(map[uuid.UUID]bool)
+	incluir := func(id uuid.UUID) {
+		if !vistas[id] {
+			vistas[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, registro := range registros {
+		if registro.AntecedenteID != nil {
+			analise.RegistrosAntecedente++
+			antecedentes[*registro.AntecedenteID]++
+			incluir(*registro.AntecedenteID)
+		}
+		if registro.ConsequenciaID != nil {
+			analise.RegistrosConsequencia++
+			consequencias[*registro.ConsequenciaID]++
+			incluir(*registro.ConsequenciaID)
+		}
+		if registro.AntecedenteID != nil && registro.ConsequenciaID != nil {
+			if sequencias[*registro.AntecedenteID] == nil {
+				sequencias[*registro.AntecedenteID] = make(map[uuid.UUID]int)
+			}
+			sequencias[*registro.AntecedenteID][*registro.ConsequenciaID]++
+		}
+	}
+
+	lista, err := s.repo.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	categorias := make(map[uuid.UUID]*models.CategoriaABC, len(lista))
+	for _, categoria := range lista {
+		categorias[categoria.ID] = categoria
+	}
+
+	analise.Antecedentes = frequenciasABC(antecedentes, analise.RegistrosAntecedente, categorias)
+	analise.Consequencias = frequenciasABC(consequencias, analise.RegistrosConsequencia, categorias)
+	for _, antecedente := range analise.Antecedentes {
+		seguintes := sequencias[antecedente.CategoriaID]
+		total := 0
+		for _, ocorrencias := range seguintes {
+			total += ocorrencias
+		}
+		if total == 0 {
+			continue
+		}
+		analise.SequenciasAntecedentes = append(analise.SequenciasAntecedentes, models.ConsequenciasAntecedente{
+			AntecedenteID: antecedente.CategoriaID,
+			Nome:          antecedente.Nome,
+			Ocorrencias:   total,
+			Consequencias: frequenciasABC(seguintes, total, categorias),
+		})
+	}
+
+	analise.Funcoes, analise.FuncaoHipotetica = pontuarFuncoes(analise)
+	analise.AmostraSuficiente = analise.RegistrosAntecedente >= models.MinimoRegistrosAnaliseABC ||
+		analise.RegistrosConsequencia >= models.MinimoRegistrosAnaliseABC
+	return analise, nil
+}
+
+// validarCategoriaABC garante que a categoria selecionada em um registro existe e é do tipo esperado.
+// Uma categoria inativa só é aceita quando já era a categoria do registro.
+func validarCategoriaABC(ctx context.Context, repo repository.CategoriaABCRepository, id, atual *uuid.UUID, tipo models.TipoCategoriaABC) error {
+	if id == nil || *id == uuid.Nil {
+		return nil
+	}
+
+	categoria, err := repo.GetByID(ctx, *id)
+	if err != nil {
+		return err
+	}
+	if categoria == nil {
+		return ErrCategoriaABCNotFound
+	}
+	if categoria.Tipo != tipo {
+		return ErrCategoriaABCTipo
+	}
+	if !categoria.Ativa && (atual == nil || *atual != *id) {
+		return ErrCategoriaABCInativa
+	}
+	return nil
+}
+
+// frequenciasABC converte a contagem de cada categoria em frequências com a probabilidade em relação ao total,
+// das mais frequentes para as menos frequentes
+func frequenciasABC(contagem map[uuid.UUID]int, total int, categorias map[uuid.UUID]*models.CategoriaABC) []models.FrequenciaCategoriaABC {
+	frequencias := make([]models.FrequenciaCategoriaABC, 0, len(contagem))
+	for id, ocorrencias := range contagem {
+		frequencia := models.FrequenciaCategoriaABC{
+			CategoriaID:   id,
+			Ocorrencias:   ocorrencias,
+			Probabilidade: percentual(ocorrencias, total),
+		}
+		if categoria, ok := categorias[id]; ok {
+			frequencia.Nome = categoria.Nome
+			frequencia.Funcao = categoria.Funcao
+		}
+		frequencias = append(frequencias, frequencia)
+	}
+	sort.SliceStable(frequencias, func(i, j int) bool {
+		if frequencias[i].Ocorrencias != frequencias[j].Ocorrencias {
+			return frequencias[i].Ocorrencias > frequencias[j].Ocorrencias
+		}
+		return frequencias[i].Nome < frequencias[j].Nome
+	})
+	return frequencias
+}
+
+// pontuarFuncoes soma, para cada função, a probabilidade dos antecedentes e das consequências que a sugerem
+// e retorna a função de maior pontuação, ou nula quando nenhuma pontua ou há empate na primeira posição
+func pontuarFuncoes(analise *models.AnaliseABC) ([]models.PontuacaoFuncao, *models.FuncaoComportamento) {
+	soma := func(frequencias []models.FrequenciaCategoriaABC, funcao models.FuncaoComportamento) float64 {
+		var total float64
+		for _, frequencia := range frequencias {
+			if frequencia.Funcao != nil && *frequencia.Funcao == funcao {
+				total += frequencia.Probabilidade
+			}
+		}
+		return total
+	}
+
+	pontuacoes := make([]models.PontuacaoFuncao, 0, len(models.FuncoesComportamento))
+	for _, funcao := range models.FuncoesComportamento {
+		pontuacao := models.PontuacaoFuncao{
+			Funcao:        funcao,
+			Antecedentes:  soma(analise.Antecedentes, funcao),
+			Consequencias: soma(analise.Consequencias, funcao),
+		}
+		switch {
+		case analise.RegistrosAntecedente > 0 && analise.RegistrosConsequencia > 0:
+			pontuacao.Pontuacao = (pontuacao.Antecedentes + pontuacao.Consequencias) / 2
+		case analise.RegistrosAntecedente > 0:
+			pontuacao.Pontuacao = pontuacao.Antecedentes
+		default:
+			pontuacao.Pontuacao = pontuacao.Consequencias
+		}
+		pontuacoes = append(pontuacoes, pontuacao)
+	}
+
+	var hipotetica *models.FuncaoComportamento
+	maior, empate := 0.0, false
+	for i := range pontuacoes {
+		switch {
+		case pontuacoes[i].Pontuacao > maior:
+			maior, empate = pontuacoes[i].Pontuacao, false
+			hipotetica = &pontuacoes[i].Funcao
+		case pontuacoes[i].Pontuacao == maior && maior > 0:
+			empate = true
+		}
+	}
+	if empate {
+		return pontuacoes, nil
+	}
+	return pontuacoes, hipotetica
+}
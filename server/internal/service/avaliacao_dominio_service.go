@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// AvaliacaoDominioService avalia os critérios de domínio das etapas com base nas coletas ABA
+// e promove as etapas que atingiram o critério
+type AvaliacaoDominioService struct {
+	coletaRepo   repository.ColetaABARepository
+	sessaoRepo   repository.SessaoRepository
+	etapaRepo    repository.EtapaProgramaRepository
+	etapaService *EtapaProgramaService
+}
+
+// NewAvaliacaoDominioService cria uma nova instância de AvaliacaoDominioService
+func NewAvaliacaoDominioService(
+	coletaRepo repository.ColetaABARepository,
+	sessaoRepo repository.SessaoRepository,
+	etapaRepo repository.EtapaProgramaRepository,
+	etapaService *EtapaProgramaService,
+) *AvaliacaoDominioService {
+	return &AvaliacaoDominioService{
+		coletaRepo:   coletaRepo,
+		sessaoRepo:   sessaoRepo,
+		etapaRepo:    etapaRepo,
+		etapaService: etapaService,
+	}
+}
+
+// AvaliarSessao avalia as etapas em treino trabalhadas em uma sessão encerrada e marca como
+// dominadas as que atingiram o critério, abrindo a próxima etapa de cada programa
+func (s *AvaliacaoDominioService) AvaliarSessao(ctx context.Context, sessao *models.Sessao) ([]*models.ResultadoAvaliacaoDominio, error) {
+	coletas, err := s.coletaRepo.ListAllBySessao(ctx, sessao.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var etapaIDs []uuid.UUID
+	vistas := make(map[uuid.UUID]bool)
+	for _, coleta := range coletas {
+		if !vistas[coleta.EtapaProgramaID] {
+			vistas[coleta.EtapaProgramaID] = true
+			etapaIDs = append(etapaIDs, coleta.EtapaProgramaID)
+		}
+	}
+
+	resultados := make([]*models.ResultadoAvaliacaoDominio, 0, len(etapaIDs))
+	for _, etapaID := range etapaIDs {
+		etapa, err := s.etapaRepo.GetByID(ctx, etapaID)
+		if err != nil {
+			return nil, err
+		}
+		if etapa == nil || etapa.Status != models.StatusEtapaEmTreino || !etapa.CriterioDominio.Configurado() {
+			continue
+		}
+
+		resultado, err := s.AvaliarEtapa(ctx, etapa)
+		if err != nil {
+			return nil, err
+		}
+		resultados = append(resultados, resultado)
+		if !resultado.Atingido {
+			continue
+		}
+
+		sessaoID := sessao.ID
+		if _, _, err := s.etapaService.MarcarDominada(ctx, etapa.ProgramaID, etapa.ID, resultado.Motivo, &sessaoID); err != nil {
+			return nil, err
+		}
+	}
+	return resultados, nil
+}
+
+// AvaliarEtapa verifica se as sessões realizadas mais recentes da etapa atendem ao critério de domínio.
+// Só contam as coletas de treino: as sondagens de manutenção ficam de fora e, numa etapa reaberta, apenas as
+// coletas posteriores à reabertura são avaliadas.
+func (s *AvaliacaoDominioService) AvaliarEtapa(ctx context.Context, etapa *models.EtapaPrograma) (*models.ResultadoAvaliacaoDominio, error) {
+	criterio := etapa.CriterioDominio
+	resultado := &models.ResultadoAvaliacaoDominio{
+		EtapaProgramaID:  etapa.ID,
+		Criterio:         criterio,
+		SessoesAvaliadas: []uuid.UUID{},
+		Percentuais:      []float64{},
+	}
+	if !criterio.Configurado() {
+		resultado.Motivo = "a etapa não possui critério de domínio estruturado"
+		return resultado, nil
+	}
+
+	coletas, err := s.coletaRepo.ListTreinoByEtapa(ctx, etapa.ID, etapa.ReabertaEm)
+	if err != nil {
+		return nil, err
+	}
+	porSessao := make(map[uuid.UUID][]*models.ColetaABA)
+	var sessaoIDs []uuid.UUID
+	for _, coleta := range coletas {
+		if _, ok := porSessao[coleta.SessaoID]; !ok {
+			sessaoIDs = append(sessaoIDs, coleta.SessaoID)
+		}
+		porSessao[coleta.SessaoID] = append(porSessao[coleta.SessaoID], coleta)
+	}
+
+	sessoes, err := s.sessaoRepo.ListByIDs(ctx, sessaoIDs)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(sessoes, func(i, j int) bool {
+		return sessoes[i].Data.After(sessoes[j].Data)
+	})
+
+	terapeutas := make(map[uuid.UUID]bool)
+	for _, sessao := range sessoes {
+		if len(resultado.SessoesAvaliadas) == criterio.SessoesConsecutivas {
+			break
+		}
+		tentativas := porSessao[sessao.ID]
+		if sessao.Status != models.StatusSessaoRealizada || len(tentativas) < criterio.MinimoTentativas {
+			continue
+		}
+		resultado.SessoesAvaliadas = append(resultado.SessoesAvaliadas, sessao.ID)
+		resultado.Percentuais = append(resultado.Percentuais, percentualCriterio(tentativas, criterio.MetricaOuPadrao()))
+		terapeutas[sessao.TerapeutaID] = true
+	}
+	resultado.Terapeutas = len(terapeutas)
+
+	if len(resultado.SessoesAvaliadas) < criterio.SessoesConsecutivas {
+		resultado.Motivo = fmt.Sprintf("há apenas %d de %d sessões realizadas com dados suficientes",
+			len(resultado.SessoesAvaliadas), criterio.SessoesConsecutivas)
+		return resultado, nil
+	}
+	for _, valor := range resultado.Percentuais {
+		if valor < criterio.PercentualMinimo {
+			resultado.Motivo = fmt.Sprintf("nem todas as %d últimas sessões atingiram %.0f%% de respostas (%s)",
+				criterio.SessoesConsecutivas, criterio.PercentualMinimo, criterio.MetricaOuPadrao())
+			return resultado, nil
+		}
+	}
+	if resultado.Terapeutas < criterio.MinimoTerapeutas {
+		resultado.Motivo = fmt.Sprintf("as sessões foram conduzidas por %d terapeuta(s), o critério exige %d",
+			resultado.Terapeutas, criterio.MinimoTerapeutas)
+		return resultado, nil
+	}
+
+	resultado.Atingido = true
+	resultado.Motivo = fmt.Sprintf("critério atingido: %.0f%% de respostas (%s) em %d sessões consecutivas com %d terapeuta(s)",
+		criterio.PercentualMinimo, criterio.MetricaOuPadrao(), criterio.SessoesConsecutivas, resultado.Terapeutas)
+	return resultado, nil
+}
+
+// AvaliarEtapaDoPrograma avalia o critério de domínio de uma etapa sem alterar sua situação
+func (s *AvaliacaoDominioService) AvaliarEtapaDoPrograma(ctx context.Context, programaID, etapaID uuid.UUID) (*models.ResultadoAvaliacaoDominio, error) {
+	etapa, err := s.etapaService.GetEtapa(ctx, programaID, etapaID)
+	if err != nil {
+		return nil, err
+	}
+	return s.AvaliarEtapa(ctx, etapa)
+}
+
+// percentualCriterio calcula o percentual de tentativas que contam para a métrica do critério
+func percentualCriterio(coletas []*models.ColetaABA, metrica models.MetricaDominio) float64 {
+	contam := 0
+	for _, coleta := range coletas {
+		switch metrica {
+		case models.MetricaDominioAcerto:
+			if coleta.Resultado == models.ResultadoColetaAcerto {
+				contam++
+			}
+		default:
+			if coleta.IsIndependente() {
+				contam++
+			}
+		}
+	}
+	return percentual(contam, len(coletas))
+}
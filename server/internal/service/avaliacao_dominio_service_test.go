@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+)
+
+func TestPercentualCriterio(t *testing.T) {
+	prompt := uuid.New()
+	coletas := []*models.ColetaABA{
+		{Resultado: models.ResultadoColetaAcerto},
+		{Resultado: models.ResultadoColetaAcerto, PromptUtilizadoID: prompt},
+		{Resultado: models.ResultadoColetaAjuda, PromptUtilizadoID: prompt},
+		{Resultado: models.ResultadoColetaErro},
+	}
+
+	tests := []struct {
+		nome    string
+		coletas []*models.ColetaABA
+		metrica models.MetricaDominio
+		want    float64
+	}{
+		{"acertos com e sem prompt contam na métrica de acerto", coletas, models.MetricaDominioAcerto, 50},
+		{"só acertos sem prompt contam na métrica independente", coletas, models.MetricaDominioIndependente, 25},
+		{"métrica desconhecida usa a independente", coletas, "", 25},
+		{"sem coletas", nil, models.MetricaDominioAcerto, 0},
+		{"todas independentes", coletas[:1], models.MetricaDominioIndependente, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			if got := percentualCriterio(tt.coletas, tt.metrica); got != tt.want {
+				t.Errorf("percentualCriterio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
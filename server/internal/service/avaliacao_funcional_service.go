@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrAvaliacaoFuncionalNotFound       = errors.New("avaliação funcional não encontrada")
+	ErrAvaliacaoFuncionalBloqueada      = errors.New("a avaliação funcional só pode ser alterada enquanto estiver em rascunho")
+	ErrAvaliacaoFuncionalIncompleta     = errors.New("a avaliação funcional precisa do período dos registros ABC, da função hipotética e do grau de confiança para ser enviada à revisão")
+	ErrTransicaoAvaliacaoFuncional      = errors.New("transição de situação não permitida para a avaliação funcional")
+	ErrComportamentoForaDoPaciente      = errors.New("o comportamento alvo não pertence ao paciente")
+	ErrComportamentoNaoInadequado       = errors.New("a avaliação funcional e o plano de intervenção só podem incluir comportamentos inadequados")
+	ErrComportamentosAvaliacaoDuplicado = errors.New("o mesmo comportamento foi informado mais de uma vez")
+)
+
+// AvaliacaoFuncionalService encapsula a lógica de negócio das avaliações funcionais do comportamento (FBA):
+// o registro das avaliações indiretas, os dados descritivos ABC, a função hipotética e o fluxo de
+// rascunho, revisão e aprovação com a assinatura do avaliador
+type AvaliacaoFuncionalService struct {
+	repo              repository.AvaliacaoFuncionalRepository
+	comportamentoRepo repository.ComportamentoAlvoRepository
+	auditoriaRepo     repository.EventoAuditoriaRepository
+	analiseABCService *AnaliseABCService
+	transactor        repository.Transactor
+}
+
+// NewAvaliacaoFuncionalService cria uma nova instância de AvaliacaoFuncionalService
+func NewAvaliacaoFuncionalService(repo repository.AvaliacaoFuncionalRepository, comportamentoRepo repository.ComportamentoAlvoRepository, auditoriaRepo repository.EventoAuditoriaRepository, analiseABCService *AnaliseABCService, transactor repository.Transactor) *AvaliacaoFuncionalService {
+	return &AvaliacaoFuncionalService{
+		repo:              repo,
+		comportamentoRepo: comportamentoRepo,
+		auditoriaRepo:     auditoriaRepo,
+		analiseABCService: analiseABCService,
+		transactor:        transactor,
+	}
+}
+
+// CreateAvaliacao abre uma avaliação funcional em rascunho para comportamentos inadequados do paciente
+func (s *AvaliacaoFuncionalService) CreateAvaliacao(ctx context.Context, pacienteID uuid.UUID, req *models.CreateAvaliacaoFuncionalRequest, usuarioID string) (*models.AvaliacaoFuncional, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if req.PeriodoInicio != nil && req.PeriodoFim != nil && !req.PeriodoInicio.Before(*req.PeriodoFim) {
+		return nil, ErrPeriodoInvalido
+	}
+
+	avaliacao := req.ToAvaliacaoFuncional(pacienteID, usuarioID)
+	comportamentos, err := s.comportamentosAvaliacao(ctx, pacienteID, req.ComportamentoIDs)
+	if err != nil {
+		return nil, err
+	}
+	avaliacao.Comportamentos = comportamentos
+	avaliacao.Respostas = respostasAvaliacaoFuncional(req.Respostas)
+
+	if err := s.repo.Create(ctx, avaliacao); err != nil {
+		return nil, err
+	}
+	if err := s.registrarTransicao(ctx, avaliacao, "", "", usuarioID); err != nil {
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+// GetAvaliacao busca uma avaliação pelo ID, garantindo que pertence ao paciente informado,
+// com os dados descritivos ABC de cada comportamento no período da avaliação. No rascunho, os dados
+// são calculados a partir dos registros atuais; depois do envio à revisão, vêm da cópia feita no envio.
+func (s *AvaliacaoFuncionalService) GetAvaliacao(ctx context.Context, pacienteID, id uuid.UUID) (*models.AvaliacaoFuncional, error) {
+	avaliacao, err := s.getAvaliacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if avaliacao.Status != models.StatusAvaliacaoFuncionalRascunho {
+		avaliacao.DadosABC = make([]models.AnaliseABC, 0, len(avaliacao.CopiaDadosABC))
+		for i := range avaliacao.CopiaDadosABC {
+			avaliacao.DadosABC = append(avaliacao.DadosABC, avaliacao.CopiaDadosABC[i].AnaliseABC())
+		}
+		return avaliacao, nil
+	}
+
+	if avaliacao.DadosABC, err = s.analisarDadosABC(ctx, avaliacao); err != nil {
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+// UpdateAvaliacao atualiza uma avaliação em rascunho
+func (s *AvaliacaoFuncionalService) UpdateAvaliacao(ctx context.Context, pacienteID, id uuid.UUID, req *models.UpdateAvaliacaoFuncionalRequest) (*models.AvaliacaoFuncional, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	avaliacao, err := s.getAvaliacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if avaliacao.Status != models.StatusAvaliacaoFuncionalRascunho {
+		return nil, ErrAvaliacaoFuncionalBloqueada
+	}
+
+	avaliacao.ApplyUpdates(req)
+	if avaliacao.PeriodoInicio != nil && avaliacao.PeriodoFim != nil && !avaliacao.PeriodoInicio.Before(*avaliacao.PeriodoFim) {
+		return nil, ErrPeriodoInvalido
+	}
+	if req.ComportamentoIDs != nil {
+		if avaliacao.Comportamentos, err = s.comportamentosAvaliacao(ctx, pacienteID, req.ComportamentoIDs); err != nil {
+			return nil, err
+		}
+	}
+	if req.Respostas != nil {
+		avaliacao.Respostas = respostasAvaliacaoFuncional(req.Respostas)
+	}
+
+	if err := s.repo.Update(ctx, avaliacao); err != nil {
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+// DeleteAvaliacao exclui uma avaliação que ainda não foi aprovada
+func (s *AvaliacaoFuncionalService) DeleteAvaliacao(ctx context.Context, pacienteID, id uuid.UUID) error {
+	avaliacao, err := s.getAvaliacao(ctx, pacienteID, id)
+	if err != nil {
+		return err
+	}
+	if avaliacao.Status == models.StatusAvaliacaoFuncionalAprovada {
+		return ErrTransicaoAvaliacaoFuncional
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListAvaliacoes retorna uma lista paginada das avaliações funcionais de um paciente
+func (s *AvaliacaoFuncionalService) ListAvaliacoes(ctx context.Context, pacienteID uuid.UUID, status *models.StatusAvaliacaoFuncional, page, pageSize int) ([]*models.AvaliacaoFuncional, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	avaliacoes, err := s.repo.ListByPaciente(ctx, pacienteID, status, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByPaciente(ctx, pacienteID, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return avaliacoes, total, nil
+}
+
+// ListAvaliacoesComportamento retorna as avaliações funcionais que incluem o comportamento alvo
+func (s *AvaliacaoFuncionalService) ListAvaliacoesComportamento(ctx context.Context, comportamentoID uuid.UUID) ([]*models.AvaliacaoFuncional, error) {
+	comportamento, err := s.comportamentoRepo.GetByID(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if comportamento == nil {
+		return nil, ErrComportamentoNotFound
+	}
+	return s.repo.ListByComportamento(ctx, comportamentoID)
+}
+
+// EnviarRevisao envia uma avaliação em rascunho para revisão. A avaliação precisa do período dos registros
+// ABC, da função hipotética e do grau de confiança. A análise ABC do período é copiada para a avaliação,
+// e a revisão e a aprovação passam a considerar essa cópia.
+func (s *AvaliacaoFuncionalService) EnviarRevisao(ctx context.Context, pacienteID, id uuid.UUID, req *models.TransicaoAvaliacaoFuncionalRequest, usuarioID string) (*models.AvaliacaoFuncional, error) {
+	avaliacao, err := s.getAvaliacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if avaliacao.Status != models.StatusAvaliacaoFuncionalRascunho {
+		return nil, ErrTransicaoAvaliacaoFuncional
+	}
+	if avaliacao.PeriodoInicio == nil || avaliacao.PeriodoFim == nil || avaliacao.FuncaoHipotetica == nil || avaliacao.Confianca == nil {
+		return nil, ErrAvaliacaoFuncionalIncompleta
+	}
+
+	analises, err := s.analisarDadosABC(ctx, avaliacao)
+	if err != nil {
+		return nil, err
+	}
+	avaliacao.CopiaDadosABC = make([]models.DadosABCAvaliacaoFuncional, 0, len(analises))
+	for i, analise := range analises {
+		avaliacao.CopiaDadosABC = append(avaliacao.CopiaDadosABC, models.CopiarAnaliseABC(analise, i+1))
+	}
+	avaliacao.DadosABC = analises
+
+	agora := time.Now()
+	avaliacao.EnviadaEm = &agora
+	return s.transicionar(ctx, avaliacao, models.StatusAvaliacaoFuncionalEmRevisao, motivoTransicao(req), usuarioID)
+}
+
+// DevolverRascunho devolve uma avaliação em revisão ao rascunho para ajustes, descartando a cópia dos dados ABC
+func (s *AvaliacaoFuncionalService) DevolverRascunho(ctx context.Context, pacienteID, id uuid.UUID, req *models.TransicaoAvaliacaoFuncionalRequest, usuarioID string) (*models.AvaliacaoFuncional, error) {
+	avaliacao, err := s.getAvaliacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if avaliacao.Status != models.StatusAvaliacaoFuncionalEmRevisao {
+		return nil, ErrTransicaoAvaliacaoFuncional
+	}
+
+	avaliacao.EnviadaEm = nil
+	avaliacao.CopiaDadosABC = nil
+	return s.transicionar(ctx, avaliacao, models.StatusAvaliacaoFuncionalRascunho, motivoTransicao(req), usuarioID)
+}
+
+// Aprovar aprova uma avaliação em revisão, registrando a assinatura do avaliador. A assinatura vale para
+// os dados ABC copiados no envio à revisão.
+func (s *AvaliacaoFuncionalService) Aprovar(ctx context.Context, pacienteID, id uuid.UUID, req *models.AssinarAvaliacaoFuncionalRequest, usuarioID string) (*models.AvaliacaoFuncional, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	avaliacao, err := s.getAvaliacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if avaliacao.Status != models.StatusAvaliacaoFuncionalEmRevisao {
+		return nil, ErrTransicaoAvaliacaoFuncional
+	}
+
+	agora := time.Now()
+	avaliacao.Avaliador = req.Avaliador
+	avaliacao.RegistroProfissional = req.RegistroProfissional
+	avaliacao.AssinadaPor = usuarioID
+	avaliacao.AssinadaEm = &agora
+	return s.transicionar(ctx, avaliacao, models.StatusAvaliacaoFuncionalAprovada, req.Motivo, usuarioID)
+}
+
+// ListHistorico retorna os eventos de auditoria das mudanças de situação da avaliação
+func (s *AvaliacaoFuncionalService) ListHistorico(ctx context.Context, pacienteID, id uuid.UUID) ([]*models.EventoAuditoria, error) {
+	if _, err := s.getAvaliacao(ctx, pacienteID, id); err != nil {
+		return nil, err
+	}
+	return s.auditoriaRepo.ListByEntidades(ctx, []uuid.UUID{id})
+}
+
+// getAvaliacao busca a avaliação e garante que pertence ao paciente
+func (s *AvaliacaoFuncionalService) getAvaliacao(ctx context.Context, pacienteID, id uuid.UUID) (*models.AvaliacaoFuncional, error) {
+	avaliacao, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if avaliacao == nil || avaliacao.PacienteID != pacienteID {
+		return nil, ErrAvaliacaoFuncionalNotFound
+	}
+	return avaliacao, nil
+}
+
+// transicionar grava, na mesma transação, a nova situação da avaliação e o evento de auditoria correspondente.
+// A avaliação é bloqueada e a situação lida é conferida antes da gravação, para que transições simultâneas
+// não partam da mesma situação.
+func (s *AvaliacaoFuncionalService) transicionar(ctx context.Context, avaliacao *models.AvaliacaoFuncional, status models.StatusAvaliacaoFuncional, motivo, usuarioID string) (*models.AvaliacaoFuncional, error) {
+	anterior := avaliacao.Status
+	avaliacao.Status = status
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		atual, err := s.repo.LockStatus(ctx, avaliacao.ID)
+		if err != nil {
+			return err
+		}
+		if atual != anterior {
+			return ErrTransicaoAvaliacaoFuncional
+		}
+		if err := s.repo.Update(ctx, avaliacao); err != nil {
+			return err
+		}
+		return s.registrarTransicao(ctx, avaliacao, anterior, motivo, usuarioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+// analisarDadosABC calcula a análise ABC de cada comportamento da avaliação no período da avaliação
+func (s *AvaliacaoFuncionalService) analisarDadosABC(ctx context.Context, avaliacao *models.AvaliacaoFuncional) ([]models.AnaliseABC, error) {
+	analises := make([]models.AnaliseABC, 0, len(avaliacao.Comportamentos))
+	for _, comportamentoID := range avaliacao.ComportamentoIDs() {
+		analise, err := s.analiseABCService.AnalisarComportamento(ctx, comportamentoID, avaliacao.PeriodoInicio, avaliacao.PeriodoFim)
+		if errors.Is(err, ErrComportamentoNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		analises = append(analises, *analise)
+	}
+	return analises, nil
+}
+
+// registrarTransicao grava o evento de auditoria da mudança de situação da avaliação
+func (s *AvaliacaoFuncionalService) registrarTransicao(ctx context.Context, avaliacao *models.AvaliacaoFuncional, anterior models.StatusAvaliacaoFuncional, motivo, usuarioID string) error {
+	return s.auditoriaRepo.Create(ctx, &models.EventoAuditoria{
+		EntidadeTipo:   models.EntidadeAuditoriaAvaliacaoFuncional,
+		EntidadeID:     avaliacao.ID,
+		StatusAnterior: string(anterior),
+		StatusNovo:     string(avaliacao.Status),
+		Motivo:         motivo,
+		UsuarioID:      usuarioID,
+	})
+}
+
+// comportamentosAvaliacao valida os comportamentos informados e os converte na lista da avaliação
+func (s *AvaliacaoFuncionalService) comportamentosAvaliacao(ctx context.Context, pacienteID uuid.UUID, ids []uuid.UUID) ([]models.ComportamentoAvaliacaoFuncional, error) {
+	if err := validarComportamentosInadequados(ctx, s.comportamentoRepo, pacienteID, ids); err != nil {
+		return nil, err
+	}
+	comportamentos := make([]models.ComportamentoAvaliacaoFuncional, 0, len(ids))
+	for i, id := range ids {
+		comportamentos = append(comportamentos, models.ComportamentoAvaliacaoFuncional{ComportamentoID: id, Ordem: i + 1})
+	}
+	return comportamentos, nil
+}
+
+// validarComportamentosInadequados garante que os comportamentos existem, são distintos, pertencem ao
+// paciente e são inadequados
+func validarComportamentosInadequados(ctx context.Context, repo repository.ComportamentoAlvoRepository, pacienteID uuid.UUID, ids []uuid.UUID) error {
+	vistos := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if vistos[id] {
+			return ErrComportamentosAvaliacaoDuplicado
+		}
+		vistos[id] = true
+
+		comportamento, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if comportamento == nil {
+			return ErrComportamentoNotFound
+		}
+		if comportamento.PacienteID != pacienteID {
+			return ErrComportamentoForaDoPaciente
+		}
+		if comportamento.Tipo != models.TipoComportamentoInadequado {
+			return ErrComportamentoNaoInadequado
+		}
+	}
+	return nil
+}
+
+// respostasAvaliacaoFuncional converte as respostas das avaliações indiretas, na ordem informada
+func respostasAvaliacaoFuncional(req []models.RespostaAvaliacaoFuncionalRequest) []models.RespostaAvaliacaoFuncional {
+	respostas := make([]models.RespostaAvaliacaoFuncional, 0, len(req))
+	for i, r := range req {
+		respostas = append(respostas, models.RespostaAvaliacaoFuncional{
+			Ordem:       i + 1,
+			Instrumento: r.Instrumento,
+			Informante:  r.Informante,
+			Pergunta:    r.Pergunta,
+			Resposta:    r.Resposta,
+			Funcao:      r.Funcao,
+		})
+	}
+	return respostas
+}
+
+// motivoTransicao retorna o motivo informado na transição, quando houver
+func motivoTransicao(req *models.TransicaoAvaliacaoFuncionalRequest) string {
+	if req == nil {
+		return ""
+	}
+	return req.Motivo
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrAvaliacaoPreferenciaNotFound = errors.New("avaliação de preferência não encontrada")
+	ErrItensPreferencia             = errors.New("os itens avaliados devem ter nomes distintos")
+	ErrTentativasPreferencia        = errors.New("as tentativas não correspondem ao formato da avaliação ou referenciam itens inexistentes")
+	ErrDuracaoPreferencia           = errors.New("a avaliação de operante livre exige a duração da observação e tempos de engajamento dentro dela")
+)
+
+// AvaliacaoPreferenciaService encapsula a lógica de negócio das avaliações de preferência de reforçadores
+// (MSWO, pareada e operante livre) e do ranking de reforçadores do paciente
+type AvaliacaoPreferenciaService struct {
+	repo       repository.AvaliacaoPreferenciaRepository
+	sessaoRepo repository.SessaoRepository
+}
+
+// NewAvaliacaoPreferenciaService cria uma nova instância de AvaliacaoPreferenciaService
+func NewAvaliacaoPreferenciaService(repo repository.AvaliacaoPreferenciaRepository, sessaoRepo repository.SessaoRepository) *AvaliacaoPreferenciaService {
+	return &AvaliacaoPreferenciaService{repo: repo, sessaoRepo: sessaoRepo}
+}
+
+// CreateAvaliacao registra uma avaliação de preferência do paciente e calcula o ranking dos itens
+func (s *AvaliacaoPreferenciaService) CreateAvaliacao(ctx context.Context, pacienteID uuid.UUID, req *models.CreateAvaliacaoPreferenciaRequest, usuarioID string) (*models.AvaliacaoPreferencia, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if req.SessaoID != nil {
+		if err := s.validarSessao(ctx, pacienteID, *req.SessaoID); err != nil {
+			return nil, err
+		}
+	}
+
+	avaliacao := req.ToAvaliacaoPreferencia(pacienteID, usuarioID)
+	if err := montarAvaliacaoPreferencia(avaliacao, req.Itens, req.Tentativas); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, avaliacao); err != nil {
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+// GetAvaliacao busca uma avaliação pelo ID, garantindo que pertence ao paciente informado
+func (s *AvaliacaoPreferenciaService) GetAvaliacao(ctx context.Context, pacienteID, id uuid.UUID) (*models.AvaliacaoPreferencia, error) {
+	avaliacao, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if avaliacao == nil || avaliacao.PacienteID != pacienteID {
+		return nil, ErrAvaliacaoPreferenciaNotFound
+	}
+	return avaliacao, nil
+}
+
+// UpdateAvaliacao atualiza uma avaliação existente e recalcula o ranking dos itens. Tentativas informadas
+// sem novos itens referenciam os itens já cadastrados, na ordem em que foram avaliados.
+func (s *AvaliacaoPreferenciaService) UpdateAvaliacao(ctx context.Context, pacienteID, id uuid.UUID, req *models.UpdateAvaliacaoPreferenciaRequest) (*models.AvaliacaoPreferencia, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	avaliacao, err := s.GetAvaliacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	avaliacao.ApplyUpdates(req)
+	switch {
+	case req.Itens != nil:
+		err = montarAvaliacaoPreferencia(avaliacao, req.Itens, req.Tentativas)
+	case req.Tentativas != nil:
+		avaliacao.Tentativas, err = tentativasPreferencia(avaliacao.Tipo, avaliacao.Itens, req.Tentativas)
+		if err == nil {
+			err = validarAvaliacaoPreferencia(avaliacao)
+		}
+	default:
+		err = validarAvaliacaoPreferencia(avaliacao)
+	}
+	if err != nil {
+		return nil, err
+	}
+	avaliacao.Calcular()
+
+	if err := s.repo.Update(ctx, avaliacao); err != nil {
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+// DeleteAvaliacao exclui uma avaliação pelo ID
+func (s *AvaliacaoPreferenciaService) DeleteAvaliacao(ctx context.Context, pacienteID, id uuid.UUID) error {
+	if _, err := s.GetAvaliacao(ctx, pacienteID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListAvaliacoes retorna uma lista paginada das avaliações de um paciente no intervalo [inicio, fim)
+func (s *AvaliacaoPreferenciaService) ListAvaliacoes(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time, page, pageSize int) ([]*models.AvaliacaoPreferencia, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, 0, ErrPeriodoInvalido
+	}
+
+	offset := (page - 1) * pageSize
+	avaliacoes, err := s.repo.ListByPaciente(ctx, pacienteID, inicio, fim, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByPaciente(ctx, pacienteID, inicio, fim)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return avaliacoes, total, nil
+}
+
+// ReforcadoresPaciente consolida as avaliações do paciente no intervalo [inicio, fim) em um ranking de
+// reforçadores, ordenado pelo percentual médio de cada item
+func (s *AvaliacaoPreferenciaService) ReforcadoresPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time) (*models.ReforcadoresPaciente, error) {
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, ErrPeriodoInvalido
+	}
+
+	avaliacoes, err := s.repo.ListAllByPaciente(ctx, pacienteID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReforcadoresPaciente{
+		PacienteID:   pacienteID,
+		Avaliacoes:   len(avaliacoes),
+		Reforcadores: rankingReforcadores(avaliacoes),
+	}, nil
+}
+
+// ReforcadoresSessao retorna o ranking de reforçadores do paciente da sessão, considerando as avaliações
+// realizadas até o dia da sessão. É o conjunto de opções do campo "reforco_utilizado" das coletas.
+func (s *AvaliacaoPreferenciaService) ReforcadoresSessao(ctx context.Context, sessaoID uuid.UUID) (*models.ReforcadoresPaciente, error) {
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sessao == nil {
+		return nil, ErrSessaoNotFound
+	}
+
+	fim := time.Date(sessao.Data.Year(), sessao.Data.Month(), sessao.Data.Day()+1, 0, 0, 0, 0, sessao.Data.Location())
+	return s.ReforcadoresPaciente(ctx, sessao.PacienteID, nil, &fim)
+}
+
+// validarSessao garante que a sessão existe, não foi cancelada e pertence ao paciente
+func (s *AvaliacaoPreferenciaService) validarSessao(ctx context.Context, pacienteID, sessaoID uuid.UUID) error {
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return err
+	}
+	if sessao == nil {
+		return ErrSessaoNotFound
+	}
+	if sessao.Status == models.StatusSessaoCancelada {
+		return ErrSessaoCancelada
+	}
+	if sessao.PacienteID != pacienteID {
+		return ErrSessaoForaDoPaciente
+	}
+	return nil
+}
+
+// montarAvaliacaoPreferencia substitui os itens e as tentativas da avaliação, valida os dados e calcula o ranking
+func montarAvaliacaoPreferencia(avaliacao *models.AvaliacaoPreferencia, itensReq []models.ItemPreferenciaRequest, tentativasReq []models.TentativaPreferenciaRequest) error {
+	nomes := make(map[string]bool, len(itensReq))
+	itens := make([]models.ItemPreferencia, 0, len(itensReq))
+	for i, req := range itensReq {
+		nome := strings.TrimSpace(req.Nome)
+		chave := strings.ToLower(nome)
+		if nome == "" || nomes[chave] {
+			return ErrItensPreferencia
+		}
+		nomes[chave] = true
+		itens = append(itens, models.ItemPreferencia{
+			ID:                  uuid.New(),
+			Ordem:               i + 1,
+			Nome:                nome,
+			SegundosEngajamento: req.SegundosEngajamento,
+		})
+	}
+
+	tentativas, err := tentativasPreferencia(avaliacao.Tipo, itens, tentativasReq)
+	if err != nil {
+		return err
+	}
+	avaliacao.Itens, avaliacao.Tentativas = itens, tentativas
+	if err := validarAvaliacaoPreferencia(avaliacao); err != nil {
+		return err
+	}
+	avaliacao.Calcular()
+	return nil
+}
+
+// tentativasPreferencia converte as tentativas, que referenciam os itens pela posição, validando o formato
+// de cada uma. No MSWO, um item não pode ser escolhido duas vezes na mesma rodada.
+func tentativasPreferencia(tipo models.TipoAvaliacaoPreferencia, itens []models.ItemPreferencia, req []models.TentativaPreferenciaRequest) ([]models.TentativaPreferencia, error) {
+	item := func(indice int) (*uuid.UUID, bool) {
+		if indice < 0 || indice >= len(itens) {
+			return nil, false
+		}
+		id := itens[indice].ID
+		return &id, true
+	}
+
+	tentativas := make([]models.TentativaPreferencia, 0, len(req))
+	escolhidos := make(map[int]map[int]bool)
+	for i, r := range req {
+		tentativa := models.TentativaPreferencia{Numero: i + 1, Rodada: r.Rodada}
+		switch tipo {
+		case models.TipoAvaliacaoPreferenciaMSWO:
+			if len(r.Opcoes) > 0 {
+				return nil, ErrTentativasPreferencia
+			}
+			if tentativa.Rodada < 1 {
+				tentativa.Rodada = 1
+			}
+			if r.Selecionado != nil {
+				if escolhidos[tentativa.Rodada] == nil {
+					escolhidos[tentativa.Rodada] = make(map[int]bool)
+				}
+				if escolhidos[tentativa.Rodada][*r.Selecionado] {
+					return nil, ErrTentativasPreferencia
+				}
+				escolhidos[tentativa.Rodada][*r.Selecionado] = true
+			}
+		case models.TipoAvaliacaoPreferenciaPareada:
+			if len(r.Opcoes) != 2 || r.Opcoes[0] == r.Opcoes[1] {
+				return nil, ErrTentativasPreferencia
+			}
+			var okA, okB bool
+			tentativa.ItemAID, okA = item(r.Opcoes[0])
+			tentativa.ItemBID, okB = item(r.Opcoes[1])
+			if !okA || !okB {
+				return nil, ErrTentativasPreferencia
+			}
+			if r.Selecionado != nil && *r.Selecionado != r.Opcoes[0] && *r.Selecionado != r.Opcoes[1] {
+				return nil, ErrTentativasPreferencia
+			}
+			tentativa.Rodada = 1
+		default:
+			return nil, ErrTentativasPreferencia
+		}
+
+		if r.Selecionado != nil {
+			var ok bool
+			if tentativa.SelecionadoID, ok = item(*r.Selecionado); !ok {
+				return nil, ErrTentativasPreferencia
+			}
+		}
+		tentativas = append(tentativas, tentativa)
+	}
+	return tentativas, nil
+}
+
+// validarAvaliacaoPreferencia garante que a avaliação traz os dados exigidos pelo seu formato
+func validarAvaliacaoPreferencia(avaliacao *models.AvaliacaoPreferencia) error {
+	if avaliacao.Tipo != models.TipoAvaliacaoPreferenciaOperanteLivre {
+		if len(avaliacao.Tentativas) == 0 {
+			return ErrTentativasPreferencia
+		}
+		return nil
+	}
+
+	if avaliacao.DuracaoSegundos < 1 {
+		return ErrDuracaoPreferencia
+	}
+	for _, item := range avaliacao.Itens {
+		if item.SegundosEngajamento > avaliacao.DuracaoSegundos {
+			return ErrDuracaoPreferencia
+		}
+	}
+	return nil
+}
+
+// rankingReforcadores consolida os itens das avaliações, já em ordem cronológica, pelo nome. Itens com o
+// mesmo percentual médio compartilham a mesma posição e são listados a partir do avaliado mais recentemente.
+func rankingReforcadores(avaliacoes []*models.AvaliacaoPreferencia) []models.ReforcadorPaciente {
+	indices := make(map[string]int)
+	somas := make([]float64, 0)
+	reforcadores := make([]models.ReforcadorPaciente, 0)
+	for _, avaliacao := range avaliacoes {
+		for _, item := range avaliacao.Itens {
+			chave := strings.ToLower(strings.TrimSpace(item.Nome))
+			i, ok := indices[chave]
+			if !ok {
+				i = len(reforcadores)
+				indices[chave] = i
+				somas = append(somas, 0)
+				reforcadores = append(reforcadores, models.ReforcadorPaciente{})
+			}
+			somas[i] += item.Percentual
+			reforcadores[i].Avaliacoes++
+			reforcadores[i].Nome = item.Nome
+			reforcadores[i].UltimaPosicao = item.Posicao
+			reforcadores[i].UltimaAvaliacao = avaliacao.Data
+		}
+	}
+	for i := range reforcadores {
+		reforcadores[i].PercentualMedio = somas[i] / float64(reforcadores[i].Avaliacoes)
+	}
+
+	sort.SliceStable(reforcadores, func(i, j int) bool {
+		if reforcadores[i].PercentualMedio != reforcadores[j].PercentualMedio {
+			return reforcadores[i].PercentualMedio > reforcadores[j].PercentualMedio
+		}
+		return reforcadores[i].UltimaAvaliacao.After(reforcadores[j].UltimaAvaliacao)
+	})
+	for i := range reforcadores {
+		if i > 0 && reforcadores[i].PercentualMedio == reforcadores[i-1].PercentualMedio {
+			reforcadores[i].Posicao = reforcadores[i-1].Posicao
+		} else {
+			reforcadores[i].Posicao = i + 1
+		}
+	}
+	return reforcadores
+}
@@ -0,0 +1,446 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrColetaNotFound      = errors.New("coleta ABA não encontrada")
+	ErrEtapaNotFound       = errors.New("etapa do programa não encontrada")
+	ErrTipoPromptNotFound  = errors.New("tipo de prompt não encontrado")
+	ErrEtapaForaDoPaciente = errors.New("a etapa não pertence a um programa do paciente da sessão")
+	ErrSessaoCancelada     = errors.New("não é possível registrar dados em uma sessão cancelada")
+)
+
+// ColetaABAService encapsula a lógica de negócio relacionada às coletas de tentativas ABA
+type ColetaABAService struct {
+	repo         repository.ColetaABARepository
+	sessaoRepo   repository.SessaoRepository
+	etapaRepo    repository.EtapaProgramaRepository
+	programaRepo repository.ProgramaABARepository
+	promptRepo   repository.TipoPromptRepository
+	alvoRepo     repository.AlvoEtapaRepository
+	sondagemRepo repository.SondagemManutencaoRepository
+	reforcadores *AvaliacaoPreferenciaService
+	transactor   repository.Transactor
+}
+
+// NewColetaABAService cria uma nova instância de ColetaABAService
+func NewColetaABAService(
+	repo repository.ColetaABARepository,
+	sessaoRepo repository.SessaoRepository,
+	etapaRepo repository.EtapaProgramaRepository,
+	programaRepo repository.ProgramaABARepository,
+	promptRepo repository.TipoPromptRepository,
+	alvoRepo repository.AlvoEtapaRepository,
+	sondagemRepo repository.SondagemManutencaoRepository,
+	reforcadores *AvaliacaoPreferenciaService,
+	transactor repository.Transactor,
+) *ColetaABAService {
+	return &ColetaABAService{
+		repo:         repo,
+		sessaoRepo:   sessaoRepo,
+		etapaRepo:    etapaRepo,
+		programaRepo: programaRepo,
+		promptRepo:   promptRepo,
+		alvoRepo:     alvoRepo,
+		sondagemRepo: sondagemRepo,
+		reforcadores: reforcadores,
+		transactor:   transactor,
+	}
+}
+
+// CreateColeta registra uma nova tentativa em uma sessão. A tentativa, o vínculo com a sondagem de manutenção
+// e a data da última tentativa do alvo são gravados na mesma transação.
+func (s *ColetaABAService) CreateColeta(ctx context.Context, sessaoID uuid.UUID, req *models.CreateColetaABARequest) (*models.ColetaABA, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	sessao, err := s.getSessaoAtiva(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Secundario && sessao.ObservadorSecundarioID == nil {
+		return nil, ErrObservadorSecundarioAusente
+	}
+	if err := s.validateEtapa(ctx, sessao, req.EtapaProgramaID); err != nil {
+		return nil, err
+	}
+	if req.PromptUtilizadoID != nil {
+		if err := s.validatePrompt(ctx, *req.PromptUtilizadoID); err != nil {
+			return nil, err
+		}
+	}
+	var alvo *models.AlvoEtapa
+	if req.AlvoID != nil {
+		if alvo, err = s.getAlvo(ctx, req.EtapaProgramaID, *req.AlvoID); err != nil {
+			return nil, err
+		}
+	}
+
+	var sondagem *models.SondagemManutencao
+	if req.SondagemManutencaoID != nil {
+		if sondagem, err = s.getSondagemManutencao(ctx, sessao, req); err != nil {
+			return nil, err
+		}
+	}
+
+	coleta := req.ToColetaABA(sessaoID)
+	if coleta.ReforcoUtilizado, err = s.reforcoSessao(ctx, sessaoID, req.ReforcoUtilizado); err != nil {
+		return nil, err
+	}
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, coleta); err != nil {
+			return err
+		}
+
+		// A sondagem respondida em outra sessão passa a ser avaliada no encerramento desta
+		if sondagem != nil && !req.Secundario && (sondagem.SessaoID == nil || *sondagem.SessaoID != sessaoID) {
+			sondagem.SessaoID = &sessaoID
+			if err := s.sondagemRepo.Update(ctx, sondagem); err != nil {
+				return err
+			}
+		}
+
+		// A data da última tentativa define o rodízio das sondagens de manutenção
+		if alvo != nil && !req.Secundario && (alvo.UltimaColetaEm == nil || alvo.UltimaColetaEm.Before(sessao.Data)) {
+			data := sessao.Data
+			alvo.UltimaColetaEm = &data
+			if err := s.alvoRepo.Update(ctx, alvo); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return coleta, nil
+}
+
+// GetColeta busca uma coleta ABA de uma sessão pelo ID
+func (s *ColetaABAService) GetColeta(ctx context.Context, sessaoID, id uuid.UUID) (*models.ColetaABA, error) {
+	coleta, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if coleta == nil || coleta.SessaoID != sessaoID {
+		return nil, ErrColetaNotFound
+	}
+	return coleta, nil
+}
+
+// UpdateColeta atualiza uma coleta ABA existente
+func (s *ColetaABAService) UpdateColeta(ctx context.Context, sessaoID, id uuid.UUID, req *models.UpdateColetaABARequest) (*models.ColetaABA, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	if _, err := s.getSessaoAtiva(ctx, sessaoID); err != nil {
+		return nil, err
+	}
+	coleta, err := s.GetColeta(ctx, sessaoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.PromptUtilizadoID != nil && *req.PromptUtilizadoID != uuid.Nil {
+		if err := s.validatePrompt(ctx, *req.PromptUtilizadoID); err != nil {
+			return nil, err
+		}
+	}
+
+	coleta.ApplyUpdates(req)
+	if req.ReforcoUtilizado != nil {
+		if coleta.ReforcoUtilizado, err = s.reforcoSessao(ctx, sessaoID, coleta.ReforcoUtilizado); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Update(ctx, coleta); err != nil {
+		return nil, err
+	}
+	return coleta, nil
+}
+
+// reforcoSessao normaliza o reforço utilizado na tentativa: quando corresponde a um dos reforçadores do
+// ranking da sessão, retorna o nome como aparece no ranking; caso contrário, o texto informado é mantido,
+// pois o ranking é apenas uma sugestão e o paciente pode ainda não ter avaliações de preferência.
+func (s *ColetaABAService) reforcoSessao(ctx context.Context, sessaoID uuid.UUID, nome string) (string, error) {
+	nome = strings.TrimSpace(nome)
+	if nome == "" {
+		return "", nil
+	}
+	ranking, err := s.reforcadores.ReforcadoresSessao(ctx, sessaoID)
+	if err != nil {
+		return "", err
+	}
+	if reforcador := ranking.Reforcador(nome); reforcador != nil {
+		return reforcador.Nome, nil
+	}
+	return nome, nil
+}
+
+// DeleteColeta exclui uma coleta ABA pelo ID
+func (s *ColetaABAService) DeleteColeta(ctx context.Context, sessaoID, id uuid.UUID) error {
+	if _, err := s.getSessaoAtiva(ctx, sessaoID); err != nil {
+		return err
+	}
+	if _, err := s.GetColeta(ctx, sessaoID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListColetasBySessao retorna uma lista paginada das coletas de uma sessão,
+// opcionalmente filtradas por etapa
+func (s *ColetaABAService) ListColetasBySessao(ctx context.Context, sessaoID uuid.UUID, etapaID *uuid.UUID, page, pageSize int) ([]*models.ColetaABA, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if sessao == nil {
+		return nil, 0, ErrSessaoNotFound
+	}
+
+	offset := (page - 1) * pageSize
+	if etapaID != nil {
+		coletas, err := s.repo.ListBySessaoAndEtapa(ctx, sessaoID, *etapaID, pageSize, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		total, err := s.repo.CountBySessaoAndEtapa(ctx, sessaoID, *etapaID)
+		if err != nil {
+			return nil, 0, err
+		}
+		return coletas, total, nil
+	}
+
+	coletas, err := s.repo.ListBySessao(ctx, sessaoID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountBySessao(ctx, sessaoID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return coletas, total, nil
+}
+
+// ListColetasByEtapa retorna, em ordem cronológica, as tentativas do observador principal de uma etapa em
+// todas as sessões
+func (s *ColetaABAService) ListColetasByEtapa(ctx context.Context, programaID, etapaID uuid.UUID) ([]*models.ColetaABA, error) {
+	etapa, err := s.etapaRepo.GetByID(ctx, etapaID)
+	if err != nil {
+		return nil, err
+	}
+	if etapa == nil || etapa.ProgramaID != programaID {
+		return nil, ErrEtapaNotFound
+	}
+	return s.repo.ListByEtapa(ctx, etapaID)
+}
+
+// GetResumoSessao retorna, para cada etapa trabalhada na sessão, o percentual de acertos,
+// o percentual de respostas independentes e a distribuição dos prompts utilizados
+func (s *ColetaABAService) GetResumoSessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.ResumoEtapaColeta, error) {
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sessao == nil {
+		return nil, ErrSessaoNotFound
+	}
+
+	coletas, err := s.repo.ListAllBySessao(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var promptIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, coleta := range coletas {
+		if coleta.PromptUtilizadoID != uuid.Nil && !seen[coleta.PromptUtilizadoID] {
+			seen[coleta.PromptUtilizadoID] = true
+			promptIDs = append(promptIDs, coleta.PromptUtilizadoID)
+		}
+	}
+	prompts, err := s.promptRepo.ListByIDs(ctx, promptIDs)
+	if err != nil {
+		return nil, err
+	}
+	nomes := make(map[uuid.UUID]string, len(prompts))
+	for _, prompt := range prompts {
+		nomes[prompt.ID] = prompt.Tipo
+	}
+
+	return resumirColetas(coletas, nomes), nil
+}
+
+// resumirColetas agrupa as coletas por etapa, mantendo a ordem em que as etapas aparecem,
+// e calcula os indicadores de cada uma
+func resumirColetas(coletas []*models.ColetaABA, nomesPrompt map[uuid.UUID]string) []*models.ResumoEtapaColeta {
+	resumos := make([]*models.ResumoEtapaColeta, 0)
+	porEtapa := make(map[uuid.UUID]*models.ResumoEtapaColeta)
+	promptsPorEtapa := make(map[uuid.UUID]map[uuid.UUID]int)
+	ordemPrompts := make(map[uuid.UUID][]uuid.UUID)
+
+	for _, coleta := range coletas {
+		resumo, ok := porEtapa[coleta.EtapaProgramaID]
+		if !ok {
+			resumo = &models.ResumoEtapaColeta{
+				EtapaProgramaID:     coleta.EtapaProgramaID,
+				DistribuicaoPrompts: []models.DistribuicaoPrompt{},
+			}
+			porEtapa[coleta.EtapaProgramaID] = resumo
+			promptsPorEtapa[coleta.EtapaProgramaID] = make(map[uuid.UUID]int)
+			resumos = append(resumos, resumo)
+		}
+
+		resumo.TotalTentativas++
+		switch coleta.Resultado {
+		case models.ResultadoColetaAcerto:
+			resumo.Acertos++
+		case models.ResultadoColetaErro:
+			resumo.Erros++
+		case models.ResultadoColetaAjuda:
+			resumo.Ajudas++
+		}
+		if coleta.IsIndependente() {
+			resumo.Independentes++
+		}
+		if coleta.PromptUtilizadoID != uuid.Nil {
+			contagem := promptsPorEtapa[coleta.EtapaProgramaID]
+			if contagem[coleta.PromptUtilizadoID] == 0 {
+				ordemPrompts[coleta.EtapaProgramaID] = append(ordemPrompts[coleta.EtapaProgramaID], coleta.PromptUtilizadoID)
+			}
+			contagem[coleta.PromptUtilizadoID]++
+		}
+	}
+
+	for _, resumo := range resumos {
+		resumo.PercentualAcerto = percentual(resumo.Acertos, resumo.TotalTentativas)
+		resumo.PercentualIndependente = percentual(resumo.Independentes, resumo.TotalTentativas)
+		contagem := promptsPorEtapa[resumo.EtapaProgramaID]
+		for _, promptID := range ordemPrompts[resumo.EtapaProgramaID] {
+			resumo.DistribuicaoPrompts = append(resumo.DistribuicaoPrompts, models.DistribuicaoPrompt{
+				PromptID:   promptID,
+				Tipo:       nomesPrompt[promptID],
+				Quantidade: contagem[promptID],
+				Percentual: percentual(contagem[promptID], resumo.TotalTentativas),
+			})
+		}
+	}
+
+	return resumos
+}
+
+// getSessaoAtiva busca a sessão e garante que ela ainda aceita registros
+func (s *ColetaABAService) getSessaoAtiva(ctx context.Context, sessaoID uuid.UUID) (*models.Sessao, error) {
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sessao == nil {
+		return nil, ErrSessaoNotFound
+	}
+	if sessao.Status == models.StatusSessaoCancelada {
+		return nil, ErrSessaoCancelada
+	}
+	if sessao.Status == models.StatusSessaoRealizada {
+		return nil, ErrSessaoEncerrada
+	}
+	return sessao, nil
+}
+
+// validateEtapa garante que a etapa existe e pertence a um programa do paciente da sessão
+func (s *ColetaABAService) validateEtapa(ctx context.Context, sessao *models.Sessao, etapaID uuid.UUID) error {
+	etapa, err := s.etapaRepo.GetByID(ctx, etapaID)
+	if err != nil {
+		return err
+	}
+	if etapa == nil {
+		return ErrEtapaNotFound
+	}
+
+	programa, err := s.programaRepo.GetByID(ctx, etapa.ProgramaID)
+	if err != nil {
+		return err
+	}
+	if programa == nil || programa.PacienteID != sessao.PacienteID {
+		return ErrEtapaForaDoPaciente
+	}
+	return nil
+}
+
+// getAlvo busca o alvo da tentativa e garante que ele pertence à etapa e já foi introduzido no ensino
+func (s *ColetaABAService) getAlvo(ctx context.Context, etapaID, alvoID uuid.UUID) (*models.AlvoEtapa, error) {
+	alvo, err := s.alvoRepo.GetByID(ctx, alvoID)
+	if err != nil {
+		return nil, err
+	}
+	if alvo == nil {
+		return nil, ErrAlvoNotFound
+	}
+	if alvo.EtapaProgramaID != etapaID {
+		return nil, ErrAlvoForaDaEtapa
+	}
+	if alvo.Status == models.StatusAlvoAguardando {
+		return nil, ErrAlvoAguardando
+	}
+	return alvo, nil
+}
+
+// getSondagemManutencao busca a sondagem de manutenção respondida pela tentativa e garante que ela está
+// pendente, é do paciente da sessão e da mesma etapa e alvo da tentativa
+func (s *ColetaABAService) getSondagemManutencao(ctx context.Context, sessao *models.Sessao, req *models.CreateColetaABARequest) (*models.SondagemManutencao, error) {
+	sondagem, err := s.sondagemRepo.GetByID(ctx, *req.SondagemManutencaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sondagem == nil || sondagem.PacienteID != sessao.PacienteID {
+		return nil, ErrSondagemManutencaoNotFound
+	}
+	if sondagem.Status != models.StatusSondagemManutencaoPendente {
+		return nil, ErrSondagemManutencaoEncerrada
+	}
+	if !sondagem.MesmaHabilidade(req.EtapaProgramaID, req.AlvoID) {
+		return nil, ErrSondagemManutencaoForaDaEtapa
+	}
+	return sondagem, nil
+}
+
+// validatePrompt garante que o tipo de prompt informado existe
+func (s *ColetaABAService) validatePrompt(ctx context.Context, promptID uuid.UUID) error {
+	prompt, err := s.promptRepo.GetByID(ctx, promptID)
+	if err != nil {
+		return err
+	}
+	if prompt == nil {
+		return ErrTipoPromptNotFound
+	}
+	return nil
+}
+
+// percentual calcula a porcentagem de parte sobre total, retornando zero quando não há total
+func percentual(parte, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(parte) * 100 / float64(total)
+}
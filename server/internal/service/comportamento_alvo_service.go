@@ -12,17 +12,20 @@ import (
 
 // Erros comuns do serviço
 var (
-	ErrComportamentoNotFound = errors.New("comportamento alvo não encontrado")
+	ErrComportamentoNotFound      = errors.New("comportamento alvo não encontrado")
+	ErrMetodoRegistroComRegistros = errors.New("o método de registro não pode ser alterado depois que o comportamento já possui registros ou observações por intervalo")
 )
 
 // ComportamentoAlvoService encapsula a lógica de negócio relacionada a comportamentos alvo
 type ComportamentoAlvoService struct {
-	repo repository.ComportamentoAlvoRepository
+	repo           repository.ComportamentoAlvoRepository
+	registroRepo   repository.RegistroComportamentoRepository
+	observacaoRepo repository.ObservacaoIntervaloRepository
 }
 
 // NewComportamentoAlvoService cria uma nova instância de ComportamentoAlvoService
-func NewComportamentoAlvoService(repo repository.ComportamentoAlvoRepository) *ComportamentoAlvoService {
-	return &ComportamentoAlvoService{repo: repo}
+func NewComportamentoAlvoService(repo repository.ComportamentoAlvoRepository, registroRepo repository.RegistroComportamentoRepository, observacaoRepo repository.ObservacaoIntervaloRepository) *ComportamentoAlvoService {
+	return &ComportamentoAlvoService{repo: repo, registroRepo: registroRepo, observacaoRepo: observacaoRepo}
 }
 
 // CreateComportamento cria um novo comportamento alvo
@@ -45,7 +48,8 @@ func (s *ComportamentoAlvoService) GetComportamento(ctx context.Context, id uuid
 	return comportamento, nil
 }
 
-// UpdateComportamento atualiza um comportamento alvo existente
+// UpdateComportamento atualiza um comportamento alvo existente. O método de registro só pode mudar enquanto
+// o comportamento não tiver registros nem observações por intervalo, que seriam interpretados no método novo.
 func (s *ComportamentoAlvoService) UpdateComportamento(ctx context.Context, comportamento *models.ComportamentoAlvo) (*models.ComportamentoAlvo, error) {
 	existing, err := s.repo.GetByID(ctx, comportamento.ID)
 	if err != nil {
@@ -55,6 +59,20 @@ func (s *ComportamentoAlvoService) UpdateComportamento(ctx context.Context, comp
 		return nil, ErrComportamentoNotFound
 	}
 
+	if comportamento.MetodoRegistro != existing.MetodoRegistro {
+		registros, err := s.registroRepo.CountByComportamento(ctx, comportamento.ID, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		observacoes, err := s.observacaoRepo.CountByComportamento(ctx, comportamento.ID, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if registros > 0 || observacoes > 0 {
+			return nil, ErrMetodoRegistroComRegistros
+		}
+	}
+
 	if err := s.repo.Update(ctx, comportamento); err != nil {
 		return nil, err
 	}
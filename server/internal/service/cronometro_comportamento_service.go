@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrCronometroNotFound        = errors.New("cronômetro não encontrado")
+	ErrCronometroEmAndamento     = errors.New("já existe um cronômetro deste tipo em andamento para o comportamento na sessão")
+	ErrCronometroEncerrado       = errors.New("o cronômetro já foi encerrado")
+	ErrCronometroDuracaoInvalido = errors.New("cronômetros de duração exigem um comportamento registrado por duração")
+	ErrSessaoEncerrada           = errors.New("a sessão já foi encerrada")
+)
+
+// CronometroComportamentoService encapsula a lógica de negócio dos cronômetros de duração e latência.
+// Os tempos são medidos pelo relógio do servidor, de modo que cronômetros abertos sobrevivem a
+// reconexões do cliente.
+type CronometroComportamentoService struct {
+	repo              repository.CronometroComportamentoRepository
+	comportamentoRepo repository.ComportamentoAlvoRepository
+	sessaoRepo        repository.SessaoRepository
+	transactor        repository.Transactor
+}
+
+// NewCronometroComportamentoService cria uma nova instância de CronometroComportamentoService
+func NewCronometroComportamentoService(repo repository.CronometroComportamentoRepository, comportamentoRepo repository.ComportamentoAlvoRepository, sessaoRepo repository.SessaoRepository, transactor repository.Transactor) *CronometroComportamentoService {
+	return &CronometroComportamentoService{repo: repo, comportamentoRepo: comportamentoRepo, sessaoRepo: sessaoRepo, transactor: transactor}
+}
+
+// IniciarCronometro inicia um cronômetro para o comportamento na sessão informada. A sessão fica bloqueada
+// enquanto sua situação é conferida e o cronômetro é criado, para que ela não seja encerrada ao mesmo tempo.
+func (s *CronometroComportamentoService) IniciarCronometro(ctx context.Context, comportamentoID uuid.UUID, req *models.IniciarCronometroRequest, usuarioID string) (*models.CronometroComportamento, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	comportamento, err := s.comportamentoRepo.GetByID(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if comportamento == nil {
+		return nil, ErrComportamentoNotFound
+	}
+	if req.Tipo == models.TipoCronometroDuracao && comportamento.MetodoRegistro != models.MetodoRegistroDuracao {
+		return nil, ErrCronometroDuracaoInvalido
+	}
+
+	var cronometro *models.CronometroComportamento
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.sessaoRepo.LockSessao(ctx, req.SessaoID); err != nil {
+			return err
+		}
+		if err := s.validateSessao(ctx, comportamento, req.SessaoID, req.Secundario); err != nil {
+			return err
+		}
+
+		aberto, err := s.repo.GetAberto(ctx, comportamentoID, req.SessaoID, req.Tipo, req.Secundario)
+		if err != nil {
+			return err
+		}
+		if aberto != nil {
+			return ErrCronometroEmAndamento
+		}
+
+		cronometro = &models.CronometroComportamento{
+			ComportamentoID: comportamentoID,
+			SessaoID:        req.SessaoID,
+			Tipo:            req.Tipo,
+			IniciadoEm:      time.Now(),
+			UsuarioID:       usuarioID,
+			Secundario:      req.Secundario,
+		}
+		return s.repo.Create(ctx, cronometro)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cronometro, nil
+}
+
+// EncerrarCronometro para um cronômetro em andamento. Cronômetros de duração geram automaticamente
+// um registro de comportamento com os segundos medidos.
+func (s *CronometroComportamentoService) EncerrarCronometro(ctx context.Context, comportamentoID, id uuid.UUID, req *models.EncerrarCronometroRequest) (*models.CronometroComportamento, error) {
+	cronometro, err := s.getCronometro(ctx, comportamentoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !cronometro.EmAndamento() {
+		return nil, ErrCronometroEncerrado
+	}
+
+	agora := time.Now()
+	segundos := agora.Sub(cronometro.IniciadoEm).Seconds()
+	cronometro.EncerradoEm = &agora
+	cronometro.Segundos = &segundos
+	cronometro.AtualizarDecorrido(agora)
+
+	var registro *models.RegistroComportamento
+	if cronometro.Tipo == models.TipoCronometroDuracao {
+		registro = &models.RegistroComportamento{
+			ComportamentoID: comportamentoID,
+			SessaoID:        &cronometro.SessaoID,
+			DataHora:        cronometro.IniciadoEm,
+			Valor:           segundos,
+			Secundario:      cronometro.Secundario,
+		}
+		if req != nil {
+			registro.Contexto = req.Contexto
+			registro.Consequencia = req.Consequencia
+		}
+	}
+
+	encerrado, err := s.repo.Encerrar(ctx, cronometro, registro)
+	if err != nil {
+		return nil, err
+	}
+	if !encerrado {
+		return nil, ErrCronometroEncerrado
+	}
+	return cronometro, nil
+}
+
+// DescartarCronometro exclui um cronômetro em andamento iniciado por engano
+func (s *CronometroComportamentoService) DescartarCronometro(ctx context.Context, comportamentoID, id uuid.UUID) error {
+	cronometro, err := s.getCronometro(ctx, comportamentoID, id)
+	if err != nil {
+		return err
+	}
+	if !cronometro.EmAndamento() {
+		return ErrCronometroEncerrado
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListCronometrosSessao retorna os cronômetros da sessão com o tempo decorrido calculado pelo servidor
+func (s *CronometroComportamentoService) ListCronometrosSessao(ctx context.Context, sessaoID uuid.UUID, apenasAbertos bool) ([]*models.CronometroComportamento, error) {
+	if _, err := s.getSessao(ctx, sessaoID); err != nil {
+		return nil, err
+	}
+
+	cronometros, err := s.repo.ListBySessao(ctx, sessaoID, apenasAbertos)
+	if err != nil {
+		return nil, err
+	}
+	agora := time.Now()
+	for _, cronometro := range cronometros {
+		cronometro.AtualizarDecorrido(agora)
+	}
+	return cronometros, nil
+}
+
+// ResumoSessao calcula, por comportamento, a duração total, a duração média por episódio, o percentual
+// do tempo da sessão e as latências medidas pelo observador principal
+func (s *CronometroComportamentoService) ResumoSessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.ResumoCronometrosComportamento, error) {
+	sessao, err := s.getSessao(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+
+	cronometros, err := s.repo.ListBySessao(ctx, sessaoID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resumos := make([]*models.ResumoCronometrosComportamento, 0)
+	porComportamento := make(map[uuid.UUID]*models.ResumoCronometrosComportamento)
+	for _, cronometro := range cronometros {
+		if cronometro.Secundario {
+			continue
+		}
+		resumo, ok := porComportamento[cronometro.ComportamentoID]
+		if !ok {
+			resumo = &models.ResumoCronometrosComportamento{ComportamentoID: cronometro.ComportamentoID}
+			comportamento, err := s.comportamentoRepo.GetByID(ctx, cronometro.ComportamentoID)
+			if err != nil {
+				return nil, err
+			}
+			if comportamento != nil {
+				resumo.Descricao = comportamento.Descricao
+			}
+			porComportamento[cronometro.ComportamentoID] = resumo
+			resumos = append(resumos, resumo)
+		}
+
+		if cronometro.EmAndamento() || cronometro.Segundos == nil {
+			resumo.EmAndamento++
+			continue
+		}
+		segundos := *cronometro.Segundos
+		switch cronometro.Tipo {
+		case models.TipoCronometroDuracao:
+			resumo.Episodios++
+			resumo.DuracaoTotal += segundos
+		case models.TipoCronometroLatencia:
+			if resumo.Latencias == 0 || segundos < resumo.LatenciaMinima {
+				resumo.LatenciaMinima = segundos
+			}
+			resumo.LatenciaMaxima = math.Max(resumo.LatenciaMaxima, segundos)
+			resumo.LatenciaMedia += segundos
+			resumo.Latencias++
+		}
+	}
+
+	duracaoSessao := float64(sessao.DuracaoMinutos) * 60
+	for _, resumo := range resumos {
+		if resumo.Episodios > 0 {
+			resumo.DuracaoMedia = resumo.DuracaoTotal / float64(resumo.Episodios)
+		}
+		if duracaoSessao > 0 {
+			resumo.PercentualSessao = resumo.DuracaoTotal / duracaoSessao * 100
+		}
+		if resumo.Latencias > 0 {
+			resumo.LatenciaMedia /= float64(resumo.Latencias)
+		}
+	}
+	return resumos, nil
+}
+
+// getCronometro busca um cronômetro pelo ID, garantindo que pertence ao comportamento informado
+func (s *CronometroComportamentoService) getCronometro(ctx context.Context, comportamentoID, id uuid.UUID) (*models.CronometroComportamento, error) {
+	cronometro, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cronometro == nil || cronometro.ComportamentoID != comportamentoID {
+		return nil, ErrCronometroNotFound
+	}
+	return cronometro, nil
+}
+
+// getSessao busca a sessão dos cronômetros
+func (s *CronometroComportamentoService) getSessao(ctx context.Context, sessaoID uuid.UUID) (*models.Sessao, error) {
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sessao == nil {
+		return nil, ErrSessaoNotFound
+	}
+	return sessao, nil
+}
+
+// validateSessao garante que a sessão está em aberto, pertence ao paciente do comportamento e, para o
+// observador secundário, possui observador secundário definido
+func (s *CronometroComportamentoService) validateSessao(ctx context.Context, comportamento *models.ComportamentoAlvo, sessaoID uuid.UUID, secundario bool) error {
+	sessao, err := s.getSessao(ctx, sessaoID)
+	if err != nil {
+		return err
+	}
+	switch sessao.Status {
+	case models.StatusSessaoCancelada:
+		return ErrSessaoCancelada
+	case models.StatusSessaoRealizada:
+		return ErrSessaoEncerrada
+	}
+	if sessao.PacienteID != comportamento.PacienteID {
+		return ErrSessaoForaDoPaciente
+	}
+	if secundario && sessao.ObservadorSecundarioID == nil {
+		return ErrObservadorSecundarioAusente
+	}
+	return nil
+}
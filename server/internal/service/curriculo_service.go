@@ -0,0 +1,664 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/grafico"
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrCurriculoNotFound                = errors.New("currículo não encontrado")
+	ErrEstruturaCurriculoInvalida       = errors.New("a estrutura do currículo é inválida: os níveis e os domínios devem ser distintos e cada marco deve referir um nível existente e ocupar uma posição única no seu domínio e nível")
+	ErrCurriculoEmUso                   = errors.New("o currículo já foi aplicado e não pode ser excluído")
+	ErrCurriculoInativo                 = errors.New("o currículo está inativo e não pode ser aplicado")
+	ErrAplicacaoCurriculoNotFound       = errors.New("aplicação de currículo não encontrada")
+	ErrAplicacaoCurriculoConcluida      = errors.New("a aplicação já foi concluída e não pode ser alterada")
+	ErrAplicacaoCurriculoEmAndamento    = errors.New("a aplicação precisa estar concluída")
+	ErrAplicacaoCurriculoVazia          = errors.New("a aplicação precisa de ao menos um marco pontuado para ser concluída")
+	ErrAplicacaoCurriculoBaseNotFound   = errors.New("não há aplicação anterior do mesmo currículo para comparar")
+	ErrAplicacoesCurriculoIncompativeis = errors.New("só é possível comparar aplicações do mesmo currículo e do mesmo paciente")
+	ErrMarcoForaDoCurriculo             = errors.New("o marco não pertence ao currículo da aplicação")
+	ErrPontuacaoMarcoInvalida           = errors.New("a pontuação do marco deve ser 0, 0.5 ou 1")
+	ErrMarcosPontuacaoDuplicados        = errors.New("o mesmo marco foi pontuado mais de uma vez")
+	ErrMarcoSemDeficit                  = errors.New("o marco foi atingido ou não foi pontuado nesta aplicação")
+)
+
+// maximoAplicacoesGrade é o número de aplicações exibidas na grade de marcos, uma cor para cada
+const maximoAplicacoesGrade = 5
+
+// CurriculoService encapsula os currículos de avaliação baseados em marcos (VB-MAPP e semelhantes), as
+// aplicações aos pacientes, a comparação entre aplicações e a criação de programas a partir dos déficits
+type CurriculoService struct {
+	repo          repository.CurriculoRepository
+	aplicacaoRepo repository.AplicacaoCurriculoRepository
+	programaRepo  repository.ProgramaABARepository
+}
+
+// NewCurriculoService cria uma nova instância de CurriculoService
+func NewCurriculoService(repo repository.CurriculoRepository, aplicacaoRepo repository.AplicacaoCurriculoRepository, programaRepo repository.ProgramaABARepository) *CurriculoService {
+	return &CurriculoService{repo: repo, aplicacaoRepo: aplicacaoRepo, programaRepo: programaRepo}
+}
+
+// CreateCurriculo cria um currículo com a estrutura completa de níveis, domínios e marcos
+func (s *CurriculoService) CreateCurriculo(ctx context.Context, req *models.CreateCurriculoRequest) (*models.Curriculo, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if !estruturaCurriculoValida(req) {
+		return nil, ErrEstruturaCurriculoInvalida
+	}
+
+	curriculo := req.ToCurriculo()
+	if err := s.repo.Create(ctx, curriculo); err != nil {
+		return nil, err
+	}
+	return curriculo, nil
+}
+
+// GetCurriculo busca um currículo pelo ID, com a estrutura completa
+func (s *CurriculoService) GetCurriculo(ctx context.Context, id uuid.UUID) (*models.Curriculo, error) {
+	curriculo, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if curriculo == nil || curriculo.DeletedAt.Valid {
+		return nil, ErrCurriculoNotFound
+	}
+	return curriculo, nil
+}
+
+// UpdateCurriculo atualiza o nome, a descrição ou a situação de um currículo
+func (s *CurriculoService) UpdateCurriculo(ctx context.Context, id uuid.UUID, req *models.UpdateCurriculoRequest) (*models.Curriculo, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	curriculo, err := s.GetCurriculo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	curriculo.ApplyUpdates(req)
+	if err := s.repo.Update(ctx, curriculo); err != nil {
+		return nil, err
+	}
+	return curriculo, nil
+}
+
+// DeleteCurriculo exclui um currículo que ainda não foi aplicado. Currículos aplicados podem ser inativados.
+func (s *CurriculoService) DeleteCurriculo(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetCurriculo(ctx, id); err != nil {
+		return err
+	}
+
+	aplicacoes, err := s.aplicacaoRepo.CountByCurriculo(ctx, id)
+	if err != nil {
+		return err
+	}
+	if aplicacoes > 0 {
+		return ErrCurriculoEmUso
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListCurriculos retorna os currículos, opcionalmente apenas os ativos
+func (s *CurriculoService) ListCurriculos(ctx context.Context, apenasAtivos bool) ([]*models.Curriculo, error) {
+	return s.repo.List(ctx, apenasAtivos)
+}
+
+// CreateAplicacao inicia a aplicação de um currículo ativo ao paciente
+func (s *CurriculoService) CreateAplicacao(ctx context.Context, pacienteID uuid.UUID, req *models.CreateAplicacaoCurriculoRequest, usuarioID string) (*models.AplicacaoCurriculo, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	curriculo, err := s.GetCurriculo(ctx, req.CurriculoID)
+	if err != nil {
+		return nil, err
+	}
+	if !curriculo.Ativo {
+		return nil, ErrCurriculoInativo
+	}
+
+	aplicacao := req.ToAplicacaoCurriculo(pacienteID, usuarioID)
+	if err := s.aplicacaoRepo.Create(ctx, aplicacao); err != nil {
+		return nil, err
+	}
+	return aplicacao, nil
+}
+
+// GetAplicacao busca uma aplicação pelo ID, garantindo que pertence ao paciente informado
+func (s *CurriculoService) GetAplicacao(ctx context.Context, pacienteID, id uuid.UUID) (*models.AplicacaoCurriculo, error) {
+	aplicacao, err := s.aplicacaoRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if aplicacao == nil || aplicacao.PacienteID != pacienteID {
+		return nil, ErrAplicacaoCurriculoNotFound
+	}
+	return aplicacao, nil
+}
+
+// UpdateAplicacao atualiza os dados de uma aplicação em andamento
+func (s *CurriculoService) UpdateAplicacao(ctx context.Context, pacienteID, id uuid.UUID, req *models.UpdateAplicacaoCurriculoRequest) (*models.AplicacaoCurriculo, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	aplicacao, err := s.GetAplicacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if aplicacao.Status != models.StatusAplicacaoCurriculoEmAndamento {
+		return nil, ErrAplicacaoCurriculoConcluida
+	}
+
+	aplicacao.ApplyUpdates(req)
+	if err := s.aplicacaoRepo.Update(ctx, aplicacao); err != nil {
+		return nil, err
+	}
+	return aplicacao, nil
+}
+
+// DeleteAplicacao exclui uma aplicação em andamento. Aplicações concluídas fazem parte do histórico do paciente.
+func (s *CurriculoService) DeleteAplicacao(ctx context.Context, pacienteID, id uuid.UUID) error {
+	aplicacao, err := s.GetAplicacao(ctx, pacienteID, id)
+	if err != nil {
+		return err
+	}
+	if aplicacao.Status != models.StatusAplicacaoCurriculoEmAndamento {
+		return ErrAplicacaoCurriculoConcluida
+	}
+	return s.aplicacaoRepo.Delete(ctx, id)
+}
+
+// ListAplicacoes retorna uma lista paginada das aplicações de um paciente, opcionalmente de um currículo
+func (s *CurriculoService) ListAplicacoes(ctx context.Context, pacienteID uuid.UUID, curriculoID *uuid.UUID, page, pageSize int) ([]*models.AplicacaoCurriculo, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	aplicacoes, err := s.aplicacaoRepo.ListByPaciente(ctx, pacienteID, curriculoID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.aplicacaoRepo.CountByPaciente(ctx, pacienteID, curriculoID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return aplicacoes, total, nil
+}
+
+// PontuarMarcos grava a pontuação de marcos do currículo em uma aplicação em andamento
+func (s *CurriculoService) PontuarMarcos(ctx context.Context, pacienteID, id uuid.UUID, req *models.PontuarMarcosRequest) (*models.AplicacaoCurriculo, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	aplicacao, err := s.GetAplicacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if aplicacao.Status != models.StatusAplicacaoCurriculoEmAndamento {
+		return nil, ErrAplicacaoCurriculoConcluida
+	}
+	curriculo, err := s.repo.GetByID(ctx, aplicacao.CurriculoID)
+	if err != nil {
+		return nil, err
+	}
+	if curriculo == nil {
+		return nil, ErrCurriculoNotFound
+	}
+	marcos := indexarMarcos(curriculo)
+
+	pontuacoes := make([]models.PontuacaoMarco, 0, len(req.Pontuacoes))
+	vistos := make(map[uuid.UUID]bool, len(req.Pontuacoes))
+	for _, p := range req.Pontuacoes {
+		if _, ok := marcos[p.MarcoID]; !ok {
+			return nil, ErrMarcoForaDoCurriculo
+		}
+		if vistos[p.MarcoID] {
+			return nil, ErrMarcosPontuacaoDuplicados
+		}
+		vistos[p.MarcoID] = true
+		if !pontuacaoMarcoValida(*p.Pontuacao) {
+			return nil, ErrPontuacaoMarcoInvalida
+		}
+		pontuacoes = append(pontuacoes, models.PontuacaoMarco{MarcoID: p.MarcoID, Pontuacao: *p.Pontuacao, Observacao: p.Observacao})
+	}
+
+	if err := s.aplicacaoRepo.SalvarPontuacoes(ctx, aplicacao.ID, pontuacoes); err != nil {
+		return nil, err
+	}
+	return s.GetAplicacao(ctx, pacienteID, id)
+}
+
+// ConcluirAplicacao conclui a aplicação, que deixa de aceitar alterações
+func (s *CurriculoService) ConcluirAplicacao(ctx context.Context, pacienteID, id uuid.UUID) (*models.AplicacaoCurriculo, error) {
+	aplicacao, err := s.GetAplicacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if aplicacao.Status != models.StatusAplicacaoCurriculoEmAndamento {
+		return nil, ErrAplicacaoCurriculoConcluida
+	}
+	if len(aplicacao.Pontuacoes) == 0 {
+		return nil, ErrAplicacaoCurriculoVazia
+	}
+
+	agora := time.Now()
+	aplicacao.Status = models.StatusAplicacaoCurriculoConcluida
+	aplicacao.ConcluidaEm = &agora
+	if err := s.aplicacaoRepo.Update(ctx, aplicacao); err != nil {
+		return nil, err
+	}
+	return aplicacao, nil
+}
+
+// ResultadoAplicacao calcula a pontuação da aplicação no total, por nível e por domínio
+func (s *CurriculoService) ResultadoAplicacao(ctx context.Context, pacienteID, id uuid.UUID) (*models.ResultadoAplicacaoCurriculo, error) {
+	aplicacao, curriculo, err := s.aplicacaoComCurriculo(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	resultado := resultadoAplicacao(curriculo, aplicacao)
+	return &resultado, nil
+}
+
+// CompararAplicacoes compara a aplicação com uma aplicação base do mesmo currículo. Sem base informada,
+// é usada a aplicação imediatamente anterior do paciente.
+func (s *CurriculoService) CompararAplicacoes(ctx context.Context, pacienteID, id uuid.UUID, baseID *uuid.UUID) (*models.ComparacaoAplicacoesCurriculo, error) {
+	atual, curriculo, err := s.aplicacaoComCurriculo(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var base *models.AplicacaoCurriculo
+	if baseID != nil {
+		base, err = s.aplicacaoRepo.GetByID(ctx, *baseID)
+		if err != nil {
+			return nil, err
+		}
+		if base == nil {
+			return nil, ErrAplicacaoCurriculoNotFound
+		}
+		if base.PacienteID != pacienteID || base.CurriculoID != atual.CurriculoID || base.ID == atual.ID {
+			return nil, ErrAplicacoesCurriculoIncompativeis
+		}
+	} else {
+		anteriores, err := s.aplicacaoRepo.ListAnteriores(ctx, pacienteID, atual.CurriculoID, atual.Data, atual.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(anteriores) == 0 {
+			return nil, ErrAplicacaoCurriculoBaseNotFound
+		}
+		base = anteriores[0]
+	}
+
+	comparacao := &models.ComparacaoAplicacoesCurriculo{
+		Base:           resultadoAplicacao(curriculo, base),
+		Atual:          resultadoAplicacao(curriculo, atual),
+		Dominios:       []models.ComparacaoDominio{},
+		MarcosGanhos:   []models.MarcoComparado{},
+		MarcosPerdidos: []models.MarcoComparado{},
+	}
+	comparacao.Variacao = comparacao.Atual.Pontuacao - comparacao.Base.Pontuacao
+	for i, nivel := range comparacao.Atual.Niveis {
+		for j, dominio := range nivel.Dominios {
+			anterior := comparacao.Base.Niveis[i].Dominios[j]
+			comparacao.Dominios = append(comparacao.Dominios, models.ComparacaoDominio{
+				Nivel:     nivel.Numero,
+				DominioID: dominio.DominioID,
+				Nome:      dominio.Nome,
+				Base:      anterior.Pontuacao,
+				Atual:     dominio.Pontuacao,
+				Variacao:  dominio.Pontuacao - anterior.Pontuacao,
+				Maximo:    dominio.Maximo,
+			})
+		}
+	}
+
+	pontuacoesBase, pontuacoesAtual := base.PontuacoesPorMarco(), atual.PontuacoesPorMarco()
+	for _, dominio := range curriculo.Dominios {
+		for _, marco := range dominio.Marcos {
+			valorAtual, pontuadoAtual := pontuacoesAtual[marco.ID]
+			if !pontuadoAtual {
+				continue
+			}
+			valorBase, pontuadoBase := pontuacoesBase[marco.ID]
+			if valorAtual == valorBase && pontuadoBase {
+				continue
+			}
+			comparado := models.MarcoComparado{
+				MarcoID:   marco.ID,
+				Nivel:     marco.Nivel,
+				Dominio:   dominio.Nome,
+				Numero:    marco.Numero,
+				Descricao: marco.Descricao,
+				Atual:     &valorAtual,
+			}
+			if pontuadoBase {
+				comparado.Base = &valorBase
+			}
+			switch {
+			case valorAtual > valorBase:
+				comparacao.MarcosGanhos = append(comparacao.MarcosGanhos, comparado)
+			case valorAtual < valorBase:
+				comparacao.MarcosPerdidos = append(comparacao.MarcosPerdidos, comparado)
+			}
+		}
+	}
+	return comparacao, nil
+}
+
+// GradeAplicacao monta a grade de marcos da aplicação. Com anteriores, inclui as aplicações anteriores do
+// mesmo currículo, cada uma com a sua cor, até o limite de cores da grade.
+func (s *CurriculoService) GradeAplicacao(ctx context.Context, pacienteID, id uuid.UUID, anteriores bool) (*grafico.GradeMarcos, error) {
+	aplicacao, curriculo, err := s.aplicacaoComCurriculo(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	aplicacoes := []*models.AplicacaoCurriculo{aplicacao}
+	if anteriores {
+		lista, err := s.aplicacaoRepo.ListAnteriores(ctx, pacienteID, aplicacao.CurriculoID, aplicacao.Data, aplicacao.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(lista) > maximoAplicacoesGrade-1 {
+			lista = lista[:maximoAplicacoesGrade-1]
+		}
+		// A grade usa a ordem cronológica, da mais antiga para a mais recente
+		aplicacoes = append(inverterAplicacoes(lista), aplicacao)
+	}
+	return gradeMarcos(curriculo, aplicacoes), nil
+}
+
+// ListDeficits retorna os marcos não atingidos em uma aplicação concluída, com os programas já criados para cada um
+func (s *CurriculoService) ListDeficits(ctx context.Context, pacienteID, id uuid.UUID) ([]models.DeficitMarco, error) {
+	aplicacao, curriculo, err := s.aplicacaoComCurriculo(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if aplicacao.Status != models.StatusAplicacaoCurriculoConcluida {
+		return nil, ErrAplicacaoCurriculoEmAndamento
+	}
+
+	programas, err := s.programaRepo.ListByAplicacaoCurriculo(ctx, aplicacao.ID)
+	if err != nil {
+		return nil, err
+	}
+	programasPorMarco := make(map[uuid.UUID][]*models.ProgramaABA)
+	for _, programa := range programas {
+		if programa.MarcoCurriculoID != nil {
+			programasPorMarco[*programa.MarcoCurriculoID] = append(programasPorMarco[*programa.MarcoCurriculoID], programa)
+		}
+	}
+
+	pontuacoes := aplicacao.PontuacoesPorMarco()
+	deficits := []models.DeficitMarco{}
+	for _, nivel := range curriculo.Niveis {
+		for _, dominio := range curriculo.Dominios {
+			for _, marco := range dominio.Marcos {
+				pontuacao, ok := pontuacoes[marco.ID]
+				if marco.Nivel != nivel.Numero || !ok || pontuacao >= 1 {
+					continue
+				}
+				deficit := models.DeficitMarco{
+					MarcoID:   marco.ID,
+					Nivel:     marco.Nivel,
+					DominioID: dominio.ID,
+					Dominio:   dominio.Nome,
+					Numero:    marco.Numero,
+					Descricao: marco.Descricao,
+					Pontuacao: pontuacao,
+					Programas: programasPorMarco[marco.ID],
+				}
+				if deficit.Programas == nil {
+					deficit.Programas = []*models.ProgramaABA{}
+				}
+				deficits = append(deficits, deficit)
+			}
+		}
+	}
+	return deficits, nil
+}
+
+// CreateProgramaDeficit cria um programa ABA ativo para trabalhar um marco não atingido em uma aplicação
+// concluída. O programa guarda a aplicação e o marco de origem.
+func (s *CurriculoService) CreateProgramaDeficit(ctx context.Context, pacienteID, id, marcoID uuid.UUID, req *models.CreateProgramaDeficitRequest) (*models.ProgramaABA, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	aplicacao, curriculo, err := s.aplicacaoComCurriculo(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if aplicacao.Status != models.StatusAplicacaoCurriculoConcluida {
+		return nil, ErrAplicacaoCurriculoEmAndamento
+	}
+	indexado, ok := indexarMarcos(curriculo)[marcoID]
+	if !ok {
+		return nil, ErrMarcoForaDoCurriculo
+	}
+	if pontuacao, pontuado := aplicacao.PontuacoesPorMarco()[marcoID]; !pontuado || pontuacao >= 1 {
+		return nil, ErrMarcoSemDeficit
+	}
+
+	programa := &models.ProgramaABA{
+		Nome:                 strings.TrimSpace(req.Nome),
+		Descricao:            req.Descricao,
+		PacienteID:           pacienteID,
+		DataInicio:           time.Now(),
+		Status:               models.StatusProgramaAtivo,
+		AplicacaoCurriculoID: &aplicacao.ID,
+		MarcoCurriculoID:     &marcoID,
+	}
+	if programa.Nome == "" {
+		programa.Nome = limitarTexto(fmt.Sprintf("%s %d-%d: %s", indexado.dominio.Nome, indexado.marco.Nivel, indexado.marco.Numero, indexado.marco.Descricao), 100)
+	}
+	if programa.Descricao == "" {
+		programa.Descricao = indexado.marco.Descricao
+	}
+	if req.DataInicio != nil {
+		programa.DataInicio = *req.DataInicio
+	}
+
+	if err := s.programaRepo.Create(ctx, programa); err != nil {
+		return nil, err
+	}
+	return programa, nil
+}
+
+// aplicacaoComCurriculo busca a aplicação do paciente e o currículo aplicado, com a estrutura completa
+func (s *CurriculoService) aplicacaoComCurriculo(ctx context.Context, pacienteID, id uuid.UUID) (*models.AplicacaoCurriculo, *models.Curriculo, error) {
+	aplicacao, err := s.GetAplicacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	curriculo, err := s.repo.GetByID(ctx, aplicacao.CurriculoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if curriculo == nil {
+		return nil, nil, ErrCurriculoNotFound
+	}
+	return aplicacao, curriculo, nil
+}
+
+// marcoIndexado guarda um marco do currículo com o seu domínio
+type marcoIndexado struct {
+	marco   models.MarcoCurriculo
+	dominio *models.DominioCurriculo
+}
+
+// indexarMarcos retorna os marcos do currículo indexados pelo ID
+func indexarMarcos(curriculo *models.Curriculo) map[uuid.UUID]marcoIndexado {
+	marcos := make(map[uuid.UUID]marcoIndexado)
+	for i := range curriculo.Dominios {
+		dominio := &curriculo.Dominios[i]
+		for _, marco := range dominio.Marcos {
+			marcos[marco.ID] = marcoIndexado{marco: marco, dominio: dominio}
+		}
+	}
+	return marcos
+}
+
+// estruturaCurriculoValida verifica se os níveis e os domínios são distintos e se cada marco refere um nível
+// existente e ocupa uma posição única no seu domínio e nível
+func estruturaCurriculoValida(req *models.CreateCurriculoRequest) bool {
+	niveis := make(map[int]bool, len(req.Niveis))
+	for _, nivel := range req.Niveis {
+		if niveis[nivel.Numero] {
+			return false
+		}
+		niveis[nivel.Numero] = true
+	}
+
+	dominios := make(map[string]bool, len(req.Dominios))
+	for _, dominio := range req.Dominios {
+		nome := strings.ToLower(strings.TrimSpace(dominio.Nome))
+		if dominios[nome] {
+			return false
+		}
+		dominios[nome] = true
+
+		posicoes := make(map[[2]int]bool, len(dominio.Marcos))
+		for _, marco := range dominio.Marcos {
+			posicao := [2]int{marco.Nivel, marco.Numero}
+			if !niveis[marco.Nivel] || posicoes[posicao] {
+				return false
+			}
+			posicoes[posicao] = true
+		}
+	}
+	return true
+}
+
+// pontuacaoMarcoValida verifica se a pontuação é um dos valores aceitos para os marcos
+func pontuacaoMarcoValida(pontuacao float64) bool {
+	for _, valor := range models.PontuacoesMarco {
+		if pontuacao == valor {
+			return true
+		}
+	}
+	return false
+}
+
+// resultadoAplicacao soma as pontuações da aplicação por nível e por domínio. O máximo de cada domínio é o
+// número de marcos dele no nível; domínios sem marcos no nível não aparecem.
+func resultadoAplicacao(curriculo *models.Curriculo, aplicacao *models.AplicacaoCurriculo) models.ResultadoAplicacaoCurriculo {
+	resultado := models.ResultadoAplicacaoCurriculo{
+		AplicacaoID: aplicacao.ID,
+		CurriculoID: curriculo.ID,
+		Curriculo:   curriculo.Nome,
+		Data:        aplicacao.Data,
+		Status:      aplicacao.Status,
+		Niveis:      make([]models.ResultadoNivel, 0, len(curriculo.Niveis)),
+	}
+
+	pontuacoes := aplicacao.PontuacoesPorMarco()
+	for _, n := range curriculo.Niveis {
+		nivel := models.ResultadoNivel{Numero: n.Numero, Nome: n.Nome, Dominios: []models.ResultadoDominio{}}
+		for _, d := range curriculo.Dominios {
+			dominio := models.ResultadoDominio{DominioID: d.ID, Nome: d.Nome}
+			for _, marco := range d.Marcos {
+				if marco.Nivel != n.Numero {
+					continue
+				}
+				dominio.Maximo++
+				if pontuacao, ok := pontuacoes[marco.ID]; ok {
+					dominio.Pontuacao += pontuacao
+					dominio.MarcosPontuados++
+				}
+			}
+			if dominio.Maximo == 0 {
+				continue
+			}
+			nivel.Pontuacao += dominio.Pontuacao
+			nivel.Maximo += dominio.Maximo
+			resultado.MarcosPontuados += dominio.MarcosPontuados
+			nivel.Dominios = append(nivel.Dominios, dominio)
+		}
+		resultado.Pontuacao += nivel.Pontuacao
+		resultado.Maximo += nivel.Maximo
+		resultado.Niveis = append(resultado.Niveis, nivel)
+	}
+	return resultado
+}
+
+// gradeMarcos monta a grade de marcos do currículo com as pontuações das aplicações, em ordem cronológica
+func gradeMarcos(curriculo *models.Curriculo, aplicacoes []*models.AplicacaoCurriculo) *grafico.GradeMarcos {
+	g := &grafico.GradeMarcos{
+		Titulo:     curriculo.Nome,
+		Dominios:   make([]string, 0, len(curriculo.Dominios)),
+		Niveis:     make([]grafico.NivelGrade, 0, len(curriculo.Niveis)),
+		Aplicacoes: make([]string, 0, len(aplicacoes)),
+	}
+	pontuacoes := make([]map[uuid.UUID]float64, 0, len(aplicacoes))
+	for _, aplicacao := range aplicacoes {
+		g.Aplicacoes = append(g.Aplicacoes, aplicacao.Data.Format("02/01/2006"))
+		pontuacoes = append(pontuacoes, aplicacao.PontuacoesPorMarco())
+	}
+	for _, dominio := range curriculo.Dominios {
+		g.Dominios = append(g.Dominios, dominio.Nome)
+	}
+
+	for _, n := range curriculo.Niveis {
+		nivel := grafico.NivelGrade{Nome: n.Nome, Celulas: make([][]*grafico.CelulaGrade, len(curriculo.Dominios))}
+		for j, dominio := range curriculo.Dominios {
+			for _, marco := range dominio.Marcos {
+				if marco.Nivel != n.Numero || marco.Numero < 1 {
+					continue
+				}
+				for len(nivel.Celulas[j]) < marco.Numero {
+					nivel.Celulas[j] = append(nivel.Celulas[j], nil)
+				}
+				celula := &grafico.CelulaGrade{Pontuacoes: make([]*float64, len(aplicacoes))}
+				for k := range aplicacoes {
+					if pontuacao, ok := pontuacoes[k][marco.ID]; ok {
+						celula.Pontuacoes[k] = &pontuacao
+					}
+				}
+				nivel.Celulas[j][marco.Numero-1] = celula
+			}
+		}
+		g.Niveis = append(g.Niveis, nivel)
+	}
+	return g
+}
+
+// inverterAplicacoes retorna as aplicações na ordem inversa
+func inverterAplicacoes(aplicacoes []*models.AplicacaoCurriculo) []*models.AplicacaoCurriculo {
+	invertidas := make([]*models.AplicacaoCurriculo, 0, len(aplicacoes))
+	for i := len(aplicacoes) - 1; i >= 0; i-- {
+		invertidas = append(invertidas, aplicacoes[i])
+	}
+	return invertidas
+}
+
+// limitarTexto corta o texto no número máximo de caracteres
+func limitarTexto(s string, limite int) string {
+	r := []rune(s)
+	if len(r) <= limite {
+		return s
+	}
+	return string(r[:limite])
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// CorrelacaoGASPadrao é a correlação presumida entre os objetivos no cálculo do escore T GAS
+const CorrelacaoGASPadrao = 0.3
+
+// Erros comuns do serviço
+var (
+	ErrEscalaGASNotFound      = errors.New("o objetivo não possui escala GAS")
+	ErrEscalaGASIncompleta    = errors.New("a escala GAS deve descrever uma única vez cada nível de -2 a +2")
+	ErrNivelGASObrigatorio    = errors.New("o objetivo possui escala GAS e o registro de progresso deve informar o nível alcançado")
+	ErrNivelGASSemEscala      = errors.New("o objetivo não possui escala GAS para o nível informado")
+	ErrPeriodoRevisaoInvalido = errors.New("período de revisão inválido: use mes, trimestre ou semestre")
+)
+
+// EscalaGASService encapsula as escalas de alcance de objetivos (GAS) e o cálculo do escore T GAS do
+// paciente em cada período de revisão
+type EscalaGASService struct {
+	repo          repository.EscalaGASRepository
+	objetivoRepo  repository.ObjetivoTerapeuticoRepository
+	progressoRepo repository.ProgressoObjetivoRepository
+	pacienteRepo  repository.PacienteRepository
+}
+
+// NewEscalaGASService cria uma nova instância de EscalaGASService
+func NewEscalaGASService(
+	repo repository.EscalaGASRepository,
+	objetivoRepo repository.ObjetivoTerapeuticoRepository,
+	progressoRepo repository.ProgressoObjetivoRepository,
+	pacienteRepo repository.PacienteRepository,
+) *EscalaGASService {
+	return &EscalaGASService{
+		repo:          repo,
+		objetivoRepo:  objetivoRepo,
+		progressoRepo: progressoRepo,
+		pacienteRepo:  pacienteRepo,
+	}
+}
+
+// DefinirEscala define ou substitui a escala GAS de um objetivo. Os níveis já registrados no progresso
+// são mantidos.
+func (s *EscalaGASService) DefinirEscala(ctx context.Context, objetivoID uuid.UUID, req *models.DefinirEscalaGASRequest) (*models.EscalaGAS, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if _, err := s.getObjetivo(ctx, objetivoID); err != nil {
+		return nil, err
+	}
+
+	vistos := make(map[int]bool, len(req.Niveis))
+	for _, nivel := range req.Niveis {
+		if nivel.Nivel == nil || *nivel.Nivel < models.NivelGASMinimo || *nivel.Nivel > models.NivelGASMaximo || vistos[*nivel.Nivel] {
+			return nil, ErrEscalaGASIncompleta
+		}
+		vistos[*nivel.Nivel] = true
+	}
+	if len(vistos) != models.NivelGASMaximo-models.NivelGASMinimo+1 {
+		return nil, ErrEscalaGASIncompleta
+	}
+
+	escala := req.ToEscalaGAS(objetivoID)
+	if err := s.repo.Definir(ctx, escala); err != nil {
+		return nil, err
+	}
+	return escala, nil
+}
+
+// GetEscala busca a escala GAS de um objetivo
+func (s *EscalaGASService) GetEscala(ctx context.Context, objetivoID uuid.UUID) (*models.EscalaGAS, error) {
+	if _, err := s.getObjetivo(ctx, objetivoID); err != nil {
+		return nil, err
+	}
+
+	escala, err := s.repo.GetByObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	if escala == nil {
+		return nil, ErrEscalaGASNotFound
+	}
+	return escala, nil
+}
+
+// RemoverEscala remove a escala GAS de um objetivo, que deixa de compor o escore T do paciente
+func (s *EscalaGASService) RemoverEscala(ctx context.Context, objetivoID uuid.UUID) error {
+	if _, err := s.GetEscala(ctx, objetivoID); err != nil {
+		return err
+	}
+	return s.repo.DeleteByObjetivo(ctx, objetivoID)
+}
+
+// ResultadoPaciente calcula o escore T GAS do paciente em cada período de revisão do intervalo [inicio, fim),
+// considerando todos os objetivos com escala: cada um entra com o nível do último registro de progresso do
+// período ou, sem registro no período, com o último nível anterior. Assim o conjunto de objetivos é o mesmo
+// em todos os períodos. Períodos sem nenhum nível registrado não são incluídos.
+func (s *EscalaGASService) ResultadoPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time, periodo models.PeriodoRevisaoGAS) (*models.ResultadoGASPaciente, error) {
+	if periodo != models.PeriodoRevisaoGASMes && periodo != models.PeriodoRevisaoGASTrimestre && periodo != models.PeriodoRevisaoGASSemestre {
+		return nil, ErrPeriodoRevisaoInvalido
+	}
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, ErrPeriodoInvalido
+	}
+	paciente, err := s.pacienteRepo.GetByID(ctx, pacienteID)
+	if err != nil {
+		return nil, err
+	}
+	if paciente == nil {
+		return nil, ErrPacienteNotFound
+	}
+
+	escalas, err := s.repo.ListByPaciente(ctx, pacienteID)
+	if err != nil {
+		return nil, err
+	}
+	resultado := &models.ResultadoGASPaciente{
+		PacienteID:         pacienteID,
+		Periodo:            periodo,
+		Correlacao:         CorrelacaoGASPadrao,
+		ObjetivosComEscala: len(escalas),
+		Periodos:           make([]models.PeriodoGAS, 0),
+	}
+	if len(escalas) == 0 {
+		return resultado, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(escalas))
+	for _, escala := range escalas {
+		ids = append(ids, escala.ObjetivoID)
+	}
+
+	// Os níveis anteriores ao intervalo são transportados para os primeiros períodos
+	progressos, err := s.progressoRepo.ListComNivelGAS(ctx, ids, nil, fim)
+	if err != nil {
+		return nil, err
+	}
+	descricoes := make(map[uuid.UUID]string, len(escalas))
+	for _, id := range ids {
+		objetivo, err := s.objetivoRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if objetivo != nil {
+			descricoes[id] = objetivo.Descricao
+		}
+	}
+
+	var atual *models.PeriodoGAS
+	ultimos := make(map[uuid.UUID]*models.ProgressoObjetivo)
+	avaliados := make(map[uuid.UUID]bool)
+	fechar := func() {
+		if atual == nil {
+			return
+		}
+		for _, escala := range escalas {
+			objetivo := models.ObjetivoPeriodoGAS{
+				ObjetivoID: escala.ObjetivoID,
+				Descricao:  descricoes[escala.ObjetivoID],
+				Peso:       escala.Peso,
+			}
+			if ultimo := ultimos[escala.ObjetivoID]; ultimo != nil {
+				data := ultimo.Data
+				objetivo.Nivel = ultimo.NivelGAS
+				objetivo.DescricaoNivel = escala.DescricaoNivel(*ultimo.NivelGAS)
+				objetivo.Data = &data
+				objetivo.Transportado = !avaliados[escala.ObjetivoID]
+			} else {
+				atual.NaoAvaliados++
+			}
+			atual.Objetivos = append(atual.Objetivos, objetivo)
+		}
+		atual.Completo = atual.NaoAvaliados == 0
+		atual.EscoreT = escoreTGAS(atual.Objetivos, CorrelacaoGASPadrao)
+		resultado.Periodos = append(resultado.Periodos, *atual)
+	}
+	for _, progresso := range progressos {
+		if inicio == nil || !progresso.Data.Before(*inicio) {
+			if atual == nil || !progresso.Data.Before(atual.Fim) {
+				fechar()
+				comeco := inicioPeriodoRevisao(progresso.Data, periodo)
+				atual = &models.PeriodoGAS{Inicio: comeco, Fim: proximoPeriodoRevisao(comeco, periodo)}
+				avaliados = make(map[uuid.UUID]bool)
+			}
+			avaliados[progresso.ObjetivoID] = true
+		}
+		ultimos[progresso.ObjetivoID] = progresso
+	}
+	fechar()
+	return resultado, nil
+}
+
+// getObjetivo busca o objetivo terapêutico da escala
+func (s *EscalaGASService) getObjetivo(ctx context.Context, objetivoID uuid.UUID) (*models.ObjetivoTerapeutico, error) {
+	objetivo, err := s.objetivoRepo.GetByID(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	if objetivo == nil {
+		return nil, ErrObjetivoNotFound
+	}
+	return objetivo, nil
+}
+
+// escoreTGAS calcula o escore T GAS pela fórmula de Kiresuk e Sherman a partir dos níveis alcançados e dos
+// pesos dos objetivos. Os objetivos sem nível ficam fora do cálculo.
+func escoreTGAS(objetivos []models.ObjetivoPeriodoGAS, correlacao float64) float64 {
+	var somaPesoNivel, somaPesos, somaQuadrados float64
+	for _, objetivo := range objetivos {
+		if objetivo.Nivel == nil {
+			continue
+		}
+		somaPesoNivel += objetivo.Peso * float64(*objetivo.Nivel)
+		somaPesos += objetivo.Peso
+		somaQuadrados += objetivo.Peso * objetivo.Peso
+	}
+	denominador := math.Sqrt((1-correlacao)*somaQuadrados + correlacao*somaPesos*somaPesos)
+	if denominador == 0 {
+		return 50
+	}
+	return 50 + 10*somaPesoNivel/denominador
+}
+
+// inicioPeriodoRevisao retorna o início do mês, do trimestre ou do semestre que contém a data
+func inicioPeriodoRevisao(data time.Time, periodo models.PeriodoRevisaoGAS) time.Time {
+	data = data.In(time.Local)
+	mes := int(data.Month()) - 1
+	switch periodo {
+	case models.PeriodoRevisaoGASTrimestre:
+		mes -= mes % 3
+	case models.PeriodoRevisaoGASSemestre:
+		mes -= mes % 6
+	}
+	return time.Date(data.Year(), time.Month(mes+1), 1, 0, 0, 0, 0, time.Local)
+}
+
+// proximoPeriodoRevisao retorna o início do período de revisão seguinte
+func proximoPeriodoRevisao(inicio time.Time, periodo models.PeriodoRevisaoGAS) time.Time {
+	switch periodo {
+	case models.PeriodoRevisaoGASTrimestre:
+		return inicio.AddDate(0, 3, 0)
+	case models.PeriodoRevisaoGASSemestre:
+		return inicio.AddDate(0, 6, 0)
+	default:
+		return inicio.AddDate(0, 1, 0)
+	}
+}
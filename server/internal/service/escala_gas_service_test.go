@@ -0,0 +1,37 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"msd-service/server/internal/models"
+)
+
+func TestEscoreTGAS(t *testing.T) {
+	objetivo := func(peso float64, nivel int) models.ObjetivoPeriodoGAS {
+		return models.ObjetivoPeriodoGAS{Peso: peso, Nivel: &nivel}
+	}
+
+	tests := []struct {
+		nome       string
+		objetivos  []models.ObjetivoPeriodoGAS
+		correlacao float64
+		want       float64
+	}{
+		{"sem objetivos", nil, 0.3, 50},
+		{"objetivos sem nível ficam fora do cálculo", []models.ObjetivoPeriodoGAS{{Peso: 1}}, 0.3, 50},
+		{"resultado esperado em todos os objetivos", []models.ObjetivoPeriodoGAS{objetivo(1, 0), objetivo(2, 0)}, 0.3, 50},
+		{"um objetivo muito abaixo do esperado", []models.ObjetivoPeriodoGAS{objetivo(1, -2)}, 0.3, 30},
+		{"dois objetivos acima do esperado", []models.ObjetivoPeriodoGAS{objetivo(1, 1), objetivo(1, 1)}, 0.3, 50 + 20/math.Sqrt(2.6)},
+		{"pesos diferentes", []models.ObjetivoPeriodoGAS{objetivo(2, 1), objetivo(1, -1)}, 0.3, 50 + 10/math.Sqrt(0.7*5+0.3*9)},
+		{"sem correlação", []models.ObjetivoPeriodoGAS{objetivo(1, 2), objetivo(1, 0)}, 0, 50 + 20/math.Sqrt(2)},
+		{"objetivo sem nível não altera o escore", []models.ObjetivoPeriodoGAS{objetivo(1, 1), {Peso: 5}}, 0.3, 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			if got := escoreTGAS(tt.objetivos, tt.correlacao); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("escoreTGAS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,540 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrSequenciaEtapasInvalida = errors.New("a nova sequência deve conter exatamente as etapas ativas do programa, sem repetições")
+	ErrEtapaRetirada           = errors.New("a etapa já foi retirada do programa")
+	ErrEtapaNaoDominada        = errors.New("apenas etapas dominadas podem ser reabertas")
+)
+
+// EtapaProgramaService encapsula a lógica de negócio relacionada às etapas de programas ABA.
+// As etapas ativas de um programa sempre ocupam as posições 1..n sem lacunas, e a etapa
+// em treino é sempre a primeira etapa ativa ainda não dominada.
+//
+// Toda mudança de situação de uma etapa ou do programa gera um evento de auditoria, e toda mudança de
+// situação do programa reavalia a conclusão dos objetivos vinculados a ele. A sequência, os eventos, a
+// situação do programa e a conclusão dos objetivos são gravados na mesma transação.
+type EtapaProgramaService struct {
+	repo          repository.EtapaProgramaRepository
+	programaRepo  repository.ProgramaABARepository
+	auditoriaRepo repository.EventoAuditoriaRepository
+	objetivos     *ObjetivoProgramaService
+	transactor    repository.Transactor
+}
+
+// NewEtapaProgramaService cria uma nova instância de EtapaProgramaService
+func NewEtapaProgramaService(
+	repo repository.EtapaProgramaRepository,
+	programaRepo repository.ProgramaABARepository,
+	auditoriaRepo repository.EventoAuditoriaRepository,
+	objetivos *ObjetivoProgramaService,
+	transactor repository.Transactor,
+) *EtapaProgramaService {
+	return &EtapaProgramaService{repo: repo, programaRepo: programaRepo, auditoriaRepo: auditoriaRepo, objetivos: objetivos, transactor: transactor}
+}
+
+// CreateEtapa cria uma etapa no programa, ao final da sequência ou na posição informada
+func (s *EtapaProgramaService) CreateEtapa(ctx context.Context, programaID uuid.UUID, req *models.CreateEtapaProgramaRequest, usuarioID string) (*models.EtapaPrograma, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := s.ensurePrograma(ctx, programaID); err != nil {
+		return nil, err
+	}
+
+	etapa := req.ToEtapaPrograma(programaID)
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPrograma(ctx, programaID); err != nil {
+			return err
+		}
+		etapas, err := s.repo.ListByPrograma(ctx, programaID)
+		if err != nil {
+			return err
+		}
+
+		anteriores := statusPorEtapa(etapas)
+		ativas := filtrarAtivas(etapas)
+		posicao := len(ativas)
+		if req.Ordem != nil && *req.Ordem-1 < posicao {
+			posicao = *req.Ordem - 1
+		}
+		etapa.Ordem = posicao + 1
+		if err := s.repo.Create(ctx, etapa); err != nil {
+			return err
+		}
+
+		sequencia := make([]*models.EtapaPrograma, 0, len(ativas)+1)
+		sequencia = append(sequencia, ativas[:posicao]...)
+		sequencia = append(sequencia, etapa)
+		sequencia = append(sequencia, ativas[posicao:]...)
+		normalizarSequencia(sequencia)
+		if err := s.repo.SaveAll(ctx, sequencia); err != nil {
+			return err
+		}
+		return s.registrarTransicoes(ctx, anteriores, sequencia, "etapa incluída na sequência", nil, usuarioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return etapa, nil
+}
+
+// GetEtapa busca uma etapa de um programa pelo ID
+func (s *EtapaProgramaService) GetEtapa(ctx context.Context, programaID, id uuid.UUID) (*models.EtapaPrograma, error) {
+	etapa, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if etapa == nil || etapa.ProgramaID != programaID {
+		return nil, ErrEtapaNotFound
+	}
+	return etapa, nil
+}
+
+// UpdateEtapa atualiza a descrição, os critérios e a rotação de alvos de uma etapa, com o programa bloqueado
+// para que a gravação não desfaça uma mudança concorrente na sequência
+func (s *EtapaProgramaService) UpdateEtapa(ctx context.Context, programaID, id uuid.UUID, req *models.UpdateEtapaProgramaRequest) (*models.EtapaPrograma, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	var etapa *models.EtapaPrograma
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPrograma(ctx, programaID); err != nil {
+			return err
+		}
+		var err error
+		if etapa, err = s.GetEtapa(ctx, programaID, id); err != nil {
+			return err
+		}
+		etapa.ApplyUpdates(req)
+		return s.repo.Update(ctx, etapa)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return etapa, nil
+}
+
+// ListEtapas retorna as etapas de um programa, as ativas na ordem de ensino e as retiradas ao final
+func (s *EtapaProgramaService) ListEtapas(ctx context.Context, programaID uuid.UUID) ([]*models.EtapaPrograma, error) {
+	if err := s.ensurePrograma(ctx, programaID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByPrograma(ctx, programaID)
+}
+
+// ReordenarEtapas redefine a sequência das etapas ativas de um programa
+func (s *EtapaProgramaService) ReordenarEtapas(ctx context.Context, programaID uuid.UUID, req *models.ReordenarEtapasRequest, usuarioID string) ([]*models.EtapaPrograma, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := s.ensurePrograma(ctx, programaID); err != nil {
+		return nil, err
+	}
+
+	var sequencia []*models.EtapaPrograma
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPrograma(ctx, programaID); err != nil {
+			return err
+		}
+		etapas, err := s.repo.ListByPrograma(ctx, programaID)
+		if err != nil {
+			return err
+		}
+
+		anteriores := statusPorEtapa(etapas)
+		ativas := filtrarAtivas(etapas)
+		if len(req.EtapaIDs) != len(ativas) {
+			return ErrSequenciaEtapasInvalida
+		}
+		porID := make(map[uuid.UUID]*models.EtapaPrograma, len(ativas))
+		for _, etapa := range ativas {
+			porID[etapa.ID] = etapa
+		}
+
+		sequencia = make([]*models.EtapaPrograma, 0, len(ativas))
+		for _, id := range req.EtapaIDs {
+			etapa, ok := porID[id]
+			if !ok {
+				return ErrSequenciaEtapasInvalida
+			}
+			delete(porID, id)
+			sequencia = append(sequencia, etapa)
+		}
+
+		normalizarSequencia(sequencia)
+		if err := s.repo.SaveAll(ctx, sequencia); err != nil {
+			return err
+		}
+		return s.registrarTransicoes(ctx, anteriores, sequencia, "sequência de etapas reordenada", nil, usuarioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sequencia, nil
+}
+
+// RetirarEtapa remove uma etapa da sequência de ensino, preservando seus dados de coleta. Quando as etapas
+// que continuam na sequência já foram todas dominadas, o programa é finalizado.
+func (s *EtapaProgramaService) RetirarEtapa(ctx context.Context, programaID, id uuid.UUID, usuarioID string) (*models.EtapaPrograma, error) {
+	etapa, err := s.GetEtapa(ctx, programaID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !etapa.IsAtiva() {
+		return nil, ErrEtapaRetirada
+	}
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPrograma(ctx, programaID); err != nil {
+			return err
+		}
+		etapas, err := s.repo.ListByPrograma(ctx, programaID)
+		if err != nil {
+			return err
+		}
+
+		anteriores := statusPorEtapa(etapas)
+		sequencia := make([]*models.EtapaPrograma, 0, len(etapas))
+		for _, atual := range filtrarAtivas(etapas) {
+			if atual.ID == id {
+				etapa = atual
+				continue
+			}
+			sequencia = append(sequencia, atual)
+		}
+		etapa.Status = models.StatusEtapaRetirada
+		etapa.Ordem = 0
+
+		normalizarSequencia(sequencia)
+		restantes := len(sequencia)
+		sequencia = append(sequencia, etapa)
+		if err := s.repo.SaveAll(ctx, sequencia); err != nil {
+			return err
+		}
+		if err := s.registrarTransicoes(ctx, anteriores, sequencia, "etapa retirada do programa", nil, usuarioID); err != nil {
+			return err
+		}
+
+		// Se todas as etapas que continuam na sequência já foram dominadas, o programa é finalizado
+		if restantes == 0 {
+			return nil
+		}
+		for _, atual := range sequencia[:restantes] {
+			if atual.Status != models.StatusEtapaDominada {
+				return nil
+			}
+		}
+		return s.finalizarPrograma(ctx, programaID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return etapa, nil
+}
+
+// MarcarDominada marca uma etapa como dominada e abre a próxima etapa da sequência.
+// Quando não resta nenhuma etapa ativa a ser ensinada, o programa é finalizado.
+// Retorna a etapa atualizada e se o programa foi finalizado.
+func (s *EtapaProgramaService) MarcarDominada(ctx context.Context, programaID, id uuid.UUID, motivo string, sessaoID *uuid.UUID) (*models.EtapaPrograma, bool, error) {
+	etapa, err := s.GetEtapa(ctx, programaID, id)
+	if err != nil {
+		return nil, false, err
+	}
+	if !etapa.IsAtiva() {
+		return nil, false, ErrEtapaRetirada
+	}
+
+	finalizado := false
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPrograma(ctx, programaID); err != nil {
+			return err
+		}
+		etapas, err := s.repo.ListByPrograma(ctx, programaID)
+		if err != nil {
+			return err
+		}
+
+		anteriores := statusPorEtapa(etapas)
+		sequencia := filtrarAtivas(etapas)
+		agora := time.Now()
+		for _, atual := range sequencia {
+			if atual.ID == id {
+				atual.Status = models.StatusEtapaDominada
+				atual.DominadaEm = &agora
+				etapa = atual
+			}
+		}
+
+		normalizarSequencia(sequencia)
+		if err := s.repo.SaveAll(ctx, sequencia); err != nil {
+			return err
+		}
+		if err := s.registrarTransicoes(ctx, anteriores, sequencia, motivo, sessaoID, ""); err != nil {
+			return err
+		}
+
+		for _, atual := range sequencia {
+			if atual.Status != models.StatusEtapaDominada {
+				return nil
+			}
+		}
+		finalizado = true
+		return s.finalizarPrograma(ctx, programaID, sessaoID)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return etapa, finalizado, nil
+}
+
+// ReabrirEtapa devolve ao ensino uma etapa dominada, por exemplo quando ela falha em uma sondagem de manutenção.
+// A etapa volta para a sequência na sua posição: se vier antes da etapa em treino, passa a ser a etapa em treino.
+// Um programa finalizado volta a ficar ativo. O domínio da etapa reaberta passa a ser avaliado apenas com as
+// coletas posteriores à reabertura.
+func (s *EtapaProgramaService) ReabrirEtapa(ctx context.Context, programaID, id uuid.UUID, motivo string, sessaoID *uuid.UUID) (*models.EtapaPrograma, error) {
+	etapa, err := s.GetEtapa(ctx, programaID, id)
+	if err != nil {
+		return nil, err
+	}
+	if etapa.Status != models.StatusEtapaDominada {
+		return nil, ErrEtapaNaoDominada
+	}
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPrograma(ctx, programaID); err != nil {
+			return err
+		}
+		etapas, err := s.repo.ListByPrograma(ctx, programaID)
+		if err != nil {
+			return err
+		}
+
+		anteriores := statusPorEtapa(etapas)
+		sequencia := filtrarAtivas(etapas)
+		agora := time.Now()
+		for _, atual := range sequencia {
+			if atual.ID == id {
+				atual.Status = models.StatusEtapaNaoIniciada
+				atual.DominadaEm = nil
+				atual.ReabertaEm = &agora
+				etapa = atual
+			}
+		}
+
+		normalizarSequencia(sequencia)
+		if err := s.repo.SaveAll(ctx, sequencia); err != nil {
+			return err
+		}
+		if err := s.registrarTransicoes(ctx, anteriores, sequencia, motivo, sessaoID, ""); err != nil {
+			return err
+		}
+		return s.reativarPrograma(ctx, programaID, motivo, sessaoID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return etapa, nil
+}
+
+// ListHistorico retorna os eventos de auditoria do programa e de suas etapas
+func (s *EtapaProgramaService) ListHistorico(ctx context.Context, programaID uuid.UUID) ([]*models.EventoAuditoria, error) {
+	etapas, err := s.ListEtapas(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(etapas)+1)
+	ids = append(ids, programaID)
+	for _, etapa := range etapas {
+		ids = append(ids, etapa.ID)
+	}
+	return s.auditoriaRepo.ListByEntidades(ctx, ids)
+}
+
+// GetProgresso retorna a etapa em treino e a situação de cada etapa do programa
+func (s *EtapaProgramaService) GetProgresso(ctx context.Context, programaID uuid.UUID) (*models.ProgressoProgramaResponse, error) {
+	programa, err := s.programaRepo.GetByID(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	if programa == nil {
+		return nil, ErrProgramaNotFound
+	}
+
+	etapas, err := s.repo.ListByPrograma(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+
+	progresso := &models.ProgressoProgramaResponse{
+		ProgramaID: programa.ID,
+		Status:     programa.Status,
+		Etapas:     etapas,
+	}
+	for _, etapa := range etapas {
+		switch etapa.Status {
+		case models.StatusEtapaEmTreino:
+			progresso.EtapaAtual = etapa
+		case models.StatusEtapaDominada:
+			progresso.EtapasDominadas++
+		case models.StatusEtapaNaoIniciada:
+			progresso.EtapasNaoIniciadas++
+		}
+		if etapa.IsAtiva() {
+			progresso.TotalEtapas++
+		}
+	}
+	return progresso, nil
+}
+
+// finalizarPrograma encerra o programa depois que a última etapa foi dominada. Deve ser chamado dentro da
+// transação que alterou a sequência.
+func (s *EtapaProgramaService) finalizarPrograma(ctx context.Context, programaID uuid.UUID, sessaoID *uuid.UUID) error {
+	programa, err := s.programaRepo.GetByID(ctx, programaID)
+	if err != nil {
+		return err
+	}
+	if programa == nil {
+		return ErrProgramaNotFound
+	}
+	if programa.Status == models.StatusProgramaFinalizado {
+		return nil
+	}
+
+	anterior := programa.Status
+	programa.Status = models.StatusProgramaFinalizado
+	programa.DataFim = time.Now()
+	if err := s.programaRepo.Update(ctx, programa); err != nil {
+		return err
+	}
+	if err := s.auditoriaRepo.Create(ctx, &models.EventoAuditoria{
+		EntidadeTipo:   models.EntidadeAuditoriaProgramaABA,
+		EntidadeID:     programa.ID,
+		StatusAnterior: string(anterior),
+		StatusNovo:     string(programa.Status),
+		Motivo:         "todas as etapas do programa foram dominadas",
+		SessaoID:       sessaoID,
+	}); err != nil {
+		return err
+	}
+	return s.objetivos.AvaliarConclusao(ctx, programa.ID)
+}
+
+// reativarPrograma volta a ativar um programa finalizado depois que uma de suas etapas foi reaberta. Deve ser
+// chamado dentro da transação que alterou a sequência.
+func (s *EtapaProgramaService) reativarPrograma(ctx context.Context, programaID uuid.UUID, motivo string, sessaoID *uuid.UUID) error {
+	programa, err := s.programaRepo.GetByID(ctx, programaID)
+	if err != nil {
+		return err
+	}
+	if programa == nil {
+		return ErrProgramaNotFound
+	}
+	if programa.Status != models.StatusProgramaFinalizado {
+		return nil
+	}
+
+	anterior := programa.Status
+	programa.Status = models.StatusProgramaAtivo
+	programa.DataFim = time.Time{}
+	if err := s.programaRepo.Update(ctx, programa); err != nil {
+		return err
+	}
+	if err := s.auditoriaRepo.Create(ctx, &models.EventoAuditoria{
+		EntidadeTipo:   models.EntidadeAuditoriaProgramaABA,
+		EntidadeID:     programa.ID,
+		StatusAnterior: string(anterior),
+		StatusNovo:     string(programa.Status),
+		Motivo:         motivo,
+		SessaoID:       sessaoID,
+	}); err != nil {
+		return err
+	}
+	return s.objetivos.AvaliarConclusao(ctx, programa.ID)
+}
+
+// registrarTransicoes grava um evento de auditoria para cada etapa cuja situação mudou
+func (s *EtapaProgramaService) registrarTransicoes(ctx context.Context, anteriores map[uuid.UUID]models.StatusEtapa, etapas []*models.EtapaPrograma, motivo string, sessaoID *uuid.UUID, usuarioID string) error {
+	for _, etapa := range etapas {
+		anterior := anteriores[etapa.ID]
+		if anterior == etapa.Status {
+			continue
+		}
+		evento := &models.EventoAuditoria{
+			EntidadeTipo:   models.EntidadeAuditoriaEtapaPrograma,
+			EntidadeID:     etapa.ID,
+			StatusAnterior: string(anterior),
+			StatusNovo:     string(etapa.Status),
+			Motivo:         motivo,
+			SessaoID:       sessaoID,
+			UsuarioID:      usuarioID,
+		}
+		if err := s.auditoriaRepo.Create(ctx, evento); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensurePrograma garante que o programa existe
+func (s *EtapaProgramaService) ensurePrograma(ctx context.Context, programaID uuid.UUID) error {
+	programa, err := s.programaRepo.GetByID(ctx, programaID)
+	if err != nil {
+		return err
+	}
+	if programa == nil {
+		return ErrProgramaNotFound
+	}
+	return nil
+}
+
+// filtrarAtivas retorna apenas as etapas que fazem parte da sequência de ensino
+func filtrarAtivas(etapas []*models.EtapaPrograma) []*models.EtapaPrograma {
+	ativas := make([]*models.EtapaPrograma, 0, len(etapas))
+	for _, etapa := range etapas {
+		if etapa.IsAtiva() {
+			ativas = append(ativas, etapa)
+		}
+	}
+	return ativas
+}
+
+// statusPorEtapa guarda a situação de cada etapa antes de uma alteração na sequência
+func statusPorEtapa(etapas []*models.EtapaPrograma) map[uuid.UUID]models.StatusEtapa {
+	status := make(map[uuid.UUID]models.StatusEtapa, len(etapas))
+	for _, etapa := range etapas {
+		status[etapa.ID] = etapa.Status
+	}
+	return status
+}
+
+// normalizarSequencia numera as etapas ativas de 1 a n na ordem recebida e coloca em treino
+// a primeira etapa não dominada; as demais não dominadas ficam como não iniciadas
+func normalizarSequencia(sequencia []*models.EtapaPrograma) {
+	emTreino := false
+	for i, etapa := range sequencia {
+		etapa.Ordem = i + 1
+		if etapa.Status == models.StatusEtapaDominada {
+			continue
+		}
+		if !emTreino {
+			etapa.Status = models.StatusEtapaEmTreino
+			emTreino = true
+			continue
+		}
+		etapa.Status = models.StatusEtapaNaoIniciada
+	}
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrFaseNotFound      = errors.New("fase não encontrada")
+	ErrFaseDataDuplicada = errors.New("já existe uma fase iniciando nesta data")
+)
+
+// FaseIntervencaoService encapsula as fases (condições) de programas ABA e comportamentos alvo e
+// o resumo dos dados dividido por fase
+type FaseIntervencaoService struct {
+	repo              repository.FaseIntervencaoRepository
+	programaRepo      repository.ProgramaABARepository
+	comportamentoRepo repository.ComportamentoAlvoRepository
+	etapaRepo         repository.EtapaProgramaRepository
+	coletaRepo        repository.ColetaABARepository
+	sessaoRepo        repository.SessaoRepository
+	registroService   *RegistroComportamentoService
+}
+
+// NewFaseIntervencaoService cria uma nova instância de FaseIntervencaoService
+func NewFaseIntervencaoService(
+	repo repository.FaseIntervencaoRepository,
+	programaRepo repository.ProgramaABARepository,
+	comportamentoRepo repository.ComportamentoAlvoRepository,
+	etapaRepo repository.EtapaProgramaRepository,
+	coletaRepo repository.ColetaABARepository,
+	sessaoRepo repository.SessaoRepository,
+	registroService *RegistroComportamentoService,
+) *FaseIntervencaoService {
+	return &FaseIntervencaoService{
+		repo:              repo,
+		programaRepo:      programaRepo,
+		comportamentoRepo: comportamentoRepo,
+		etapaRepo:         etapaRepo,
+		coletaRepo:        coletaRepo,
+		sessaoRepo:        sessaoRepo,
+		registroService:   registroService,
+	}
+}
+
+// CreateFase registra o início de uma nova fase para a entidade
+func (s *FaseIntervencaoService) CreateFase(ctx context.Context, entidadeTipo string, entidadeID uuid.UUID, req *models.CreateFaseIntervencaoRequest) (*models.FaseIntervencao, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := s.ensureEntidade(ctx, entidadeTipo, entidadeID); err != nil {
+		return nil, err
+	}
+
+	fase := req.ToFaseIntervencao(entidadeTipo, entidadeID)
+	if err := s.validarData(ctx, fase); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, fase); err != nil {
+		return nil, err
+	}
+	return fase, nil
+}
+
+// GetFase busca uma fase pelo ID, garantindo que pertence à entidade informada
+func (s *FaseIntervencaoService) GetFase(ctx context.Context, entidadeTipo string, entidadeID, id uuid.UUID) (*models.FaseIntervencao, error) {
+	fase, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if fase == nil || fase.EntidadeTipo != entidadeTipo || fase.EntidadeID != entidadeID {
+		return nil, ErrFaseNotFound
+	}
+	return fase, nil
+}
+
+// UpdateFase atualiza o tipo, a data de início ou a descrição de uma fase
+func (s *FaseIntervencaoService) UpdateFase(ctx context.Context, entidadeTipo string, entidadeID, id uuid.UUID, req *models.UpdateFaseIntervencaoRequest) (*models.FaseIntervencao, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	fase, err := s.GetFase(ctx, entidadeTipo, entidadeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fase.ApplyUpdates(req)
+	if err := s.validarData(ctx, fase); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, fase); err != nil {
+		return nil, err
+	}
+	return fase, nil
+}
+
+// DeleteFase exclui uma fase; seus dados passam a pertencer à fase anterior
+func (s *FaseIntervencaoService) DeleteFase(ctx context.Context, entidadeTipo string, entidadeID, id uuid.UUID) error {
+	if _, err := s.GetFase(ctx, entidadeTipo, entidadeID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListFases retorna as fases da entidade em ordem cronológica
+func (s *FaseIntervencaoService) ListFases(ctx context.Context, entidadeTipo string, entidadeID uuid.UUID) ([]*models.FaseIntervencao, error) {
+	if err := s.ensureEntidade(ctx, entidadeTipo, entidadeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByEntidade(ctx, entidadeTipo, entidadeID)
+}
+
+// ResumoPrograma resume o percentual de respostas por sessão realizada do programa em cada fase
+func (s *FaseIntervencaoService) ResumoPrograma(ctx context.Context, programaID uuid.UUID, metrica models.MetricaDominio) (*models.ResumoFasesResponse, error) {
+	fases, err := s.ListFases(ctx, models.EntidadeFaseProgramaABA, programaID)
+	if err != nil {
+		return nil, err
+	}
+	etapas, err := s.etapaRepo.ListByPrograma(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	sessoes, err := sessoesRealizadasComColetas(ctx, s.coletaRepo, s.sessaoRepo, etapas)
+	if err != nil {
+		return nil, err
+	}
+
+	datas := make([]time.Time, len(sessoes))
+	valores := make([]float64, len(sessoes))
+	for i, sc := range sessoes {
+		datas[i] = sc.sessao.Data
+		valores[i] = percentualCriterio(sc.coletas, metrica)
+	}
+
+	medida := "% de respostas independentes por sessão"
+	if metrica == models.MetricaDominioAcerto {
+		medida = "% de acertos por sessão"
+	}
+	resumo := resumirPorFase(fases, datas, valores)
+	resumo.EntidadeTipo = models.EntidadeFaseProgramaABA
+	resumo.EntidadeID = programaID
+	resumo.Medida = medida
+	return resumo, nil
+}
+
+// ResumoComportamento resume a medida diária do comportamento em cada fase, considerando apenas
+// os dias com registros
+func (s *FaseIntervencaoService) ResumoComportamento(ctx context.Context, comportamentoID uuid.UUID) (*models.ResumoFasesResponse, error) {
+	fases, err := s.ListFases(ctx, models.EntidadeFaseComportamentoAlvo, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	comportamento, err := s.comportamentoRepo.GetByID(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	agregados, err := s.registroService.AgregarRegistros(ctx, comportamentoID, nil, nil, models.PeriodoAgregacaoDia)
+	if err != nil {
+		return nil, err
+	}
+
+	var datas []time.Time
+	var valores []float64
+	for _, agregado := range agregados {
+		if agregado.Registros == 0 {
+			continue
+		}
+		datas = append(datas, agregado.Inicio)
+		valores = append(valores, agregado.Valor)
+	}
+
+	resumo := resumirPorFase(fases, datas, valores)
+	resumo.EntidadeTipo = models.EntidadeFaseComportamentoAlvo
+	resumo.EntidadeID = comportamentoID
+	resumo.Medida = rotuloMetodoRegistro(comportamento.MetodoRegistro)
+	return resumo, nil
+}
+
+// ensureEntidade verifica se o programa ou comportamento das fases existe
+func (s *FaseIntervencaoService) ensureEntidade(ctx context.Context, entidadeTipo string, entidadeID uuid.UUID) error {
+	switch entidadeTipo {
+	case models.EntidadeFaseProgramaABA:
+		programa, err := s.programaRepo.GetByID(ctx, entidadeID)
+		if err != nil {
+			return err
+		}
+		if programa == nil {
+			return ErrProgramaNotFound
+		}
+	case models.EntidadeFaseComportamentoAlvo:
+		comportamento, err := s.comportamentoRepo.GetByID(ctx, entidadeID)
+		if err != nil {
+			return err
+		}
+		if comportamento == nil {
+			return ErrComportamentoNotFound
+		}
+	default:
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// validarData impede que duas fases da mesma entidade comecem no mesmo instante
+func (s *FaseIntervencaoService) validarData(ctx context.Context, fase *models.FaseIntervencao) error {
+	fases, err := s.repo.ListByEntidade(ctx, fase.EntidadeTipo, fase.EntidadeID)
+	if err != nil {
+		return err
+	}
+	for _, existente := range fases {
+		if existente.ID != fase.ID && existente.DataInicio.Equal(fase.DataInicio) {
+			return ErrFaseDataDuplicada
+		}
+	}
+	return nil
+}
+
+// resumirPorFase distribui os pontos (em ordem cronológica) entre as fases e compara as fases adjacentes
+func resumirPorFase(fases []*models.FaseIntervencao, datas []time.Time, valores []float64) *models.ResumoFasesResponse {
+	porFase := make([][]float64, len(fases))
+	resposta := &models.ResumoFasesResponse{
+		Fases:       make([]models.ResumoFase, 0, len(fases)),
+		Comparacoes: make([]models.ComparacaoFases, 0),
+	}
+	for i, data := range datas {
+		indice := indiceFase(fases, data)
+		if indice < 0 {
+			resposta.PontosSemFase++
+			continue
+		}
+		porFase[indice] = append(porFase[indice], valores[i])
+	}
+
+	for i, fase := range fases {
+		resumo := models.ResumoFase{
+			FaseID:     fase.ID,
+			Tipo:       fase.Tipo,
+			Descricao:  fase.Descricao,
+			DataInicio: fase.DataInicio,
+			Pontos:     len(porFase[i]),
+		}
+		if i+1 < len(fases) {
+			fim := fases[i+1].DataInicio
+			resumo.DataFim = &fim
+		}
+		if pontos := porFase[i]; len(pontos) > 0 {
+			resumo.Media = media(pontos)
+			resumo.Mediana = mediana(pontos)
+			resumo.Inclinacao = inclinacao(pontos)
+			primeiro, ultimo := pontos[0], pontos[len(pontos)-1]
+			resumo.Primeiro, resumo.Ultimo = &primeiro, &ultimo
+		}
+		resposta.Fases = append(resposta.Fases, resumo)
+	}
+
+	for i := 1; i < len(resposta.Fases); i++ {
+		anterior, atual := resposta.Fases[i-1], resposta.Fases[i]
+		if anterior.Pontos == 0 || atual.Pontos == 0 {
+			continue
+		}
+		mudanca := *atual.Primeiro - *anterior.Ultimo
+		resposta.Comparacoes = append(resposta.Comparacoes, models.ComparacaoFases{
+			FaseAnteriorID:      anterior.FaseID,
+			FaseID:              atual.FaseID,
+			DiferencaMedia:      atual.Media - anterior.Media,
+			DiferencaMediana:    atual.Mediana - anterior.Mediana,
+			MudancaNivel:        &mudanca,
+			DiferencaInclinacao: atual.Inclinacao - anterior.Inclinacao,
+		})
+	}
+	return resposta
+}
+
+// indiceFase retorna o índice da fase vigente na data, ou -1 quando a data antecede todas as fases
+func indiceFase(fases []*models.FaseIntervencao, data time.Time) int {
+	indice := -1
+	for i, fase := range fases {
+		if fase.DataInicio.After(data) {
+			break
+		}
+		indice = i
+	}
+	return indice
+}
+
+// rotulosFases retorna os rótulos de condição das fases para os gráficos
+func rotulosFases(fases []*models.FaseIntervencao) []string {
+	rotulos := make([]string, len(fases))
+	for i, fase := range fases {
+		rotulos[i] = fase.Tipo.Rotulo()
+	}
+	return rotulos
+}
+
+// media retorna a média aritmética dos valores
+func media(valores []float64) float64 {
+	var soma float64
+	for _, v := range valores {
+		soma += v
+	}
+	return soma / float64(len(valores))
+}
+
+// mediana retorna a mediana dos valores
+func mediana(valores []float64) float64 {
+	ordenados := append([]float64(nil), valores...)
+	sort.Float64s(ordenados)
+	meio := len(ordenados) / 2
+	if len(ordenados)%2 == 0 {
+		return (ordenados[meio-1] + ordenados[meio]) / 2
+	}
+	return ordenados[meio]
+}
+
+// inclinacao retorna a inclinação da reta de mínimos quadrados dos valores em função da sua posição
+func inclinacao(valores []float64) float64 {
+	n := float64(len(valores))
+	if n < 2 {
+		return 0
+	}
+	mediaX, mediaY := (n-1)/2, media(valores)
+	var cov, varX float64
+	for i, v := range valores {
+		x := float64(i) - mediaX
+		cov += x * (v - mediaY)
+		varX += x * x
+	}
+	return cov / varX
+}
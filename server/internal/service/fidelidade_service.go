@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrChecklistNotFound           = errors.New("lista de verificação de fidelidade não encontrada")
+	ErrChecklistInativo            = errors.New("a lista de verificação de fidelidade está inativa")
+	ErrChecklistForaDoPaciente     = errors.New("a lista de verificação pertence a um programa de outro paciente")
+	ErrAvaliacaoFidelidadeNotFound = errors.New("avaliação de fidelidade não encontrada")
+	ErrRespostasFidelidade         = errors.New("as respostas devem pontuar cada item da lista de verificação exatamente uma vez")
+)
+
+// FidelidadeService encapsula a lógica de negócio das listas de verificação de integridade do tratamento
+// e das avaliações de fidelidade preenchidas pelos supervisores
+type FidelidadeService struct {
+	checklistRepo repository.ChecklistFidelidadeRepository
+	avaliacaoRepo repository.AvaliacaoFidelidadeRepository
+	programaRepo  repository.ProgramaABARepository
+	sessaoRepo    repository.SessaoRepository
+	limiar        float64
+}
+
+// NewFidelidadeService cria uma nova instância de FidelidadeService. As avaliações com percentual abaixo
+// do limiar informado são sinalizadas.
+func NewFidelidadeService(
+	checklistRepo repository.ChecklistFidelidadeRepository,
+	avaliacaoRepo repository.AvaliacaoFidelidadeRepository,
+	programaRepo repository.ProgramaABARepository,
+	sessaoRepo repository.SessaoRepository,
+	limiar float64,
+) *FidelidadeService {
+	if limiar <= 0 || limiar > 100 {
+		limiar = models.LimiarFidelidadePadrao
+	}
+	return &FidelidadeService{
+		checklistRepo: checklistRepo,
+		avaliacaoRepo: avaliacaoRepo,
+		programaRepo:  programaRepo,
+		sessaoRepo:    sessaoRepo,
+		limiar:        limiar,
+	}
+}
+
+// CreateChecklist cria uma lista de verificação para um programa ABA
+func (s *FidelidadeService) CreateChecklist(ctx context.Context, programaID uuid.UUID, req *models.CreateChecklistFidelidadeRequest) (*models.ChecklistFidelidade, error) {
+	if req == nil || len(req.Itens) == 0 {
+		return nil, ErrInvalidInput
+	}
+	if _, err := s.getPrograma(ctx, programaID); err != nil {
+		return nil, err
+	}
+
+	checklist := req.ToChecklistFidelidade(programaID)
+	if err := s.checklistRepo.Create(ctx, checklist); err != nil {
+		return nil, err
+	}
+	return checklist, nil
+}
+
+// GetChecklist busca uma lista de verificação pelo ID, garantindo que pertence ao programa informado
+func (s *FidelidadeService) GetChecklist(ctx context.Context, programaID, id uuid.UUID) (*models.ChecklistFidelidade, error) {
+	checklist, err := s.checklistRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if checklist == nil || checklist.ProgramaID != programaID {
+		return nil, ErrChecklistNotFound
+	}
+	return checklist, nil
+}
+
+// UpdateChecklist atualiza uma lista de verificação, substituindo os itens quando informados
+func (s *FidelidadeService) UpdateChecklist(ctx context.Context, programaID, id uuid.UUID, req *models.UpdateChecklistFidelidadeRequest) (*models.ChecklistFidelidade, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	checklist, err := s.GetChecklist(ctx, programaID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	checklist.ApplyUpdates(req)
+	var itens []models.ItemChecklistFidelidade
+	if req.Itens != nil {
+		itens = models.ItensChecklistFidelidade(req.Itens)
+	}
+	if err := s.checklistRepo.Update(ctx, checklist, itens); err != nil {
+		return nil, err
+	}
+	return checklist, nil
+}
+
+// DeleteChecklist exclui uma lista de verificação. As avaliações já registradas são preservadas.
+func (s *FidelidadeService) DeleteChecklist(ctx context.Context, programaID, id uuid.UUID) error {
+	if _, err := s.GetChecklist(ctx, programaID, id); err != nil {
+		return err
+	}
+	return s.checklistRepo.Delete(ctx, id)
+}
+
+// ListChecklists retorna as listas de verificação de um programa ABA
+func (s *FidelidadeService) ListChecklists(ctx context.Context, programaID uuid.UUID) ([]*models.ChecklistFidelidade, error) {
+	if _, err := s.getPrograma(ctx, programaID); err != nil {
+		return nil, err
+	}
+	return s.checklistRepo.ListByPrograma(ctx, programaID)
+}
+
+// CreateAvaliacao registra uma lista de verificação preenchida pelo supervisor para uma sessão e calcula
+// o percentual de fidelidade do terapeuta
+func (s *FidelidadeService) CreateAvaliacao(ctx context.Context, sessaoID uuid.UUID, req *models.CreateAvaliacaoFidelidadeRequest, supervisorID string) (*models.AvaliacaoFidelidade, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sessao == nil {
+		return nil, ErrSessaoNotFound
+	}
+	if sessao.Status == models.StatusSessaoCancelada {
+		return nil, ErrSessaoCancelada
+	}
+
+	checklist, err := s.checklistRepo.GetByID(ctx, req.ChecklistID)
+	if err != nil {
+		return nil, err
+	}
+	if checklist == nil {
+		return nil, ErrChecklistNotFound
+	}
+	if !checklist.Ativo {
+		return nil, ErrChecklistInativo
+	}
+	programa, err := s.getPrograma(ctx, checklist.ProgramaID)
+	if err != nil {
+		return nil, err
+	}
+	if programa.PacienteID != sessao.PacienteID {
+		return nil, ErrChecklistForaDoPaciente
+	}
+
+	respostas, err := respostasFidelidade(checklist, req.Respostas)
+	if err != nil {
+		return nil, err
+	}
+	avaliacao := &models.AvaliacaoFidelidade{
+		ChecklistID:  checklist.ID,
+		ProgramaID:   checklist.ProgramaID,
+		SessaoID:     sessao.ID,
+		TerapeutaID:  sessao.TerapeutaID,
+		SupervisorID: supervisorID,
+		Data:         sessao.Data,
+		Observacoes:  req.Observacoes,
+		Respostas:    respostas,
+	}
+	avaliacao.Calcular(s.limiar)
+
+	if err := s.avaliacaoRepo.Create(ctx, avaliacao); err != nil {
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+// GetAvaliacao busca uma avaliação pelo ID, garantindo que pertence à sessão informada
+func (s *FidelidadeService) GetAvaliacao(ctx context.Context, sessaoID, id uuid.UUID) (*models.AvaliacaoFidelidade, error) {
+	avaliacao, err := s.avaliacaoRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if avaliacao == nil || avaliacao.SessaoID != sessaoID {
+		return nil, ErrAvaliacaoFidelidadeNotFound
+	}
+	return avaliacao, nil
+}
+
+// UpdateAvaliacao atualiza as respostas ou as observações de uma avaliação e recalcula o percentual
+func (s *FidelidadeService) UpdateAvaliacao(ctx context.Context, sessaoID, id uuid.UUID, req *models.UpdateAvaliacaoFidelidadeRequest) (*models.AvaliacaoFidelidade, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	avaliacao, err := s.GetAvaliacao(ctx, sessaoID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Respostas != nil {
+		checklist, err := s.checklistRepo.GetByID(ctx, avaliacao.ChecklistID)
+		if err != nil {
+			return nil, err
+		}
+		if checklist == nil {
+			return nil, ErrChecklistNotFound
+		}
+		respostas, err := respostasFidelidade(checklist, req.Respostas)
+		if err != nil {
+			return nil, err
+		}
+		avaliacao.Respostas = respostas
+	}
+	if req.Observacoes != nil {
+		avaliacao.Observacoes = *req.Observacoes
+	}
+	avaliacao.Calcular(s.limiar)
+
+	if err := s.avaliacaoRepo.Update(ctx, avaliacao); err != nil {
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+// DeleteAvaliacao exclui uma avaliação de fidelidade pelo ID
+func (s *FidelidadeService) DeleteAvaliacao(ctx context.Context, sessaoID, id uuid.UUID) error {
+	if _, err := s.GetAvaliacao(ctx, sessaoID, id); err != nil {
+		return err
+	}
+	return s.avaliacaoRepo.Delete(ctx, id)
+}
+
+// ListAvaliacoesSessao retorna as avaliações de fidelidade registradas para uma sessão
+func (s *FidelidadeService) ListAvaliacoesSessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.AvaliacaoFidelidade, error) {
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sessao == nil {
+		return nil, ErrSessaoNotFound
+	}
+	return s.avaliacaoRepo.ListBySessao(ctx, sessaoID)
+}
+
+// ResumoTerapeuta retorna a evolução da fidelidade de um terapeuta no intervalo [inicio, fim)
+func (s *FidelidadeService) ResumoTerapeuta(ctx context.Context, terapeutaID uuid.UUID, inicio, fim *time.Time, periodo models.PeriodoAgregacao) (*models.ResumoFidelidade, error) {
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, ErrPeriodoInvalido
+	}
+
+	avaliacoes, err := s.avaliacaoRepo.ListByTerapeuta(ctx, terapeutaID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	resumo := s.resumir(avaliacoes, periodo)
+	resumo.TerapeutaID = &terapeutaID
+	return resumo, nil
+}
+
+// ResumoPrograma retorna a evolução da fidelidade de implementação de um programa no intervalo [inicio, fim)
+func (s *FidelidadeService) ResumoPrograma(ctx context.Context, programaID uuid.UUID, inicio, fim *time.Time, periodo models.PeriodoAgregacao) (*models.ResumoFidelidade, error) {
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, ErrPeriodoInvalido
+	}
+	if _, err := s.getPrograma(ctx, programaID); err != nil {
+		return nil, err
+	}
+
+	avaliacoes, err := s.avaliacaoRepo.ListByPrograma(ctx, programaID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	resumo := s.resumir(avaliacoes, periodo)
+	resumo.ProgramaID = &programaID
+	return resumo, nil
+}
+
+// ListSinalizadas retorna uma lista paginada das avaliações com fidelidade abaixo do limiar da clínica
+func (s *FidelidadeService) ListSinalizadas(ctx context.Context, inicio, fim *time.Time, page, pageSize int) ([]*models.AvaliacaoFidelidade, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, 0, ErrPeriodoInvalido
+	}
+
+	offset := (page - 1) * pageSize
+	avaliacoes, err := s.avaliacaoRepo.ListSinalizadas(ctx, inicio, fim, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.avaliacaoRepo.CountSinalizadas(ctx, inicio, fim)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return avaliacoes, total, nil
+}
+
+// resumir agrupa as avaliações, já em ordem cronológica, por dia ou por semana
+func (s *FidelidadeService) resumir(avaliacoes []*models.AvaliacaoFidelidade, periodo models.PeriodoAgregacao) *models.ResumoFidelidade {
+	resumo := &models.ResumoFidelidade{
+		Limiar:             s.limiar,
+		Avaliacoes:         len(avaliacoes),
+		SessoesSinalizadas: make([]uuid.UUID, 0),
+		Evolucao:           make([]models.PontoFidelidade, 0),
+	}
+
+	percentuais := make([]float64, 0, len(avaliacoes))
+	sinalizadas := make(map[uuid.UUID]bool)
+	var ponto *models.PontoFidelidade
+	var somaPonto float64
+	for _, avaliacao := range avaliacoes {
+		percentuais = append(percentuais, avaliacao.Percentual)
+		if avaliacao.AbaixoDoLimiar && !sinalizadas[avaliacao.SessaoID] {
+			sinalizadas[avaliacao.SessaoID] = true
+			resumo.SessoesSinalizadas = append(resumo.SessoesSinalizadas, avaliacao.SessaoID)
+		}
+
+		if ponto == nil || !avaliacao.Data.Before(ponto.Fim) {
+			if ponto != nil {
+				ponto.Percentual = somaPonto / float64(ponto.Avaliacoes)
+				resumo.Evolucao = append(resumo.Evolucao, *ponto)
+			}
+			inicio := inicioPeriodo(avaliacao.Data, periodo)
+			ponto = &models.PontoFidelidade{Inicio: inicio, Fim: proximoPeriodo(inicio, periodo)}
+			somaPonto = 0
+		}
+		ponto.Avaliacoes++
+		somaPonto += avaliacao.Percentual
+		if avaliacao.AbaixoDoLimiar {
+			ponto.AbaixoDoLimiar++
+		}
+	}
+	if ponto != nil {
+		ponto.Percentual = somaPonto / float64(ponto.Avaliacoes)
+		resumo.Evolucao = append(resumo.Evolucao, *ponto)
+	}
+	if len(percentuais) > 0 {
+		resumo.PercentualMedio = media(percentuais)
+	}
+	return resumo
+}
+
+// getPrograma busca o programa ABA das listas de verificação
+func (s *FidelidadeService) getPrograma(ctx context.Context, programaID uuid.UUID) (*models.ProgramaABA, error) {
+	programa, err := s.programaRepo.GetByID(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	if programa == nil {
+		return nil, ErrProgramaNotFound
+	}
+	return programa, nil
+}
+
+// respostasFidelidade valida que cada item da lista foi pontuado exatamente uma vez e monta as respostas
+// na ordem dos itens
+func respostasFidelidade(checklist *models.ChecklistFidelidade, req []models.RespostaFidelidadeRequest) ([]models.RespostaFidelidade, error) {
+	resultados := make(map[uuid.UUID]models.ResultadoItemFidelidade, len(req))
+	for _, resposta := range req {
+		if _, duplicada := resultados[resposta.ItemID]; duplicada {
+			return nil, ErrRespostasFidelidade
+		}
+		resultados[resposta.ItemID] = resposta.Resultado
+	}
+	if len(resultados) != len(checklist.Itens) {
+		return nil, ErrRespostasFidelidade
+	}
+
+	respostas := make([]models.RespostaFidelidade, 0, len(checklist.Itens))
+	for _, item := range checklist.Itens {
+		resultado, ok := resultados[item.ID]
+		if !ok {
+			return nil, ErrRespostasFidelidade
+		}
+		respostas = append(respostas, models.RespostaFidelidade{
+			ItemID:    item.ID,
+			Ordem:     item.Ordem,
+			Item:      item.Descricao,
+			Resultado: resultado,
+		})
+	}
+	return respostas, nil
+}
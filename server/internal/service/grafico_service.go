@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/grafico"
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// GraficoService monta os gráficos de linha das sessões de programas ABA e dos registros de comportamento
+type GraficoService struct {
+	programaRepo      repository.ProgramaABARepository
+	coletaRepo        repository.ColetaABARepository
+	sessaoRepo        repository.SessaoRepository
+	comportamentoRepo repository.ComportamentoAlvoRepository
+	faseRepo          repository.FaseIntervencaoRepository
+	etapaService      *EtapaProgramaService
+	registroService   *RegistroComportamentoService
+}
+
+// NewGraficoService cria uma nova instância de GraficoService
+func NewGraficoService(
+	programaRepo repository.ProgramaABARepository,
+	coletaRepo repository.ColetaABARepository,
+	sessaoRepo repository.SessaoRepository,
+	comportamentoRepo repository.ComportamentoAlvoRepository,
+	faseRepo repository.FaseIntervencaoRepository,
+	etapaService *EtapaProgramaService,
+	registroService *RegistroComportamentoService,
+) *GraficoService {
+	return &GraficoService{
+		programaRepo:      programaRepo,
+		coletaRepo:        coletaRepo,
+		sessaoRepo:        sessaoRepo,
+		comportamentoRepo: comportamentoRepo,
+		faseRepo:          faseRepo,
+		etapaService:      etapaService,
+		registroService:   registroService,
+	}
+}
+
+// GraficoPrograma monta o gráfico do percentual de respostas por sessão realizada de um programa,
+// dividido pelas fases cadastradas; quando etapaID é informado, apenas as tentativas dessa etapa são consideradas.
+func (s *GraficoService) GraficoPrograma(ctx context.Context, programaID uuid.UUID, etapaID *uuid.UUID, metrica models.MetricaDominio, tendencia bool) (*grafico.Grafico, error) {
+	programa, err := s.programaRepo.GetByID(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	if programa == nil {
+		return nil, ErrProgramaNotFound
+	}
+
+	etapas, err := s.etapaService.ListEtapas(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	if etapaID != nil {
+		etapa, err := s.etapaService.GetEtapa(ctx, programaID, *etapaID)
+		if err != nil {
+			return nil, err
+		}
+		etapas = []*models.EtapaPrograma{etapa}
+	}
+
+	sessoes, err := sessoesRealizadasComColetas(ctx, s.coletaRepo, s.sessaoRepo, etapas)
+	if err != nil {
+		return nil, err
+	}
+	fases, err := s.faseRepo.ListByEntidade(ctx, models.EntidadeFaseProgramaABA, programaID)
+	if err != nil {
+		return nil, err
+	}
+
+	rotuloY := "% de respostas independentes"
+	if metrica == models.MetricaDominioAcerto {
+		rotuloY = "% de acertos"
+	}
+	g := &grafico.Grafico{
+		Titulo:    programa.Nome,
+		RotuloX:   "Sessões",
+		RotuloY:   rotuloY,
+		MinY:      0,
+		MaxY:      100,
+		Tendencia: tendencia,
+	}
+	if len(fases) > 0 {
+		g.Fases = rotulosFases(fases)
+		for _, sc := range sessoes {
+			g.Pontos = append(g.Pontos, grafico.Ponto{
+				Rotulo: sc.sessao.Data.Format("02/01"),
+				Valor:  percentualCriterio(sc.coletas, metrica),
+				Fase:   indiceFase(fases, sc.sessao.Data),
+			})
+		}
+		return g, nil
+	}
+
+	// Sem fases cadastradas, cada fase corresponde à etapa mais trabalhada nas sessões consecutivas
+	ordens := make(map[uuid.UUID]int, len(etapas))
+	for _, etapa := range etapas {
+		ordens[etapa.ID] = etapa.Ordem
+	}
+	etapaFase := uuid.Nil
+	for _, sc := range sessoes {
+		etapa := etapaPredominante(sc.coletas, ordens)
+		if len(g.Fases) == 0 || etapa != etapaFase {
+			etapaFase = etapa
+			g.Fases = append(g.Fases, fmt.Sprintf("Etapa %d", ordens[etapa]))
+		}
+		g.Pontos = append(g.Pontos, grafico.Ponto{
+			Rotulo: sc.sessao.Data.Format("02/01"),
+			Valor:  percentualCriterio(sc.coletas, metrica),
+			Fase:   len(g.Fases) - 1,
+		})
+	}
+	return g, nil
+}
+
+// GraficoComportamento monta o gráfico diário dos registros de um comportamento no intervalo [inicio, fim).
+// Um dia é observado quando tem registros ou uma sessão realizada do paciente: os dias observados sem
+// registros entram na série com zero, e os dias sem observação ficam fora dela, para não puxarem a tendência.
+func (s *GraficoService) GraficoComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, tendencia bool) (*grafico.Grafico, error) {
+	comportamento, err := s.comportamentoRepo.GetByID(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if comportamento == nil {
+		return nil, ErrComportamentoNotFound
+	}
+
+	agregados, err := s.registroService.AgregarRegistros(ctx, comportamentoID, inicio, fim, models.PeriodoAgregacaoDia)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &grafico.Grafico{
+		Titulo:    comportamento.Descricao,
+		RotuloX:   "Dias",
+		RotuloY:   rotuloMetodoRegistro(comportamento.MetodoRegistro),
+		Tendencia: tendencia,
+	}
+	switch comportamento.MetodoRegistro {
+	case models.MetodoRegistroIntensidade:
+		g.MaxY = models.IntensidadeMaxima
+	case models.MetodoRegistroIntervalo:
+		g.MaxY = 100
+	}
+	fases, err := s.faseRepo.ListByEntidade(ctx, models.EntidadeFaseComportamentoAlvo, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	g.Fases = rotulosFases(fases)
+
+	sessoes, err := s.sessaoRepo.ListRealizadasByPaciente(ctx, comportamento.PacienteID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	valores := make(map[time.Time]float64)
+	for _, sessao := range sessoes {
+		valores[inicioPeriodo(sessao.Data, models.PeriodoAgregacaoDia)] = 0
+	}
+	for _, agregado := range agregados {
+		if agregado.Registros > 0 {
+			valores[agregado.Inicio] = agregado.Valor
+		}
+	}
+	dias := make([]time.Time, 0, len(valores))
+	for dia := range valores {
+		dias = append(dias, dia)
+	}
+	sort.Slice(dias, func(i, j int) bool { return dias[i].Before(dias[j]) })
+
+	for _, dia := range dias {
+		g.Pontos = append(g.Pontos, grafico.Ponto{
+			Rotulo: dia.Format("02/01"),
+			Valor:  valores[dia],
+			Fase:   indiceFase(fases, dia),
+		})
+	}
+	return g, nil
+}
+
+// DispersaoComportamento monta a grade do gráfico de dispersão dos registros de um comportamento no intervalo [inicio, fim)
+func (s *GraficoService) DispersaoComportamento(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, opcoes models.OpcoesDispersao) (*models.DispersaoComportamento, error) {
+	return s.registroService.DispersaoRegistros(ctx, comportamentoID, inicio, fim, opcoes)
+}
+
+// MapaCalorDispersao converte a grade do gráfico de dispersão em um mapa de calor. Nos gráficos normalizados,
+// os blocos sem tempo observado ficam sem cor.
+func MapaCalorDispersao(d *models.DispersaoComportamento) *grafico.MapaCalor {
+	m := &grafico.MapaCalor{
+		Titulo:  d.Descricao,
+		RotuloX: "Dias da semana",
+		RotuloY: "Horário",
+		Legenda: "Ocorrências",
+		Colunas: d.Colunas,
+	}
+	if d.Eixo == models.EixoDispersaoData {
+		m.RotuloX = "Dias"
+	}
+	if d.Normalizado {
+		m.Legenda = "Ocorrências por hora observada"
+	}
+	for _, linha := range d.Linhas {
+		m.Linhas = append(m.Linhas, linha.Inicio)
+		valores := make([]*float64, 0, len(linha.Celulas))
+		for _, celula := range linha.Celulas {
+			valores = append(valores, celula.Valor(d.Normalizado))
+		}
+		m.Valores = append(m.Valores, valores)
+	}
+	return m
+}
+
+// sessaoColetas agrupa as tentativas registradas em uma sessão
+type sessaoColetas struct {
+	sessao  *models.Sessao
+	coletas []*models.ColetaABA
+}
+
+// sessoesRealizadasComColetas retorna, em ordem cronológica, as sessões realizadas com tentativas das etapas informadas
+func sessoesRealizadasComColetas(ctx context.Context, coletaRepo repository.ColetaABARepository, sessaoRepo repository.SessaoRepository, etapas []*models.EtapaPrograma) ([]sessaoColetas, error) {
+	porSessao := make(map[uuid.UUID][]*models.ColetaABA)
+	var sessaoIDs []uuid.UUID
+	for _, etapa := range etapas {
+		coletas, err := coletaRepo.ListByEtapa(ctx, etapa.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, coleta := range coletas {
+			if _, ok := porSessao[coleta.SessaoID]; !ok {
+				sessaoIDs = append(sessaoIDs, coleta.SessaoID)
+			}
+			porSessao[coleta.SessaoID] = append(porSessao[coleta.SessaoID], coleta)
+		}
+	}
+
+	sessoes, err := sessaoRepo.ListByIDs(ctx, sessaoIDs)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(sessoes, func(i, j int) bool {
+		return sessoes[i].Data.Before(sessoes[j].Data)
+	})
+
+	resultado := make([]sessaoColetas, 0, len(sessoes))
+	for _, sessao := range sessoes {
+		if sessao.Status == models.StatusSessaoRealizada {
+			resultado = append(resultado, sessaoColetas{sessao: sessao, coletas: porSessao[sessao.ID]})
+		}
+	}
+	return resultado, nil
+}
+
+// etapaPredominante retorna a etapa com mais tentativas na sessão; em caso de empate, a de menor ordem
+func etapaPredominante(coletas []*models.ColetaABA, ordens map[uuid.UUID]int) uuid.UUID {
+	contagem := make(map[uuid.UUID]int)
+	for _, coleta := range coletas {
+		contagem[coleta.EtapaProgramaID]++
+	}
+	predominante, maior := uuid.Nil, 0
+	for etapaID, quantidade := range contagem {
+		if quantidade > maior || (quantidade == maior && ordens[etapaID] < ordens[predominante]) {
+			predominante, maior = etapaID, quantidade
+		}
+	}
+	return predominante
+}
+
+// rotuloMetodoRegistro retorna o rótulo do eixo Y de acordo com o método de registro
+func rotuloMetodoRegistro(metodo models.MetodoRegistro) string {
+	switch metodo {
+	case models.MetodoRegistroDuracao:
+		return "Duração por dia (s)"
+	case models.MetodoRegistroIntensidade:
+		return "Intensidade média"
+	case models.MetodoRegistroIntervalo:
+		return "% de intervalos com ocorrência"
+	default:
+		return "Ocorrências por dia"
+	}
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrHierarquiaPromptNotFound = errors.New("o programa não possui hierarquia de prompts definida")
+	ErrHierarquiaPromptInvalida = errors.New("a hierarquia não pode repetir tipos de prompt")
+)
+
+// HierarquiaPromptService encapsula a hierarquia de prompts dos programas ABA e o motor de
+// esvanecimento, que recomenda o nível de prompt da próxima sessão a partir das coletas recentes
+type HierarquiaPromptService struct {
+	repo         repository.HierarquiaPromptRepository
+	programaRepo repository.ProgramaABARepository
+	etapaRepo    repository.EtapaProgramaRepository
+	coletaRepo   repository.ColetaABARepository
+	sessaoRepo   repository.SessaoRepository
+	promptRepo   repository.TipoPromptRepository
+}
+
+// NewHierarquiaPromptService cria uma nova instância de HierarquiaPromptService
+func NewHierarquiaPromptService(
+	repo repository.HierarquiaPromptRepository,
+	programaRepo repository.ProgramaABARepository,
+	etapaRepo repository.EtapaProgramaRepository,
+	coletaRepo repository.ColetaABARepository,
+	sessaoRepo repository.SessaoRepository,
+	promptRepo repository.TipoPromptRepository,
+) *HierarquiaPromptService {
+	return &HierarquiaPromptService{
+		repo:         repo,
+		programaRepo: programaRepo,
+		etapaRepo:    etapaRepo,
+		coletaRepo:   coletaRepo,
+		sessaoRepo:   sessaoRepo,
+		promptRepo:   promptRepo,
+	}
+}
+
+// DefinirHierarquia cria ou substitui a hierarquia de prompts de um programa
+func (s *HierarquiaPromptService) DefinirHierarquia(ctx context.Context, programaID uuid.UUID, req *models.DefinirHierarquiaPromptRequest) (*models.HierarquiaPrompt, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	programa, err := s.programaRepo.GetByID(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	if programa == nil {
+		return nil, ErrProgramaNotFound
+	}
+
+	vistos := make(map[uuid.UUID]bool, len(req.TipoPromptIDs))
+	for _, id := range req.TipoPromptIDs {
+		if vistos[id] {
+			return nil, ErrHierarquiaPromptInvalida
+		}
+		vistos[id] = true
+	}
+	prompts, err := s.promptRepo.ListByIDs(ctx, req.TipoPromptIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(prompts) != len(req.TipoPromptIDs) {
+		return nil, ErrTipoPromptNotFound
+	}
+
+	hierarquia := req.ToHierarquiaPrompt(programaID)
+	if err := s.repo.Replace(ctx, hierarquia); err != nil {
+		return nil, err
+	}
+	return hierarquia, nil
+}
+
+// GetHierarquia busca a hierarquia de prompts de um programa
+func (s *HierarquiaPromptService) GetHierarquia(ctx context.Context, programaID uuid.UUID) (*models.HierarquiaPrompt, error) {
+	hierarquia, err := s.repo.GetByPrograma(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	if hierarquia == nil {
+		return nil, ErrHierarquiaPromptNotFound
+	}
+	return hierarquia, nil
+}
+
+// RecomendarEtapa recomenda o nível de prompt da próxima sessão para uma etapa do programa
+func (s *HierarquiaPromptService) RecomendarEtapa(ctx context.Context, programaID, etapaID uuid.UUID) (*models.RecomendacaoPromptResponse, error) {
+	hierarquia, err := s.GetHierarquia(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+
+	etapa, err := s.etapaRepo.GetByID(ctx, etapaID)
+	if err != nil {
+		return nil, err
+	}
+	if etapa == nil || etapa.ProgramaID != programaID {
+		return nil, ErrEtapaNotFound
+	}
+
+	nomes, err := s.nomesNiveis(ctx, hierarquia)
+	if err != nil {
+		return nil, err
+	}
+	return s.recomendar(ctx, hierarquia, etapa, nomes)
+}
+
+// ListAlertasPrograma retorna as etapas em treino do programa em que o aprendiz está preso
+// em um nível de prompt ou dependente de prompt
+func (s *HierarquiaPromptService) ListAlertasPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.RecomendacaoPromptResponse, error) {
+	hierarquia, err := s.GetHierarquia(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	return s.alertas(ctx, hierarquia)
+}
+
+// ListAlertasPaciente retorna os alertas de prompt de todos os programas ativos do paciente
+func (s *HierarquiaPromptService) ListAlertasPaciente(ctx context.Context, pacienteID uuid.UUID) ([]*models.RecomendacaoPromptResponse, error) {
+	programas, err := s.programaRepo.ListByPacienteAndStatus(ctx, pacienteID, models.StatusProgramaAtivo)
+	if err != nil {
+		return nil, err
+	}
+
+	alertas := make([]*models.RecomendacaoPromptResponse, 0)
+	for _, programa := range programas {
+		hierarquia, err := s.repo.GetByPrograma(ctx, programa.ID)
+		if err != nil {
+			return nil, err
+		}
+		if hierarquia == nil {
+			continue
+		}
+		doPrograma, err := s.alertas(ctx, hierarquia)
+		if err != nil {
+			return nil, err
+		}
+		alertas = append(alertas, doPrograma...)
+	}
+	return alertas, nil
+}
+
+// alertas avalia as etapas em treino de um programa e retorna apenas as que exigem atenção
+func (s *HierarquiaPromptService) alertas(ctx context.Context, hierarquia *models.HierarquiaPrompt) ([]*models.RecomendacaoPromptResponse, error) {
+	etapas, err := s.etapaRepo.ListByPrograma(ctx, hierarquia.ProgramaID)
+	if err != nil {
+		return nil, err
+	}
+	nomes, err := s.nomesNiveis(ctx, hierarquia)
+	if err != nil {
+		return nil, err
+	}
+
+	alertas := make([]*models.RecomendacaoPromptResponse, 0)
+	for _, etapa := range etapas {
+		if etapa.Status != models.StatusEtapaEmTreino {
+			continue
+		}
+		recomendacao, err := s.recomendar(ctx, hierarquia, etapa, nomes)
+		if err != nil {
+			return nil, err
+		}
+		if recomendacao.PresoNoNivel || recomendacao.DependentePrompt {
+			alertas = append(alertas, recomendacao)
+		}
+	}
+	return alertas, nil
+}
+
+// sessaoPrompt resume as tentativas de uma etapa em uma sessão por nível da hierarquia
+type sessaoPrompt struct {
+	tentativas     map[int]int
+	sucessos       map[int]int
+	total          int
+	independentes  int
+	comPrompt      int
+	sucessosPrompt int
+}
+
+// nivelModal retorna o nível mais utilizado na sessão; em caso de empate, o mais intrusivo
+func (sp *sessaoPrompt) nivelModal() int {
+	modal, maior := -1, 0
+	for nivel, quantidade := range sp.tentativas {
+		if quantidade > maior || (quantidade == maior && nivel < modal) {
+			modal, maior = nivel, quantidade
+		}
+	}
+	return modal
+}
+
+// percentualSucesso retorna o percentual de tentativas bem-sucedidas em um nível
+func (sp *sessaoPrompt) percentualSucesso(nivel int) float64 {
+	return percentual(sp.sucessos[nivel], sp.tentativas[nivel])
+}
+
+// recomendar aplica as regras de esvanecimento da hierarquia às sessões mais recentes da etapa
+func (s *HierarquiaPromptService) recomendar(ctx context.Context, hierarquia *models.HierarquiaPrompt, etapa *models.EtapaPrograma, nomes map[uuid.UUID]string) (*models.RecomendacaoPromptResponse, error) {
+	sessoes, err := s.sessoesRecentes(ctx, hierarquia, etapa.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	independente := len(hierarquia.Niveis)
+	resposta := &models.RecomendacaoPromptResponse{
+		ProgramaID:        hierarquia.ProgramaID,
+		EtapaProgramaID:   etapa.ID,
+		Direcao:           hierarquia.Direcao,
+		SessoesAnalisadas: len(sessoes),
+	}
+
+	if len(sessoes) == 0 {
+		inicial := 0
+		if hierarquia.Direcao == models.DirecaoPromptMenosParaMais {
+			inicial = independente
+		}
+		resposta.NivelRecomendado = resumoNivel(hierarquia, inicial, nomes)
+		resposta.Acao = models.AcaoPromptIniciar
+		resposta.Justificativa = "não há sessões realizadas com dados para esta etapa"
+		return resposta, nil
+	}
+
+	ultima := sessoes[len(sessoes)-1]
+	atual := ultima.nivelModal()
+	nivelAtual := resumoNivel(hierarquia, atual, nomes)
+	resposta.NivelAtual = &nivelAtual
+
+	recomendado := atual
+	switch hierarquia.Direcao {
+	case models.DirecaoPromptMenosParaMais:
+		// O nível recomendado é o menos intrusivo que controlou a resposta na última sessão
+		recomendado = -1
+		for nivel := independente; nivel >= 0; nivel-- {
+			if ultima.tentativas[nivel] > 0 && ultima.percentualSucesso(nivel) >= hierarquia.PercentualAvanco {
+				recomendado = nivel
+				break
+			}
+		}
+		if recomendado < 0 {
+			recomendado = atual
+		}
+		resposta.Justificativa = fmt.Sprintf("nível menos intrusivo com ao menos %.0f%% de sucesso na última sessão", hierarquia.PercentualAvanco)
+	default:
+		janela := ultimas(sessoes, hierarquia.SessoesAvanco)
+		avanca := len(janela) == hierarquia.SessoesAvanco
+		for _, sessao := range janela {
+			if sessao.nivelModal() != atual || sessao.percentualSucesso(atual) < hierarquia.PercentualAvanco {
+				avanca = false
+			}
+		}
+		switch {
+		case avanca && atual < independente:
+			recomendado = atual + 1
+			resposta.Justificativa = fmt.Sprintf("%d sessões com ao menos %.0f%% de sucesso no nível %s",
+				hierarquia.SessoesAvanco, hierarquia.PercentualAvanco, nivelAtual.Tipo)
+		case ultima.percentualSucesso(atual) < hierarquia.PercentualRetorno && atual > 0:
+			recomendado = atual - 1
+			resposta.Justificativa = fmt.Sprintf("sucesso abaixo de %.0f%% no nível %s na última sessão",
+				hierarquia.PercentualRetorno, nivelAtual.Tipo)
+		default:
+			resposta.Justificativa = fmt.Sprintf("desempenho no nível %s ainda não atende às regras de esvanecimento", nivelAtual.Tipo)
+		}
+	}
+
+	resposta.NivelRecomendado = resumoNivel(hierarquia, recomendado, nomes)
+	switch {
+	case recomendado > atual:
+		resposta.Acao = models.AcaoPromptEsvanecer
+	case recomendado < atual:
+		resposta.Acao = models.AcaoPromptAumentar
+	default:
+		resposta.Acao = models.AcaoPromptManter
+	}
+
+	// Estagnação e dependência de prompt são avaliadas na janela de estagnação
+	janela := ultimas(sessoes, hierarquia.SessoesEstagnacao)
+	var total, independentes, comPrompt, sucessosPrompt int
+	preso := len(janela) == hierarquia.SessoesEstagnacao
+	for _, sessao := range janela {
+		total += sessao.total
+		independentes += sessao.independentes
+		comPrompt += sessao.comPrompt
+		sucessosPrompt += sessao.sucessosPrompt
+		if sessao.nivelModal() != atual || atual == independente {
+			preso = false
+		}
+	}
+	resposta.PercentualIndependente = percentual(independentes, total)
+	resposta.PresoNoNivel = preso
+	resposta.DependentePrompt = len(janela) == hierarquia.SessoesEstagnacao &&
+		comPrompt > 0 &&
+		percentual(sucessosPrompt, comPrompt) >= hierarquia.PercentualAvanco &&
+		resposta.PercentualIndependente < hierarquia.LimiteDependencia
+
+	return resposta, nil
+}
+
+// sessoesRecentes agrupa as coletas da etapa por sessão realizada, em ordem cronológica,
+// limitadas à maior janela usada pelas regras da hierarquia
+func (s *HierarquiaPromptService) sessoesRecentes(ctx context.Context, hierarquia *models.HierarquiaPrompt, etapaID uuid.UUID) ([]*sessaoPrompt, error) {
+	coletas, err := s.coletaRepo.ListByEtapa(ctx, etapaID)
+	if err != nil {
+		return nil, err
+	}
+
+	posicoes := make(map[uuid.UUID]int, len(hierarquia.Niveis))
+	for i, nivel := range hierarquia.Niveis {
+		posicoes[nivel.TipoPromptID] = i
+	}
+	independente := len(hierarquia.Niveis)
+
+	porSessao := make(map[uuid.UUID]*sessaoPrompt)
+	var sessaoIDs []uuid.UUID
+	for _, coleta := range coletas {
+		nivel := independente
+		if coleta.PromptUtilizadoID != uuid.Nil {
+			posicao, ok := posicoes[coleta.PromptUtilizadoID]
+			if !ok {
+				continue
+			}
+			nivel = posicao
+		}
+
+		sessao, ok := porSessao[coleta.SessaoID]
+		if !ok {
+			sessao = &sessaoPrompt{tentativas: make(map[int]int), sucessos: make(map[int]int)}
+			porSessao[coleta.SessaoID] = sessao
+			sessaoIDs = append(sessaoIDs, coleta.SessaoID)
+		}
+		sessao.total++
+		sessao.tentativas[nivel]++
+		if nivel == independente {
+			if coleta.Resultado == models.ResultadoColetaAcerto {
+				sessao.sucessos[nivel]++
+				sessao.independentes++
+			}
+			continue
+		}
+		sessao.comPrompt++
+		if coleta.Resultado != models.ResultadoColetaErro {
+			sessao.sucessos[nivel]++
+			sessao.sucessosPrompt++
+		}
+	}
+
+	sessoes, err := s.sessaoRepo.ListByIDs(ctx, sessaoIDs)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(sessoes, func(i, j int) bool {
+		return sessoes[i].Data.Before(sessoes[j].Data)
+	})
+
+	resultado := make([]*sessaoPrompt, 0, len(sessoes))
+	for _, sessao := range sessoes {
+		if sessao.Status == models.StatusSessaoRealizada {
+			resultado = append(resultado, porSessao[sessao.ID])
+		}
+	}
+
+	janela := hierarquia.SessoesAvanco
+	if hierarquia.SessoesEstagnacao > janela {
+		janela = hierarquia.SessoesEstagnacao
+	}
+	return ultimas(resultado, janela), nil
+}
+
+// nomesNiveis busca os nomes dos tipos de prompt usados na hierarquia
+func (s *HierarquiaPromptService) nomesNiveis(ctx context.Context, hierarquia *models.HierarquiaPrompt) (map[uuid.UUID]string, error) {
+	ids := make([]uuid.UUID, 0, len(hierarquia.Niveis))
+	for _, nivel := range hierarquia.Niveis {
+		ids = append(ids, nivel.TipoPromptID)
+	}
+	prompts, err := s.promptRepo.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	nomes := make(map[uuid.UUID]string, len(prompts))
+	for _, prompt := range prompts {
+		nomes[prompt.ID] = prompt.Tipo
+	}
+	return nomes, nil
+}
+
+// resumoNivel descreve um nível da hierarquia pelo seu índice; o índice após o último nível
+// representa a resposta independente
+func resumoNivel(hierarquia *models.HierarquiaPrompt, indice int, nomes map[uuid.UUID]string) models.NivelPromptResumo {
+	if indice >= len(hierarquia.Niveis) {
+		return models.NivelPromptResumo{Posicao: len(hierarquia.Niveis) + 1, Tipo: "Independente"}
+	}
+	nivel := hierarquia.Niveis[indice]
+	tipoPromptID := nivel.TipoPromptID
+	return models.NivelPromptResumo{
+		Posicao:      nivel.Posicao,
+		TipoPromptID: &tipoPromptID,
+		Tipo:         nomes[nivel.TipoPromptID],
+	}
+}
+
+// ultimas retorna as n sessões mais recentes da lista
+func ultimas(lista []*sessaoPrompt, n int) []*sessaoPrompt {
+	if n <= 0 {
+		return lista[:0]
+	}
+	if len(lista) <= n {
+		return lista
+	}
+	return lista[len(lista)-n:]
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// coletaRepoFake devolve as mesmas coletas para qualquer etapa
+type coletaRepoFake struct {
+	repository.ColetaABARepository
+	coletas []*models.ColetaABA
+}
+
+func (r *coletaRepoFake) ListByEtapa(ctx context.Context, etapaID uuid.UUID) ([]*models.ColetaABA, error) {
+	return r.coletas, nil
+}
+
+// sessaoRepoFake devolve as sessões cadastradas com os IDs pedidos
+type sessaoRepoFake struct {
+	repository.SessaoRepository
+	sessoes map[uuid.UUID]*models.Sessao
+}
+
+func (r *sessaoRepoFake) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Sessao, error) {
+	sessoes := make([]*models.Sessao, 0, len(ids))
+	for _, id := range ids {
+		if sessao, ok := r.sessoes[id]; ok {
+			sessoes = append(sessoes, sessao)
+		}
+	}
+	return sessoes, nil
+}
+
+// tentativaPrompt descreve uma tentativa pelo índice do nível de prompt, ou -1 para a resposta independente
+type tentativaPrompt struct {
+	nivel     int
+	resultado models.ResultadoColeta
+}
+
+// repetir cria n tentativas iguais
+func repetir(n, nivel int, resultado models.ResultadoColeta) []tentativaPrompt {
+	tentativas := make([]tentativaPrompt, n)
+	for i := range tentativas {
+		tentativas[i] = tentativaPrompt{nivel: nivel, resultado: resultado}
+	}
+	return tentativas
+}
+
+func TestRecomendar(t *testing.T) {
+	fisica, gestual := uuid.New(), uuid.New()
+	nomes := map[uuid.UUID]string{fisica: "Física total", gestual: "Gestual"}
+	hierarquia := func(direcao models.DirecaoPrompt) *models.HierarquiaPrompt {
+		return &models.HierarquiaPrompt{
+			Direcao:           direcao,
+			PercentualAvanco:  80,
+			SessoesAvanco:     2,
+			PercentualRetorno: 50,
+			SessoesEstagnacao: 3,
+			LimiteDependencia: 20,
+			Niveis: []models.NivelHierarquiaPrompt{
+				{TipoPromptID: fisica, Posicao: 1},
+				{TipoPromptID: gestual, Posicao: 2},
+			},
+		}
+	}
+	acerto, ajuda, erro := models.ResultadoColetaAcerto, models.ResultadoColetaAjuda, models.ResultadoColetaErro
+
+	tests := []struct {
+		nome         string
+		direcao      models.DirecaoPrompt
+		sessoes      [][]tentativaPrompt
+		acao         models.AcaoPrompt
+		posicao      int
+		preso        bool
+		dependente   bool
+		independente float64
+	}{
+		{"sem sessões começa pelo nível mais intrusivo", models.DirecaoPromptMaisParaMenos, nil, models.AcaoPromptIniciar, 1, false, false, 0},
+		{"sem sessões começa pela resposta independente", models.DirecaoPromptMenosParaMais, nil, models.AcaoPromptIniciar, 3, false, false, 0},
+		{
+			"uma sessão com sucesso ainda não basta para esvanecer",
+			models.DirecaoPromptMaisParaMenos,
+			[][]tentativaPrompt{repetir(5, 0, ajuda)},
+			models.AcaoPromptManter, 1, false, false, 0,
+		},
+		{
+			"duas sessões com sucesso esvanecem para o nível seguinte",
+			models.DirecaoPromptMaisParaMenos,
+			[][]tentativaPrompt{repetir(5, 0, ajuda), repetir(5, 0, ajuda)},
+			models.AcaoPromptEsvanecer, 2, false, false, 0,
+		},
+		{
+			"sucesso abaixo do retorno volta ao nível anterior",
+			models.DirecaoPromptMaisParaMenos,
+			[][]tentativaPrompt{append(repetir(2, 1, ajuda), repetir(3, 1, erro)...)},
+			models.AcaoPromptAumentar, 1, false, false, 0,
+		},
+		{
+			"sucesso entre o retorno e o avanço por toda a janela fica preso no nível",
+			models.DirecaoPromptMaisParaMenos,
+			[][]tentativaPrompt{
+				append(repetir(3, 0, ajuda), repetir(2, 0, erro)...),
+				append(repetir(3, 0, ajuda), repetir(2, 0, erro)...),
+				append(repetir(3, 0, ajuda), repetir(2, 0, erro)...),
+			},
+			models.AcaoPromptManter, 1, true, false, 0,
+		},
+		{
+			"sucesso só com prompt por toda a janela indica dependência",
+			models.DirecaoPromptMaisParaMenos,
+			[][]tentativaPrompt{repetir(5, 0, ajuda), repetir(5, 0, ajuda), repetir(5, 0, ajuda)},
+			models.AcaoPromptEsvanecer, 2, true, true, 0,
+		},
+		{
+			"respostas independentes não ficam presas",
+			models.DirecaoPromptMaisParaMenos,
+			[][]tentativaPrompt{repetir(4, -1, acerto), repetir(4, -1, acerto), append(repetir(3, -1, acerto), repetir(1, 1, ajuda)...)},
+			models.AcaoPromptManter, 3, false, false, 100 * 11 / 12.0,
+		},
+		{
+			"menos para mais recomenda o nível menos intrusivo que controlou a resposta",
+			models.DirecaoPromptMenosParaMais,
+			[][]tentativaPrompt{append(append(repetir(2, -1, erro), repetir(4, 1, ajuda)...), repetir(4, 0, ajuda)...)},
+			models.AcaoPromptEsvanecer, 2, false, false, 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			h := hierarquia(tt.direcao)
+			coletas := &coletaRepoFake{}
+			sessoes := &sessaoRepoFake{sessoes: make(map[uuid.UUID]*models.Sessao)}
+			inicio := time.Date(2024, 3, 4, 14, 0, 0, 0, time.UTC)
+			for i, tentativas := range tt.sessoes {
+				sessao := &models.Sessao{ID: uuid.New(), Data: inicio.AddDate(0, 0, i), Status: models.StatusSessaoRealizada}
+				sessoes.sessoes[sessao.ID] = sessao
+				for _, tentativa := range tentativas {
+					coleta := &models.ColetaABA{SessaoID: sessao.ID, Resultado: tentativa.resultado}
+					if tentativa.nivel >= 0 {
+						coleta.PromptUtilizadoID = h.Niveis[tentativa.nivel].TipoPromptID
+					}
+					coletas.coletas = append(coletas.coletas, coleta)
+				}
+			}
+			s := &HierarquiaPromptService{coletaRepo: coletas, sessaoRepo: sessoes}
+
+			got, err := s.recomendar(context.Background(), h, &models.EtapaPrograma{ID: uuid.New()}, nomes)
+			if err != nil {
+				t.Fatalf("recomendar() error = %v", err)
+			}
+			if got.Acao != tt.acao {
+				t.Errorf("Acao = %v, want %v", got.Acao, tt.acao)
+			}
+			if got.NivelRecomendado.Posicao != tt.posicao {
+				t.Errorf("NivelRecomendado.Posicao = %v, want %v", got.NivelRecomendado.Posicao, tt.posicao)
+			}
+			if got.PresoNoNivel != tt.preso {
+				t.Errorf("PresoNoNivel = %v, want %v", got.PresoNoNivel, tt.preso)
+			}
+			if got.DependentePrompt != tt.dependente {
+				t.Errorf("DependentePrompt = %v, want %v", got.DependentePrompt, tt.dependente)
+			}
+			if got.PercentualIndependente != tt.independente {
+				t.Errorf("PercentualIndependente = %v, want %v", got.PercentualIndependente, tt.independente)
+			}
+		})
+	}
+}
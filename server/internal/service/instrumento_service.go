@@ -0,0 +1,560 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrInstrumentoNotFound           = errors.New("instrumento não encontrado")
+	ErrInstrumentoPadraoNotFound     = errors.New("instrumento padrão não encontrado")
+	ErrDefinicaoInstrumentoInvalida  = errors.New("a definição do instrumento é inválida: escalas, subescalas e itens devem ter códigos e números distintos, as opções de cada escala devem ter valores distintos, os itens e as faixas devem referir escalas e subescalas existentes e uma faixa que exige seguimento requer faixas da etapa de seguimento")
+	ErrInstrumentoEmUso              = errors.New("o instrumento já foi aplicado e não pode ser excluído")
+	ErrInstrumentoInativo            = errors.New("o instrumento está inativo e não pode ser aplicado")
+	ErrAplicacaoInstrumentoNotFound  = errors.New("aplicação de instrumento não encontrada")
+	ErrRespostasInstrumentoInvalidas = errors.New("as respostas devem cobrir cada item do instrumento uma única vez, com o valor de uma das opções da escala do item")
+	ErrSeguimentoNaoRequerido        = errors.New("a aplicação não está aguardando a entrevista de seguimento")
+	ErrItensSeguimentoInvalidos      = errors.New("a entrevista de seguimento deve informar cada item que pontuou na etapa inicial uma única vez, e apenas esses itens")
+)
+
+// InstrumentoService encapsula os questionários padronizados (M-CHAT-R/F, ATEC e semelhantes): as definições
+// versionadas, a pontuação automática das aplicações, a classificação de risco com entrevista de seguimento
+// e a evolução das pontuações de cada paciente
+type InstrumentoService struct {
+	repo          repository.InstrumentoRepository
+	aplicacaoRepo repository.AplicacaoInstrumentoRepository
+}
+
+// NewInstrumentoService cria uma nova instância de InstrumentoService
+func NewInstrumentoService(repo repository.InstrumentoRepository, aplicacaoRepo repository.AplicacaoInstrumentoRepository) *InstrumentoService {
+	return &InstrumentoService{repo: repo, aplicacaoRepo: aplicacaoRepo}
+}
+
+// CreateInstrumento cria uma nova versão de instrumento. A versão é a seguinte à última já usada pelo código.
+func (s *InstrumentoService) CreateInstrumento(ctx context.Context, req *models.CreateInstrumentoRequest) (*models.Instrumento, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if !definicaoInstrumentoValida(req) {
+		return nil, ErrDefinicaoInstrumentoInvalida
+	}
+
+	versao, err := s.repo.UltimaVersao(ctx, req.Codigo)
+	if err != nil {
+		return nil, err
+	}
+
+	instrumento := req.ToInstrumento()
+	instrumento.Versao = versao + 1
+	if err := s.repo.Create(ctx, instrumento); err != nil {
+		return nil, err
+	}
+	return instrumento, nil
+}
+
+// InstalarInstrumentoPadrao cria uma nova versão a partir de uma das definições distribuídas com o serviço
+func (s *InstrumentoService) InstalarInstrumentoPadrao(ctx context.Context, codigo string) (*models.Instrumento, error) {
+	definicao, ok := definicoesPadrao[codigo]
+	if !ok {
+		return nil, ErrInstrumentoPadraoNotFound
+	}
+	return s.CreateInstrumento(ctx, definicao())
+}
+
+// ListInstrumentosPadrao retorna as definições de instrumentos distribuídas com o serviço
+func (s *InstrumentoService) ListInstrumentosPadrao() []models.InstrumentoPadrao {
+	padroes := make([]models.InstrumentoPadrao, 0, len(codigosPadrao))
+	for _, codigo := range codigosPadrao {
+		definicao := definicoesPadrao[codigo]()
+		padroes = append(padroes, models.InstrumentoPadrao{
+			Codigo:    definicao.Codigo,
+			Nome:      definicao.Nome,
+			Descricao: definicao.Descricao,
+			Itens:     len(definicao.Itens),
+		})
+	}
+	return padroes
+}
+
+// GetInstrumento busca uma versão de instrumento pelo ID, com a definição completa
+func (s *InstrumentoService) GetInstrumento(ctx context.Context, id uuid.UUID) (*models.Instrumento, error) {
+	instrumento, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if instrumento == nil || instrumento.DeletedAt.Valid {
+		return nil, ErrInstrumentoNotFound
+	}
+	return instrumento, nil
+}
+
+// UpdateInstrumento atualiza o nome, a descrição ou a situação de uma versão de instrumento
+func (s *InstrumentoService) UpdateInstrumento(ctx context.Context, id uuid.UUID, req *models.UpdateInstrumentoRequest) (*models.Instrumento, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	instrumento, err := s.GetInstrumento(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	instrumento.ApplyUpdates(req)
+	if err := s.repo.Update(ctx, instrumento); err != nil {
+		return nil, err
+	}
+	return instrumento, nil
+}
+
+// DeleteInstrumento exclui uma versão de instrumento que ainda não foi aplicada. Versões aplicadas podem ser inativadas.
+func (s *InstrumentoService) DeleteInstrumento(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetInstrumento(ctx, id); err != nil {
+		return err
+	}
+
+	aplicacoes, err := s.aplicacaoRepo.CountByInstrumento(ctx, id)
+	if err != nil {
+		return err
+	}
+	if aplicacoes > 0 {
+		return ErrInstrumentoEmUso
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListInstrumentos retorna as versões de instrumentos, opcionalmente de um código e apenas as ativas
+func (s *InstrumentoService) ListInstrumentos(ctx context.Context, codigo string, apenasAtivos bool) ([]*models.Instrumento, error) {
+	return s.repo.List(ctx, codigo, apenasAtivos)
+}
+
+// CreateAplicacao registra a aplicação de um questionário ao paciente e calcula a pontuação e a classificação.
+// Quando a faixa da pontuação total exige seguimento, a aplicação fica aguardando a entrevista.
+func (s *InstrumentoService) CreateAplicacao(ctx context.Context, pacienteID uuid.UUID, req *models.CreateAplicacaoInstrumentoRequest, usuarioID string) (*models.AplicacaoInstrumento, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	instrumento, err := s.instrumentoAplicado(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	respostas, ok := pontuarRespostas(instrumento, req.Respostas)
+	if !ok {
+		return nil, ErrRespostasInstrumentoInvalidas
+	}
+
+	aplicacao := &models.AplicacaoInstrumento{
+		PacienteID:    pacienteID,
+		InstrumentoID: instrumento.ID,
+		Codigo:        instrumento.Codigo,
+		Versao:        instrumento.Versao,
+		Data:          req.Data,
+		Informante:    req.Informante,
+		Observacoes:   req.Observacoes,
+		UsuarioID:     usuarioID,
+		Status:        models.StatusAplicacaoInstrumentoConcluida,
+		Respostas:     respostas,
+	}
+
+	pontos := make([]float64, 0, len(respostas))
+	for _, resposta := range respostas {
+		pontos = append(pontos, resposta.Pontos)
+	}
+	aplicacao.Pontuacao = combinarPontos(instrumento.Regra, pontos)
+	if faixa := classificar(instrumento.Faixas, models.EtapaFaixaInicial, "", aplicacao.Pontuacao); faixa != nil {
+		aplicacao.Classificacao = faixa.Classificacao
+		aplicacao.RequerSeguimento = faixa.RequerSeguimento
+	}
+	if aplicacao.RequerSeguimento {
+		aplicacao.Status = models.StatusAplicacaoInstrumentoAguardandoSeguimento
+	}
+	aplicacao.Subescalas = pontuarSubescalas(instrumento, respostas)
+
+	if err := s.aplicacaoRepo.Create(ctx, aplicacao); err != nil {
+		return nil, err
+	}
+	return aplicacao, nil
+}
+
+// GetAplicacao busca uma aplicação pelo ID, garantindo que pertence ao paciente informado
+func (s *InstrumentoService) GetAplicacao(ctx context.Context, pacienteID, id uuid.UUID) (*models.AplicacaoInstrumento, error) {
+	aplicacao, err := s.aplicacaoRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if aplicacao == nil || aplicacao.PacienteID != pacienteID {
+		return nil, ErrAplicacaoInstrumentoNotFound
+	}
+	return aplicacao, nil
+}
+
+// DeleteAplicacao exclui uma aplicação do paciente
+func (s *InstrumentoService) DeleteAplicacao(ctx context.Context, pacienteID, id uuid.UUID) error {
+	if _, err := s.GetAplicacao(ctx, pacienteID, id); err != nil {
+		return err
+	}
+	return s.aplicacaoRepo.Delete(ctx, id)
+}
+
+// ListAplicacoes retorna uma lista paginada das aplicações do paciente, opcionalmente de um instrumento
+func (s *InstrumentoService) ListAplicacoes(ctx context.Context, pacienteID uuid.UUID, codigo string, page, pageSize int) ([]*models.AplicacaoInstrumento, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	aplicacoes, err := s.aplicacaoRepo.ListByPaciente(ctx, pacienteID, codigo, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.aplicacaoRepo.CountByPaciente(ctx, pacienteID, codigo)
+	if err != nil {
+		return nil, 0, err
+	}
+	return aplicacoes, total, nil
+}
+
+// RegistrarSeguimento registra a entrevista de seguimento de uma aplicação. Cada item que pontuou na etapa
+// inicial é aprovado ou reprovado; a pontuação do seguimento é o número de itens reprovados, classificada
+// pelas faixas da etapa de seguimento, e a aplicação é concluída.
+func (s *InstrumentoService) RegistrarSeguimento(ctx context.Context, pacienteID, id uuid.UUID, req *models.SeguimentoAplicacaoRequest) (*models.AplicacaoInstrumento, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	aplicacao, err := s.GetAplicacao(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+	if aplicacao.Status != models.StatusAplicacaoInstrumentoAguardandoSeguimento {
+		return nil, ErrSeguimentoNaoRequerido
+	}
+
+	instrumento, err := s.repo.GetByID(ctx, aplicacao.InstrumentoID)
+	if err != nil {
+		return nil, err
+	}
+	if instrumento == nil {
+		return nil, ErrInstrumentoNotFound
+	}
+
+	emRisco := itensEmRisco(instrumento, aplicacao.Respostas)
+	resultados := make(map[int]bool, len(req.Itens))
+	for _, item := range req.Itens {
+		if _, ok := emRisco[item.Numero]; !ok {
+			return nil, ErrItensSeguimentoInvalidos
+		}
+		if _, repetido := resultados[item.Numero]; repetido {
+			return nil, ErrItensSeguimentoInvalidos
+		}
+		resultados[item.Numero] = *item.Aprovado
+	}
+	if len(resultados) != len(emRisco) {
+		return nil, ErrItensSeguimentoInvalidos
+	}
+
+	reprovados := 0.0
+	for i := range aplicacao.Respostas {
+		aprovado, ok := resultados[aplicacao.Respostas[i].Numero]
+		if !ok {
+			continue
+		}
+		aplicacao.Respostas[i].SeguimentoAprovado = &aprovado
+		if !aprovado {
+			reprovados++
+		}
+	}
+
+	agora := time.Now()
+	aplicacao.PontuacaoSeguimento = &reprovados
+	aplicacao.ClassificacaoSeguimento = ""
+	if faixa := classificar(instrumento.Faixas, models.EtapaFaixaSeguimento, "", reprovados); faixa != nil {
+		aplicacao.ClassificacaoSeguimento = faixa.Classificacao
+	}
+	aplicacao.SeguimentoEm = &agora
+	aplicacao.Status = models.StatusAplicacaoInstrumentoConcluida
+	if req.Observacoes != nil {
+		aplicacao.Observacoes = *req.Observacoes
+	}
+
+	if err := s.aplicacaoRepo.RegistrarSeguimento(ctx, aplicacao); err != nil {
+		return nil, err
+	}
+	return aplicacao, nil
+}
+
+// EvolucaoInstrumento retorna as pontuações do paciente em um instrumento ao longo do tempo, considerando
+// todas as versões do instrumento
+func (s *InstrumentoService) EvolucaoInstrumento(ctx context.Context, pacienteID uuid.UUID, codigo string) (*models.EvolucaoInstrumento, error) {
+	aplicacoes, err := s.aplicacaoRepo.ListEvolucao(ctx, pacienteID, codigo)
+	if err != nil {
+		return nil, err
+	}
+
+	evolucao := &models.EvolucaoInstrumento{
+		PacienteID: pacienteID,
+		Codigo:     codigo,
+		Pontos:     make([]models.PontoEvolucaoInstrumento, 0, len(aplicacoes)),
+	}
+	if len(aplicacoes) > 0 {
+		instrumento, err := s.repo.GetByID(ctx, aplicacoes[len(aplicacoes)-1].InstrumentoID)
+		if err != nil {
+			return nil, err
+		}
+		if instrumento != nil {
+			evolucao.Nome = instrumento.Nome
+		}
+	}
+
+	for i, aplicacao := range aplicacoes {
+		ponto := models.PontoEvolucaoInstrumento{
+			AplicacaoID:   aplicacao.ID,
+			Data:          aplicacao.Data,
+			Versao:        aplicacao.Versao,
+			Status:        aplicacao.Status,
+			Pontuacao:     aplicacao.Pontuacao,
+			Classificacao: aplicacao.ClassificacaoFinal(),
+			Subescalas:    make([]models.PontuacaoSubescalaEvolucao, 0, len(aplicacao.Subescalas)),
+		}
+		if i > 0 {
+			variacao := aplicacao.Pontuacao - aplicacoes[i-1].Pontuacao
+			ponto.Variacao = &variacao
+		}
+		for _, subescala := range aplicacao.Subescalas {
+			ponto.Subescalas = append(ponto.Subescalas, models.PontuacaoSubescalaEvolucao{
+				Codigo:    subescala.Codigo,
+				Nome:      subescala.Nome,
+				Pontuacao: subescala.Pontuacao,
+			})
+		}
+		evolucao.Pontos = append(evolucao.Pontos, ponto)
+	}
+	return evolucao, nil
+}
+
+// instrumentoAplicado resolve a versão de instrumento de uma nova aplicação: a versão informada ou a
+// versão ativa mais recente do código
+func (s *InstrumentoService) instrumentoAplicado(ctx context.Context, req *models.CreateAplicacaoInstrumentoRequest) (*models.Instrumento, error) {
+	if req.InstrumentoID != nil {
+		instrumento, err := s.GetInstrumento(ctx, *req.InstrumentoID)
+		if err != nil {
+			return nil, err
+		}
+		if !instrumento.Ativo {
+			return nil, ErrInstrumentoInativo
+		}
+		return instrumento, nil
+	}
+	if req.Codigo == "" {
+		return nil, ErrInvalidInput
+	}
+
+	instrumento, err := s.repo.GetAtivoMaisRecente(ctx, req.Codigo)
+	if err != nil {
+		return nil, err
+	}
+	if instrumento == nil {
+		return nil, ErrInstrumentoNotFound
+	}
+	return instrumento, nil
+}
+
+// definicaoInstrumentoValida verifica a consistência interna de uma definição de instrumento
+func definicaoInstrumentoValida(req *models.CreateInstrumentoRequest) bool {
+	escalas := make(map[string]bool, len(req.Escalas))
+	for _, escala := range req.Escalas {
+		if escalas[escala.Codigo] {
+			return false
+		}
+		escalas[escala.Codigo] = true
+		valores := make(map[float64]bool, len(escala.Opcoes))
+		for _, opcao := range escala.Opcoes {
+			if valores[*opcao.Valor] {
+				return false
+			}
+			valores[*opcao.Valor] = true
+		}
+	}
+
+	subescalas := make(map[string]bool, len(req.Subescalas))
+	for _, subescala := range req.Subescalas {
+		if subescalas[subescala.Codigo] {
+			return false
+		}
+		subescalas[subescala.Codigo] = true
+	}
+
+	numeros := make(map[int]bool, len(req.Itens))
+	for _, item := range req.Itens {
+		if numeros[item.Numero] || !escalas[item.Escala] {
+			return false
+		}
+		if item.Subescala != "" && !subescalas[item.Subescala] {
+			return false
+		}
+		numeros[item.Numero] = true
+	}
+
+	requerSeguimento, temSeguimento := false, false
+	for _, faixa := range req.Faixas {
+		if *faixa.Minimo > *faixa.Maximo {
+			return false
+		}
+		if faixa.Subescala != "" && !subescalas[faixa.Subescala] {
+			return false
+		}
+		if faixa.Etapa == models.EtapaFaixaSeguimento {
+			if faixa.Subescala != "" || faixa.RequerSeguimento {
+				return false
+			}
+			temSeguimento = true
+		} else if faixa.RequerSeguimento {
+			if faixa.Subescala != "" {
+				return false
+			}
+			requerSeguimento = true
+		}
+	}
+	return !requerSeguimento || temSeguimento
+}
+
+// pontuarRespostas converte as respostas nos pontos de cada item, invertendo os itens invertidos. As
+// respostas devem cobrir todos os itens uma única vez, com valores das opções da escala do item.
+func pontuarRespostas(instrumento *models.Instrumento, req []models.RespostaItemRequest) ([]models.RespostaAplicacaoInstrumento, bool) {
+	if len(req) != len(instrumento.Itens) {
+		return nil, false
+	}
+
+	escalas := make(map[string]*models.EscalaInstrumento, len(instrumento.Escalas))
+	for i := range instrumento.Escalas {
+		escalas[instrumento.Escalas[i].Codigo] = &instrumento.Escalas[i]
+	}
+	valores := make(map[int]float64, len(req))
+	for _, resposta := range req {
+		if _, repetido := valores[resposta.Numero]; repetido {
+			return nil, false
+		}
+		valores[resposta.Numero] = *resposta.Valor
+	}
+
+	respostas := make([]models.RespostaAplicacaoInstrumento, 0, len(instrumento.Itens))
+	for _, item := range instrumento.Itens {
+		valor, ok := valores[item.Numero]
+		escala := escalas[item.Escala]
+		if !ok || escala == nil {
+			return nil, false
+		}
+		var opcao *models.OpcaoEscalaInstrumento
+		for i := range escala.Opcoes {
+			if escala.Opcoes[i].Valor == valor {
+				opcao = &escala.Opcoes[i]
+				break
+			}
+		}
+		if opcao == nil {
+			return nil, false
+		}
+
+		pontos := valor
+		if item.Invertido {
+			minimo, maximo := escala.Limites()
+			pontos = maximo + minimo - valor
+		}
+		respostas = append(respostas, models.RespostaAplicacaoInstrumento{
+			Numero: item.Numero,
+			Valor:  valor,
+			Rotulo: opcao.Rotulo,
+			Pontos: pontos,
+		})
+	}
+	return respostas, true
+}
+
+// pontuarSubescalas calcula e classifica a pontuação de cada subescala com os pontos dos seus itens
+func pontuarSubescalas(instrumento *models.Instrumento, respostas []models.RespostaAplicacaoInstrumento) []models.PontuacaoSubescalaAplicacao {
+	if len(instrumento.Subescalas) == 0 {
+		return nil
+	}
+
+	pontos := make(map[int]float64, len(respostas))
+	for _, resposta := range respostas {
+		pontos[resposta.Numero] = resposta.Pontos
+	}
+	porSubescala := make(map[string][]float64, len(instrumento.Subescalas))
+	for _, item := range instrumento.Itens {
+		if item.Subescala != "" {
+			porSubescala[item.Subescala] = append(porSubescala[item.Subescala], pontos[item.Numero])
+		}
+	}
+
+	pontuacoes := make([]models.PontuacaoSubescalaAplicacao, 0, len(instrumento.Subescalas))
+	for _, subescala := range instrumento.Subescalas {
+		pontuacao := models.PontuacaoSubescalaAplicacao{
+			Codigo:    subescala.Codigo,
+			Nome:      subescala.Nome,
+			Pontuacao: combinarPontos(instrumento.Regra, porSubescala[subescala.Codigo]),
+		}
+		if faixa := classificar(instrumento.Faixas, models.EtapaFaixaInicial, subescala.Codigo, pontuacao.Pontuacao); faixa != nil {
+			pontuacao.Classificacao = faixa.Classificacao
+		}
+		pontuacoes = append(pontuacoes, pontuacao)
+	}
+	return pontuacoes
+}
+
+// combinarPontos combina os pontos dos itens pela regra de pontuação do instrumento
+func combinarPontos(regra models.RegraPontuacao, pontos []float64) float64 {
+	if regra == models.RegraPontuacaoMedia {
+		return media(pontos)
+	}
+	total := 0.0
+	for _, p := range pontos {
+		total += p
+	}
+	return total
+}
+
+// classificar retorna a primeira faixa da etapa e da subescala que contém o valor, ou nil
+func classificar(faixas []models.FaixaInstrumento, etapa models.EtapaFaixa, subescala string, valor float64) *models.FaixaInstrumento {
+	for i := range faixas {
+		faixa := &faixas[i]
+		if faixa.Etapa == etapa && faixa.Subescala == subescala && valor >= faixa.Minimo && valor <= faixa.Maximo {
+			return faixa
+		}
+	}
+	return nil
+}
+
+// itensEmRisco retorna os números dos itens que pontuaram na etapa inicial, isto é, cujos pontos ficaram
+// acima do menor valor da escala
+func itensEmRisco(instrumento *models.Instrumento, respostas []models.RespostaAplicacaoInstrumento) map[int]struct{} {
+	minimos := make(map[string]float64, len(instrumento.Escalas))
+	for i := range instrumento.Escalas {
+		minimo, _ := instrumento.Escalas[i].Limites()
+		minimos[instrumento.Escalas[i].Codigo] = minimo
+	}
+	escalaDoItem := make(map[int]string, len(instrumento.Itens))
+	for _, item := range instrumento.Itens {
+		escalaDoItem[item.Numero] = item.Escala
+	}
+
+	emRisco := make(map[int]struct{})
+	for _, resposta := range respostas {
+		if resposta.Pontos > minimos[escalaDoItem[resposta.Numero]] {
+			emRisco[resposta.Numero] = struct{}{}
+		}
+	}
+	return emRisco
+}
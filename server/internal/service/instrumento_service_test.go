@@ -0,0 +1,140 @@
+package service
+
+import (
+	"testing"
+
+	"msd-service/server/internal/models"
+)
+
+// respostasUniformes responde todos os itens do instrumento com o mesmo valor
+func respostasUniformes(instrumento *models.Instrumento, valor float64) []models.RespostaItemRequest {
+	respostas := make([]models.RespostaItemRequest, 0, len(instrumento.Itens))
+	for _, item := range instrumento.Itens {
+		v := valor
+		respostas = append(respostas, models.RespostaItemRequest{Numero: item.Numero, Valor: &v})
+	}
+	return respostas
+}
+
+func TestPontuarRespostas(t *testing.T) {
+	mchat := definicaoMChatRF().ToInstrumento()
+	atec := definicaoATEC().ToInstrumento()
+
+	// No ATEC, as subescalas de linguagem e de percepção são invertidas e valem 2 pontos por item na resposta 0
+	invertidosATEC := 0
+	for _, item := range atec.Itens {
+		if item.Invertido {
+			invertidosATEC++
+		}
+	}
+
+	tests := []struct {
+		nome        string
+		instrumento *models.Instrumento
+		respostas   func() []models.RespostaItemRequest
+		ok          bool
+		total       float64
+	}{
+		{
+			"M-CHAT-R/F com todas as respostas \"sim\" pontua só os itens invertidos",
+			mchat,
+			func() []models.RespostaItemRequest { return respostasUniformes(mchat, 0) },
+			true, 3,
+		},
+		{
+			"M-CHAT-R/F com todas as respostas \"não\"",
+			mchat,
+			func() []models.RespostaItemRequest { return respostasUniformes(mchat, 1) },
+			true, 17,
+		},
+		{
+			"ATEC com todas as respostas no menor valor",
+			atec,
+			func() []models.RespostaItemRequest { return respostasUniformes(atec, 0) },
+			true, float64(2 * invertidosATEC),
+		},
+		{
+			"faltando um item",
+			mchat,
+			func() []models.RespostaItemRequest { return respostasUniformes(mchat, 0)[1:] },
+			false, 0,
+		},
+		{
+			"item repetido",
+			mchat,
+			func() []models.RespostaItemRequest {
+				respostas := respostasUniformes(mchat, 0)
+				respostas[1].Numero = respostas[0].Numero
+				return respostas
+			},
+			false, 0,
+		},
+		{
+			"item inexistente",
+			mchat,
+			func() []models.RespostaItemRequest {
+				respostas := respostasUniformes(mchat, 0)
+				respostas[0].Numero = 99
+				return respostas
+			},
+			false, 0,
+		},
+		{
+			"valor fora das opções da escala",
+			mchat,
+			func() []models.RespostaItemRequest { return respostasUniformes(mchat, 2) },
+			false, 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			respostas, ok := pontuarRespostas(tt.instrumento, tt.respostas())
+			if ok != tt.ok {
+				t.Fatalf("pontuarRespostas() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			total := 0.0
+			for _, resposta := range respostas {
+				total += resposta.Pontos
+			}
+			if total != tt.total {
+				t.Errorf("pontuarRespostas() total = %v, want %v", total, tt.total)
+			}
+		})
+	}
+}
+
+func TestClassificar(t *testing.T) {
+	faixas := definicaoMChatRF().ToInstrumento().Faixas
+
+	tests := []struct {
+		nome      string
+		etapa     models.EtapaFaixa
+		subescala string
+		valor     float64
+		want      string
+	}{
+		{"baixo risco", models.EtapaFaixaInicial, "", 0, "baixo_risco"},
+		{"limite do baixo risco", models.EtapaFaixaInicial, "", 2, "baixo_risco"},
+		{"médio risco", models.EtapaFaixaInicial, "", 3, "medio_risco"},
+		{"alto risco", models.EtapaFaixaInicial, "", 8, "alto_risco"},
+		{"pontuação máxima", models.EtapaFaixaInicial, "", 20, "alto_risco"},
+		{"acima de todas as faixas", models.EtapaFaixaInicial, "", 21, ""},
+		{"seguimento negativo", models.EtapaFaixaSeguimento, "", 1, "negativo"},
+		{"seguimento positivo", models.EtapaFaixaSeguimento, "", 2, "positivo"},
+		{"subescala sem faixas", models.EtapaFaixaInicial, "linguagem", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			got := ""
+			if faixa := classificar(faixas, tt.etapa, tt.subescala, tt.valor); faixa != nil {
+				got = faixa.Classificacao
+			}
+			if got != tt.want {
+				t.Errorf("classificar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
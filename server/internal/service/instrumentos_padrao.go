@@ -0,0 +1,229 @@
+package service
+
+import (
+	"msd-service/server/internal/models"
+)
+
+// codigosPadrao define a ordem em que os instrumentos padrão são listados
+var codigosPadrao = []string{"mchat_rf", "atec"}
+
+// definicoesPadrao reúne as definições de instrumentos distribuídas com o serviço, por código
+var definicoesPadrao = map[string]func() *models.CreateInstrumentoRequest{
+	"mchat_rf": definicaoMChatRF,
+	"atec":     definicaoATEC,
+}
+
+// definicaoMChatRF retorna a definição do M-CHAT-R/F. Cada item pontua 1 quando a resposta indica risco:
+// "não" na maioria dos itens e "sim" nos itens 2, 5 e 12, que são invertidos. A pontuação de 3 a 7
+// exige a entrevista de seguimento, cujo resultado é positivo com 2 ou mais itens reprovados.
+func definicaoMChatRF() *models.CreateInstrumentoRequest {
+	itens := []string{
+		"Se você apontar para algum objeto no outro lado do cômodo, a criança olha para o objeto?",
+		"Alguma vez você já se perguntou se a criança pode ser surda?",
+		"A criança brinca de faz de conta?",
+		"A criança gosta de subir nas coisas?",
+		"A criança faz movimentos incomuns com os dedos perto dos olhos?",
+		"A criança aponta com o dedo para pedir algo ou para conseguir ajuda?",
+		"A criança aponta com o dedo para mostrar algo interessante para você?",
+		"A criança se interessa por outras crianças?",
+		"A criança traz objetos para mostrar a você, só para compartilhar?",
+		"A criança responde quando você a chama pelo nome?",
+		"Quando você sorri para a criança, ela sorri de volta?",
+		"A criança fica incomodada com barulhos do dia a dia?",
+		"A criança anda?",
+		"A criança olha nos seus olhos quando você fala com ela, brinca com ela ou a veste?",
+		"A criança tenta imitar o que você faz?",
+		"Quando você vira a cabeça para olhar para algo, a criança olha em volta para ver o que você está olhando?",
+		"A criança tenta fazer você olhar para ela?",
+		"A criança entende quando você pede para ela fazer algo?",
+		"Quando acontece algo novo, a criança olha para o seu rosto para ver como você reage?",
+		"A criança gosta de atividades de movimento, como ser balançada ou pular nos seus joelhos?",
+	}
+	invertidos := map[int]bool{2: true, 5: true, 12: true}
+
+	req := &models.CreateInstrumentoRequest{
+		Codigo:    "mchat_rf",
+		Nome:      "M-CHAT-R/F",
+		Descricao: "Modified Checklist for Autism in Toddlers, Revised with Follow-Up: rastreio de risco para TEA entre 16 e 30 meses, respondido pelos pais",
+		Regra:     models.RegraPontuacaoSoma,
+		Escalas: []models.EscalaInstrumentoRequest{
+			{Codigo: "sim_nao", Opcoes: opcoesPadrao("Sim", "Não")},
+		},
+		Faixas: []models.FaixaInstrumentoRequest{
+			faixaPadrao(models.EtapaFaixaInicial, 0, 2, "baixo_risco", "Sem necessidade de ação, salvo se a vigilância indicar risco; repetir o rastreio após os 24 meses se aplicado antes dessa idade", false),
+			faixaPadrao(models.EtapaFaixaInicial, 3, 7, "medio_risco", "Aplicar a entrevista de seguimento aos itens que pontuaram", true),
+			faixaPadrao(models.EtapaFaixaInicial, 8, 20, "alto_risco", "Encaminhar imediatamente para avaliação diagnóstica e intervenção precoce, sem necessidade do seguimento", false),
+			faixaPadrao(models.EtapaFaixaSeguimento, 0, 1, "negativo", "Rastreio negativo; manter a vigilância nas consultas de rotina", false),
+			faixaPadrao(models.EtapaFaixaSeguimento, 2, 20, "positivo", "Rastreio positivo; encaminhar para avaliação diagnóstica e intervenção precoce", false),
+		},
+	}
+	for i, texto := range itens {
+		req.Itens = append(req.Itens, models.ItemInstrumentoRequest{
+			Numero:    i + 1,
+			Texto:     texto,
+			Escala:    "sim_nao",
+			Invertido: invertidos[i+1],
+		})
+	}
+	return req
+}
+
+// definicaoATEC retorna a definição do ATEC, medida de desfecho sem pontos de corte: pontuações menores
+// indicam menos sintomas. As subescalas de linguagem e de percepção são respondidas em escalas em que a
+// resposta "muito" indica a habilidade presente, por isso os seus itens são invertidos.
+func definicaoATEC() *models.CreateInstrumentoRequest {
+	subescalas := []struct {
+		codigo    string
+		nome      string
+		escala    string
+		invertido bool
+		itens     []string
+	}{
+		{
+			codigo: "linguagem", nome: "I. Fala/Linguagem/Comunicação", escala: "verdade", invertido: true,
+			itens: []string{
+				"Sabe o próprio nome",
+				"Responde a \"não\" ou \"pare\"",
+				"Segue alguns comandos",
+				"Usa uma palavra de cada vez (não, comer, água etc.)",
+				"Usa duas palavras de cada vez (não quero, ir embora etc.)",
+				"Usa três palavras de cada vez (quero mais leite etc.)",
+				"Sabe 10 ou mais palavras",
+				"Usa frases com 4 ou mais palavras",
+				"Explica o que quer",
+				"Faz perguntas com significado",
+				"A fala costuma ser relevante e com significado",
+				"Usa com frequência várias frases seguidas",
+				"Mantém uma conversa razoavelmente boa",
+				"Tem habilidade de comunicação normal para a idade",
+			},
+		},
+		{
+			codigo: "sociabilidade", nome: "II. Sociabilidade", escala: "descritivo",
+			itens: []string{
+				"Parece estar fechado em uma concha; não se consegue alcançá-lo",
+				"Ignora as outras pessoas",
+				"Presta pouca ou nenhuma atenção quando alguém se dirige a ele",
+				"Não coopera e é resistente",
+				"Não faz contato visual",
+				"Prefere ser deixado sozinho",
+				"Não demonstra afeto",
+				"Não cumprimenta os pais",
+				"Evita contato com os outros",
+				"Não imita",
+				"Não gosta de ser segurado ou abraçado",
+				"Não compartilha nem mostra",
+				"Não acena \"tchau\"",
+				"É desagradável e não obedece",
+				"Faz birras",
+				"Não tem amigos",
+				"Raramente sorri",
+				"É insensível aos sentimentos dos outros",
+				"É indiferente a ser apreciado",
+				"É indiferente quando os pais vão embora",
+			},
+		},
+		{
+			codigo: "percepcao", nome: "III. Percepção Sensorial/Cognitiva", escala: "descritivo", invertido: true,
+			itens: []string{
+				"Responde ao próprio nome",
+				"Responde a elogios",
+				"Olha para pessoas e animais",
+				"Olha para figuras e para a TV",
+				"Desenha, pinta e faz arte",
+				"Brinca com brinquedos de forma apropriada",
+				"Tem expressão facial apropriada",
+				"Entende histórias na TV",
+				"Entende explicações",
+				"Tem consciência do ambiente",
+				"Tem consciência do perigo",
+				"Demonstra imaginação",
+				"Inicia atividades",
+				"Veste-se sozinho",
+				"É curioso e interessado",
+				"É aventureiro e explora",
+				"Está \"sintonizado\", não parece \"desligado\"",
+				"Olha para onde os outros estão olhando",
+			},
+		},
+		{
+			codigo: "saude", nome: "IV. Saúde/Aspectos Físicos/Comportamento", escala: "problema",
+			itens: []string{
+				"Urina na cama",
+				"Urina na roupa ou na fralda",
+				"Evacua na roupa ou na fralda",
+				"Diarreia",
+				"Constipação",
+				"Problemas de sono",
+				"Come demais ou muito pouco",
+				"Dieta extremamente restrita",
+				"Hiperatividade",
+				"Letargia",
+				"Bate em si mesmo ou se machuca",
+				"Bate nos outros ou os machuca",
+				"Comportamento destrutivo",
+				"Sensibilidade a sons",
+				"Ansiedade ou medo",
+				"Infelicidade ou choro",
+				"Convulsões",
+				"Fala obsessiva",
+				"Rotinas rígidas",
+				"Grita",
+				"Exige que as coisas sejam sempre iguais",
+				"Frequentemente agitado",
+				"Pouco sensível à dor",
+				"Fixação em certos objetos ou assuntos",
+				"Movimentos repetitivos (balançar, agitar as mãos etc.)",
+			},
+		},
+	}
+
+	req := &models.CreateInstrumentoRequest{
+		Codigo:    "atec",
+		Nome:      "ATEC",
+		Descricao: "Autism Treatment Evaluation Checklist: medida de desfecho do tratamento em quatro subescalas, respondida pelos pais ou pelos terapeutas",
+		Regra:     models.RegraPontuacaoSoma,
+		Escalas: []models.EscalaInstrumentoRequest{
+			{Codigo: "verdade", Opcoes: opcoesPadrao("Não é verdade", "Um pouco verdade", "Muito verdade")},
+			{Codigo: "descritivo", Opcoes: opcoesPadrao("Não descreve", "Descreve um pouco", "Descreve muito")},
+			{Codigo: "problema", Opcoes: opcoesPadrao("Não é um problema", "Problema leve", "Problema moderado", "Problema grave")},
+		},
+	}
+	numero := 0
+	for _, subescala := range subescalas {
+		req.Subescalas = append(req.Subescalas, models.SubescalaInstrumentoRequest{Codigo: subescala.codigo, Nome: subescala.nome})
+		for _, texto := range subescala.itens {
+			numero++
+			req.Itens = append(req.Itens, models.ItemInstrumentoRequest{
+				Numero:    numero,
+				Texto:     texto,
+				Escala:    subescala.escala,
+				Subescala: subescala.codigo,
+				Invertido: subescala.invertido,
+			})
+		}
+	}
+	return req
+}
+
+// opcoesPadrao cria as opções de uma escala com valores de 0 em diante, na ordem dos rótulos
+func opcoesPadrao(rotulos ...string) []models.OpcaoEscalaRequest {
+	opcoes := make([]models.OpcaoEscalaRequest, 0, len(rotulos))
+	for i, rotulo := range rotulos {
+		valor := float64(i)
+		opcoes = append(opcoes, models.OpcaoEscalaRequest{Rotulo: rotulo, Valor: &valor})
+	}
+	return opcoes
+}
+
+// faixaPadrao cria uma faixa de classificação da pontuação total
+func faixaPadrao(etapa models.EtapaFaixa, minimo, maximo float64, classificacao, descricao string, requerSeguimento bool) models.FaixaInstrumentoRequest {
+	return models.FaixaInstrumentoRequest{
+		Etapa:            etapa,
+		Minimo:           &minimo,
+		Maximo:           &maximo,
+		Classificacao:    classificacao,
+		Descricao:        descricao,
+		RequerSeguimento: requerSeguimento,
+	}
+}
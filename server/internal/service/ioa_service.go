@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrObservadorSecundarioAusente = errors.New("dados do observador secundário exigem uma sessão com observador secundário definido")
+)
+
+// IOAService calcula a concordância entre observadores (IOA) a partir dos dados registrados pelos
+// observadores principal e secundário de uma sessão
+type IOAService struct {
+	sessaoRepo        repository.SessaoRepository
+	coletaRepo        repository.ColetaABARepository
+	registroRepo      repository.RegistroComportamentoRepository
+	observacaoRepo    repository.ObservacaoIntervaloRepository
+	comportamentoRepo repository.ComportamentoAlvoRepository
+}
+
+// NewIOAService cria uma nova instância de IOAService
+func NewIOAService(
+	sessaoRepo repository.SessaoRepository,
+	coletaRepo repository.ColetaABARepository,
+	registroRepo repository.RegistroComportamentoRepository,
+	observacaoRepo repository.ObservacaoIntervaloRepository,
+	comportamentoRepo repository.ComportamentoAlvoRepository,
+) *IOAService {
+	return &IOAService{
+		sessaoRepo:        sessaoRepo,
+		coletaRepo:        coletaRepo,
+		registroRepo:      registroRepo,
+		observacaoRepo:    observacaoRepo,
+		comportamentoRepo: comportamentoRepo,
+	}
+}
+
+// CalcularIOASessao calcula o IOA tentativa a tentativa das coletas e, para cada comportamento observado
+// pelo observador secundário, o IOA de contagem total, de contagem exata por intervalo e intervalo a intervalo
+func (s *IOAService) CalcularIOASessao(ctx context.Context, sessaoID uuid.UUID, duracaoIntervalo int) (*models.IOASessao, error) {
+	if duracaoIntervalo < 1 {
+		duracaoIntervalo = models.DuracaoIntervaloIOAPadrao
+	}
+
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sessao == nil {
+		return nil, ErrSessaoNotFound
+	}
+	return s.calcular(ctx, sessao, duracaoIntervalo)
+}
+
+// CoberturaPaciente calcula a cobertura de IOA das sessões realizadas de um paciente no intervalo [inicio, fim)
+func (s *IOAService) CoberturaPaciente(ctx context.Context, pacienteID uuid.UUID, inicio, fim *time.Time, meta float64) (*models.CoberturaIOA, error) {
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, ErrPeriodoInvalido
+	}
+
+	sessoes, err := s.sessaoRepo.ListRealizadasByPaciente(ctx, pacienteID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	cobertura, err := s.cobertura(ctx, sessoes, meta)
+	if err != nil {
+		return nil, err
+	}
+	cobertura.PacienteID = &pacienteID
+	return cobertura, nil
+}
+
+// CoberturaTerapeuta calcula a cobertura de IOA das sessões realizadas por um terapeuta no intervalo [inicio, fim)
+func (s *IOAService) CoberturaTerapeuta(ctx context.Context, terapeutaID uuid.UUID, inicio, fim *time.Time, meta float64) (*models.CoberturaIOA, error) {
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, ErrPeriodoInvalido
+	}
+
+	sessoes, err := s.sessaoRepo.ListRealizadasByTerapeuta(ctx, terapeutaID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	cobertura, err := s.cobertura(ctx, sessoes, meta)
+	if err != nil {
+		return nil, err
+	}
+	cobertura.TerapeutaID = &terapeutaID
+	return cobertura, nil
+}
+
+// calcular reúne os dados dos dois observadores da sessão e calcula as medidas de IOA
+func (s *IOAService) calcular(ctx context.Context, sessao *models.Sessao, duracaoIntervalo int) (*models.IOASessao, error) {
+	resultado := &models.IOASessao{
+		SessaoID:               sessao.ID,
+		PacienteID:             sessao.PacienteID,
+		TerapeutaID:            sessao.TerapeutaID,
+		ObservadorSecundarioID: sessao.ObservadorSecundarioID,
+		DuracaoIntervalo:       duracaoIntervalo,
+		Comportamentos:         make([]models.IOAComportamento, 0),
+	}
+
+	coletas, err := s.coletaRepo.ListObservadoresBySessao(ctx, sessao.ID)
+	if err != nil {
+		return nil, err
+	}
+	resultado.Tentativas = ioaTentativas(coletas)
+
+	registros, err := s.registroRepo.ListObservadoresBySessao(ctx, sessao.ID)
+	if err != nil {
+		return nil, err
+	}
+	observacoes, err := s.observacaoRepo.ListObservadoresBySessao(ctx, sessao.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apenas os comportamentos observados pelo observador secundário entram no cálculo
+	ids := make([]uuid.UUID, 0)
+	observados := make(map[uuid.UUID]bool)
+	for _, registro := range registros {
+		if registro.Secundario && !observados[registro.ComportamentoID] {
+			observados[registro.ComportamentoID] = true
+			ids = append(ids, registro.ComportamentoID)
+		}
+	}
+	for _, observacao := range observacoes {
+		if observacao.Secundario && !observados[observacao.ComportamentoID] {
+			observados[observacao.ComportamentoID] = true
+			ids = append(ids, observacao.ComportamentoID)
+		}
+	}
+
+	for _, id := range ids {
+		comportamento, err := s.comportamentoRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if comportamento == nil {
+			continue
+		}
+		resultado.Comportamentos = append(resultado.Comportamentos, ioaComportamento(sessao, comportamento, registros, observacoes, duracaoIntervalo))
+	}
+
+	medidas := make([]float64, 0)
+	for _, tentativas := range resultado.Tentativas {
+		medidas = append(medidas, tentativas.Percentual)
+	}
+	for _, comportamento := range resultado.Comportamentos {
+		for _, medida := range []*float64{comportamento.ContagemTotal, comportamento.ContagemExataPorIntervalo, comportamento.IntervaloAIntervalo} {
+			if medida != nil {
+				medidas = append(medidas, *medida)
+			}
+		}
+	}
+	if len(medidas) > 0 {
+		geral := media(medidas)
+		resultado.IOAGeral = &geral
+		resultado.Aceitavel = geral >= models.IOAAceitavel
+	}
+	return resultado, nil
+}
+
+// cobertura calcula o percentual de sessões realizadas com IOA e a concordância média dessas sessões
+func (s *IOAService) cobertura(ctx context.Context, sessoes []*models.Sessao, meta float64) (*models.CoberturaIOA, error) {
+	if meta <= 0 {
+		meta = models.MetaCoberturaIOAPadrao
+	}
+
+	cobertura := &models.CoberturaIOA{SessoesRealizadas: len(sessoes), MetaCobertura: meta}
+	valores := make([]float64, 0)
+	for _, sessao := range sessoes {
+		if sessao.ObservadorSecundarioID == nil {
+			continue
+		}
+		ioa, err := s.calcular(ctx, sessao, models.DuracaoIntervaloIOAPadrao)
+		if err != nil {
+			return nil, err
+		}
+		if ioa.IOAGeral == nil {
+			continue
+		}
+		cobertura.SessoesComIOA++
+		valores = append(valores, *ioa.IOAGeral)
+		if !ioa.Aceitavel {
+			cobertura.SessoesAbaixoDoAceitavel++
+		}
+	}
+
+	cobertura.Cobertura = percentual(cobertura.SessoesComIOA, cobertura.SessoesRealizadas)
+	cobertura.AtingeMeta = cobertura.SessoesRealizadas > 0 && cobertura.Cobertura >= meta
+	if len(valores) > 0 {
+		medio := media(valores)
+		cobertura.IOAMedio = &medio
+	}
+	return cobertura, nil
+}
+
+// ioaTentativas compara, etapa a etapa, as tentativas dos dois observadores na ordem de registro.
+// Tentativas sem correspondente no outro observador contam como discordância.
+func ioaTentativas(coletas []*models.ColetaABA) []models.IOATentativas {
+	etapas := make([]uuid.UUID, 0)
+	vistas := make(map[uuid.UUID]bool)
+	principal := make(map[uuid.UUID][]*models.ColetaABA)
+	secundario := make(map[uuid.UUID][]*models.ColetaABA)
+	for _, coleta := range coletas {
+		if !vistas[coleta.EtapaProgramaID] {
+			vistas[coleta.EtapaProgramaID] = true
+			etapas = append(etapas, coleta.EtapaProgramaID)
+		}
+		if coleta.Secundario {
+			secundario[coleta.EtapaProgramaID] = append(secundario[coleta.EtapaProgramaID], coleta)
+		} else {
+			principal[coleta.EtapaProgramaID] = append(principal[coleta.EtapaProgramaID], coleta)
+		}
+	}
+
+	resultado := make([]models.IOATentativas, 0)
+	for _, etapaID := range etapas {
+		p, sec := principal[etapaID], secundario[etapaID]
+		if len(sec) == 0 {
+			continue
+		}
+		ioa := models.IOATentativas{
+			EtapaProgramaID:      etapaID,
+			TentativasPrincipal:  len(p),
+			TentativasSecundario: len(sec),
+		}
+		for i := 0; i < len(p) && i < len(sec); i++ {
+			if p[i].Resultado == sec[i].Resultado && p[i].PromptUtilizadoID == sec[i].PromptUtilizadoID {
+				ioa.Concordancias++
+			}
+		}
+		comparadas := len(p)
+		if len(sec) > comparadas {
+			comparadas = len(sec)
+		}
+		ioa.Percentual = percentual(ioa.Concordancias, comparadas)
+		resultado = append(resultado, ioa)
+	}
+	return resultado
+}
+
+// ioaComportamento calcula as medidas de IOA aplicáveis ao método de registro do comportamento
+func ioaComportamento(sessao *models.Sessao, comportamento *models.ComportamentoAlvo, registros []*models.RegistroComportamento, observacoes []*models.ObservacaoIntervalo, duracaoIntervalo int) models.IOAComportamento {
+	ioa := models.IOAComportamento{
+		ComportamentoID: comportamento.ID,
+		Descricao:       comportamento.Descricao,
+		MetodoRegistro:  comportamento.MetodoRegistro,
+	}
+
+	var principal, secundario []*models.RegistroComportamento
+	for _, registro := range registros {
+		if registro.ComportamentoID != comportamento.ID {
+			continue
+		}
+		if registro.Secundario {
+			secundario = append(secundario, registro)
+			ioa.TotalSecundario += registro.Valor
+		} else {
+			principal = append(principal, registro)
+			ioa.TotalPrincipal += registro.Valor
+		}
+	}
+
+	switch comportamento.MetodoRegistro {
+	case models.MetodoRegistroFrequencia:
+		total := razaoTotais(ioa.TotalPrincipal, ioa.TotalSecundario)
+		ioa.ContagemTotal = &total
+		if sessao.DuracaoMinutos > 0 {
+			exata := contagemExataPorIntervalo(sessao, principal, secundario, duracaoIntervalo)
+			ioa.ContagemExataPorIntervalo = &exata
+		}
+	case models.MetodoRegistroDuracao:
+		total := razaoTotais(ioa.TotalPrincipal, ioa.TotalSecundario)
+		ioa.ContagemTotal = &total
+	case models.MetodoRegistroIntervalo:
+		var obsPrincipal, obsSecundario []*models.ObservacaoIntervalo
+		ioa.TotalPrincipal, ioa.TotalSecundario = 0, 0
+		for _, observacao := range observacoes {
+			if observacao.ComportamentoID != comportamento.ID {
+				continue
+			}
+			if observacao.Secundario {
+				obsSecundario = append(obsSecundario, observacao)
+				ioa.TotalSecundario += float64(observacao.IntervalosComOcorrencia)
+			} else {
+				obsPrincipal = append(obsPrincipal, observacao)
+				ioa.TotalPrincipal += float64(observacao.IntervalosComOcorrencia)
+			}
+		}
+		ioa.IntervaloAIntervalo = intervaloAIntervalo(obsPrincipal, obsSecundario)
+	}
+	return ioa
+}
+
+// razaoTotais retorna o IOA de contagem total: o menor total dividido pelo maior
+func razaoTotais(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 100
+	}
+	return math.Min(a, b) / math.Max(a, b) * 100
+}
+
+// contagemExataPorIntervalo divide a sessão em intervalos e retorna o percentual de intervalos em que
+// os dois observadores registraram exatamente a mesma contagem
+func contagemExataPorIntervalo(sessao *models.Sessao, principal, secundario []*models.RegistroComportamento, duracaoIntervalo int) float64 {
+	n := int(math.Ceil(float64(sessao.DuracaoMinutos*60) / float64(duracaoIntervalo)))
+	contar := func(registros []*models.RegistroComportamento) []float64 {
+		contagens := make([]float64, n)
+		for _, registro := range registros {
+			i := int(registro.DataHora.Sub(sessao.Data).Seconds()) / duracaoIntervalo
+			if registro.DataHora.Before(sessao.Data) || i >= n {
+				continue
+			}
+			contagens[i] += registro.Valor
+		}
+		return contagens
+	}
+
+	p, sec := contar(principal), contar(secundario)
+	concordancias := 0
+	for i := 0; i < n; i++ {
+		if p[i] == sec[i] {
+			concordancias++
+		}
+	}
+	return percentual(concordancias, n)
+}
+
+// intervaloAIntervalo compara, na ordem de início, as observações por intervalos dos dois observadores
+// e retorna o percentual de intervalos com a mesma pontuação. Retorna nil quando não há pares a comparar.
+func intervaloAIntervalo(principal, secundario []*models.ObservacaoIntervalo) *float64 {
+	total, concordancias := 0, 0
+	for i := 0; i < len(principal) && i < len(secundario); i++ {
+		p, sec := principal[i].Intervalos, secundario[i].Intervalos
+		for j := 0; j < len(p) || j < len(sec); j++ {
+			total++
+			if j < len(p) && j < len(sec) && p[j].Ocorreu == sec[j].Ocorreu {
+				concordancias++
+			}
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	resultado := percentual(concordancias, total)
+	return &resultado
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"msd-service/server/internal/models"
+)
+
+func TestRazaoTotais(t *testing.T) {
+	tests := []struct {
+		nome string
+		a, b float64
+		want float64
+	}{
+		{"sem registros nos dois observadores", 0, 0, 100},
+		{"totais iguais", 7, 7, 100},
+		{"menor total sobre o maior", 8, 10, 80},
+		{"ordem dos observadores não importa", 10, 8, 80},
+		{"um observador sem registros", 0, 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			if got := razaoTotais(tt.a, tt.b); got != tt.want {
+				t.Errorf("razaoTotais(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContagemExataPorIntervalo(t *testing.T) {
+	inicio := time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC)
+	sessao := &models.Sessao{Data: inicio, DuracaoMinutos: 1}
+	registro := func(segundos int, valor float64) *models.RegistroComportamento {
+		return &models.RegistroComportamento{DataHora: inicio.Add(time.Duration(segundos) * time.Second), Valor: valor}
+	}
+
+	tests := []struct {
+		nome                  string
+		principal, secundario []*models.RegistroComportamento
+		want                  float64
+	}{
+		{"sem registros, todos os intervalos concordam", nil, nil, 100},
+		{
+			"mesma contagem em momentos diferentes do intervalo",
+			[]*models.RegistroComportamento{registro(5, 1), registro(45, 2)},
+			[]*models.RegistroComportamento{registro(15, 1), registro(50, 1), registro(55, 1)},
+			100,
+		},
+		{
+			"contagem diferente em um dos três intervalos",
+			[]*models.RegistroComportamento{registro(5, 1), registro(25, 2)},
+			[]*models.RegistroComportamento{registro(10, 1), registro(30, 1)},
+			200.0 / 3,
+		},
+		{
+			"registros fora da sessão são ignorados",
+			[]*models.RegistroComportamento{registro(-10, 1), registro(60, 1), registro(90, 3)},
+			nil,
+			100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			got := contagemExataPorIntervalo(sessao, tt.principal, tt.secundario, 20)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("contagemExataPorIntervalo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntervaloAIntervalo(t *testing.T) {
+	observacao := func(ocorreu ...bool) *models.ObservacaoIntervalo {
+		o := &models.ObservacaoIntervalo{}
+		for _, oc := range ocorreu {
+			o.Intervalos = append(o.Intervalos, models.IntervaloObservado{Ocorreu: oc})
+		}
+		return o
+	}
+
+	tests := []struct {
+		nome                  string
+		principal, secundario []*models.ObservacaoIntervalo
+		want                  *float64
+	}{
+		{"sem pares a comparar", []*models.ObservacaoIntervalo{observacao(true)}, nil, nil},
+		{
+			"concordância total",
+			[]*models.ObservacaoIntervalo{observacao(true, false)},
+			[]*models.ObservacaoIntervalo{observacao(true, false)},
+			ptrFloat(100),
+		},
+		{
+			"um intervalo discordante em três",
+			[]*models.ObservacaoIntervalo{observacao(true, false, true)},
+			[]*models.ObservacaoIntervalo{observacao(true, true, true)},
+			ptrFloat(200.0 / 3),
+		},
+		{
+			"intervalo sem par conta como discordância",
+			[]*models.ObservacaoIntervalo{observacao(true, false)},
+			[]*models.ObservacaoIntervalo{observacao(true)},
+			ptrFloat(50),
+		},
+		{
+			"observações sem par são ignoradas",
+			[]*models.ObservacaoIntervalo{observacao(false), observacao(true, true)},
+			[]*models.ObservacaoIntervalo{observacao(false)},
+			ptrFloat(100),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			got := intervaloAIntervalo(tt.principal, tt.secundario)
+			switch {
+			case got == nil || tt.want == nil:
+				if got != tt.want {
+					t.Errorf("intervaloAIntervalo() = %v, want %v", got, tt.want)
+				}
+			case math.Abs(*got-*tt.want) > 1e-9:
+				t.Errorf("intervaloAIntervalo() = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func ptrFloat(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrModeloProgramaNotFound = errors.New("modelo de programa não encontrado")
+	ErrModeloProgramaEmUso    = errors.New("o modelo já foi instanciado e não pode ser excluído")
+	ErrModeloProgramaInativo  = errors.New("o modelo de programa está inativo e não pode ser instanciado")
+)
+
+// ModeloProgramaService encapsula a biblioteca de modelos de programa da clínica, a criação de programas ABA
+// para os pacientes a partir dos modelos e o relatório de uso dos modelos
+type ModeloProgramaService struct {
+	repo         repository.ModeloProgramaRepository
+	programaRepo repository.ProgramaABARepository
+	etapaRepo    repository.EtapaProgramaRepository
+	pacienteRepo repository.PacienteRepository
+	promptRepo   repository.TipoPromptRepository
+}
+
+// NewModeloProgramaService cria uma nova instância de ModeloProgramaService
+func NewModeloProgramaService(
+	repo repository.ModeloProgramaRepository,
+	programaRepo repository.ProgramaABARepository,
+	etapaRepo repository.EtapaProgramaRepository,
+	pacienteRepo repository.PacienteRepository,
+	promptRepo repository.TipoPromptRepository,
+) *ModeloProgramaService {
+	return &ModeloProgramaService{
+		repo:         repo,
+		programaRepo: programaRepo,
+		etapaRepo:    etapaRepo,
+		pacienteRepo: pacienteRepo,
+		promptRepo:   promptRepo,
+	}
+}
+
+// CreateModelo cria um modelo de programa na biblioteca
+func (s *ModeloProgramaService) CreateModelo(ctx context.Context, req *models.CreateModeloProgramaRequest) (*models.ModeloPrograma, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := s.validarHierarquia(ctx, req.Hierarquia); err != nil {
+		return nil, err
+	}
+
+	modelo := req.ToModeloPrograma()
+	if err := s.repo.Create(ctx, modelo); err != nil {
+		return nil, err
+	}
+	return modelo, nil
+}
+
+// GetModelo busca um modelo de programa pelo ID, com as etapas, os alvos e a hierarquia de prompts
+func (s *ModeloProgramaService) GetModelo(ctx context.Context, id uuid.UUID) (*models.ModeloPrograma, error) {
+	modelo, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if modelo == nil {
+		return nil, ErrModeloProgramaNotFound
+	}
+	return modelo, nil
+}
+
+// UpdateModelo atualiza um modelo de programa. Os programas já instanciados a partir dele não são alterados.
+func (s *ModeloProgramaService) UpdateModelo(ctx context.Context, id uuid.UUID, req *models.UpdateModeloProgramaRequest) (*models.ModeloPrograma, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if err := s.validarHierarquia(ctx, req.Hierarquia); err != nil {
+		return nil, err
+	}
+
+	modelo, err := s.GetModelo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	modelo.ApplyUpdates(req)
+	if err := s.repo.Update(ctx, modelo, req.Etapas != nil || req.Hierarquia != nil); err != nil {
+		return nil, err
+	}
+	return modelo, nil
+}
+
+// DeleteModelo exclui um modelo que ainda não foi instanciado. Modelos em uso podem ser inativados.
+func (s *ModeloProgramaService) DeleteModelo(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetModelo(ctx, id); err != nil {
+		return err
+	}
+
+	programas, err := s.programaRepo.ListByModeloPrograma(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(programas) > 0 {
+		return ErrModeloProgramaEmUso
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListModelos retorna os modelos da biblioteca, opcionalmente de uma área e apenas os ativos
+func (s *ModeloProgramaService) ListModelos(ctx context.Context, area string, apenasAtivos bool) ([]*models.ModeloPrograma, error) {
+	return s.repo.List(ctx, area, apenasAtivos)
+}
+
+// InstanciarModelo cria um programa ABA ativo para o paciente copiando as etapas, os alvos, os critérios e
+// a hierarquia de prompts do modelo. A primeira etapa entra em treino e os alvos de cada etapa são
+// introduzidos conforme a rotação configurada.
+func (s *ModeloProgramaService) InstanciarModelo(ctx context.Context, id uuid.UUID, req *models.InstanciarModeloProgramaRequest) (*models.ProgramaABA, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	modelo, err := s.GetModelo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !modelo.Ativo {
+		return nil, ErrModeloProgramaInativo
+	}
+	paciente, err := s.pacienteRepo.GetByID(ctx, req.PacienteID)
+	if err != nil {
+		return nil, err
+	}
+	if paciente == nil {
+		return nil, ErrPacienteNotFound
+	}
+
+	programa := &models.ProgramaABA{
+		ID:               uuid.New(),
+		Nome:             strings.TrimSpace(req.Nome),
+		Descricao:        req.Descricao,
+		PacienteID:       req.PacienteID,
+		DataInicio:       time.Now(),
+		Status:           models.StatusProgramaAtivo,
+		ModeloProgramaID: &modelo.ID,
+	}
+	if programa.Nome == "" {
+		programa.Nome = modelo.Nome
+	}
+	if programa.Descricao == "" {
+		programa.Descricao = modelo.Descricao
+	}
+	if req.DataInicio != nil {
+		programa.DataInicio = *req.DataInicio
+	}
+
+	etapas := make([]*models.EtapaPrograma, 0, len(modelo.Etapas))
+	var alvos []*models.AlvoEtapa
+	for _, e := range modelo.Etapas {
+		etapa := &models.EtapaPrograma{
+			ID:              uuid.New(),
+			ProgramaID:      programa.ID,
+			Descricao:       e.Descricao,
+			CriterioSucesso: e.CriterioSucesso,
+			CriterioDominio: e.CriterioDominio,
+			RotacaoAlvos:    e.RotacaoAlvos,
+			Status:          models.StatusEtapaNaoIniciada,
+		}
+		etapas = append(etapas, etapa)
+
+		alvosEtapa := make([]*models.AlvoEtapa, 0, len(e.Alvos))
+		for _, a := range e.Alvos {
+			alvosEtapa = append(alvosEtapa, &models.AlvoEtapa{
+				EtapaProgramaID: etapa.ID,
+				Descricao:       a.Descricao,
+				Ordem:           a.Ordem,
+				Status:          models.StatusAlvoAguardando,
+			})
+		}
+		introduzirAlvos(etapa, alvosEtapa)
+		alvos = append(alvos, alvosEtapa...)
+	}
+	normalizarSequencia(etapas)
+
+	if err := s.repo.Instanciar(ctx, programa, etapas, alvos, modelo.ToHierarquiaPrompt(programa.ID)); err != nil {
+		return nil, err
+	}
+	return programa, nil
+}
+
+// ListProgramasModelo retorna os programas instanciados a partir de um modelo
+func (s *ModeloProgramaService) ListProgramasModelo(ctx context.Context, id uuid.UUID) ([]*models.ProgramaABA, error) {
+	if _, err := s.GetModelo(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.programaRepo.ListByModeloPrograma(ctx, id)
+}
+
+// UsoModelo retorna o uso de um modelo: os programas e pacientes que o utilizam e o tempo até o domínio
+func (s *ModeloProgramaService) UsoModelo(ctx context.Context, id uuid.UUID) (*models.UsoModeloPrograma, error) {
+	modelo, err := s.GetModelo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.uso(ctx, modelo)
+}
+
+// ListUsoModelos retorna o uso de cada modelo da biblioteca, opcionalmente de uma área, dos mais usados
+// para os menos usados
+func (s *ModeloProgramaService) ListUsoModelos(ctx context.Context, area string) ([]*models.UsoModeloPrograma, error) {
+	modelos, err := s.repo.List(ctx, area, false)
+	if err != nil {
+		return nil, err
+	}
+
+	usos := make([]*models.UsoModeloPrograma, 0, len(modelos))
+	for _, modelo := range modelos {
+		uso, err := s.uso(ctx, modelo)
+		if err != nil {
+			return nil, err
+		}
+		usos = append(usos, uso)
+	}
+	sort.SliceStable(usos, func(i, j int) bool { return usos[i].Programas > usos[j].Programas })
+	return usos, nil
+}
+
+// uso calcula o uso de um modelo a partir dos programas instanciados e das etapas de cada programa
+func (s *ModeloProgramaService) uso(ctx context.Context, modelo *models.ModeloPrograma) (*models.UsoModeloPrograma, error) {
+	programas, err := s.programaRepo.ListByModeloPrograma(ctx, modelo.ID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(programas))
+	for _, programa := range programas {
+		ids = append(ids, programa.ID)
+	}
+	etapas, err := s.etapaRepo.ListByProgramas(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	porPrograma := make(map[uuid.UUID][]*models.EtapaPrograma, len(programas))
+	for _, etapa := range etapas {
+		if etapa.IsAtiva() {
+			porPrograma[etapa.ProgramaID] = append(porPrograma[etapa.ProgramaID], etapa)
+		}
+	}
+
+	uso := &models.UsoModeloPrograma{
+		ModeloID:  modelo.ID,
+		Nome:      modelo.Nome,
+		Area:      modelo.Area,
+		Ativo:     modelo.Ativo,
+		Programas: len(programas),
+	}
+	pacientes := make(map[uuid.UUID]bool, len(programas))
+	var diasEtapa, diasPrograma []float64
+	for _, programa := range programas {
+		pacientes[programa.PacienteID] = true
+		if programa.Status == models.StatusProgramaAtivo {
+			uso.ProgramasAtivos++
+		}
+
+		ativas := porPrograma[programa.ID]
+		var dominadas []time.Time
+		for _, etapa := range ativas {
+			if etapa.Status == models.StatusEtapaDominada && etapa.DominadaEm != nil {
+				dominadas = append(dominadas, *etapa.DominadaEm)
+			}
+		}
+		sort.Slice(dominadas, func(i, j int) bool { return dominadas[i].Before(dominadas[j]) })
+
+		uso.EtapasTotais += len(ativas)
+		uso.EtapasDominadas += len(dominadas)
+		anterior := programa.DataInicio
+		for _, dominadaEm := range dominadas {
+			diasEtapa = append(diasEtapa, float64(diasEntre(anterior, dominadaEm)))
+			anterior = dominadaEm
+		}
+		if len(ativas) > 0 && len(dominadas) == len(ativas) {
+			uso.ProgramasDominados++
+			diasPrograma = append(diasPrograma, float64(diasEntre(programa.DataInicio, dominadas[len(dominadas)-1])))
+		}
+	}
+	uso.Pacientes = len(pacientes)
+	if len(diasEtapa) > 0 {
+		valor := media(diasEtapa)
+		uso.MediaDiasEtapa = &valor
+	}
+	if len(diasPrograma) > 0 {
+		valor := media(diasPrograma)
+		uso.MediaDiasPrograma = &valor
+	}
+	return uso, nil
+}
+
+// validarHierarquia verifica se a hierarquia informada não repete tipos de prompt e se todos existem
+func (s *ModeloProgramaService) validarHierarquia(ctx context.Context, req *models.DefinirHierarquiaPromptRequest) error {
+	if req == nil {
+		return nil
+	}
+
+	vistos := make(map[uuid.UUID]bool, len(req.TipoPromptIDs))
+	for _, id := range req.TipoPromptIDs {
+		if vistos[id] {
+			return ErrHierarquiaPromptInvalida
+		}
+		vistos[id] = true
+	}
+	prompts, err := s.promptRepo.ListByIDs(ctx, req.TipoPromptIDs)
+	if err != nil {
+		return err
+	}
+	if len(prompts) != len(req.TipoPromptIDs) {
+		return ErrTipoPromptNotFound
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrVinculoObjetivoNotFound      = errors.New("o programa não está vinculado ao objetivo")
+	ErrProgramaJaVinculado          = errors.New("o programa já está vinculado ao objetivo")
+	ErrProgramaOutroPaciente        = errors.New("o programa e o objetivo devem ser do mesmo paciente")
+	ErrConclusaoObjetivoNaoPendente = errors.New("o objetivo não tem conclusão pendente de confirmação")
+	ErrConclusaoObjetivoRestrita    = errors.New("apenas supervisores podem confirmar a conclusão de objetivos")
+)
+
+// ObjetivoProgramaService encapsula os vínculos entre objetivos terapêuticos e programas ABA, o progresso
+// dos objetivos derivado dos programas vinculados e a conclusão dos objetivos. Quando todos os programas de
+// um objetivo em progresso são finalizados, a conclusão fica pendente até a confirmação do supervisor.
+type ObjetivoProgramaService struct {
+	repo          repository.ObjetivoProgramaRepository
+	objetivoRepo  repository.ObjetivoTerapeuticoRepository
+	programaRepo  repository.ProgramaABARepository
+	etapaRepo     repository.EtapaProgramaRepository
+	auditoriaRepo repository.EventoAuditoriaRepository
+	transactor    repository.Transactor
+}
+
+// NewObjetivoProgramaService cria uma nova instância de ObjetivoProgramaService
+func NewObjetivoProgramaService(
+	repo repository.ObjetivoProgramaRepository,
+	objetivoRepo repository.ObjetivoTerapeuticoRepository,
+	programaRepo repository.ProgramaABARepository,
+	etapaRepo repository.EtapaProgramaRepository,
+	auditoriaRepo repository.EventoAuditoriaRepository,
+	transactor repository.Transactor,
+) *ObjetivoProgramaService {
+	return &ObjetivoProgramaService{
+		repo:          repo,
+		objetivoRepo:  objetivoRepo,
+		programaRepo:  programaRepo,
+		etapaRepo:     etapaRepo,
+		auditoriaRepo: auditoriaRepo,
+		transactor:    transactor,
+	}
+}
+
+// VincularPrograma vincula um programa ABA do paciente ao objetivo e reavalia a conclusão do objetivo
+func (s *ObjetivoProgramaService) VincularPrograma(ctx context.Context, objetivoID uuid.UUID, req *models.VincularProgramaObjetivoRequest, usuarioID string) (*models.ObjetivoPrograma, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	objetivo, err := s.getObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	programa, err := s.programaRepo.GetByID(ctx, req.ProgramaID)
+	if err != nil {
+		return nil, err
+	}
+	if programa == nil {
+		return nil, ErrProgramaNotFound
+	}
+	if programa.PacienteID != objetivo.PacienteID {
+		return nil, ErrProgramaOutroPaciente
+	}
+	existente, err := s.repo.Get(ctx, objetivoID, programa.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existente != nil {
+		return nil, ErrProgramaJaVinculado
+	}
+
+	vinculo := &models.ObjetivoPrograma{
+		ObjetivoID: objetivoID,
+		ProgramaID: programa.ID,
+		UsuarioID:  usuarioID,
+	}
+	if err := s.repo.Create(ctx, vinculo); err != nil {
+		return nil, err
+	}
+	if err := s.avaliarObjetivo(ctx, objetivo); err != nil {
+		return nil, err
+	}
+	return vinculo, nil
+}
+
+// DesvincularPrograma remove o vínculo entre o programa e o objetivo e reavalia a conclusão do objetivo
+func (s *ObjetivoProgramaService) DesvincularPrograma(ctx context.Context, objetivoID, programaID uuid.UUID) error {
+	objetivo, err := s.getObjetivo(ctx, objetivoID)
+	if err != nil {
+		return err
+	}
+	vinculo, err := s.repo.Get(ctx, objetivoID, programaID)
+	if err != nil {
+		return err
+	}
+	if vinculo == nil {
+		return ErrVinculoObjetivoNotFound
+	}
+
+	if err := s.repo.Delete(ctx, objetivoID, programaID); err != nil {
+		return err
+	}
+	return s.avaliarObjetivo(ctx, objetivo)
+}
+
+// ListProgramasObjetivo retorna os programas vinculados a um objetivo
+func (s *ObjetivoProgramaService) ListProgramasObjetivo(ctx context.Context, objetivoID uuid.UUID) ([]*models.ProgramaABA, error) {
+	if _, err := s.getObjetivo(ctx, objetivoID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListProgramasByObjetivo(ctx, objetivoID)
+}
+
+// ListObjetivosPrograma retorna os objetivos aos quais um programa serve
+func (s *ObjetivoProgramaService) ListObjetivosPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.ObjetivoTerapeutico, error) {
+	programa, err := s.programaRepo.GetByID(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	if programa == nil {
+		return nil, ErrProgramaNotFound
+	}
+	return s.repo.ListObjetivosByPrograma(ctx, programaID)
+}
+
+// GetProgresso calcula o progresso do objetivo a partir das etapas dominadas dos programas vinculados.
+// Programas finalizados contam como concluídos e o percentual do objetivo é a média dos programas.
+func (s *ObjetivoProgramaService) GetProgresso(ctx context.Context, objetivoID uuid.UUID) (*models.ProgressoProgramasObjetivo, error) {
+	objetivo, err := s.getObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	programas, err := s.repo.ListProgramasByObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(programas))
+	for _, programa := range programas {
+		ids = append(ids, programa.ID)
+	}
+	etapas, err := s.etapaRepo.ListByProgramas(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	progresso := &models.ProgressoProgramasObjetivo{
+		ObjetivoID:          objetivo.ID,
+		Status:              objetivo.Status,
+		Programas:           make([]models.ProgressoProgramaObjetivo, 0, len(programas)),
+		TotalProgramas:      len(programas),
+		ConclusaoPendente:   objetivo.ConclusaoPendente,
+		ConclusaoPendenteEm: objetivo.ConclusaoPendenteEm,
+	}
+	indice := make(map[uuid.UUID]int, len(programas))
+	for i, programa := range programas {
+		indice[programa.ID] = i
+		progresso.Programas = append(progresso.Programas, models.ProgressoProgramaObjetivo{
+			ProgramaID: programa.ID,
+			Nome:       programa.Nome,
+			Status:     programa.Status,
+		})
+	}
+	for _, etapa := range etapas {
+		if !etapa.IsAtiva() {
+			continue
+		}
+		item := &progresso.Programas[indice[etapa.ProgramaID]]
+		item.TotalEtapas++
+		if etapa.Status == models.StatusEtapaDominada {
+			item.EtapasDominadas++
+		}
+	}
+
+	percentuais := make([]float64, 0, len(programas))
+	for i := range progresso.Programas {
+		item := &progresso.Programas[i]
+		item.Percentual = percentual(item.EtapasDominadas, item.TotalEtapas)
+		if item.Status == models.StatusProgramaFinalizado {
+			item.Percentual = 100
+			progresso.ProgramasFinalizados++
+		}
+		percentuais = append(percentuais, item.Percentual)
+	}
+	if len(percentuais) > 0 {
+		progresso.Percentual = media(percentuais)
+	}
+	return progresso, nil
+}
+
+// ConfirmarConclusao registra a confirmação do supervisor para um objetivo com conclusão pendente,
+// passando o objetivo para concluído. A conclusão e o evento de auditoria são gravados na mesma transação,
+// e a gravação condicionada à conclusão pendente impede que duas confirmações simultâneas sejam registradas.
+func (s *ObjetivoProgramaService) ConfirmarConclusao(ctx context.Context, objetivoID uuid.UUID, usuarioID, papel string) (*models.ObjetivoTerapeutico, error) {
+	if papel != models.PapelSupervisor {
+		return nil, ErrConclusaoObjetivoRestrita
+	}
+	objetivo, err := s.getObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	if !objetivo.ConclusaoPendente {
+		return nil, ErrConclusaoObjetivoNaoPendente
+	}
+
+	agora := time.Now()
+	anterior := objetivo.Status
+	objetivo.Status = models.StatusObjetivoConcluido
+	objetivo.DataFim = agora
+	objetivo.ConclusaoPendente = false
+	objetivo.ConcluidoPor = usuarioID
+	objetivo.ConcluidoEm = &agora
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		confirmada, err := s.objetivoRepo.ConfirmarConclusao(ctx, objetivo)
+		if err != nil {
+			return err
+		}
+		if !confirmada {
+			return ErrConclusaoObjetivoNaoPendente
+		}
+		return s.auditoriaRepo.Create(ctx, &models.EventoAuditoria{
+			EntidadeTipo:   models.EntidadeAuditoriaObjetivoTerapeutico,
+			EntidadeID:     objetivo.ID,
+			StatusAnterior: string(anterior),
+			StatusNovo:     string(objetivo.Status),
+			Motivo:         "conclusão confirmada pelo supervisor depois da finalização de todos os programas vinculados",
+			UsuarioID:      usuarioID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objetivo, nil
+}
+
+// AvaliarConclusao reavalia a conclusão dos objetivos vinculados a um programa depois de uma mudança na
+// situação do programa
+func (s *ObjetivoProgramaService) AvaliarConclusao(ctx context.Context, programaID uuid.UUID) error {
+	objetivos, err := s.repo.ListObjetivosByPrograma(ctx, programaID)
+	if err != nil {
+		return err
+	}
+	for _, objetivo := range objetivos {
+		if err := s.avaliarObjetivo(ctx, objetivo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// avaliarObjetivo marca a conclusão do objetivo em progresso como pendente quando todos os programas
+// vinculados estão finalizados, e desfaz a marcação quando algum deixa de estar
+func (s *ObjetivoProgramaService) avaliarObjetivo(ctx context.Context, objetivo *models.ObjetivoTerapeutico) error {
+	pendente := false
+	if objetivo.Status == models.StatusObjetivoEmProgresso {
+		programas, err := s.repo.ListProgramasByObjetivo(ctx, objetivo.ID)
+		if err != nil {
+			return err
+		}
+		pendente = len(programas) > 0
+		for _, programa := range programas {
+			if programa.Status != models.StatusProgramaFinalizado {
+				pendente = false
+				break
+			}
+		}
+	}
+	if pendente == objetivo.ConclusaoPendente {
+		return nil
+	}
+
+	objetivo.ConclusaoPendente = pendente
+	objetivo.ConclusaoPendenteEm = nil
+	if pendente {
+		agora := time.Now()
+		objetivo.ConclusaoPendenteEm = &agora
+	}
+	return s.objetivoRepo.Update(ctx, objetivo)
+}
+
+// getObjetivo busca o objetivo terapêutico do vínculo
+func (s *ObjetivoProgramaService) getObjetivo(ctx context.Context, objetivoID uuid.UUID) (*models.ObjetivoTerapeutico, error) {
+	objetivo, err := s.objetivoRepo.GetByID(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	if objetivo == nil {
+		return nil, ErrObjetivoNotFound
+	}
+	return objetivo, nil
+}
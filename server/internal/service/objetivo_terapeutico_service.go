@@ -55,6 +55,18 @@ func (s *ObjetivoTerapeuticoService) UpdateObjetivo(ctx context.Context, objetiv
 		return nil, ErrObjetivoNotFound
 	}
 
+	// A sinalização é mantida pelo acompanhamento de progresso e não pode ser alterada diretamente
+	objetivo.Sinalizado = existing.Sinalizado
+	objetivo.SinalizadoEm = existing.SinalizadoEm
+	// A conclusão pendente é mantida pelos programas vinculados e só vale para objetivos em progresso
+	objetivo.ConclusaoPendente = existing.ConclusaoPendente && objetivo.Status == models.StatusObjetivoEmProgresso
+	objetivo.ConclusaoPendenteEm = nil
+	if objetivo.ConclusaoPendente {
+		objetivo.ConclusaoPendenteEm = existing.ConclusaoPendenteEm
+	}
+	objetivo.ConcluidoPor = existing.ConcluidoPor
+	objetivo.ConcluidoEm = existing.ConcluidoEm
+
 	if err := s.repo.Update(ctx, objetivo); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrObservacaoNotFound         = errors.New("observação por intervalos não encontrada")
+	ErrMetodoRegistroIncompativel = errors.New("o comportamento não é registrado por intervalos")
+	ErrSessaoForaDoPaciente       = errors.New("a sessão não pertence ao paciente do comportamento")
+)
+
+// ObservacaoIntervaloService encapsula a lógica de negócio das observações por intervalos
+// (intervalo parcial, intervalo total e amostragem momentânea)
+type ObservacaoIntervaloService struct {
+	repo              repository.ObservacaoIntervaloRepository
+	comportamentoRepo repository.ComportamentoAlvoRepository
+	sessaoRepo        repository.SessaoRepository
+}
+
+// NewObservacaoIntervaloService cria uma nova instância de ObservacaoIntervaloService
+func NewObservacaoIntervaloService(repo repository.ObservacaoIntervaloRepository, comportamentoRepo repository.ComportamentoAlvoRepository, sessaoRepo repository.SessaoRepository) *ObservacaoIntervaloService {
+	return &ObservacaoIntervaloService{repo: repo, comportamentoRepo: comportamentoRepo, sessaoRepo: sessaoRepo}
+}
+
+// CreateObservacao registra uma observação por intervalos de um comportamento alvo
+func (s *ObservacaoIntervaloService) CreateObservacao(ctx context.Context, comportamentoID uuid.UUID, req *models.CreateObservacaoIntervaloRequest, usuarioID string) (*models.ObservacaoIntervalo, error) {
+	if req == nil || len(req.Intervalos) == 0 {
+		return nil, ErrInvalidInput
+	}
+
+	comportamento, err := s.getComportamento(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validarSessaoComportamento(ctx, s.sessaoRepo, comportamento, req.SessaoID, req.Secundario); err != nil {
+		return nil, err
+	}
+
+	observacao := req.ToObservacaoIntervalo(comportamentoID, usuarioID)
+	if err := s.repo.Create(ctx, observacao); err != nil {
+		return nil, err
+	}
+	return observacao, nil
+}
+
+// GetObservacao busca uma observação pelo ID, garantindo que pertence ao comportamento informado
+func (s *ObservacaoIntervaloService) GetObservacao(ctx context.Context, comportamentoID, id uuid.UUID) (*models.ObservacaoIntervalo, error) {
+	observacao, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if observacao == nil || observacao.ComportamentoID != comportamentoID {
+		return nil, ErrObservacaoNotFound
+	}
+	return observacao, nil
+}
+
+// UpdateObservacao atualiza uma observação existente e recalcula o percentual de intervalos
+func (s *ObservacaoIntervaloService) UpdateObservacao(ctx context.Context, comportamentoID, id uuid.UUID, req *models.UpdateObservacaoIntervaloRequest) (*models.ObservacaoIntervalo, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	observacao, err := s.GetObservacao(ctx, comportamentoID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	observacao.ApplyUpdates(req)
+	if err := s.repo.Update(ctx, observacao); err != nil {
+		return nil, err
+	}
+	return observacao, nil
+}
+
+// DeleteObservacao exclui uma observação pelo ID
+func (s *ObservacaoIntervaloService) DeleteObservacao(ctx context.Context, comportamentoID, id uuid.UUID) error {
+	if _, err := s.GetObservacao(ctx, comportamentoID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListObservacoes retorna uma lista paginada das observações de um comportamento no intervalo [inicio, fim)
+func (s *ObservacaoIntervaloService) ListObservacoes(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, page, pageSize int) ([]*models.ObservacaoIntervalo, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, 0, ErrPeriodoInvalido
+	}
+	comportamento, err := s.comportamentoRepo.GetByID(ctx, comportamentoID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if comportamento == nil {
+		return nil, 0, ErrComportamentoNotFound
+	}
+
+	offset := (page - 1) * pageSize
+	observacoes, err := s.repo.ListByComportamento(ctx, comportamentoID, inicio, fim, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByComportamento(ctx, comportamentoID, inicio, fim)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return observacoes, total, nil
+}
+
+// getComportamento busca o comportamento alvo e garante que ele é registrado por intervalos
+func (s *ObservacaoIntervaloService) getComportamento(ctx context.Context, comportamentoID uuid.UUID) (*models.ComportamentoAlvo, error) {
+	comportamento, err := s.comportamentoRepo.GetByID(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if comportamento == nil {
+		return nil, ErrComportamentoNotFound
+	}
+	if comportamento.MetodoRegistro != models.MetodoRegistroIntervalo {
+		return nil, ErrMetodoRegistroIncompativel
+	}
+	return comportamento, nil
+}
+
+// validarSessaoComportamento garante que a sessão informada existe, não foi cancelada e pertence ao paciente
+// do comportamento. Dados do observador secundário exigem uma sessão com observador secundário definido.
+func validarSessaoComportamento(ctx context.Context, sessaoRepo repository.SessaoRepository, comportamento *models.ComportamentoAlvo, sessaoID *uuid.UUID, secundario bool) error {
+	if sessaoID == nil {
+		if secundario {
+			return ErrObservadorSecundarioAusente
+		}
+		return nil
+	}
+
+	sessao, err := sessaoRepo.GetByID(ctx, *sessaoID)
+	if err != nil {
+		return err
+	}
+	if sessao == nil {
+		return ErrSessaoNotFound
+	}
+	if sessao.Status == models.StatusSessaoCancelada {
+		return ErrSessaoCancelada
+	}
+	if sessao.PacienteID != comportamento.PacienteID {
+		return ErrSessaoForaDoPaciente
+	}
+	if secundario && sessao.ObservadorSecundarioID == nil {
+		return ErrObservadorSecundarioAusente
+	}
+	return nil
+}
@@ -0,0 +1,535 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/documento"
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrPlanoIntervencaoNotFound      = errors.New("plano de intervenção não encontrado")
+	ErrVersaoPlanoNotFound           = errors.New("versão do plano de intervenção não encontrada")
+	ErrPlanoIntervencaoPublicado     = errors.New("o plano de intervenção já possui versões publicadas e não pode ser excluído")
+	ErrVersaoPlanoBloqueada          = errors.New("apenas a versão em rascunho do plano pode ser alterada ou publicada")
+	ErrVersaoPlanoRascunhoExistente  = errors.New("o plano de intervenção já possui uma versão em rascunho")
+	ErrVersaoPlanoIncompleta         = errors.New("a versão precisa de comportamentos alvo, estratégias antecedentes, comportamentos substitutos, estratégias de consequência e procedimentos de crise para ser publicada")
+	ErrVersaoPlanoNaoPublicada       = errors.New("a ciência só pode ser registrada em versões publicadas do plano")
+	ErrCienciaPlanoDuplicada         = errors.New("esta pessoa já registrou ciência nesta versão do plano")
+	ErrAvaliacaoFuncionalNaoAprovada = errors.New("o plano de intervenção só pode ser vinculado a uma avaliação funcional aprovada do paciente")
+	ErrProgramaForaDoPaciente        = errors.New("o programa ABA não pertence ao paciente")
+	ErrProgramasPlanoDuplicado       = errors.New("o mesmo programa ABA foi informado mais de uma vez")
+)
+
+// PlanoIntervencaoService encapsula a lógica de negócio dos planos de intervenção comportamental (BIP):
+// o versionamento do conteúdo, a publicação, as ciências dos responsáveis e do supervisor e o documento
+// de cada versão
+type PlanoIntervencaoService struct {
+	repo                   repository.PlanoIntervencaoRepository
+	pacienteRepo           repository.PacienteRepository
+	comportamentoRepo      repository.ComportamentoAlvoRepository
+	programaRepo           repository.ProgramaABARepository
+	avaliacaoFuncionalRepo repository.AvaliacaoFuncionalRepository
+	auditoriaRepo          repository.EventoAuditoriaRepository
+	transactor             repository.Transactor
+}
+
+// NewPlanoIntervencaoService cria uma nova instância de PlanoIntervencaoService
+func NewPlanoIntervencaoService(
+	repo repository.PlanoIntervencaoRepository,
+	pacienteRepo repository.PacienteRepository,
+	comportamentoRepo repository.ComportamentoAlvoRepository,
+	programaRepo repository.ProgramaABARepository,
+	avaliacaoFuncionalRepo repository.AvaliacaoFuncionalRepository,
+	auditoriaRepo repository.EventoAuditoriaRepository,
+	transactor repository.Transactor,
+) *PlanoIntervencaoService {
+	return &PlanoIntervencaoService{
+		repo:                   repo,
+		pacienteRepo:           pacienteRepo,
+		comportamentoRepo:      comportamentoRepo,
+		programaRepo:           programaRepo,
+		avaliacaoFuncionalRepo: avaliacaoFuncionalRepo,
+		auditoriaRepo:          auditoriaRepo,
+		transactor:             transactor,
+	}
+}
+
+// CreatePlano cria um plano de intervenção com a primeira versão em rascunho. Quando vinculado a uma
+// avaliação funcional aprovada, os comportamentos e a função não informados são copiados da avaliação.
+func (s *PlanoIntervencaoService) CreatePlano(ctx context.Context, pacienteID uuid.UUID, req *models.CreatePlanoIntervencaoRequest, usuarioID string) (*models.PlanoIntervencao, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	plano := req.ToPlanoIntervencao(pacienteID, usuarioID)
+	versao := req.ToVersaoPlanoIntervencao(usuarioID)
+	comportamentoIDs := req.ComportamentoIDs
+	if req.AvaliacaoFuncionalID != nil {
+		avaliacao, err := s.avaliacaoFuncionalRepo.GetByID(ctx, *req.AvaliacaoFuncionalID)
+		if err != nil {
+			return nil, err
+		}
+		if avaliacao == nil || avaliacao.PacienteID != pacienteID || avaliacao.Status != models.StatusAvaliacaoFuncionalAprovada {
+			return nil, ErrAvaliacaoFuncionalNaoAprovada
+		}
+		if len(comportamentoIDs) == 0 {
+			comportamentoIDs = avaliacao.ComportamentoIDs()
+		}
+		if versao.FuncaoHipotetica == nil {
+			versao.FuncaoHipotetica = avaliacao.FuncaoHipotetica
+		}
+	}
+
+	if err := s.vincular(ctx, pacienteID, versao, comportamentoIDs, req.ProgramaIDs); err != nil {
+		return nil, err
+	}
+	plano.Versoes = []models.VersaoPlanoIntervencao{*versao}
+
+	if err := s.repo.Create(ctx, plano); err != nil {
+		return nil, err
+	}
+	return plano, nil
+}
+
+// GetPlano busca um plano pelo ID, garantindo que pertence ao paciente informado
+func (s *PlanoIntervencaoService) GetPlano(ctx context.Context, pacienteID, id uuid.UUID) (*models.PlanoIntervencao, error) {
+	plano, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if plano == nil || plano.PacienteID != pacienteID {
+		return nil, ErrPlanoIntervencaoNotFound
+	}
+	return plano, nil
+}
+
+// UpdatePlano atualiza os dados do plano que não fazem parte das versões
+func (s *PlanoIntervencaoService) UpdatePlano(ctx context.Context, pacienteID, id uuid.UUID, req *models.UpdatePlanoIntervencaoRequest) (*models.PlanoIntervencao, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	plano, err := s.GetPlano(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	plano.ApplyUpdates(req)
+	if err := s.repo.Update(ctx, plano); err != nil {
+		return nil, err
+	}
+	return plano, nil
+}
+
+// DeletePlano exclui um plano que ainda não teve nenhuma versão publicada
+func (s *PlanoIntervencaoService) DeletePlano(ctx context.Context, pacienteID, id uuid.UUID) error {
+	plano, err := s.GetPlano(ctx, pacienteID, id)
+	if err != nil {
+		return err
+	}
+	for _, versao := range plano.Versoes {
+		if versao.Status != models.StatusVersaoPlanoRascunho {
+			return ErrPlanoIntervencaoPublicado
+		}
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListPlanos retorna uma lista paginada dos planos de um paciente
+func (s *PlanoIntervencaoService) ListPlanos(ctx context.Context, pacienteID uuid.UUID, page, pageSize int) ([]*models.PlanoIntervencao, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	planos, err := s.repo.ListByPaciente(ctx, pacienteID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByPaciente(ctx, pacienteID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return planos, total, nil
+}
+
+// ListPlanosComportamento retorna os planos com alguma versão que trata o comportamento alvo
+func (s *PlanoIntervencaoService) ListPlanosComportamento(ctx context.Context, comportamentoID uuid.UUID) ([]*models.PlanoIntervencao, error) {
+	comportamento, err := s.comportamentoRepo.GetByID(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if comportamento == nil {
+		return nil, ErrComportamentoNotFound
+	}
+	return s.repo.ListByComportamento(ctx, comportamentoID)
+}
+
+// ListPlanosPrograma retorna os planos com alguma versão que inclui o programa ABA como habilidade substituta
+func (s *PlanoIntervencaoService) ListPlanosPrograma(ctx context.Context, programaID uuid.UUID) ([]*models.PlanoIntervencao, error) {
+	programa, err := s.programaRepo.GetByID(ctx, programaID)
+	if err != nil {
+		return nil, err
+	}
+	if programa == nil {
+		return nil, ErrProgramaNotFound
+	}
+	return s.repo.ListByPrograma(ctx, programaID)
+}
+
+// CreateVersao cria o rascunho da próxima versão do plano a partir da versão mais recente, aplicando
+// as alterações informadas. O plano só pode ter uma versão em rascunho por vez.
+func (s *PlanoIntervencaoService) CreateVersao(ctx context.Context, pacienteID, planoID uuid.UUID, req *models.VersaoPlanoIntervencaoRequest, usuarioID string) (*models.VersaoPlanoIntervencao, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	plano, err := s.GetPlano(ctx, pacienteID, planoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(plano.Versoes) == 0 {
+		return nil, ErrVersaoPlanoNotFound
+	}
+	ultima := plano.Versoes[0]
+	if ultima.Status == models.StatusVersaoPlanoRascunho {
+		return nil, ErrVersaoPlanoRascunhoExistente
+	}
+
+	versao := ultima.NovaVersao(usuarioID)
+	versao.ApplyUpdates(req)
+	if err := s.vincularAlteracoes(ctx, pacienteID, versao, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateVersao(ctx, versao); err != nil {
+		return nil, err
+	}
+	return versao, nil
+}
+
+// GetVersao busca uma versão do plano pelo número
+func (s *PlanoIntervencaoService) GetVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int) (*models.VersaoPlanoIntervencao, error) {
+	if _, err := s.GetPlano(ctx, pacienteID, planoID); err != nil {
+		return nil, err
+	}
+
+	versao, err := s.repo.GetVersao(ctx, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	if versao == nil {
+		return nil, ErrVersaoPlanoNotFound
+	}
+	return versao, nil
+}
+
+// UpdateVersao atualiza a versão em rascunho do plano
+func (s *PlanoIntervencaoService) UpdateVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int, req *models.VersaoPlanoIntervencaoRequest) (*models.VersaoPlanoIntervencao, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	versao, err := s.GetVersao(ctx, pacienteID, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	if versao.Status != models.StatusVersaoPlanoRascunho {
+		return nil, ErrVersaoPlanoBloqueada
+	}
+
+	versao.ApplyUpdates(req)
+	if err := s.vincularAlteracoes(ctx, pacienteID, versao, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateVersao(ctx, versao); err != nil {
+		return nil, err
+	}
+	return versao, nil
+}
+
+// PublicarVersao torna vigente a versão em rascunho, substituindo a versão vigente anterior. A substituição,
+// a publicação e os eventos de auditoria são gravados na mesma transação, e cada mudança de situação é
+// condicionada à situação lida, para que publicações simultâneas não tornem duas versões vigentes.
+func (s *PlanoIntervencaoService) PublicarVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int, usuarioID string) (*models.VersaoPlanoIntervencao, error) {
+	var versao *models.VersaoPlanoIntervencao
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		plano, err := s.GetPlano(ctx, pacienteID, planoID)
+		if err != nil {
+			return err
+		}
+		versao, err = s.GetVersao(ctx, pacienteID, planoID, numero)
+		if err != nil {
+			return err
+		}
+		if versao.Status != models.StatusVersaoPlanoRascunho {
+			return ErrVersaoPlanoBloqueada
+		}
+		if len(versao.Comportamentos) == 0 ||
+			strings.TrimSpace(versao.EstrategiasAntecedentes) == "" ||
+			strings.TrimSpace(versao.ComportamentosSubstitutos) == "" ||
+			strings.TrimSpace(versao.EstrategiasConsequencia) == "" ||
+			strings.TrimSpace(versao.ProcedimentosCrise) == "" {
+			return ErrVersaoPlanoIncompleta
+		}
+
+		agora := time.Now()
+		versao.Status = models.StatusVersaoPlanoVigente
+		versao.PublicadaEm = &agora
+		publicada, err := s.repo.UpdateSituacaoVersao(ctx, versao, models.StatusVersaoPlanoRascunho)
+		if err != nil {
+			return err
+		}
+		if !publicada {
+			return ErrVersaoPlanoBloqueada
+		}
+
+		for i := range plano.Versoes {
+			anterior := &plano.Versoes[i]
+			if anterior.ID == versao.ID || anterior.Status != models.StatusVersaoPlanoVigente {
+				continue
+			}
+			anterior.Status = models.StatusVersaoPlanoSubstituida
+			anterior.SubstituidaEm = &agora
+			substituida, err := s.repo.UpdateSituacaoVersao(ctx, anterior, models.StatusVersaoPlanoVigente)
+			if err != nil {
+				return err
+			}
+			if !substituida {
+				continue
+			}
+			motivo := fmt.Sprintf("substituída pela versão %d", versao.Numero)
+			if err := s.registrarTransicao(ctx, anterior, models.StatusVersaoPlanoVigente, motivo, usuarioID); err != nil {
+				return err
+			}
+		}
+		return s.registrarTransicao(ctx, versao, models.StatusVersaoPlanoRascunho, "versão publicada", usuarioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versao, nil
+}
+
+// RegistrarCiencia registra a ciência de um responsável ou do supervisor em uma versão publicada do plano
+func (s *PlanoIntervencaoService) RegistrarCiencia(ctx context.Context, pacienteID, planoID uuid.UUID, numero int, req *models.CienciaPlanoIntervencaoRequest, usuarioID string) (*models.VersaoPlanoIntervencao, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	versao, err := s.GetVersao(ctx, pacienteID, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	if versao.Status == models.StatusVersaoPlanoRascunho {
+		return nil, ErrVersaoPlanoNaoPublicada
+	}
+	for _, ciencia := range versao.Ciencias {
+		if ciencia.Tipo == req.Tipo && strings.EqualFold(strings.TrimSpace(ciencia.Nome), strings.TrimSpace(req.Nome)) {
+			return nil, ErrCienciaPlanoDuplicada
+		}
+	}
+
+	ciencia := req.ToCienciaPlanoIntervencao(versao.ID, usuarioID)
+	if err := s.repo.CreateCiencia(ctx, ciencia); err != nil {
+		return nil, err
+	}
+	versao.Ciencias = append(versao.Ciencias, *ciencia)
+	return versao, nil
+}
+
+// ListHistorico retorna os eventos de auditoria das publicações e substituições das versões do plano
+func (s *PlanoIntervencaoService) ListHistorico(ctx context.Context, pacienteID, planoID uuid.UUID) ([]*models.EventoAuditoria, error) {
+	plano, err := s.GetPlano(ctx, pacienteID, planoID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(plano.Versoes))
+	for _, versao := range plano.Versoes {
+		ids = append(ids, versao.ID)
+	}
+	return s.auditoriaRepo.ListByEntidades(ctx, ids)
+}
+
+// DocumentoVersao monta o documento do plano na versão informada, com os comportamentos alvo, os programas
+// de habilidades substitutas, as estratégias e as ciências registradas
+func (s *PlanoIntervencaoService) DocumentoVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int) (*documento.Documento, error) {
+	plano, err := s.GetPlano(ctx, pacienteID, planoID)
+	if err != nil {
+		return nil, err
+	}
+	versao, err := s.GetVersao(ctx, pacienteID, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	paciente, err := s.pacienteRepo.GetByID(ctx, pacienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &documento.Documento{
+		Titulo:    "Plano de intervenção comportamental",
+		Subtitulo: plano.Titulo,
+		Rodape:    "Documento gerado em " + time.Now().Format("02/01/2006 15:04"),
+	}
+	if paciente != nil {
+		d.Campos = append(d.Campos, documento.Campo{Rotulo: "Paciente", Valor: paciente.Nome})
+	}
+	d.Campos = append(d.Campos, documento.Campo{Rotulo: "Versão", Valor: fmt.Sprintf("%d (%s)", versao.Numero, rotuloStatusVersaoPlano(versao.Status))})
+	if versao.PublicadaEm != nil {
+		d.Campos = append(d.Campos, documento.Campo{Rotulo: "Publicada em", Valor: versao.PublicadaEm.Format("02/01/2006")})
+	}
+	if versao.FuncaoHipotetica != nil {
+		d.Campos = append(d.Campos, documento.Campo{Rotulo: "Função hipotética", Valor: rotuloFuncaoComportamento(*versao.FuncaoHipotetica)})
+	}
+
+	comportamentos := documento.Secao{Titulo: "Comportamentos alvo"}
+	for _, item := range versao.Comportamentos {
+		comportamento, err := s.comportamentoRepo.GetByID(ctx, item.ComportamentoID)
+		if err != nil {
+			return nil, err
+		}
+		if comportamento != nil {
+			comportamentos.Itens = append(comportamentos.Itens, comportamento.Descricao)
+		}
+	}
+	substitutos := documento.Secao{Titulo: "Comportamentos substitutos", Texto: versao.ComportamentosSubstitutos}
+	for _, item := range versao.Programas {
+		programa, err := s.programaRepo.GetByID(ctx, item.ProgramaID)
+		if err != nil {
+			return nil, err
+		}
+		if programa != nil {
+			substitutos.Itens = append(substitutos.Itens, "Programa: "+programa.Nome)
+		}
+	}
+	d.Secoes = []documento.Secao{
+		comportamentos,
+		{Titulo: "Estratégias antecedentes", Texto: versao.EstrategiasAntecedentes},
+		substitutos,
+		{Titulo: "Estratégias de consequência", Texto: versao.EstrategiasConsequencia},
+		{Titulo: "Procedimentos de crise", Texto: versao.ProcedimentosCrise},
+	}
+	if strings.TrimSpace(versao.Observacoes) != "" {
+		d.Secoes = append(d.Secoes, documento.Secao{Titulo: "Observações", Texto: versao.Observacoes})
+	}
+
+	for _, ciencia := range versao.Ciencias {
+		papel := "Responsável"
+		if ciencia.Tipo == models.TipoCienciaPlanoSupervisor {
+			papel = "Supervisor"
+		}
+		d.Assinaturas = append(d.Assinaturas, documento.Assinatura{Papel: papel, Nome: ciencia.Nome, Detalhe: ciencia.Vinculo, Data: ciencia.CreatedAt})
+	}
+	return d, nil
+}
+
+// vincular valida e associa à versão os comportamentos alvo inadequados e os programas ABA do paciente
+func (s *PlanoIntervencaoService) vincular(ctx context.Context, pacienteID uuid.UUID, versao *models.VersaoPlanoIntervencao, comportamentoIDs, programaIDs []uuid.UUID) error {
+	if err := validarComportamentosInadequados(ctx, s.comportamentoRepo, pacienteID, comportamentoIDs); err != nil {
+		return err
+	}
+	versao.Comportamentos = make([]models.ComportamentoPlanoIntervencao, 0, len(comportamentoIDs))
+	for i, id := range comportamentoIDs {
+		versao.Comportamentos = append(versao.Comportamentos, models.ComportamentoPlanoIntervencao{ComportamentoID: id, Ordem: i + 1})
+	}
+
+	vistos := make(map[uuid.UUID]bool, len(programaIDs))
+	versao.Programas = make([]models.ProgramaPlanoIntervencao, 0, len(programaIDs))
+	for i, id := range programaIDs {
+		if vistos[id] {
+			return ErrProgramasPlanoDuplicado
+		}
+		vistos[id] = true
+
+		programa, err := s.programaRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if programa == nil {
+			return ErrProgramaNotFound
+		}
+		if programa.PacienteID != pacienteID {
+			return ErrProgramaForaDoPaciente
+		}
+		versao.Programas = append(versao.Programas, models.ProgramaPlanoIntervencao{ProgramaID: id, Ordem: i + 1})
+	}
+	return nil
+}
+
+// vincularAlteracoes substitui os comportamentos e os programas da versão quando informados na requisição
+func (s *PlanoIntervencaoService) vincularAlteracoes(ctx context.Context, pacienteID uuid.UUID, versao *models.VersaoPlanoIntervencao, req *models.VersaoPlanoIntervencaoRequest) error {
+	if req.ComportamentoIDs == nil && req.ProgramaIDs == nil {
+		return nil
+	}
+
+	comportamentoIDs := req.ComportamentoIDs
+	if comportamentoIDs == nil {
+		for _, comportamento := range versao.Comportamentos {
+			comportamentoIDs = append(comportamentoIDs, comportamento.ComportamentoID)
+		}
+	}
+	programaIDs := req.ProgramaIDs
+	if programaIDs == nil {
+		for _, programa := range versao.Programas {
+			programaIDs = append(programaIDs, programa.ProgramaID)
+		}
+	}
+	return s.vincular(ctx, pacienteID, versao, comportamentoIDs, programaIDs)
+}
+
+// registrarTransicao grava o evento de auditoria da mudança de situação de uma versão do plano
+func (s *PlanoIntervencaoService) registrarTransicao(ctx context.Context, versao *models.VersaoPlanoIntervencao, anterior models.StatusVersaoPlano, motivo, usuarioID string) error {
+	return s.auditoriaRepo.Create(ctx, &models.EventoAuditoria{
+		EntidadeTipo:   models.EntidadeAuditoriaVersaoPlanoIntervencao,
+		EntidadeID:     versao.ID,
+		StatusAnterior: string(anterior),
+		StatusNovo:     string(versao.Status),
+		Motivo:         motivo,
+		UsuarioID:      usuarioID,
+	})
+}
+
+// rotuloStatusVersaoPlano retorna o rótulo da situação da versão usado no documento
+func rotuloStatusVersaoPlano(status models.StatusVersaoPlano) string {
+	switch status {
+	case models.StatusVersaoPlanoVigente:
+		return "vigente"
+	case models.StatusVersaoPlanoSubstituida:
+		return "substituída"
+	default:
+		return "rascunho"
+	}
+}
+
+// rotuloFuncaoComportamento retorna o rótulo da função hipotética usado nos documentos
+func rotuloFuncaoComportamento(funcao models.FuncaoComportamento) string {
+	switch funcao {
+	case models.FuncaoComportamentoFuga:
+		return "Fuga/esquiva"
+	case models.FuncaoComportamentoAtencao:
+		return "Atenção"
+	case models.FuncaoComportamentoTangivel:
+		return "Acesso a tangíveis"
+	case models.FuncaoComportamentoAutomatica:
+		return "Automática"
+	default:
+		return string(funcao)
+	}
+}
@@ -0,0 +1,751 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/documento"
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// DiasLembreteRevisaoPadrao é a antecedência padrão, em dias, dos lembretes de revisão dos planos terapêuticos
+const DiasLembreteRevisaoPadrao = 30
+
+// Erros comuns do serviço
+var (
+	ErrPlanoTerapeuticoNotFound         = errors.New("plano terapêutico não encontrado")
+	ErrVersaoPlanoTerapeuticoNotFound   = errors.New("versão do plano terapêutico não encontrada")
+	ErrPlanoTerapeuticoAprovado         = errors.New("o plano terapêutico já possui versões aprovadas e não pode ser excluído")
+	ErrVersaoPlanoTerapeuticoBloqueada  = errors.New("apenas a versão em rascunho do plano pode ser alterada ou enviada para aprovação")
+	ErrVersaoPlanoTerapeuticoEmAberto   = errors.New("o plano terapêutico já possui uma versão em rascunho ou em aprovação")
+	ErrVersaoPlanoTerapeuticoIncompleta = errors.New("a versão precisa de objetivos terapêuticos e da carga horária das terapias para ser enviada para aprovação")
+	ErrVersaoPlanoTerapeuticoNaoEnviada = errors.New("apenas versões enviadas para aprovação podem ser aprovadas ou devolvidas")
+	ErrVersaoPlanoTerapeuticoNaoVigente = errors.New("o aceite só pode ser registrado na versão vigente do plano")
+	ErrAceitePlanoTerapeuticoDuplicado  = errors.New("esta pessoa já registrou o aceite nesta versão do plano")
+	ErrDataRevisaoPlanoInvalida         = errors.New("a data de revisão do plano deve ser posterior à data atual")
+	ErrItensPlanoTerapeuticoDuplicados  = errors.New("o mesmo objetivo, programa ou terapia foi informado mais de uma vez")
+	ErrObjetivoForaDoPaciente           = errors.New("o objetivo terapêutico não pertence ao paciente")
+	ErrAprovacaoPlanoRestrita           = errors.New("apenas supervisores podem aprovar versões do plano terapêutico")
+)
+
+// PlanoTerapeuticoService encapsula a lógica de negócio dos planos terapêuticos singulares (PTS): o
+// versionamento do conteúdo com a cópia dos objetivos e dos programas do paciente, a aprovação do
+// supervisor, os aceites dos responsáveis, o documento de cada versão e os lembretes de revisão
+type PlanoTerapeuticoService struct {
+	repo          repository.PlanoTerapeuticoRepository
+	pacienteRepo  repository.PacienteRepository
+	objetivoRepo  repository.ObjetivoTerapeuticoRepository
+	programaRepo  repository.ProgramaABARepository
+	etapaRepo     repository.EtapaProgramaRepository
+	terapiaRepo   repository.TerapiaRepository
+	objetivos     *ObjetivoProgramaService
+	auditoriaRepo repository.EventoAuditoriaRepository
+	transactor    repository.Transactor
+}
+
+// NewPlanoTerapeuticoService cria uma nova instância de PlanoTerapeuticoService
+func NewPlanoTerapeuticoService(
+	repo repository.PlanoTerapeuticoRepository,
+	pacienteRepo repository.PacienteRepository,
+	objetivoRepo repository.ObjetivoTerapeuticoRepository,
+	programaRepo repository.ProgramaABARepository,
+	etapaRepo repository.EtapaProgramaRepository,
+	terapiaRepo repository.TerapiaRepository,
+	objetivos *ObjetivoProgramaService,
+	auditoriaRepo repository.EventoAuditoriaRepository,
+	transactor repository.Transactor,
+) *PlanoTerapeuticoService {
+	return &PlanoTerapeuticoService{
+		repo:          repo,
+		pacienteRepo:  pacienteRepo,
+		objetivoRepo:  objetivoRepo,
+		programaRepo:  programaRepo,
+		etapaRepo:     etapaRepo,
+		terapiaRepo:   terapiaRepo,
+		objetivos:     objetivos,
+		auditoriaRepo: auditoriaRepo,
+		transactor:    transactor,
+	}
+}
+
+// CreatePlano cria um plano terapêutico com a primeira versão em rascunho. Sem objetivos ou programas
+// informados, a versão copia os objetivos em progresso e os programas ativos do paciente.
+func (s *PlanoTerapeuticoService) CreatePlano(ctx context.Context, pacienteID uuid.UUID, req *models.CreatePlanoTerapeuticoRequest, usuarioID string) (*models.PlanoTerapeutico, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	paciente, err := s.pacienteRepo.GetByID(ctx, pacienteID)
+	if err != nil {
+		return nil, err
+	}
+	if paciente == nil {
+		return nil, ErrPacienteNotFound
+	}
+
+	objetivoIDs := req.ObjetivoIDs
+	if objetivoIDs == nil {
+		objetivos, err := s.objetivoRepo.ListByPacienteAndStatus(ctx, pacienteID, models.StatusObjetivoEmProgresso)
+		if err != nil {
+			return nil, err
+		}
+		for _, objetivo := range objetivos {
+			objetivoIDs = append(objetivoIDs, objetivo.ID)
+		}
+	}
+	programaIDs := req.ProgramaIDs
+	if programaIDs == nil {
+		programas, err := s.programaRepo.ListByPacienteAndStatus(ctx, pacienteID, models.StatusProgramaAtivo)
+		if err != nil {
+			return nil, err
+		}
+		for _, programa := range programas {
+			programaIDs = append(programaIDs, programa.ID)
+		}
+	}
+
+	plano := req.ToPlanoTerapeutico(pacienteID, usuarioID)
+	versao := req.ToVersaoPlanoTerapeutico(usuarioID)
+	if err := s.copiarRegistros(ctx, pacienteID, versao, objetivoIDs, programaIDs); err != nil {
+		return nil, err
+	}
+	if err := s.definirCargasHorarias(ctx, versao, req.CargasHorarias); err != nil {
+		return nil, err
+	}
+	plano.Versoes = []models.VersaoPlanoTerapeutico{*versao}
+
+	if err := s.repo.Create(ctx, plano); err != nil {
+		return nil, err
+	}
+	return plano, nil
+}
+
+// GetPlano busca um plano pelo ID, garantindo que pertence ao paciente informado
+func (s *PlanoTerapeuticoService) GetPlano(ctx context.Context, pacienteID, id uuid.UUID) (*models.PlanoTerapeutico, error) {
+	plano, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if plano == nil || plano.PacienteID != pacienteID {
+		return nil, ErrPlanoTerapeuticoNotFound
+	}
+	return plano, nil
+}
+
+// UpdatePlano atualiza os dados do plano que não fazem parte das versões
+func (s *PlanoTerapeuticoService) UpdatePlano(ctx context.Context, pacienteID, id uuid.UUID, req *models.UpdatePlanoTerapeuticoRequest) (*models.PlanoTerapeutico, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	plano, err := s.GetPlano(ctx, pacienteID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	plano.ApplyUpdates(req)
+	if err := s.repo.Update(ctx, plano); err != nil {
+		return nil, err
+	}
+	return plano, nil
+}
+
+// DeletePlano exclui um plano que ainda não teve nenhuma versão aprovada
+func (s *PlanoTerapeuticoService) DeletePlano(ctx context.Context, pacienteID, id uuid.UUID) error {
+	plano, err := s.GetPlano(ctx, pacienteID, id)
+	if err != nil {
+		return err
+	}
+	for _, versao := range plano.Versoes {
+		if versao.AprovadaEm != nil {
+			return ErrPlanoTerapeuticoAprovado
+		}
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListPlanos retorna uma lista paginada dos planos de um paciente
+func (s *PlanoTerapeuticoService) ListPlanos(ctx context.Context, pacienteID uuid.UUID, page, pageSize int) ([]*models.PlanoTerapeutico, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	planos, err := s.repo.ListByPaciente(ctx, pacienteID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByPaciente(ctx, pacienteID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return planos, total, nil
+}
+
+// CreateVersao cria o rascunho da próxima versão do plano a partir da versão mais recente, copiando
+// novamente os objetivos e os programas e aplicando as alterações informadas. O plano só pode ter uma
+// versão em rascunho ou em aprovação por vez.
+func (s *PlanoTerapeuticoService) CreateVersao(ctx context.Context, pacienteID, planoID uuid.UUID, req *models.VersaoPlanoTerapeuticoRequest, usuarioID string) (*models.VersaoPlanoTerapeutico, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	plano, err := s.GetPlano(ctx, pacienteID, planoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(plano.Versoes) == 0 {
+		return nil, ErrVersaoPlanoTerapeuticoNotFound
+	}
+	ultima := plano.Versoes[0]
+	if ultima.Status == models.StatusVersaoPlanoTerapeuticoRascunho || ultima.Status == models.StatusVersaoPlanoTerapeuticoEmAprovacao {
+		return nil, ErrVersaoPlanoTerapeuticoEmAberto
+	}
+
+	versao := ultima.NovaVersao(usuarioID)
+	versao.ApplyUpdates(req)
+	if err := s.aplicarAlteracoes(ctx, pacienteID, versao, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateVersao(ctx, versao); err != nil {
+		return nil, err
+	}
+	return versao, nil
+}
+
+// GetVersao busca uma versão do plano pelo número
+func (s *PlanoTerapeuticoService) GetVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int) (*models.VersaoPlanoTerapeutico, error) {
+	if _, err := s.GetPlano(ctx, pacienteID, planoID); err != nil {
+		return nil, err
+	}
+
+	versao, err := s.repo.GetVersao(ctx, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	if versao == nil {
+		return nil, ErrVersaoPlanoTerapeuticoNotFound
+	}
+	return versao, nil
+}
+
+// UpdateVersao atualiza a versão em rascunho do plano
+func (s *PlanoTerapeuticoService) UpdateVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int, req *models.VersaoPlanoTerapeuticoRequest) (*models.VersaoPlanoTerapeutico, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	versao, err := s.GetVersao(ctx, pacienteID, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	if versao.Status != models.StatusVersaoPlanoTerapeuticoRascunho {
+		return nil, ErrVersaoPlanoTerapeuticoBloqueada
+	}
+
+	versao.ApplyUpdates(req)
+	if err := s.aplicarAlteracoes(ctx, pacienteID, versao, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateVersao(ctx, versao); err != nil {
+		return nil, err
+	}
+	return versao, nil
+}
+
+// EnviarVersao envia a versão em rascunho para aprovação do supervisor, copiando novamente os objetivos e
+// os programas para que a versão aprovada reflita a situação atual do paciente
+func (s *PlanoTerapeuticoService) EnviarVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int, usuarioID string) (*models.VersaoPlanoTerapeutico, error) {
+	versao, err := s.GetVersao(ctx, pacienteID, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	if versao.Status != models.StatusVersaoPlanoTerapeuticoRascunho {
+		return nil, ErrVersaoPlanoTerapeuticoBloqueada
+	}
+	if len(versao.Objetivos) == 0 || len(versao.CargasHorarias) == 0 {
+		return nil, ErrVersaoPlanoTerapeuticoIncompleta
+	}
+	if !versao.DataRevisao.After(time.Now()) {
+		return nil, ErrDataRevisaoPlanoInvalida
+	}
+	if err := s.copiarRegistros(ctx, pacienteID, versao, objetivoIDsVersao(versao), programaIDsVersao(versao)); err != nil {
+		return nil, err
+	}
+
+	agora := time.Now()
+	versao.Status = models.StatusVersaoPlanoTerapeuticoEmAprovacao
+	versao.EnviadaEm = &agora
+	versao.MotivoDevolucao = ""
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		// A mudança condicional de situação impede que dois envios simultâneos gravem a mesma transição
+		enviada, err := s.repo.UpdateSituacaoVersao(ctx, versao, models.StatusVersaoPlanoTerapeuticoRascunho)
+		if err != nil {
+			return err
+		}
+		if !enviada {
+			return ErrVersaoPlanoTerapeuticoBloqueada
+		}
+		if err := s.repo.UpdateVersao(ctx, versao); err != nil {
+			return err
+		}
+		return s.registrarTransicao(ctx, versao, models.StatusVersaoPlanoTerapeuticoRascunho, "versão enviada para aprovação", usuarioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versao, nil
+}
+
+// AprovarVersao registra a aprovação do supervisor e torna vigente a versão enviada, substituindo a
+// versão vigente anterior. A substituição, a aprovação e os eventos de auditoria são gravados na mesma
+// transação, e apenas a situação das versões é alterada: o conteúdo copiado fica intacto. Cada mudança de
+// situação é condicionada à situação lida, para que aprovações e devoluções simultâneas não se sobreponham.
+func (s *PlanoTerapeuticoService) AprovarVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int, req *models.AprovarVersaoPlanoTerapeuticoRequest, usuarioID, papel string) (*models.VersaoPlanoTerapeutico, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+	if papel != models.PapelSupervisor {
+		return nil, ErrAprovacaoPlanoRestrita
+	}
+
+	var versao *models.VersaoPlanoTerapeutico
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		plano, err := s.GetPlano(ctx, pacienteID, planoID)
+		if err != nil {
+			return err
+		}
+		versao, err = s.GetVersao(ctx, pacienteID, planoID, numero)
+		if err != nil {
+			return err
+		}
+		if versao.Status != models.StatusVersaoPlanoTerapeuticoEmAprovacao {
+			return ErrVersaoPlanoTerapeuticoNaoEnviada
+		}
+		if !versao.DataRevisao.After(time.Now()) {
+			return ErrDataRevisaoPlanoInvalida
+		}
+
+		agora := time.Now()
+		versao.Status = models.StatusVersaoPlanoTerapeuticoVigente
+		versao.Supervisor = req.Supervisor
+		versao.RegistroProfissional = req.RegistroProfissional
+		versao.AprovadaPor = usuarioID
+		versao.AprovadaEm = &agora
+		aprovada, err := s.repo.UpdateSituacaoVersao(ctx, versao, models.StatusVersaoPlanoTerapeuticoEmAprovacao)
+		if err != nil {
+			return err
+		}
+		if !aprovada {
+			return ErrVersaoPlanoTerapeuticoNaoEnviada
+		}
+
+		for i := range plano.Versoes {
+			anterior := &plano.Versoes[i]
+			if anterior.ID == versao.ID || anterior.Status != models.StatusVersaoPlanoTerapeuticoVigente {
+				continue
+			}
+			anterior.Status = models.StatusVersaoPlanoTerapeuticoSubstituida
+			anterior.SubstituidaEm = &agora
+			substituida, err := s.repo.UpdateSituacaoVersao(ctx, anterior, models.StatusVersaoPlanoTerapeuticoVigente)
+			if err != nil {
+				return err
+			}
+			if !substituida {
+				continue
+			}
+			motivo := fmt.Sprintf("substituída pela versão %d", versao.Numero)
+			if err := s.registrarTransicao(ctx, anterior, models.StatusVersaoPlanoTerapeuticoVigente, motivo, usuarioID); err != nil {
+				return err
+			}
+		}
+		return s.registrarTransicao(ctx, versao, models.StatusVersaoPlanoTerapeuticoEmAprovacao, "versão aprovada pelo supervisor", usuarioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versao, nil
+}
+
+// DevolverVersao devolve a versão enviada para ajustes, voltando-a para rascunho com o motivo informado
+func (s *PlanoTerapeuticoService) DevolverVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int, req *models.DevolverVersaoPlanoTerapeuticoRequest, usuarioID string) (*models.VersaoPlanoTerapeutico, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	versao, err := s.GetVersao(ctx, pacienteID, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	if versao.Status != models.StatusVersaoPlanoTerapeuticoEmAprovacao {
+		return nil, ErrVersaoPlanoTerapeuticoNaoEnviada
+	}
+
+	versao.Status = models.StatusVersaoPlanoTerapeuticoRascunho
+	versao.EnviadaEm = nil
+	versao.MotivoDevolucao = req.Motivo
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		devolvida, err := s.repo.UpdateSituacaoVersao(ctx, versao, models.StatusVersaoPlanoTerapeuticoEmAprovacao)
+		if err != nil {
+			return err
+		}
+		if !devolvida {
+			return ErrVersaoPlanoTerapeuticoNaoEnviada
+		}
+		return s.registrarTransicao(ctx, versao, models.StatusVersaoPlanoTerapeuticoEmAprovacao, req.Motivo, usuarioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versao, nil
+}
+
+// RegistrarAceite registra o aceite de um responsável na versão vigente do plano
+func (s *PlanoTerapeuticoService) RegistrarAceite(ctx context.Context, pacienteID, planoID uuid.UUID, numero int, req *models.AceitePlanoTerapeuticoRequest, usuarioID string) (*models.VersaoPlanoTerapeutico, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	versao, err := s.GetVersao(ctx, pacienteID, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	if versao.Status != models.StatusVersaoPlanoTerapeuticoVigente {
+		return nil, ErrVersaoPlanoTerapeuticoNaoVigente
+	}
+	for _, aceite := range versao.Aceites {
+		if strings.EqualFold(strings.TrimSpace(aceite.Nome), strings.TrimSpace(req.Nome)) {
+			return nil, ErrAceitePlanoTerapeuticoDuplicado
+		}
+	}
+
+	aceite := req.ToAceitePlanoTerapeutico(versao.ID, usuarioID)
+	if err := s.repo.CreateAceite(ctx, aceite); err != nil {
+		return nil, err
+	}
+	versao.Aceites = append(versao.Aceites, *aceite)
+	return versao, nil
+}
+
+// ListHistorico retorna os eventos de auditoria dos envios, aprovações, devoluções e substituições das
+// versões do plano
+func (s *PlanoTerapeuticoService) ListHistorico(ctx context.Context, pacienteID, planoID uuid.UUID) ([]*models.EventoAuditoria, error) {
+	plano, err := s.GetPlano(ctx, pacienteID, planoID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(plano.Versoes))
+	for _, versao := range plano.Versoes {
+		ids = append(ids, versao.ID)
+	}
+	return s.auditoriaRepo.ListByEntidades(ctx, ids)
+}
+
+// ListLembretesRevisao retorna os planos cuja versão vigente tem revisão vencida ou prevista para os
+// próximos dias, da revisão mais próxima para a mais distante
+func (s *PlanoTerapeuticoService) ListLembretesRevisao(ctx context.Context, dias int) ([]*models.LembreteRevisaoPlanoTerapeutico, error) {
+	if dias < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	hoje := inicioDia(time.Now())
+	planos, err := s.repo.ListRevisoesAte(ctx, hoje.AddDate(0, 0, dias+1))
+	if err != nil {
+		return nil, err
+	}
+
+	pacientes := make(map[uuid.UUID]string)
+	lembretes := make([]*models.LembreteRevisaoPlanoTerapeutico, 0, len(planos))
+	for _, plano := range planos {
+		if len(plano.Versoes) == 0 {
+			continue
+		}
+		nome, ok := pacientes[plano.PacienteID]
+		if !ok {
+			paciente, err := s.pacienteRepo.GetByID(ctx, plano.PacienteID)
+			if err != nil {
+				return nil, err
+			}
+			if paciente != nil {
+				nome = paciente.Nome
+			}
+			pacientes[plano.PacienteID] = nome
+		}
+
+		versao := plano.Versoes[0]
+		restantes := diasEntre(hoje, inicioDia(versao.DataRevisao))
+		lembretes = append(lembretes, &models.LembreteRevisaoPlanoTerapeutico{
+			PlanoID:       plano.ID,
+			PacienteID:    plano.PacienteID,
+			Paciente:      nome,
+			Titulo:        plano.Titulo,
+			Versao:        versao.Numero,
+			DataRevisao:   versao.DataRevisao,
+			DiasRestantes: restantes,
+			Vencida:       restantes < 0,
+		})
+	}
+	sort.SliceStable(lembretes, func(i, j int) bool { return lembretes[i].DataRevisao.Before(lembretes[j].DataRevisao) })
+	return lembretes, nil
+}
+
+// DocumentoVersao monta o documento do plano na versão informada, com os objetivos, os programas, a carga
+// horária semanal, a aprovação do supervisor e os aceites dos responsáveis
+func (s *PlanoTerapeuticoService) DocumentoVersao(ctx context.Context, pacienteID, planoID uuid.UUID, numero int) (*documento.Documento, error) {
+	plano, err := s.GetPlano(ctx, pacienteID, planoID)
+	if err != nil {
+		return nil, err
+	}
+	versao, err := s.GetVersao(ctx, pacienteID, planoID, numero)
+	if err != nil {
+		return nil, err
+	}
+	paciente, err := s.pacienteRepo.GetByID(ctx, pacienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &documento.Documento{
+		Titulo:    "Plano terapêutico singular",
+		Subtitulo: plano.Titulo,
+		Rodape:    "Documento gerado em " + time.Now().Format("02/01/2006 15:04"),
+	}
+	if paciente != nil {
+		d.Campos = append(d.Campos, documento.Campo{Rotulo: "Paciente", Valor: paciente.Nome})
+		d.Campos = append(d.Campos, documento.Campo{Rotulo: "Data de nascimento", Valor: paciente.DataNascimento.Format("02/01/2006")})
+	}
+	d.Campos = append(d.Campos, documento.Campo{Rotulo: "Versão", Valor: fmt.Sprintf("%d (%s)", versao.Numero, rotuloStatusVersaoPlanoTerapeutico(versao.Status))})
+	if versao.AprovadaEm != nil {
+		d.Campos = append(d.Campos, documento.Campo{Rotulo: "Aprovada em", Valor: versao.AprovadaEm.Format("02/01/2006")})
+	}
+	d.Campos = append(d.Campos, documento.Campo{Rotulo: "Próxima revisão", Valor: versao.DataRevisao.Format("02/01/2006")})
+
+	if strings.TrimSpace(versao.Avaliacao) != "" {
+		d.Secoes = append(d.Secoes, documento.Secao{Titulo: "Avaliação", Texto: versao.Avaliacao})
+	}
+	objetivos := documento.Secao{Titulo: "Objetivos terapêuticos"}
+	for _, objetivo := range versao.Objetivos {
+		objetivos.Itens = append(objetivos.Itens, fmt.Sprintf("%s (%s, %.0f%% de progresso)", objetivo.Descricao, objetivo.Status, objetivo.Percentual))
+	}
+	programas := documento.Secao{Titulo: "Programas ABA"}
+	for _, programa := range versao.Programas {
+		programas.Itens = append(programas.Itens, fmt.Sprintf("%s (%s, %d de %d etapas dominadas)", programa.Nome, programa.Status, programa.EtapasDominadas, programa.TotalEtapas))
+	}
+	cargas := documento.Secao{
+		Titulo: "Carga horária semanal",
+		Texto:  "Total: " + formatarHoras(versao.HorasSemanais()) + " por semana",
+	}
+	for _, carga := range versao.CargasHorarias {
+		item := carga.Terapia + ": " + formatarHoras(carga.HorasSemanais)
+		if strings.TrimSpace(carga.Observacoes) != "" {
+			item += " (" + carga.Observacoes + ")"
+		}
+		cargas.Itens = append(cargas.Itens, item)
+	}
+	d.Secoes = append(d.Secoes, objetivos, programas, cargas)
+	if strings.TrimSpace(versao.Observacoes) != "" {
+		d.Secoes = append(d.Secoes, documento.Secao{Titulo: "Observações", Texto: versao.Observacoes})
+	}
+
+	if versao.AprovadaEm != nil {
+		d.Assinaturas = append(d.Assinaturas, documento.Assinatura{Papel: "Supervisor", Nome: versao.Supervisor, Detalhe: versao.RegistroProfissional, Data: *versao.AprovadaEm})
+	}
+	for _, aceite := range versao.Aceites {
+		d.Assinaturas = append(d.Assinaturas, documento.Assinatura{Papel: "Responsável", Nome: aceite.Nome, Detalhe: aceite.Vinculo, Data: aceite.CreatedAt})
+	}
+	return d, nil
+}
+
+// aplicarAlteracoes substitui os objetivos, os programas e as cargas horárias da versão quando informados
+// na requisição. Os objetivos e os programas mantidos são copiados novamente.
+func (s *PlanoTerapeuticoService) aplicarAlteracoes(ctx context.Context, pacienteID uuid.UUID, versao *models.VersaoPlanoTerapeutico, req *models.VersaoPlanoTerapeuticoRequest) error {
+	objetivoIDs := req.ObjetivoIDs
+	if objetivoIDs == nil {
+		objetivoIDs = objetivoIDsVersao(versao)
+	}
+	programaIDs := req.ProgramaIDs
+	if programaIDs == nil {
+		programaIDs = programaIDsVersao(versao)
+	}
+	if err := s.copiarRegistros(ctx, pacienteID, versao, objetivoIDs, programaIDs); err != nil {
+		return err
+	}
+	if req.CargasHorarias == nil {
+		return nil
+	}
+	return s.definirCargasHorarias(ctx, versao, req.CargasHorarias)
+}
+
+// copiarRegistros valida e copia para a versão os objetivos terapêuticos, com o progresso derivado dos
+// programas vinculados, e os programas ABA do paciente, com as etapas dominadas
+func (s *PlanoTerapeuticoService) copiarRegistros(ctx context.Context, pacienteID uuid.UUID, versao *models.VersaoPlanoTerapeutico, objetivoIDs, programaIDs []uuid.UUID) error {
+	vistos := make(map[uuid.UUID]bool, len(objetivoIDs)+len(programaIDs))
+	versao.Objetivos = make([]models.ObjetivoPlanoTerapeutico, 0, len(objetivoIDs))
+	for i, id := range objetivoIDs {
+		if vistos[id] {
+			return ErrItensPlanoTerapeuticoDuplicados
+		}
+		vistos[id] = true
+
+		objetivo, err := s.objetivoRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if objetivo == nil {
+			return ErrObjetivoNotFound
+		}
+		if objetivo.PacienteID != pacienteID {
+			return ErrObjetivoForaDoPaciente
+		}
+		progresso, err := s.objetivos.GetProgresso(ctx, id)
+		if err != nil {
+			return err
+		}
+		versao.Objetivos = append(versao.Objetivos, models.ObjetivoPlanoTerapeutico{
+			ObjetivoID: id,
+			Ordem:      i + 1,
+			Descricao:  objetivo.Descricao,
+			Status:     objetivo.Status,
+			DataInicio: objetivo.DataInicio,
+			Percentual: progresso.Percentual,
+		})
+	}
+
+	versao.Programas = make([]models.ProgramaPlanoTerapeutico, 0, len(programaIDs))
+	for i, id := range programaIDs {
+		if vistos[id] {
+			return ErrItensPlanoTerapeuticoDuplicados
+		}
+		vistos[id] = true
+
+		programa, err := s.programaRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if programa == nil {
+			return ErrProgramaNotFound
+		}
+		if programa.PacienteID != pacienteID {
+			return ErrProgramaForaDoPaciente
+		}
+		versao.Programas = append(versao.Programas, models.ProgramaPlanoTerapeutico{
+			ProgramaID: id,
+			Ordem:      i + 1,
+			Nome:       programa.Nome,
+			Descricao:  programa.Descricao,
+			Status:     programa.Status,
+			DataInicio: programa.DataInicio,
+		})
+	}
+
+	etapas, err := s.etapaRepo.ListByProgramas(ctx, programaIDs)
+	if err != nil {
+		return err
+	}
+	indice := make(map[uuid.UUID]int, len(versao.Programas))
+	for i, programa := range versao.Programas {
+		indice[programa.ProgramaID] = i
+	}
+	for _, etapa := range etapas {
+		if !etapa.IsAtiva() {
+			continue
+		}
+		programa := &versao.Programas[indice[etapa.ProgramaID]]
+		programa.TotalEtapas++
+		if etapa.Status == models.StatusEtapaDominada {
+			programa.EtapasDominadas++
+		}
+	}
+	return nil
+}
+
+// definirCargasHorarias valida as terapias e substitui a carga horária semanal da versão
+func (s *PlanoTerapeuticoService) definirCargasHorarias(ctx context.Context, versao *models.VersaoPlanoTerapeutico, reqs []models.CargaHorariaPlanoTerapeuticoRequest) error {
+	vistos := make(map[uuid.UUID]bool, len(reqs))
+	versao.CargasHorarias = make([]models.CargaHorariaPlanoTerapeutico, 0, len(reqs))
+	for _, req := range reqs {
+		if vistos[req.TerapiaID] {
+			return ErrItensPlanoTerapeuticoDuplicados
+		}
+		vistos[req.TerapiaID] = true
+
+		terapia, err := s.terapiaRepo.GetByID(ctx, req.TerapiaID)
+		if err != nil {
+			return err
+		}
+		if terapia == nil {
+			return ErrTerapiaNotFound
+		}
+		versao.CargasHorarias = append(versao.CargasHorarias, models.CargaHorariaPlanoTerapeutico{
+			TerapiaID:     terapia.ID,
+			Terapia:       terapia.Nome,
+			HorasSemanais: req.HorasSemanais,
+			Observacoes:   req.Observacoes,
+		})
+	}
+	return nil
+}
+
+// registrarTransicao grava o evento de auditoria da mudança de situação de uma versão do plano
+func (s *PlanoTerapeuticoService) registrarTransicao(ctx context.Context, versao *models.VersaoPlanoTerapeutico, anterior models.StatusVersaoPlanoTerapeutico, motivo, usuarioID string) error {
+	return s.auditoriaRepo.Create(ctx, &models.EventoAuditoria{
+		EntidadeTipo:   models.EntidadeAuditoriaVersaoPlanoTerapeutico,
+		EntidadeID:     versao.ID,
+		StatusAnterior: string(anterior),
+		StatusNovo:     string(versao.Status),
+		Motivo:         motivo,
+		UsuarioID:      usuarioID,
+	})
+}
+
+// objetivoIDsVersao retorna os IDs dos objetivos copiados na versão, na ordem do plano
+func objetivoIDsVersao(versao *models.VersaoPlanoTerapeutico) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(versao.Objetivos))
+	for _, objetivo := range versao.Objetivos {
+		ids = append(ids, objetivo.ObjetivoID)
+	}
+	return ids
+}
+
+// programaIDsVersao retorna os IDs dos programas copiados na versão, na ordem do plano
+func programaIDsVersao(versao *models.VersaoPlanoTerapeutico) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(versao.Programas))
+	for _, programa := range versao.Programas {
+		ids = append(ids, programa.ProgramaID)
+	}
+	return ids
+}
+
+// rotuloStatusVersaoPlanoTerapeutico retorna o rótulo da situação da versão usado no documento
+func rotuloStatusVersaoPlanoTerapeutico(status models.StatusVersaoPlanoTerapeutico) string {
+	switch status {
+	case models.StatusVersaoPlanoTerapeuticoEmAprovacao:
+		return "em aprovação"
+	case models.StatusVersaoPlanoTerapeuticoVigente:
+		return "vigente"
+	case models.StatusVersaoPlanoTerapeuticoSubstituida:
+		return "substituída"
+	default:
+		return "rascunho"
+	}
+}
+
+// formatarHoras formata uma carga horária com vírgula decimal, como "7,5h"
+func formatarHoras(horas float64) string {
+	return strings.Replace(strconv.FormatFloat(horas, 'f', -1, 64), ".", ",", 1) + "h"
+}
@@ -17,12 +17,15 @@ var (
 
 // ProgramaABAService encapsula a lógica de negócio relacionada a programas ABA
 type ProgramaABAService struct {
-	repo repository.ProgramaABARepository
+	repo          repository.ProgramaABARepository
+	objetivos     *ObjetivoProgramaService
+	auditoriaRepo repository.EventoAuditoriaRepository
+	transactor    repository.Transactor
 }
 
 // NewProgramaABAService cria uma nova instância de ProgramaABAService
-func NewProgramaABAService(repo repository.ProgramaABARepository) *ProgramaABAService {
-	return &ProgramaABAService{repo: repo}
+func NewProgramaABAService(repo repository.ProgramaABARepository, objetivos *ObjetivoProgramaService, auditoriaRepo repository.EventoAuditoriaRepository, transactor repository.Transactor) *ProgramaABAService {
+	return &ProgramaABAService{repo: repo, objetivos: objetivos, auditoriaRepo: auditoriaRepo, transactor: transactor}
 }
 
 // CreatePrograma cria um novo programa ABA
@@ -45,8 +48,9 @@ func (s *ProgramaABAService) GetPrograma(ctx context.Context, id uuid.UUID) (*mo
 	return programa, nil
 }
 
-// UpdatePrograma atualiza um programa ABA existente
-func (s *ProgramaABAService) UpdatePrograma(ctx context.Context, programa *models.ProgramaABA) (*models.ProgramaABA, error) {
+// UpdatePrograma atualiza um programa ABA existente. Uma mudança de situação é registrada na auditoria e
+// reavalia, na mesma transação, a conclusão dos objetivos vinculados ao programa.
+func (s *ProgramaABAService) UpdatePrograma(ctx context.Context, programa *models.ProgramaABA, usuarioID string) (*models.ProgramaABA, error) {
 	existing, err := s.repo.GetByID(ctx, programa.ID)
 	if err != nil {
 		return nil, err
@@ -55,13 +59,39 @@ func (s *ProgramaABAService) UpdatePrograma(ctx context.Context, programa *model
 		return nil, ErrProgramaNotFound
 	}
 
-	if err := s.repo.Update(ctx, programa); err != nil {
+	// O modelo de origem é definido apenas na instanciação do programa
+	programa.ModeloProgramaID = existing.ModeloProgramaID
+	// O vínculo com o currículo é definido apenas na geração do programa a partir de uma aplicação
+	programa.AplicacaoCurriculoID = existing.AplicacaoCurriculoID
+	programa.MarcoCurriculoID = existing.MarcoCurriculoID
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, programa); err != nil {
+			return err
+		}
+		if programa.Status == existing.Status {
+			return nil
+		}
+		if err := s.auditoriaRepo.Create(ctx, &models.EventoAuditoria{
+			EntidadeTipo:   models.EntidadeAuditoriaProgramaABA,
+			EntidadeID:     programa.ID,
+			StatusAnterior: string(existing.Status),
+			StatusNovo:     string(programa.Status),
+			Motivo:         "situação alterada manualmente",
+			UsuarioID:      usuarioID,
+		}); err != nil {
+			return err
+		}
+		return s.objetivos.AvaliarConclusao(ctx, programa.ID)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return programa, nil
 }
 
-// DeletePrograma exclui um programa ABA pelo ID
+// DeletePrograma exclui um programa ABA pelo ID e reavalia, na mesma transação, a conclusão dos objetivos
+// vinculados a ele
 func (s *ProgramaABAService) DeletePrograma(ctx context.Context, id uuid.UUID) error {
 	programa, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -70,7 +100,12 @@ func (s *ProgramaABAService) DeletePrograma(ctx context.Context, id uuid.UUID) e
 	if programa == nil {
 		return ErrProgramaNotFound
 	}
-	return s.repo.Delete(ctx, id)
+	return s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.objetivos.AvaliarConclusao(ctx, id)
+	})
 }
 
 // ListProgramas retorna uma lista paginada de programas ABA
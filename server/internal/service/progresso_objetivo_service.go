@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Parâmetros padrão da análise de tendência do progresso
+const (
+	JanelaTendenciaPadrao    = 5
+	MinimoRegistrosTendencia = 3
+	LimiarTendenciaPadrao    = 0.25
+)
+
+// Erros comuns do serviço
+var (
+	ErrProgressoNotFound = errors.New("registro de progresso não encontrado")
+	ErrJanelaInvalida    = errors.New("a janela de análise deve conter ao menos 3 registros")
+)
+
+// ProgressoObjetivoService encapsula a lógica de negócio do acompanhamento de progresso dos objetivos
+// terapêuticos e da análise de tendência
+type ProgressoObjetivoService struct {
+	repo         repository.ProgressoObjetivoRepository
+	objetivoRepo repository.ObjetivoTerapeuticoRepository
+	escalaRepo   repository.EscalaGASRepository
+	transactor   repository.Transactor
+}
+
+// NewProgressoObjetivoService cria uma nova instância de ProgressoObjetivoService
+func NewProgressoObjetivoService(repo repository.ProgressoObjetivoRepository, objetivoRepo repository.ObjetivoTerapeuticoRepository, escalaRepo repository.EscalaGASRepository, transactor repository.Transactor) *ProgressoObjetivoService {
+	return &ProgressoObjetivoService{repo: repo, objetivoRepo: objetivoRepo, escalaRepo: escalaRepo, transactor: transactor}
+}
+
+// CreateProgresso registra uma nota de progresso para um objetivo e atualiza sua sinalização. Quando o
+// objetivo tem escala GAS, o registro deve informar o nível alcançado.
+func (s *ProgressoObjetivoService) CreateProgresso(ctx context.Context, objetivoID uuid.UUID, req *models.CreateProgressoObjetivoRequest) (*models.ProgressoObjetivo, error) {
+	if req == nil || req.Nota == nil {
+		return nil, ErrInvalidInput
+	}
+
+	objetivo, err := s.getObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validarNivelGAS(ctx, objetivoID, req.NivelGAS, true); err != nil {
+		return nil, err
+	}
+
+	progresso := req.ToProgressoObjetivo(objetivoID)
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, progresso); err != nil {
+			return err
+		}
+		return s.atualizarSinalizacao(ctx, objetivo)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return progresso, nil
+}
+
+// GetProgresso busca um registro de progresso pelo ID, garantindo que pertence ao objetivo informado
+func (s *ProgressoObjetivoService) GetProgresso(ctx context.Context, objetivoID, id uuid.UUID) (*models.ProgressoObjetivo, error) {
+	progresso, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if progresso == nil || progresso.ObjetivoID != objetivoID {
+		return nil, ErrProgressoNotFound
+	}
+	return progresso, nil
+}
+
+// UpdateProgresso atualiza um registro de progresso e recalcula a sinalização do objetivo
+func (s *ProgressoObjetivoService) UpdateProgresso(ctx context.Context, objetivoID, id uuid.UUID, req *models.UpdateProgressoObjetivoRequest) (*models.ProgressoObjetivo, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	objetivo, err := s.getObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	progresso, err := s.GetProgresso(ctx, objetivoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validarNivelGAS(ctx, objetivoID, req.NivelGAS, false); err != nil {
+		return nil, err
+	}
+
+	progresso.ApplyUpdates(req)
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, progresso); err != nil {
+			return err
+		}
+		return s.atualizarSinalizacao(ctx, objetivo)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return progresso, nil
+}
+
+// DeleteProgresso exclui um registro de progresso e recalcula a sinalização do objetivo
+func (s *ProgressoObjetivoService) DeleteProgresso(ctx context.Context, objetivoID, id uuid.UUID) error {
+	objetivo, err := s.getObjetivo(ctx, objetivoID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.GetProgresso(ctx, objetivoID, id); err != nil {
+		return err
+	}
+	return s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.atualizarSinalizacao(ctx, objetivo)
+	})
+}
+
+// ListProgresso retorna o histórico paginado de progresso de um objetivo
+func (s *ProgressoObjetivoService) ListProgresso(ctx context.Context, objetivoID uuid.UUID, page, pageSize int) ([]*models.ProgressoObjetivo, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if _, err := s.getObjetivo(ctx, objetivoID); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	progressos, err := s.repo.ListByObjetivo(ctx, objetivoID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return progressos, total, nil
+}
+
+// GetTendencia calcula a tendência do progresso de um objetivo considerando os últimos registros da janela.
+// Inclinações com valor absoluto menor que o limiar são consideradas estáveis.
+func (s *ProgressoObjetivoService) GetTendencia(ctx context.Context, objetivoID uuid.UUID, janela int, limiar float64) (*models.TendenciaObjetivoResponse, error) {
+	if janela < MinimoRegistrosTendencia {
+		return nil, ErrJanelaInvalida
+	}
+	if limiar <= 0 {
+		limiar = LimiarTendenciaPadrao
+	}
+
+	objetivo, err := s.getObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	progressos, err := s.repo.ListAllByObjetivo(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+
+	tendencia := calcularTendencia(progressos, janela, limiar)
+	tendencia.ObjetivoID = objetivoID
+	tendencia.Sinalizado = objetivo.Sinalizado
+	tendencia.SinalizadoEm = objetivo.SinalizadoEm
+	return tendencia, nil
+}
+
+// ListObjetivosSinalizados retorna os objetivos de um paciente cuja tendência permanece negativa
+func (s *ProgressoObjetivoService) ListObjetivosSinalizados(ctx context.Context, pacienteID uuid.UUID) ([]*models.ObjetivoTerapeutico, error) {
+	return s.objetivoRepo.ListSinalizadosByPaciente(ctx, pacienteID)
+}
+
+// atualizarSinalizacao sinaliza o objetivo quando a tendência é de regressão tanto na janela atual quanto
+// na janela que terminava no registro anterior, e remove a sinalização quando a regressão deixa de ocorrer.
+// Apenas a sinalização é gravada; deve ser chamado na transação que alterou o progresso.
+func (s *ProgressoObjetivoService) atualizarSinalizacao(ctx context.Context, objetivo *models.ObjetivoTerapeutico) error {
+	progressos, err := s.repo.ListAllByObjetivo(ctx, objetivo.ID)
+	if err != nil {
+		return err
+	}
+
+	sinalizar := false
+	if len(progressos) > MinimoRegistrosTendencia {
+		atual := calcularTendencia(progressos, JanelaTendenciaPadrao, LimiarTendenciaPadrao)
+		anterior := calcularTendencia(progressos[:len(progressos)-1], JanelaTendenciaPadrao, LimiarTendenciaPadrao)
+		sinalizar = atual.Classificacao == models.TendenciaRegredindo && anterior.Classificacao == models.TendenciaRegredindo
+	}
+	if sinalizar == objetivo.Sinalizado {
+		return nil
+	}
+
+	objetivo.Sinalizado = sinalizar
+	objetivo.SinalizadoEm = nil
+	if sinalizar {
+		agora := time.Now()
+		objetivo.SinalizadoEm = &agora
+	}
+	return s.objetivoRepo.UpdateSinalizacao(ctx, objetivo)
+}
+
+// validarNivelGAS verifica o nível GAS informado em um registro de progresso: só é aceito quando o objetivo
+// tem escala e, quando obrigatorio, não pode faltar em objetivos com escala
+func (s *ProgressoObjetivoService) validarNivelGAS(ctx context.Context, objetivoID uuid.UUID, nivel *int, obrigatorio bool) error {
+	if nivel == nil && !obrigatorio {
+		return nil
+	}
+
+	escala, err := s.escalaRepo.GetByObjetivo(ctx, objetivoID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case escala == nil && nivel != nil:
+		return ErrNivelGASSemEscala
+	case escala != nil && nivel == nil:
+		return ErrNivelGASObrigatorio
+	}
+	return nil
+}
+
+// getObjetivo busca o objetivo terapêutico do progresso
+func (s *ProgressoObjetivoService) getObjetivo(ctx context.Context, objetivoID uuid.UUID) (*models.ObjetivoTerapeutico, error) {
+	objetivo, err := s.objetivoRepo.GetByID(ctx, objetivoID)
+	if err != nil {
+		return nil, err
+	}
+	if objetivo == nil {
+		return nil, ErrObjetivoNotFound
+	}
+	return objetivo, nil
+}
+
+// calcularTendencia ajusta uma reta por mínimos quadrados às notas dos últimos registros da janela,
+// usando o tempo em semanas desde o primeiro registro considerado
+func calcularTendencia(progressos []*models.ProgressoObjetivo, janela int, limiar float64) *models.TendenciaObjetivoResponse {
+	if len(progressos) > janela {
+		progressos = progressos[len(progressos)-janela:]
+	}
+	tendencia := &models.TendenciaObjetivoResponse{
+		Janela:        janela,
+		Registros:     len(progressos),
+		Classificacao: models.TendenciaInsuficiente,
+		LimiarEstavel: limiar,
+	}
+	if len(progressos) == 0 {
+		return tendencia
+	}
+
+	ultima := progressos[len(progressos)-1].Nota
+	tendencia.UltimaNota = &ultima
+
+	n := float64(len(progressos))
+	var somaX, somaY float64
+	xs := make([]float64, len(progressos))
+	for i, progresso := range progressos {
+		xs[i] = progresso.Data.Sub(progressos[0].Data).Hours() / (24 * 7)
+		somaX += xs[i]
+		somaY += float64(progresso.Nota)
+	}
+	mediaX, mediaY := somaX/n, somaY/n
+	tendencia.MediaNota = mediaY
+	if len(progressos) < MinimoRegistrosTendencia {
+		return tendencia
+	}
+
+	var cov, varX float64
+	for i, progresso := range progressos {
+		cov += (xs[i] - mediaX) * (float64(progresso.Nota) - mediaY)
+		varX += (xs[i] - mediaX) * (xs[i] - mediaX)
+	}
+	if varX > 0 {
+		tendencia.Inclinacao = cov / varX
+	}
+
+	switch {
+	case tendencia.Inclinacao >= limiar:
+		tendencia.Classificacao = models.TendenciaMelhorando
+	case tendencia.Inclinacao <= -limiar:
+		tendencia.Classificacao = models.TendenciaRegredindo
+	default:
+		tendencia.Classificacao = models.TendenciaEstavel
+	}
+	return tendencia
+}
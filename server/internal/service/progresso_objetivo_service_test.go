@@ -0,0 +1,61 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"msd-service/server/internal/models"
+)
+
+func TestCalcularTendencia(t *testing.T) {
+	inicio := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	semanal := func(notas ...int) []*models.ProgressoObjetivo {
+		progressos := make([]*models.ProgressoObjetivo, 0, len(notas))
+		for i, nota := range notas {
+			progressos = append(progressos, &models.ProgressoObjetivo{Data: inicio.AddDate(0, 0, 7*i), Nota: nota})
+		}
+		return progressos
+	}
+
+	tests := []struct {
+		nome          string
+		progressos    []*models.ProgressoObjetivo
+		janela        int
+		classificacao models.ClassificacaoTendencia
+		registros     int
+		inclinacao    float64
+		media         float64
+	}{
+		{"sem registros", nil, 5, models.TendenciaInsuficiente, 0, 0, 0},
+		{"registros abaixo do mínimo", semanal(2, 4), 5, models.TendenciaInsuficiente, 2, 0, 3},
+		{"notas subindo um ponto por semana", semanal(1, 2, 3), 5, models.TendenciaMelhorando, 3, 1, 2},
+		{"notas caindo um ponto por semana", semanal(4, 3, 2, 1), 5, models.TendenciaRegredindo, 4, -1, 2.5},
+		{"notas constantes", semanal(3, 3, 3), 5, models.TendenciaEstavel, 3, 0, 3},
+		{"inclinação abaixo do limiar", semanal(2, 2, 2, 2, 2, 2, 2, 2, 2, 3), 10, models.TendenciaEstavel, 10, 4.5 / 82.5, 2.1},
+		{"só os registros da janela contam", semanal(5, 5, 1, 2, 3), 3, models.TendenciaMelhorando, 3, 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nome, func(t *testing.T) {
+			got := calcularTendencia(tt.progressos, tt.janela, 0.25)
+			if got.Classificacao != tt.classificacao {
+				t.Errorf("Classificacao = %v, want %v", got.Classificacao, tt.classificacao)
+			}
+			if got.Registros != tt.registros {
+				t.Errorf("Registros = %v, want %v", got.Registros, tt.registros)
+			}
+			if math.Abs(got.Inclinacao-tt.inclinacao) > 1e-9 {
+				t.Errorf("Inclinacao = %v, want %v", got.Inclinacao, tt.inclinacao)
+			}
+			if math.Abs(got.MediaNota-tt.media) > 1e-9 {
+				t.Errorf("MediaNota = %v, want %v", got.MediaNota, tt.media)
+			}
+			if tt.registros == 0 && got.UltimaNota != nil {
+				t.Errorf("UltimaNota = %v, want nil", *got.UltimaNota)
+			}
+			if tt.registros > 0 && (got.UltimaNota == nil || *got.UltimaNota != tt.progressos[len(tt.progressos)-1].Nota) {
+				t.Errorf("UltimaNota = %v, want a nota do último registro", got.UltimaNota)
+			}
+		})
+	}
+}
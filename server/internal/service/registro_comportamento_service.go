@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrRegistroNotFound      = errors.New("registro de comportamento não encontrado")
+	ErrValorRegistroInvalido = errors.New("valor incompatível com o método de registro do comportamento")
+	ErrPeriodoInvalido       = errors.New("período inválido: a data inicial deve ser anterior à final")
+	ErrOpcoesDispersao       = errors.New("opções do gráfico de dispersão inválidas: o bloco deve ter pelo menos 5 minutos e dividir o dia, e as horas devem estar entre 0 e 24")
+	ErrPeriodoDispersaoLongo = errors.New("período longo demais para o gráfico de dispersão por data")
+)
+
+// nomesDiaSemana são os rótulos das colunas do gráfico de dispersão por dia da semana
+var nomesDiaSemana = []string{"Dom", "Seg", "Ter", "Qua", "Qui", "Sex", "Sáb"}
+
+// RegistroComportamentoService encapsula a lógica de negócio relacionada aos registros de ocorrência
+// de comportamentos alvo
+type RegistroComportamentoService struct {
+	repo              repository.RegistroComportamentoRepository
+	comportamentoRepo repository.ComportamentoAlvoRepository
+	observacaoRepo    repository.ObservacaoIntervaloRepository
+	sessaoRepo        repository.SessaoRepository
+	categoriaRepo     repository.CategoriaABCRepository
+}
+
+// NewRegistroComportamentoService cria uma nova instância de RegistroComportamentoService
+func NewRegistroComportamentoService(repo repository.RegistroComportamentoRepository, comportamentoRepo repository.ComportamentoAlvoRepository, observacaoRepo repository.ObservacaoIntervaloRepository, sessaoRepo repository.SessaoRepository, categoriaRepo repository.CategoriaABCRepository) *RegistroComportamentoService {
+	return &RegistroComportamentoService{repo: repo, comportamentoRepo: comportamentoRepo, observacaoRepo: observacaoRepo, sessaoRepo: sessaoRepo, categoriaRepo: categoriaRepo}
+}
+
+// CreateRegistro registra uma ocorrência de um comportamento alvo
+func (s *RegistroComportamentoService) CreateRegistro(ctx context.Context, comportamentoID uuid.UUID, req *models.CreateRegistroComportamentoRequest) (*models.RegistroComportamento, error) {
+	if req == nil || req.Valor == nil {
+		return nil, ErrInvalidInput
+	}
+
+	comportamento, err := s.getComportamento(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if !comportamento.MetodoRegistro.ValidarValor(*req.Valor) {
+		return nil, ErrValorRegistroInvalido
+	}
+	if err := validarSessaoComportamento(ctx, s.sessaoRepo, comportamento, req.SessaoID, req.Secundario); err != nil {
+		return nil, err
+	}
+	if err := validarCategoriaABC(ctx, s.categoriaRepo, req.AntecedenteID, nil, models.TipoCategoriaABCAntecedente); err != nil {
+		return nil, err
+	}
+	if err := validarCategoriaABC(ctx, s.categoriaRepo, req.ConsequenciaID, nil, models.TipoCategoriaABCConsequencia); err != nil {
+		return nil, err
+	}
+
+	registro := req.ToRegistroComportamento(comportamentoID)
+	if err := s.repo.Create(ctx, registro); err != nil {
+		return nil, err
+	}
+	return registro, nil
+}
+
+// GetRegistro busca um registro pelo ID, garantindo que pertence ao comportamento informado
+func (s *RegistroComportamentoService) GetRegistro(ctx context.Context, comportamentoID, id uuid.UUID) (*models.RegistroComportamento, error) {
+	registro, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if registro == nil || registro.ComportamentoID != comportamentoID {
+		return nil, ErrRegistroNotFound
+	}
+	return registro, nil
+}
+
+// UpdateRegistro atualiza um registro de comportamento existente
+func (s *RegistroComportamentoService) UpdateRegistro(ctx context.Context, comportamentoID, id uuid.UUID, req *models.UpdateRegistroComportamentoRequest) (*models.RegistroComportamento, error) {
+	if req == nil {
+		return nil, ErrInvalidInput
+	}
+
+	comportamento, err := s.getComportamento(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	registro, err := s.GetRegistro(ctx, comportamentoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Valor != nil && !comportamento.MetodoRegistro.ValidarValor(*req.Valor) {
+		return nil, ErrValorRegistroInvalido
+	}
+	if err := validarCategoriaABC(ctx, s.categoriaRepo, req.AntecedenteID, registro.AntecedenteID, models.TipoCategoriaABCAntecedente); err != nil {
+		return nil, err
+	}
+	if err := validarCategoriaABC(ctx, s.categoriaRepo, req.ConsequenciaID, registro.ConsequenciaID, models.TipoCategoriaABCConsequencia); err != nil {
+		return nil, err
+	}
+
+	registro.ApplyUpdates(req)
+	if err := s.repo.Update(ctx, registro); err != nil {
+		return nil, err
+	}
+	return registro, nil
+}
+
+// DeleteRegistro exclui um registro de comportamento pelo ID
+func (s *RegistroComportamentoService) DeleteRegistro(ctx context.Context, comportamentoID, id uuid.UUID) error {
+	if _, err := s.GetRegistro(ctx, comportamentoID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListRegistros retorna uma lista paginada dos registros de um comportamento no intervalo [inicio, fim)
+func (s *RegistroComportamentoService) ListRegistros(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, page, pageSize int) ([]*models.RegistroComportamento, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, 0, ErrPeriodoInvalido
+	}
+	if _, err := s.getComportamento(ctx, comportamentoID); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	registros, err := s.repo.ListByComportamento(ctx, comportamentoID, inicio, fim, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByComportamento(ctx, comportamentoID, inicio, fim)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return registros, total, nil
+}
+
+// AgregarRegistros agrega os registros de um comportamento por dia ou por semana no intervalo [inicio, fim).
+// Para comportamentos registrados por intervalos, os intervalos das observações também são considerados.
+// Períodos sem registros são incluídos com valores zerados para manter a série contínua.
+func (s *RegistroComportamentoService) AgregarRegistros(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, periodo models.PeriodoAgregacao) ([]*models.AgregadoRegistroComportamento, error) {
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, ErrPeriodoInvalido
+	}
+	comportamento, err := s.getComportamento(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+
+	valores, err := s.valoresRegistrados(ctx, comportamento, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	agregados := make([]*models.AgregadoRegistroComportamento, 0)
+	if len(valores) == 0 && (inicio == nil || fim == nil) {
+		return agregados, nil
+	}
+
+	var primeiro, ultimo time.Time
+	if inicio != nil {
+		primeiro = *inicio
+	} else {
+		primeiro = valores[0].data
+	}
+	if fim != nil {
+		ultimo = fim.Add(-time.Nanosecond)
+	} else {
+		ultimo = valores[len(valores)-1].data
+	}
+
+	i := 0
+	for atual := inicioPeriodo(primeiro, periodo); !atual.After(ultimo); atual = proximoPeriodo(atual, periodo) {
+		agregado := &models.AgregadoRegistroComportamento{Inicio: atual, Fim: proximoPeriodo(atual, periodo)}
+		for ; i < len(valores) && valores[i].data.Before(agregado.Fim); i++ {
+			valor := valores[i].valor
+			if agregado.Registros == 0 || valor < agregado.Minimo {
+				agregado.Minimo = valor
+			}
+			if agregado.Registros == 0 || valor > agregado.Maximo {
+				agregado.Maximo = valor
+			}
+			agregado.Registros++
+			agregado.Soma += valor
+		}
+		if agregado.Registros > 0 {
+			agregado.Media = agregado.Soma / float64(agregado.Registros)
+		}
+		agregado.Valor = valorAgregado(agregado, comportamento.MetodoRegistro)
+		agregados = append(agregados, agregado)
+	}
+	return agregados, nil
+}
+
+// DispersaoRegistros monta o gráfico de dispersão dos registros do observador principal no intervalo [inicio, fim),
+// com as ocorrências agrupadas por dia da semana ou por data e por bloco de horário. Quando normalizado, o tempo
+// observado em cada bloco vem da duração das sessões realizadas do paciente e a taxa é dada por hora observada.
+func (s *RegistroComportamentoService) DispersaoRegistros(ctx context.Context, comportamentoID uuid.UUID, inicio, fim *time.Time, opcoes models.OpcoesDispersao) (*models.DispersaoComportamento, error) {
+	if inicio != nil && fim != nil && !inicio.Before(*fim) {
+		return nil, ErrPeriodoInvalido
+	}
+	if opcoes.Eixo == "" {
+		opcoes.Eixo = models.EixoDispersaoDiaSemana
+	}
+	if opcoes.BlocoMinutos == 0 {
+		opcoes.BlocoMinutos = models.BlocoDispersaoPadrao
+	}
+	if !opcoesDispersaoValidas(opcoes) {
+		return nil, ErrOpcoesDispersao
+	}
+
+	comportamento, err := s.getComportamento(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	registros, err := s.repo.ListAllByComportamento(ctx, comportamentoID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+
+	dispersao := &models.DispersaoComportamento{
+		ComportamentoID: comportamento.ID,
+		Descricao:       comportamento.Descricao,
+		Inicio:          inicio,
+		Fim:             fim,
+		Eixo:            opcoes.Eixo,
+		BlocoMinutos:    opcoes.BlocoMinutos,
+		Normalizado:     opcoes.Normalizar,
+		Colunas:         []string{},
+		Linhas:          []models.LinhaDispersao{},
+	}
+
+	// Colunas: os sete dias da semana ou cada data do período
+	var primeiroDia time.Time
+	colunas := 0
+	switch opcoes.Eixo {
+	case models.EixoDispersaoData:
+		var ultimoDia time.Time
+		for _, registro := range registros {
+			dia := inicioDia(registro.DataHora.Local())
+			if primeiroDia.IsZero() || dia.Before(primeiroDia) {
+				primeiroDia = dia
+			}
+			if dia.After(ultimoDia) {
+				ultimoDia = dia
+			}
+		}
+		if inicio != nil {
+			primeiroDia = inicioDia(inicio.Local())
+		}
+		if fim != nil {
+			ultimoDia = inicioDia(fim.Local().Add(-time.Nanosecond))
+		}
+		if !primeiroDia.IsZero() && !ultimoDia.Before(primeiroDia) {
+			colunas = diasEntre(primeiroDia, ultimoDia) + 1
+		}
+		if colunas > models.MaximoColunasDispersao {
+			return nil, ErrPeriodoDispersaoLongo
+		}
+		for i := 0; i < colunas; i++ {
+			dispersao.Colunas = append(dispersao.Colunas, primeiroDia.AddDate(0, 0, i).Format("02/01"))
+		}
+	default:
+		colunas = len(nomesDiaSemana)
+		dispersao.Colunas = append(dispersao.Colunas, nomesDiaSemana...)
+	}
+	coluna := func(t time.Time) int {
+		if opcoes.Eixo != models.EixoDispersaoData {
+			return int(t.Weekday())
+		}
+		i := diasEntre(primeiroDia, inicioDia(t))
+		if i < 0 || i >= colunas {
+			return -1
+		}
+		return i
+	}
+
+	blocos := 24 * 60 / opcoes.BlocoMinutos
+	grade := make([][]models.CelulaDispersao, blocos)
+	for i := range grade {
+		grade[i] = make([]models.CelulaDispersao, colunas)
+	}
+	bloco := func(t time.Time) int {
+		return (t.Hour()*60 + t.Minute()) / opcoes.BlocoMinutos
+	}
+
+	for _, registro := range registros {
+		t := registro.DataHora.Local()
+		if c := coluna(t); c >= 0 {
+			ocorrencias := ocorrenciasRegistro(registro, comportamento.MetodoRegistro)
+			grade[bloco(t)][c].Ocorrencias += ocorrencias
+			dispersao.TotalOcorrencias += ocorrencias
+		}
+	}
+
+	if opcoes.Normalizar {
+		sessoes, err := s.sessaoRepo.ListRealizadasByPaciente(ctx, comportamento.PacienteID, inicio, fim)
+		if err != nil {
+			return nil, err
+		}
+		duracaoBloco := time.Duration(opcoes.BlocoMinutos) * time.Minute
+		for _, sessao := range sessoes {
+			t := sessao.Data.Local()
+			termino := t.Add(time.Duration(sessao.DuracaoMinutos) * time.Minute)
+			for t.Before(termino) {
+				b := bloco(t)
+				ate := inicioDia(t).Add(time.Duration(b) * duracaoBloco).Add(duracaoBloco)
+				if termino.Before(ate) {
+					ate = termino
+				}
+				if c := coluna(t); c >= 0 {
+					grade[b][c].MinutosObservados += ate.Sub(t).Minutes()
+				}
+				t = ate
+			}
+		}
+		for i := range grade {
+			for j := range grade[i] {
+				if celula := &grade[i][j]; celula.MinutosObservados > 0 {
+					taxa := celula.Ocorrencias * 60 / celula.MinutosObservados
+					celula.Taxa = &taxa
+				}
+			}
+		}
+	}
+
+	primeiro, ultimo := faixaBlocosDispersao(grade, opcoes)
+	for b := primeiro; b <= ultimo; b++ {
+		dispersao.Linhas = append(dispersao.Linhas, models.LinhaDispersao{
+			Inicio:  formatarMinutos(b * opcoes.BlocoMinutos),
+			Fim:     formatarMinutos((b + 1) * opcoes.BlocoMinutos),
+			Celulas: grade[b],
+		})
+	}
+	return dispersao, nil
+}
+
+// valorRegistrado representa um valor datado usado na agregação
+type valorRegistrado struct {
+	data  time.Time
+	valor float64
+}
+
+// valoresRegistrados reúne, em ordem cronológica, os valores dos registros do comportamento e, quando ele é
+// registrado por intervalos, a pontuação (1 ou 0) de cada intervalo das observações por intervalos
+func (s *RegistroComportamentoService) valoresRegistrados(ctx context.Context, comportamento *models.ComportamentoAlvo, inicio, fim *time.Time) ([]valorRegistrado, error) {
+	registros, err := s.repo.ListAllByComportamento(ctx, comportamento.ID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	valores := make([]valorRegistrado, 0, len(registros))
+	for _, registro := range registros {
+		valores = append(valores, valorRegistrado{data: registro.DataHora, valor: registro.Valor})
+	}
+	if comportamento.MetodoRegistro != models.MetodoRegistroIntervalo {
+		return valores, nil
+	}
+
+	observacoes, err := s.observacaoRepo.ListAllByComportamento(ctx, comportamento.ID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	for _, observacao := range observacoes {
+		for _, intervalo := range observacao.Intervalos {
+			valor := 0.0
+			if intervalo.Ocorreu {
+				valor = 1
+			}
+			valores = append(valores, valorRegistrado{data: observacao.InicioIntervalo(intervalo.Numero), valor: valor})
+		}
+	}
+	sort.SliceStable(valores, func(i, j int) bool {
+		return valores[i].data.Before(valores[j].data)
+	})
+	return valores, nil
+}
+
+// getComportamento busca o comportamento alvo dos registros
+func (s *RegistroComportamentoService) getComportamento(ctx context.Context, comportamentoID uuid.UUID) (*models.ComportamentoAlvo, error) {
+	comportamento, err := s.comportamentoRepo.GetByID(ctx, comportamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if comportamento == nil {
+		return nil, ErrComportamentoNotFound
+	}
+	return comportamento, nil
+}
+
+// valorAgregado retorna a medida principal do período de acordo com o método de registro
+func valorAgregado(agregado *models.AgregadoRegistroComportamento, metodo models.MetodoRegistro) float64 {
+	switch metodo {
+	case models.MetodoRegistroIntensidade:
+		return agregado.Media
+	case models.MetodoRegistroIntervalo:
+		return agregado.Media * 100
+	default:
+		return agregado.Soma
+	}
+}
+
+// inicioPeriodo retorna o início do dia, ou da semana (segunda-feira), que contém a data
+func inicioPeriodo(data time.Time, periodo models.PeriodoAgregacao) time.Time {
+	data = data.In(time.Local)
+	dia := time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, time.Local)
+	if periodo == models.PeriodoAgregacaoSemana {
+		dia = dia.AddDate(0, 0, -((int(dia.Weekday()) + 6) % 7))
+	}
+	return dia
+}
+
+// proximoPeriodo retorna o início do período seguinte
+func proximoPeriodo(inicio time.Time, periodo models.PeriodoAgregacao) time.Time {
+	if periodo == models.PeriodoAgregacaoSemana {
+		return inicio.AddDate(0, 0, 7)
+	}
+	return inicio.AddDate(0, 0, 1)
+}
+
+// opcoesDispersaoValidas verifica o tamanho do bloco de horário e a faixa de horas do gráfico de dispersão
+func opcoesDispersaoValidas(opcoes models.OpcoesDispersao) bool {
+	if opcoes.Eixo != models.EixoDispersaoDiaSemana && opcoes.Eixo != models.EixoDispersaoData {
+		return false
+	}
+	if opcoes.BlocoMinutos < 5 || (24*60)%opcoes.BlocoMinutos != 0 {
+		return false
+	}
+	if opcoes.HoraInicio != nil && (*opcoes.HoraInicio < 0 || *opcoes.HoraInicio > 23) {
+		return false
+	}
+	if opcoes.HoraFim != nil && (*opcoes.HoraFim < 1 || *opcoes.HoraFim > 24) {
+		return false
+	}
+	return opcoes.HoraInicio == nil || opcoes.HoraFim == nil || *opcoes.HoraInicio < *opcoes.HoraFim
+}
+
+// faixaBlocosDispersao retorna o primeiro e o último bloco exibidos: a faixa de horas informada ou, na sua
+// ausência, os blocos entre o primeiro e o último com ocorrências ou tempo observado (8h às 18h sem dados)
+func faixaBlocosDispersao(grade [][]models.CelulaDispersao, opcoes models.OpcoesDispersao) (int, int) {
+	primeiro, ultimo := -1, -1
+	for b, linha := range grade {
+		for _, celula := range linha {
+			if celula.Ocorrencias > 0 || celula.MinutosObservados > 0 {
+				if primeiro < 0 {
+					primeiro = b
+				}
+				ultimo = b
+				break
+			}
+		}
+	}
+	if primeiro < 0 {
+		primeiro, ultimo = 8*60/opcoes.BlocoMinutos, 18*60/opcoes.BlocoMinutos-1
+	}
+	if opcoes.HoraInicio != nil {
+		primeiro = *opcoes.HoraInicio * 60 / opcoes.BlocoMinutos
+	}
+	if opcoes.HoraFim != nil {
+		ultimo = (*opcoes.HoraFim*60+opcoes.BlocoMinutos-1)/opcoes.BlocoMinutos - 1
+	}
+	if ultimo < primeiro {
+		ultimo = primeiro
+	}
+	return primeiro, ultimo
+}
+
+// ocorrenciasRegistro retorna quantas ocorrências um registro representa: o valor registrado na frequência
+// e nos intervalos pontuados e uma ocorrência por registro nos demais métodos
+func ocorrenciasRegistro(registro *models.RegistroComportamento, metodo models.MetodoRegistro) float64 {
+	switch metodo {
+	case models.MetodoRegistroFrequencia, models.MetodoRegistroIntervalo:
+		return registro.Valor
+	default:
+		return 1
+	}
+}
+
+// diasEntre retorna o número de dias do calendário entre duas datas à meia-noite
+func diasEntre(de, ate time.Time) int {
+	return int(math.Round(ate.Sub(de).Hours() / 24))
+}
+
+// formatarMinutos formata os minutos desde a meia-noite como HH:MM
+func formatarMinutos(minutos int) string {
+	return fmt.Sprintf("%02d:%02d", minutos/60, minutos%60)
+}
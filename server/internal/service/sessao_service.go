@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"log"
 
 	"github.com/google/uuid"
 
@@ -12,17 +13,24 @@ import (
 
 // Erros comuns do serviço
 var (
-	ErrSessaoNotFound = errors.New("sessão não encontrada")
+	ErrSessaoNotFound              = errors.New("sessão não encontrada")
+	ErrSessaoComCronometrosAbertos = errors.New("a sessão possui cronômetros em andamento; encerre-os antes de finalizar a sessão")
+	ErrSessaoRealizada             = errors.New("a sessão já foi realizada e não pode voltar para outro status")
 )
 
 // SessaoService encapsula a lógica de negócio relacionada a sessões
 type SessaoService struct {
-	repo repository.SessaoRepository
+	repo           repository.SessaoRepository
+	avaliacao      *AvaliacaoDominioService
+	alvos          *AlvoEtapaService
+	manutencao     *SondagemManutencaoService
+	cronometroRepo repository.CronometroComportamentoRepository
+	transactor     repository.Transactor
 }
 
 // NewSessaoService cria uma nova instância de SessaoService
-func NewSessaoService(repo repository.SessaoRepository) *SessaoService {
-	return &SessaoService{repo: repo}
+func NewSessaoService(repo repository.SessaoRepository, avaliacao *AvaliacaoDominioService, alvos *AlvoEtapaService, manutencao *SondagemManutencaoService, cronometroRepo repository.CronometroComportamentoRepository, transactor repository.Transactor) *SessaoService {
+	return &SessaoService{repo: repo, avaliacao: avaliacao, alvos: alvos, manutencao: manutencao, cronometroRepo: cronometroRepo, transactor: transactor}
 }
 
 // CreateSessao cria uma nova sessão
@@ -30,6 +38,7 @@ func (s *SessaoService) CreateSessao(ctx context.Context, sessao *models.Sessao)
 	if err := s.repo.Create(ctx, sessao); err != nil {
 		return nil, err
 	}
+	s.planejarManutencao(ctx, sessao.PacienteID)
 	return sessao, nil
 }
 
@@ -45,18 +54,63 @@ func (s *SessaoService) GetSessao(ctx context.Context, id uuid.UUID) (*models.Se
 	return sessao, nil
 }
 
-// UpdateSessao atualiza uma sessão existente
+// UpdateSessao atualiza uma sessão existente. A sessão é bloqueada durante a atualização, para que nenhum
+// cronômetro seja iniciado entre a verificação dos cronômetros abertos e o encerramento da sessão.
 func (s *SessaoService) UpdateSessao(ctx context.Context, sessao *models.Sessao) (*models.Sessao, error) {
-	existing, err := s.repo.GetByID(ctx, sessao.ID)
+	encerrada := false
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockSessao(ctx, sessao.ID); err != nil {
+			return err
+		}
+		existing, err := s.repo.GetByID(ctx, sessao.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrSessaoNotFound
+		}
+
+		// Uma sessão realizada já teve seus critérios avaliados; reabri-la permitiria reavaliar as mesmas coletas
+		if existing.Status == models.StatusSessaoRealizada && sessao.Status != models.StatusSessaoRealizada {
+			return ErrSessaoRealizada
+		}
+		encerrada = existing.Status != models.StatusSessaoRealizada && sessao.Status == models.StatusSessaoRealizada
+
+		// A sessão não pode ser finalizada enquanto houver cronômetros em andamento
+		if existing.Status == models.StatusSessaoPlanejada && sessao.Status != models.StatusSessaoPlanejada {
+			abertos, err := s.cronometroRepo.CountAbertosBySessao(ctx, sessao.ID)
+			if err != nil {
+				return err
+			}
+			if abertos > 0 {
+				return ErrSessaoComCronometrosAbertos
+			}
+		}
+
+		if err := s.repo.Update(ctx, sessao); err != nil {
+			return err
+		}
+		if !encerrada {
+			return nil
+		}
+
+		// Ao encerrar a sessão, avalia o critério de domínio das etapas e dos alvos trabalhados nela e, depois,
+		// as sondagens de manutenção, para que uma habilidade reaberta não seja promovida pelas sessões anteriores
+		if _, err := s.avaliacao.AvaliarSessao(ctx, sessao); err != nil {
+			return err
+		}
+		if _, err := s.alvos.AvaliarSessao(ctx, sessao); err != nil {
+			return err
+		}
+		_, err = s.manutencao.AvaliarSessao(ctx, sessao)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return nil, ErrSessaoNotFound
-	}
 
-	if err := s.repo.Update(ctx, sessao); err != nil {
-		return nil, err
+	if !encerrada {
+		s.planejarManutencao(ctx, sessao.PacienteID)
 	}
 	return sessao, nil
 }
@@ -70,7 +124,11 @@ func (s *SessaoService) DeleteSessao(ctx context.Context, id uuid.UUID) error {
 	if sessao == nil {
 		return ErrSessaoNotFound
 	}
-	return s.repo.Delete(ctx, id)
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.planejarManutencao(ctx, sessao.PacienteID)
+	return nil
 }
 
 // ListSessoes retorna uma lista paginada de sessões
@@ -118,3 +176,11 @@ func (s *SessaoService) ListSessoesByPaciente(ctx context.Context, pacienteID uu
 
 	return sessoes, total, nil
 }
+
+// planejarManutencao realoca as sondagens de manutenção do paciente depois de uma mudança na agenda.
+// Uma falha não impede a operação na sessão e é apenas registrada.
+func (s *SessaoService) planejarManutencao(ctx context.Context, pacienteID uuid.UUID) {
+	if err := s.manutencao.PlanejarPaciente(ctx, pacienteID); err != nil {
+		log.Printf("Falha ao planejar as sondagens de manutenção do paciente %s: %v", pacienteID, err)
+	}
+}
@@ -0,0 +1,463 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// Erros comuns do serviço
+var (
+	ErrSondagemManutencaoNotFound    = errors.New("sondagem de manutenção não encontrada")
+	ErrSondagemManutencaoEncerrada   = errors.New("a sondagem de manutenção já foi encerrada")
+	ErrSondagemManutencaoForaDaEtapa = errors.New("a sondagem de manutenção não é da etapa ou do alvo da tentativa")
+)
+
+// habilidadeManutencao representa uma etapa dominada ou um alvo em manutenção que deve ser sondado
+type habilidadeManutencao struct {
+	programaID uuid.UUID
+	etapa      *models.EtapaPrograma
+	alvo       *models.AlvoEtapa
+	dominadaEm time.Time
+}
+
+// alvoID retorna o ID do alvo da habilidade, ou nulo quando a habilidade é a própria etapa
+func (h habilidadeManutencao) alvoID() *uuid.UUID {
+	if h.alvo == nil {
+		return nil
+	}
+	return &h.alvo.ID
+}
+
+// SondagemManutencaoService agenda sondagens de manutenção das habilidades dominadas em intervalos crescentes
+// após o domínio, aloca cada sondagem na primeira sessão planejada do paciente a partir da data agendada
+// e reabre para ensino a habilidade que falhar na sondagem
+type SondagemManutencaoService struct {
+	repo          repository.SondagemManutencaoRepository
+	sessaoRepo    repository.SessaoRepository
+	programaRepo  repository.ProgramaABARepository
+	etapaRepo     repository.EtapaProgramaRepository
+	alvoRepo      repository.AlvoEtapaRepository
+	coletaRepo    repository.ColetaABARepository
+	etapaService  *EtapaProgramaService
+	auditoriaRepo repository.EventoAuditoriaRepository
+	transactor    repository.Transactor
+	intervalos    []int
+}
+
+// NewSondagemManutencaoService cria uma nova instância de SondagemManutencaoService. Os intervalos, em dias,
+// separam o domínio da primeira sondagem e cada sondagem aprovada da seguinte; sem intervalos, usa o padrão.
+func NewSondagemManutencaoService(
+	repo repository.SondagemManutencaoRepository,
+	sessaoRepo repository.SessaoRepository,
+	programaRepo repository.ProgramaABARepository,
+	etapaRepo repository.EtapaProgramaRepository,
+	alvoRepo repository.AlvoEtapaRepository,
+	coletaRepo repository.ColetaABARepository,
+	etapaService *EtapaProgramaService,
+	auditoriaRepo repository.EventoAuditoriaRepository,
+	transactor repository.Transactor,
+	intervalos []int,
+) *SondagemManutencaoService {
+	if len(intervalos) == 0 {
+		intervalos = models.IntervalosManutencaoPadrao
+	}
+	return &SondagemManutencaoService{
+		repo:          repo,
+		sessaoRepo:    sessaoRepo,
+		programaRepo:  programaRepo,
+		etapaRepo:     etapaRepo,
+		alvoRepo:      alvoRepo,
+		coletaRepo:    coletaRepo,
+		etapaService:  etapaService,
+		auditoriaRepo: auditoriaRepo,
+		transactor:    transactor,
+		intervalos:    intervalos,
+	}
+}
+
+// PlanejarPaciente agenda a próxima sondagem de cada habilidade dominada do paciente, cancela as sondagens
+// pendentes das habilidades que voltaram ao ensino e aloca as pendentes nas sessões planejadas. O agendamento
+// é feito em uma transação com o paciente bloqueado, para que planejamentos concorrentes não dupliquem sondagens.
+func (s *SondagemManutencaoService) PlanejarPaciente(ctx context.Context, pacienteID uuid.UUID) error {
+	return s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockPaciente(ctx, pacienteID); err != nil {
+			return err
+		}
+		return s.planejar(ctx, pacienteID)
+	})
+}
+
+// PlanejarSondagensPaciente atualiza o agendamento das sondagens do paciente e retorna as sondagens pendentes
+func (s *SondagemManutencaoService) PlanejarSondagensPaciente(ctx context.Context, pacienteID uuid.UUID) ([]*models.SondagemManutencao, error) {
+	if err := s.PlanejarPaciente(ctx, pacienteID); err != nil {
+		return nil, err
+	}
+	sondagens, err := s.repo.ListAllByPaciente(ctx, pacienteID)
+	if err != nil {
+		return nil, err
+	}
+	pendentes := make([]*models.SondagemManutencao, 0, len(sondagens))
+	for _, sondagem := range sondagens {
+		if sondagem.Status == models.StatusSondagemManutencaoPendente {
+			pendentes = append(pendentes, sondagem)
+		}
+	}
+	return pendentes, nil
+}
+
+// planejar executa o agendamento de PlanejarPaciente dentro da transação
+func (s *SondagemManutencaoService) planejar(ctx context.Context, pacienteID uuid.UUID) error {
+	habilidades, err := s.habilidadesDominadas(ctx, pacienteID)
+	if err != nil {
+		return err
+	}
+	sondagens, err := s.repo.ListAllByPaciente(ctx, pacienteID)
+	if err != nil {
+		return err
+	}
+
+	pendentes := make([]*models.SondagemManutencao, 0)
+	for _, sondagem := range sondagens {
+		if sondagem.Status != models.StatusSondagemManutencaoPendente {
+			continue
+		}
+		if !contemHabilidade(habilidades, sondagem) {
+			sondagem.Status = models.StatusSondagemManutencaoCancelada
+			sondagem.SessaoID = nil
+			if err := s.repo.Update(ctx, sondagem); err != nil {
+				return err
+			}
+			continue
+		}
+		pendentes = append(pendentes, sondagem)
+	}
+
+	for _, habilidade := range habilidades {
+		if proxima := s.proximaSondagem(pacienteID, habilidade, sondagens); proxima != nil {
+			if err := s.repo.Create(ctx, proxima); err != nil {
+				return err
+			}
+			pendentes = append(pendentes, proxima)
+		}
+	}
+	return s.alocar(ctx, pacienteID, pendentes)
+}
+
+// AvaliarSessao registra o resultado das sondagens pendentes alocadas em uma sessão encerrada. A sondagem
+// sem tentativas volta para a fila e é alocada na próxima sessão planejada; a sondagem abaixo do critério
+// reabre a habilidade para ensino. Em seguida, o agendamento do paciente é atualizado. Tudo é feito em uma
+// única transação, para que uma sondagem não fique reprovada sem a habilidade reaberta.
+func (s *SondagemManutencaoService) AvaliarSessao(ctx context.Context, sessao *models.Sessao) ([]*models.SondagemManutencao, error) {
+	var avaliadas []*models.SondagemManutencao
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		sondagens, err := s.repo.ListBySessao(ctx, sessao.ID)
+		if err != nil {
+			return err
+		}
+
+		avaliadas = make([]*models.SondagemManutencao, 0, len(sondagens))
+		for _, sondagem := range sondagens {
+			if sondagem.Status != models.StatusSondagemManutencaoPendente {
+				continue
+			}
+			coletas, err := s.coletaRepo.ListBySondagemManutencao(ctx, sondagem.ID)
+			if err != nil {
+				return err
+			}
+			if len(coletas) == 0 {
+				sondagem.SessaoID = nil
+				if err := s.repo.Update(ctx, sondagem); err != nil {
+					return err
+				}
+				continue
+			}
+
+			etapa, err := s.etapaRepo.GetByID(ctx, sondagem.EtapaProgramaID)
+			if err != nil {
+				return err
+			}
+			if etapa == nil {
+				continue
+			}
+
+			realizadaEm := sessao.Data
+			sondagem.RealizadaEm = &realizadaEm
+			sondagem.Tentativas = len(coletas)
+			sondagem.Percentual = percentualCriterio(coletas, etapa.CriterioDominio.MetricaOuPadrao())
+			sondagem.Status = models.StatusSondagemManutencaoAprovada
+			if sondagem.Percentual < limiarManutencao(etapa, sondagem.AlvoID != nil) {
+				sondagem.Status = models.StatusSondagemManutencaoReprovada
+			}
+			if err := s.repo.Update(ctx, sondagem); err != nil {
+				return err
+			}
+			if sondagem.Status == models.StatusSondagemManutencaoReprovada {
+				if err := s.reabrir(ctx, etapa, sondagem, sessao.ID); err != nil {
+					return err
+				}
+			}
+			avaliadas = append(avaliadas, sondagem)
+		}
+
+		return s.PlanejarPaciente(ctx, sessao.PacienteID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return avaliadas, nil
+}
+
+// GetSondagem busca uma sondagem pelo ID, garantindo que pertence ao paciente informado
+func (s *SondagemManutencaoService) GetSondagem(ctx context.Context, pacienteID, id uuid.UUID) (*models.SondagemManutencao, error) {
+	sondagem, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sondagem == nil || sondagem.PacienteID != pacienteID {
+		return nil, ErrSondagemManutencaoNotFound
+	}
+	return sondagem, nil
+}
+
+// ListSondagensPaciente retorna uma lista paginada das sondagens do paciente, opcionalmente filtrada pela situação
+func (s *SondagemManutencaoService) ListSondagensPaciente(ctx context.Context, pacienteID uuid.UUID, status *models.StatusSondagemManutencao, page, pageSize int) ([]*models.SondagemManutencao, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+	sondagens, err := s.repo.ListByPaciente(ctx, pacienteID, status, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountByPaciente(ctx, pacienteID, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return sondagens, total, nil
+}
+
+// ListSondagensSessao retorna as sondagens alocadas em uma sessão
+func (s *SondagemManutencaoService) ListSondagensSessao(ctx context.Context, sessaoID uuid.UUID) ([]*models.SondagemManutencao, error) {
+	sessao, err := s.sessaoRepo.GetByID(ctx, sessaoID)
+	if err != nil {
+		return nil, err
+	}
+	if sessao == nil {
+		return nil, ErrSessaoNotFound
+	}
+	return s.repo.ListBySessao(ctx, sessaoID)
+}
+
+// habilidadesDominadas retorna as etapas dominadas e os alvos em manutenção dos programas ativos e
+// finalizados do paciente
+func (s *SondagemManutencaoService) habilidadesDominadas(ctx context.Context, pacienteID uuid.UUID) ([]habilidadeManutencao, error) {
+	var programas []*models.ProgramaABA
+	for _, status := range []models.StatusPrograma{models.StatusProgramaAtivo, models.StatusProgramaFinalizado} {
+		encontrados, err := s.programaRepo.ListByPacienteAndStatus(ctx, pacienteID, status)
+		if err != nil {
+			return nil, err
+		}
+		programas = append(programas, encontrados...)
+	}
+
+	habilidades := make([]habilidadeManutencao, 0)
+	for _, programa := range programas {
+		etapas, err := s.etapaRepo.ListByPrograma(ctx, programa.ID)
+		if err != nil {
+			return nil, err
+		}
+		ativas := filtrarAtivas(etapas)
+		porID := make(map[uuid.UUID]*models.EtapaPrograma, len(ativas))
+		ids := make([]uuid.UUID, 0, len(ativas))
+		for _, etapa := range ativas {
+			porID[etapa.ID] = etapa
+			ids = append(ids, etapa.ID)
+			if etapa.Status != models.StatusEtapaDominada {
+				continue
+			}
+			dominadaEm := etapa.UpdatedAt
+			if etapa.DominadaEm != nil {
+				dominadaEm = *etapa.DominadaEm
+			}
+			habilidades = append(habilidades, habilidadeManutencao{programaID: programa.ID, etapa: etapa, dominadaEm: dominadaEm})
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		alvos, err := s.alvoRepo.ListByEtapas(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, alvo := range alvos {
+			if alvo.Status != models.StatusAlvoManutencao {
+				continue
+			}
+			dominadoEm := alvo.UpdatedAt
+			if alvo.DominadoEm != nil {
+				dominadoEm = *alvo.DominadoEm
+			}
+			habilidades = append(habilidades, habilidadeManutencao{programaID: programa.ID, etapa: porID[alvo.EtapaProgramaID], alvo: alvo, dominadaEm: dominadoEm})
+		}
+	}
+	return habilidades, nil
+}
+
+// proximaSondagem monta a próxima sondagem da habilidade, ou retorna nulo quando já há uma pendente.
+// A contagem de sondagens recomeça a cada novo domínio da habilidade.
+func (s *SondagemManutencaoService) proximaSondagem(pacienteID uuid.UUID, habilidade habilidadeManutencao, sondagens []*models.SondagemManutencao) *models.SondagemManutencao {
+	sequencia := 1
+	base := habilidade.dominadaEm
+	for _, sondagem := range sondagens {
+		if !sondagem.MesmaHabilidade(habilidade.etapa.ID, habilidade.alvoID()) {
+			continue
+		}
+		if sondagem.Status == models.StatusSondagemManutencaoPendente {
+			return nil
+		}
+		if sondagem.Status != models.StatusSondagemManutencaoAprovada || sondagem.RealizadaEm == nil || sondagem.RealizadaEm.Before(habilidade.dominadaEm) {
+			continue
+		}
+		if sondagem.Sequencia >= sequencia {
+			sequencia = sondagem.Sequencia + 1
+			base = *sondagem.RealizadaEm
+		}
+	}
+
+	return &models.SondagemManutencao{
+		PacienteID:      pacienteID,
+		ProgramaID:      habilidade.programaID,
+		EtapaProgramaID: habilidade.etapa.ID,
+		AlvoID:          habilidade.alvoID(),
+		Sequencia:       sequencia,
+		AgendadaPara:    inicioDia(base).AddDate(0, 0, s.intervalo(sequencia)),
+		Status:          models.StatusSondagemManutencaoPendente,
+	}
+}
+
+// alocar atribui cada sondagem pendente à primeira sessão planejada do paciente a partir de hoje e da data
+// agendada. As sondagens sem sessão disponível ficam na fila até uma nova sessão ser planejada.
+func (s *SondagemManutencaoService) alocar(ctx context.Context, pacienteID uuid.UUID, pendentes []*models.SondagemManutencao) error {
+	if len(pendentes) == 0 {
+		return nil
+	}
+	sessoes, err := s.sessaoRepo.ListPlanejadasByPaciente(ctx, pacienteID, inicioDia(time.Now()))
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(pendentes, func(i, j int) bool {
+		return pendentes[i].AgendadaPara.Before(pendentes[j].AgendadaPara)
+	})
+	for _, sondagem := range pendentes {
+		var sessaoID *uuid.UUID
+		for _, sessao := range sessoes {
+			if !sessao.Data.Before(sondagem.AgendadaPara) {
+				sessaoID = &sessao.ID
+				break
+			}
+		}
+		if mesmaSessao(sondagem.SessaoID, sessaoID) {
+			continue
+		}
+		sondagem.SessaoID = sessaoID
+		if err := s.repo.Update(ctx, sondagem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reabrir devolve ao ensino a habilidade reprovada na sondagem: o alvo volta para aquisição e a etapa
+// volta para a sequência do programa
+func (s *SondagemManutencaoService) reabrir(ctx context.Context, etapa *models.EtapaPrograma, sondagem *models.SondagemManutencao, sessaoID uuid.UUID) error {
+	motivo := fmt.Sprintf("sondagem de manutenção reprovada com %.1f%%", sondagem.Percentual)
+	if sondagem.AlvoID == nil {
+		if etapa.Status != models.StatusEtapaDominada {
+			return nil
+		}
+		_, err := s.etapaService.ReabrirEtapa(ctx, etapa.ProgramaID, etapa.ID, motivo, &sessaoID)
+		return err
+	}
+
+	alvo, err := s.alvoRepo.GetByID(ctx, *sondagem.AlvoID)
+	if err != nil {
+		return err
+	}
+	if alvo == nil || alvo.Status != models.StatusAlvoManutencao {
+		return nil
+	}
+	anterior := alvo.Status
+	agora := time.Now()
+	alvo.Status = models.StatusAlvoAquisicao
+	alvo.DominadoEm = nil
+	alvo.ReabertoEm = &agora
+	if err := s.alvoRepo.Update(ctx, alvo); err != nil {
+		return err
+	}
+	return s.auditoriaRepo.Create(ctx, &models.EventoAuditoria{
+		EntidadeTipo:   models.EntidadeAuditoriaAlvoEtapa,
+		EntidadeID:     alvo.ID,
+		StatusAnterior: string(anterior),
+		StatusNovo:     string(alvo.Status),
+		Motivo:         motivo,
+		SessaoID:       &sessaoID,
+	})
+}
+
+// intervalo retorna o intervalo em dias antes da sondagem de número informado; depois do último intervalo
+// configurado, o último se repete
+func (s *SondagemManutencaoService) intervalo(sequencia int) int {
+	if sequencia > len(s.intervalos) {
+		return s.intervalos[len(s.intervalos)-1]
+	}
+	return s.intervalos[sequencia-1]
+}
+
+// limiarManutencao retorna o percentual mínimo da sondagem: o critério de domínio da rotação para os alvos,
+// o critério de domínio da etapa ou, sem critério configurado, o percentual padrão
+func limiarManutencao(etapa *models.EtapaPrograma, alvo bool) float64 {
+	if alvo && etapa.RotacaoAlvos.Configurada() {
+		return etapa.RotacaoAlvos.PercentualDominio
+	}
+	if etapa.CriterioDominio.PercentualMinimo > 0 {
+		return etapa.CriterioDominio.PercentualMinimo
+	}
+	return models.PercentualManutencaoPadrao
+}
+
+// contemHabilidade indica se a sondagem é de uma das habilidades dominadas
+func contemHabilidade(habilidades []habilidadeManutencao, sondagem *models.SondagemManutencao) bool {
+	for _, habilidade := range habilidades {
+		if sondagem.MesmaHabilidade(habilidade.etapa.ID, habilidade.alvoID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// mesmaSessao compara duas referências opcionais de sessão
+func mesmaSessao(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// inicioDia retorna a meia-noite do dia da data informada
+func inicioDia(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"msd-service/server/internal/models"
+	"msd-service/server/internal/repository"
+)
+
+// TipoPromptService encapsula a lógica de negócio relacionada a tipos de prompt
+type TipoPromptService struct {
+	repo repository.TipoPromptRepository
+}
+
+// NewTipoPromptService cria uma nova instância de TipoPromptService
+func NewTipoPromptService(repo repository.TipoPromptRepository) *TipoPromptService {
+	return &TipoPromptService{repo: repo}
+}
+
+// CreateTipoPrompt cria um novo tipo de prompt
+func (s *TipoPromptService) CreateTipoPrompt(ctx context.Context, prompt *models.TipoPrompt) (*models.TipoPrompt, error) {
+	if err := s.repo.Create(ctx, prompt); err != nil {
+		return nil, err
+	}
+	return prompt, nil
+}
+
+// GetTipoPrompt busca um tipo de prompt pelo ID
+func (s *TipoPromptService) GetTipoPrompt(ctx context.Context, id uuid.UUID) (*models.TipoPrompt, error) {
+	prompt, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if prompt == nil {
+		return nil, ErrTipoPromptNotFound
+	}
+	return prompt, nil
+}
+
+// UpdateTipoPrompt atualiza um tipo de prompt existente
+func (s *TipoPromptService) UpdateTipoPrompt(ctx context.Context, prompt *models.TipoPrompt) (*models.TipoPrompt, error) {
+	existing, err := s.repo.GetByID(ctx, prompt.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrTipoPromptNotFound
+	}
+
+	if err := s.repo.Update(ctx, prompt); err != nil {
+		return nil, err
+	}
+	return prompt, nil
+}
+
+// DeleteTipoPrompt exclui um tipo de prompt pelo ID
+func (s *TipoPromptService) DeleteTipoPrompt(ctx context.Context, id uuid.UUID) error {
+	prompt, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if prompt == nil {
+		return ErrTipoPromptNotFound
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// ListTiposPrompt retorna uma lista paginada de tipos de prompt
+func (s *TipoPromptService) ListTiposPrompt(ctx context.Context, page, pageSize int) ([]*models.TipoPrompt, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+	prompts, err := s.repo.List(ctx, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return prompts, total, nil
+}